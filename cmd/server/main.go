@@ -2,12 +2,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -17,10 +21,14 @@ import (
 	"github.com/openinfradev/tks-api/internal/keycloak"
 	"github.com/openinfradev/tks-api/internal/mail"
 	"github.com/openinfradev/tks-api/internal/route"
+	"github.com/openinfradev/tks-api/internal/stream"
 	argowf "github.com/openinfradev/tks-api/pkg/argo-client"
 	"github.com/openinfradev/tks-api/pkg/log"
 )
 
+// 종료할 때 처리 중인 요청을 기다리는 시간
+const serverShutdownTimeout = 30 * time.Second
+
 func init() {
 	flag.String("external-address", "http://tks-api.tks.svc:9110", "service address")
 	flag.Int("port", 8080, "service port")
@@ -34,6 +42,10 @@ func init() {
 	flag.String("dbpassword", "password", "password for postgreSQL user")
 	flag.String("kubeconfig-path", "", "path of kubeconfig. used development only!")
 	flag.String("jwt-secret", "tks-api-secret", "secret value of jwt")
+	flag.String("token-secret", "", "secret to sign the unsubscribe links and the impersonation tokens. they are not issued if empty")
+	flag.String("second-factor-secret", "", "secret to encrypt the TOTP secrets and the second factor tokens. the second factor can not be enrolled if empty")
+	flag.String("trusted-proxies", "", "comma separated CIDRs of the reverse proxies whose X-Forwarded-For is trusted. the remote address is used if empty")
+	flag.String("audit-signing-secret", "", "secret to sign the checkpoints of the audit log. the checkpoints are not created and the audits are not purged if empty")
 	flag.String("git-base-url", "https://github.com", "git base url")
 	flag.String("git-account", "decapod10", "git account of admin cluster")
 	flag.String("external-gitea-url", "http://ip-10-0-76-86.ap-northeast-2.compute.internal:30303", "gitea url for byoh agent download")
@@ -54,6 +66,7 @@ func init() {
 	flag.String("keycloak-admin", "admin", "user of keycloak")
 	flag.String("keycloak-password", "admin", "password of keycloak")
 	flag.String("keycloak-client-secret", keycloak.DefaultClientSecret, "realm of keycloak")
+	flag.String("keycloak-issuer-address", "", "URL of keycloak in the iss claim of the tokens, if it differs from keycloak-address")
 
 	flag.String("mail-provider", "aws", "mail provider")
 	// mail (smtp)
@@ -70,6 +83,19 @@ func init() {
 	// alerts
 	flag.String("alert-slack", "", "slack url for LMA alert")
 
+	// stream
+	flag.String("stream-backend", "memory", "backend of the notification stream among the replicas (memory, postgres)")
+
+	// audit
+	flag.String("audit-forwarder", "", "sink to stream the audits to the SIEM (syslog, http). disabled if empty")
+	flag.String("audit-forwarder-address", "", "host:port of the syslog server, or URL of the http collector")
+	flag.Bool("audit-forwarder-tls", false, "use TLS to the syslog server")
+	flag.String("audit-forwarder-token", "", "bearer token of the http collector")
+
+	// retention
+	flag.Duration("retention-interval", 24*time.Hour, "interval of the job purging the expired data by the retention policies. disabled if 0")
+	flag.String("retention-archive-dir", "", "directory to archive the expired data before the purge")
+
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	flag.Parse()
 
@@ -104,7 +130,9 @@ func init() {
 // @host		tks-api-dev.taco-cat.xyz
 // @BasePath	/api/1.0/
 func main() {
-	ctx := context.Background()
+	// 종료 신호를 받으면 요청 처리와 백그라운드 작업을 멈춘다.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 	log.Info(ctx, "*** Arguments *** ")
 	for i, s := range viper.AllSettings() {
 		log.Info(ctx, fmt.Sprintf("%s : %v", i, s))
@@ -152,6 +180,7 @@ func main() {
 		AdminId:       viper.GetString("keycloak-admin"),
 		AdminPassword: viper.GetString("keycloak-password"),
 		ClientSecret:  viper.GetString("keycloak-client-secret"),
+		IssuerAddress: viper.GetString("keycloak-issuer-address"),
 	})
 
 	err = keycloak.InitializeKeycloak(ctx)
@@ -162,12 +191,30 @@ func main() {
 	if err != nil {
 		log.Fatal(ctx, "failed to initialize ses : ", err)
 	}
+	err = stream.Initialize(ctx, db)
+	if err != nil {
+		log.Fatal(ctx, "failed to initialize stream : ", err)
+	}
 
-	route := route.SetupRouter(db, argoClient, keycloak, asset)
+	route := route.SetupRouter(ctx, db, argoClient, keycloak, asset)
+
+	server := &http.Server{
+		Addr:    "0.0.0.0:" + strconv.Itoa(viper.GetInt("port")),
+		Handler: route,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error(shutdownCtx, "failed to shutdown server : ", err)
+		}
+	}()
 
 	log.Info(ctx, "Starting server on ", viper.GetInt("port"))
-	err = http.ListenAndServe("0.0.0.0:"+strconv.Itoa(viper.GetInt("port")), route)
-	if err != nil {
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatal(ctx, err)
 	}
+	log.Info(context.Background(), "Server is stopped")
 }
@@ -15,9 +15,12 @@ import (
 
 const endpointFilePath = "./internal/delivery/api/endpoint.go"
 
+const sensitiveMarker = "+sensitive"
+
 type endpointDecl struct {
-	Name  string
-	Group string
+	Name      string
+	Group     string
+	Sensitive bool
 }
 
 const indexTemplateStr = ` // This is generated code. DO NOT EDIT.
@@ -41,6 +44,9 @@ const apiMapTemplateStr = `var ApiMap = map[Endpoint]EndpointInfo{
     {{.Name}}: {
 		Name: "{{.Name}}", 
 		Group: "{{.Group}}",
+		{{- if .Sensitive}}
+		Sensitive: true,
+		{{- end}}
 	},
 {{- end}}
 }
@@ -108,10 +114,20 @@ func main() {
 						}
 					}
 
+					sensitive := false
+					if vs.Comment != nil {
+						for _, comment := range vs.Comment.List {
+							if strings.Contains(comment.Text, sensitiveMarker) {
+								sensitive = true
+							}
+						}
+					}
+
 					for _, name := range vs.Names {
 						endpoints = append(endpoints, endpointDecl{
-							Name:  name.Name,
-							Group: currentGroup,
+							Name:      name.Name,
+							Group:     currentGroup,
+							Sensitive: sensitive,
 						})
 					}
 				}
@@ -0,0 +1,83 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Store keeps the archived objects. An object store such as S3 can be plugged in by implementing Store.
+type Store interface {
+	Put(ctx context.Context, key string, body []byte) error
+}
+
+// New returns the store by retention-archive-dir. It returns nil if the archive is not configured.
+func New() Store {
+	dir := viper.GetString("retention-archive-dir")
+	if dir == "" {
+		return nil
+	}
+	return NewLocalStore(dir)
+}
+
+// Encode writes the records in gzip compressed JSON lines.
+func Encode(records []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// LocalStore writes the objects as files under the directory.
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+// Put writes the object to a temporary file and renames it, so a partially written object is never left with the key.
+func (s *LocalStore) Put(ctx context.Context, key string, body []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(s.dir)+string(os.PathSeparator)) {
+		return fmt.Errorf("invalid archive key %s", key)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".archive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(body); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,56 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorePut(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(dir)
+
+	body, err := Encode([]interface{}{map[string]string{"id": "1"}, map[string]string{"id": "2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Put(context.Background(), "org1/audit/20240102T030405-0001.jsonl.gz", body); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "org1", "audit", "20240102T030405-0001.jsonl.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"id\":\"1\"}\n{\"id\":\"2\"}\n"; !bytes.Equal(content, []byte(want)) {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "org1", "audit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary files are left. entries = %v", entries)
+	}
+}
+
+func TestLocalStorePutInvalidKey(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	if err := store.Put(context.Background(), "../outside.jsonl.gz", []byte("x")); err == nil {
+		t.Error("Put() error = nil, want error for the key outside the directory")
+	}
+}
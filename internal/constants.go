@@ -9,6 +9,7 @@ const ContextKeyRequestID ContextKey = "REQUEST_ID"
 const (
 	PasswordExpiredDuration = 30 * 24 * time.Hour
 	EmailCodeExpireTime     = 5 * time.Minute
+	SecondFactorExpireTime  = 5 * time.Minute
 	API_VERSION             = "/1.0"
 	API_PREFIX              = "/api"
 	ADMINAPI_PREFIX         = "/admin"
@@ -17,6 +18,18 @@ const (
 	SYSTEM_API_PREFIX  = "/system-api"
 )
 
+// 로그인 및 인증번호 확인 실패에 대한 제한
+const (
+	LoginFailureWindow       = 15 * time.Minute
+	LoginDelayThreshold      = 3
+	LoginMaxDelay            = 30 * time.Second
+	AccountLockThreshold     = 10
+	AccountLockDuration      = 30 * time.Minute
+	ClientIpLockThreshold    = 50
+	ClientIpLockDuration     = 15 * time.Minute
+	EmailCodeMaxFailureCount = 5
+)
+
 // 일단 DB 로 데이터를 관리하지 않고, 하드코딩 처리함.
 const SERVICE_LMA = `{
 	"name": "Logging,Monitoring,Alerting",
@@ -62,6 +62,9 @@ func InitDB() (*gorm.DB, error) {
 func migrateSchema(db *gorm.DB) error {
 	if err := db.AutoMigrate(&model.CacheEmailCode{},
 		&model.ExpiredTokenTime{},
+		&model.RevokedSession{},
+		&model.StreamTicket{},
+		&model.LoginFailure{},
 		&model.Role{},
 		&model.CloudAccount{},
 		&model.StackTemplate{},
@@ -80,6 +83,31 @@ func migrateSchema(db *gorm.DB) error {
 		&model.SystemNotificationTemplate{},
 		&model.SystemNotificationRule{},
 		&model.SystemNotificationCondition{},
+		&model.SystemNotificationCredential{},
+		&model.SystemNotificationCredentialNonce{},
+		&model.NotificationChannel{},
+		&model.NotificationDelivery{},
+		&model.MailOutbox{},
+		&model.OnCallSchedule{},
+		&model.OnCallScheduleMember{},
+		&model.EscalationPolicy{},
+		&model.EscalationTier{},
+		&model.SystemNotificationSilence{},
+		&model.SystemNotificationDigestItem{},
+		&model.NotificationPreference{},
+		&model.ServiceAccount{},
+		&model.ApiToken{},
+		&model.SecurityPolicy{},
+		&model.SecondFactor{},
+		&model.SecondFactorChallenge{},
+		&model.SecondFactorSession{},
+		&model.IdentityProvider{},
+		&model.IdentityProviderRoleMapping{},
+		&model.Invitation{},
+		&model.InvitationProject{},
+		&model.UserImportJob{},
+		&model.UserImportRow{},
+		&model.Impersonation{},
 		&model.Permission{},
 		&model.Endpoint{},
 		&model.Project{},
@@ -87,6 +115,11 @@ func migrateSchema(db *gorm.DB) error {
 		&model.ProjectNamespace{},
 		&model.ProjectRole{},
 		&model.Audit{},
+		&model.AuditCheckpoint{},
+		&model.AuditForwardCursor{},
+		&model.RetentionPolicy{},
+		&model.RetentionJob{},
+		&model.RetentionRun{},
 		&model.PolicyTemplateSupportedVersion{},
 		&model.PolicyTemplate{},
 		&model.Policy{},
@@ -100,22 +133,36 @@ func migrateSchema(db *gorm.DB) error {
 func EnsureDefaultRows(db *gorm.DB) error {
 	// Create default rows
 	repoFactory := repository.Repository{
-		Auth:                       repository.NewAuthRepository(db),
-		User:                       repository.NewUserRepository(db),
-		Cluster:                    repository.NewClusterRepository(db),
-		Organization:               repository.NewOrganizationRepository(db),
-		AppGroup:                   repository.NewAppGroupRepository(db),
-		AppServeApp:                repository.NewAppServeAppRepository(db),
-		CloudAccount:               repository.NewCloudAccountRepository(db),
-		StackTemplate:              repository.NewStackTemplateRepository(db),
-		SystemNotification:         repository.NewSystemNotificationRepository(db),
-		SystemNotificationRule:     repository.NewSystemNotificationRuleRepository(db),
-		SystemNotificationTemplate: repository.NewSystemNotificationTemplateRepository(db),
-		Role:                       repository.NewRoleRepository(db),
-		Permission:                 repository.NewPermissionRepository(db),
-		Endpoint:                   repository.NewEndpointRepository(db),
-		Project:                    repository.NewProjectRepository(db),
-		Dashboard:                  repository.NewDashboardRepository(db),
+		Auth:                         repository.NewAuthRepository(db),
+		User:                         repository.NewUserRepository(db),
+		Cluster:                      repository.NewClusterRepository(db),
+		Organization:                 repository.NewOrganizationRepository(db),
+		AppGroup:                     repository.NewAppGroupRepository(db),
+		AppServeApp:                  repository.NewAppServeAppRepository(db),
+		CloudAccount:                 repository.NewCloudAccountRepository(db),
+		StackTemplate:                repository.NewStackTemplateRepository(db),
+		SystemNotification:           repository.NewSystemNotificationRepository(db),
+		SystemNotificationRule:       repository.NewSystemNotificationRuleRepository(db),
+		SystemNotificationCredential: repository.NewSystemNotificationCredentialRepository(db),
+		NotificationChannel:          repository.NewNotificationChannelRepository(db),
+		NotificationDelivery:         repository.NewNotificationDeliveryRepository(db),
+		MailOutbox:                   repository.NewMailOutboxRepository(db),
+		EscalationPolicy:             repository.NewEscalationPolicyRepository(db),
+		OnCallSchedule:               repository.NewOnCallScheduleRepository(db),
+		SystemNotificationSilence:    repository.NewSystemNotificationSilenceRepository(db),
+		SystemNotificationDigest:     repository.NewSystemNotificationDigestRepository(db),
+		NotificationPreference:       repository.NewNotificationPreferenceRepository(db),
+		ServiceAccount:               repository.NewServiceAccountRepository(db),
+		ApiToken:                     repository.NewApiTokenRepository(db),
+		SecurityPolicy:               repository.NewSecurityPolicyRepository(db),
+		SecondFactor:                 repository.NewSecondFactorRepository(db),
+		IdentityProvider:             repository.NewIdentityProviderRepository(db),
+		SystemNotificationTemplate:   repository.NewSystemNotificationTemplateRepository(db),
+		Role:                         repository.NewRoleRepository(db),
+		Permission:                   repository.NewPermissionRepository(db),
+		Endpoint:                     repository.NewEndpointRepository(db),
+		Project:                      repository.NewProjectRepository(db),
+		Dashboard:                    repository.NewDashboardRepository(db),
 	}
 
 	//
@@ -143,5 +190,81 @@ func EnsureDefaultRows(db *gorm.DB) error {
 		}
 	}
 
+	if err := syncPermissionEndpoints(db); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncPermissionEndpoints links endpoints newly added to the permission templates to the permissions already stored for each role.
+func syncPermissionEndpoints(db *gorm.DB) error {
+	permissionTemplate := model.NewDefaultPermissionSet()
+	templateEndpoints := make(map[string][]*model.Endpoint)
+	for _, root := range []*model.Permission{
+		permissionTemplate.Dashboard,
+		permissionTemplate.Stack,
+		permissionTemplate.Policy,
+		permissionTemplate.ProjectManagement,
+		permissionTemplate.Notification,
+		permissionTemplate.Configuration,
+	} {
+		collectPermissionEndpoints(root, "", templateEndpoints)
+	}
+
+	var storedPermissions []*model.Permission
+	if err := db.Preload("Children.Children.Endpoints").Preload("Children.Endpoints").Preload("Endpoints").
+		Where("parent_id IS NULL").Find(&storedPermissions).Error; err != nil {
+		return err
+	}
+
+	storedEndpoints := make(map[*model.Permission]string)
+	for _, root := range storedPermissions {
+		collectStoredPermissions(root, "", storedEndpoints)
+	}
+
+	for permission, path := range storedEndpoints {
+		linked := make(map[string]struct{})
+		for _, ep := range permission.Endpoints {
+			linked[ep.Name] = struct{}{}
+		}
+
+		missing := make([]*model.Endpoint, 0)
+		for _, ep := range templateEndpoints[path] {
+			if _, ok := linked[ep.Name]; !ok {
+				missing = append(missing, ep)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		if err := db.Model(permission).Association("Endpoints").Append(missing); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func collectPermissionEndpoints(permission *model.Permission, parentPath string, out map[string][]*model.Endpoint) {
+	path := parentPath + "/" + permission.Key
+	if len(permission.Children) == 0 {
+		out[path] = permission.Endpoints
+		return
+	}
+	for _, child := range permission.Children {
+		collectPermissionEndpoints(child, path, out)
+	}
+}
+
+func collectStoredPermissions(permission *model.Permission, parentPath string, out map[*model.Permission]string) {
+	path := parentPath + "/" + permission.Key
+	if len(permission.Children) == 0 {
+		out[permission] = path
+		return
+	}
+	for _, child := range permission.Children {
+		collectStoredPermissions(child, path, out)
+	}
+}
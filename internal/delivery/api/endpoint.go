@@ -4,6 +4,8 @@ type Endpoint int
 type EndpointInfo struct {
 	Name  string
 	Group string
+	// 폐기된 토큰을 즉시 거부하도록 매 요청마다 keycloak 에서 토큰을 확인한다. 끝에 "// +sensitive" 주석을 단 엔드포인트이다.
+	Sensitive bool
 }
 
 // Comment below is special purpose for code generation.
@@ -12,6 +14,9 @@ type EndpointInfo struct {
 const (
 	// Auth
 	Login Endpoint = iota
+	LoginSecondFactor
+	LoginWithIdentityProvider
+	GetLoginIdentityProviders
 	Logout
 	RefreshToken
 	FindId
@@ -21,32 +26,62 @@ const (
 	VerifyToken
 
 	// User
-	CreateUser
+	CreateUser // +sensitive
 	ListUser
 	GetUser
-	DeleteUser
+	DeleteUser // +sensitive
 	UpdateUsers
-	UpdateUser
-	ResetPassword
+	UpdateUser    // +sensitive
+	ResetPassword // +sensitive
+	UnlockUser
+	ResetUserSecondFactor // +sensitive
+	GetUserSessions
+	RevokeUserSession  // +sensitive
+	RevokeUserSessions // +sensitive
+	ImportUsers
+	GetUserImports
+	GetUserImport
+	ExportUsers
 	CheckId
 	CheckEmail
 	GetPermissionsByAccountId
 
+	// Invitation
+	CreateInvitation
+	GetInvitations
+	GetInvitation
+	ResendInvitation
+	RevokeInvitation
+
 	// MyProfile
 	GetMyProfile
 	UpdateMyProfile
-	UpdateMyPassword
+	UpdateMyPassword // +sensitive
 	RenewPasswordExpiredDate
-	DeleteMyProfile
+	DeleteMyProfile // +sensitive
+	GetMyNotificationPreference
+	UpdateMyNotificationPreference
+	GetMyApiTokens
+	CreateMyApiToken // +sensitive
+	RevokeMyApiToken
+	GetMySecondFactor
+	CreateMySecondFactor
+	VerifyMySecondFactor
+	RegenerateMyRecoveryCodes
+	DeleteMySecondFactor // +sensitive
+	GetMySessions
+	RevokeMySession // +sensitive
 
 	// Organization
 	Admin_CreateOrganization
-	Admin_DeleteOrganization
+	Admin_DeleteOrganization // +sensitive
 	GetOrganizations
 	GetOrganization
 	CheckOrganizationName
-	UpdateOrganization
+	UpdateOrganization // +sensitive
 	UpdatePrimaryCluster
+	GetSecurityPolicy
+	UpdateSecurityPolicy // +sensitive
 
 	// Cluster
 	CreateCluster
@@ -57,8 +92,8 @@ const (
 	GetClusterSiteValues
 	InstallCluster
 	ResumeCluster
-	CreateBootstrapKubeconfig
-	GetBootstrapKubeconfig
+	CreateBootstrapKubeconfig // +sensitive
+	GetBootstrapKubeconfig    // +sensitive
 	GetNodes
 
 	//Appgroup
@@ -151,6 +186,46 @@ const (
 	UpdateSystemNotificationRule
 	MakeDefaultSystemNotificationRules
 
+	// SystemNotificationCredential
+	CreateSystemNotificationCredential
+	GetSystemNotificationCredentials
+	DeleteSystemNotificationCredential
+	RotateSystemNotificationCredential
+
+	// NotificationChannel
+	CreateNotificationChannel
+	GetNotificationChannels
+	GetNotificationChannel
+	UpdateNotificationChannel
+	DeleteNotificationChannel
+	TestNotificationChannel
+	GetNotificationDeliveries
+
+	// EscalationPolicy
+	CreateEscalationPolicy
+	GetEscalationPolicies
+	GetEscalationPolicy
+	UpdateEscalationPolicy
+	DeleteEscalationPolicy
+
+	// SystemNotificationSilence
+	CreateSystemNotificationSilence
+	GetSystemNotificationSilences
+	GetSystemNotificationSilence
+	UpdateSystemNotificationSilence
+	DeleteSystemNotificationSilence
+
+	// OnCallSchedule
+	CreateOnCallSchedule
+	GetOnCallSchedules
+	GetOnCallSchedule
+	UpdateOnCallSchedule
+	DeleteOnCallSchedule
+
+	// MailOutbox
+	Admin_GetMailOutboxes
+	Admin_ResendMailOutbox
+
 	// SystemNotification
 	CreateSystemNotification
 	GetSystemNotifications
@@ -158,6 +233,7 @@ const (
 	DeleteSystemNotification
 	UpdateSystemNotification
 	CreateSystemNotificationAction
+	CreateNotificationStreamTicket
 
 	// PolicyNotification
 	GetPolicyNotifications
@@ -171,7 +247,7 @@ const (
 	GetStack            // 스택관리/조회
 	UpdateStack         // 스택관리/수정
 	DeleteStack         // 스택관리/삭제
-	GetStackKubeconfig  // 스택관리/조회
+	GetStackKubeconfig  // 스택관리/조회 // +sensitive
 	GetStackStatus      // 스택관리/조회
 	SetFavoriteStack    // 스택관리/조회
 	DeleteFavoriteStack // 스택관리/조회
@@ -199,23 +275,30 @@ const (
 	SetFavoriteProjectNamespace
 	UnSetFavoriteProject
 	UnSetFavoriteProjectNamespace
-	GetProjectKubeconfig
+	GetProjectKubeconfig // +sensitive
 	GetProjectNamespaceK8sResources
-	GetProjectNamespaceKubeconfig
+	GetProjectNamespaceKubeconfig // +sensitive
 
 	// Audit
 	GetAudits
 	GetAudit
 	DeleteAudit
+	VerifyAudits
+	ExportAudits
+
+	// Retention
+	Admin_GetRetentionPolicies
+	Admin_UpdateRetentionPolicies
+	Admin_GetRetentionStatus
 
 	// Role
-	CreateTksRole
+	CreateTksRole // +sensitive
 	ListTksRoles
 	GetTksRole
-	DeleteTksRole
-	UpdateTksRole
+	DeleteTksRole // +sensitive
+	UpdateTksRole // +sensitive
 	GetPermissionsByRoleId
-	UpdatePermissionsByRoleId
+	UpdatePermissionsByRoleId // +sensitive
 	IsRoleNameExisted
 	AppendUsersToRole
 	GetUsersInRoleId
@@ -224,12 +307,47 @@ const (
 	// Permission
 	GetPermissionTemplates
 
+	// ServiceAccount
+	CreateServiceAccount
+	GetServiceAccounts
+	GetServiceAccount
+	UpdateServiceAccount
+	DeleteServiceAccount
+	GetServiceAccountTokens
+	CreateServiceAccountToken // +sensitive
+	RevokeServiceAccountToken
+
+	// IdentityProvider
+	CreateIdentityProvider // +sensitive
+	GetIdentityProviders
+	GetIdentityProvider
+	UpdateIdentityProvider // +sensitive
+	DeleteIdentityProvider // +sensitive
+
+	// Scim
+	ScimGetServiceProviderConfig
+	ScimGetUsers
+	ScimGetUser
+	ScimCreateUser
+	ScimReplaceUser
+	ScimPatchUser
+	ScimDeleteUser
+	ScimGetGroups
+	ScimGetGroup
+	ScimReplaceGroup
+	ScimPatchGroup
+
 	// Admin_User
-	Admin_CreateUser
+	Admin_CreateUser // +sensitive
 	Admin_ListUser
 	Admin_GetUser
-	Admin_DeleteUser
-	Admin_UpdateUser
+	Admin_DeleteUser // +sensitive
+	Admin_UpdateUser // +sensitive
+
+	// Impersonation
+	Admin_CreateImpersonation // +sensitive
+	Admin_GetImpersonations
+	Admin_StopImpersonation
 
 	// Admin Role
 	Admin_ListTksRoles
@@ -68,3 +68,33 @@ func countEndpoints(perm *model.Permission, usageCount map[string]int) {
 		usageCount[endpoint.Name]++
 	}
 }
+
+func TestSensitiveEndpoints(t *testing.T) {
+	// 자격 증명을 발급하거나 인증 설정을 바꾸는 엔드포인트는 항상 토큰을 introspection 해야 한다.
+	endpoints := []api.Endpoint{
+		api.CreateMyApiToken,
+		api.CreateServiceAccountToken,
+		api.Admin_CreateImpersonation,
+		api.RevokeMySession,
+		api.RevokeUserSession,
+		api.RevokeUserSessions,
+		api.ResetUserSecondFactor,
+		api.DeleteMySecondFactor,
+		api.CreateIdentityProvider,
+		api.UpdateIdentityProvider,
+		api.DeleteIdentityProvider,
+		api.UpdateSecurityPolicy,
+		api.GetStackKubeconfig,
+		api.GetProjectKubeconfig,
+		api.GetProjectNamespaceKubeconfig,
+	}
+	for _, endpoint := range endpoints {
+		if !api.ApiMap[endpoint].Sensitive {
+			t.Errorf("%s is not marked as sensitive", endpoint)
+		}
+	}
+
+	if api.ApiMap[api.GetUser].Sensitive {
+		t.Errorf("%s is marked as sensitive", api.GetUser)
+	}
+}
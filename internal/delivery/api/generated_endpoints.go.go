@@ -7,6 +7,18 @@ var ApiMap = map[Endpoint]EndpointInfo{
 		Name: "Login", 
 		Group: "Auth",
 	},
+    LoginSecondFactor: {
+		Name: "LoginSecondFactor", 
+		Group: "Auth",
+	},
+    LoginWithIdentityProvider: {
+		Name: "LoginWithIdentityProvider", 
+		Group: "Auth",
+	},
+    GetLoginIdentityProviders: {
+		Name: "GetLoginIdentityProviders", 
+		Group: "Auth",
+	},
     Logout: {
 		Name: "Logout", 
 		Group: "Auth",
@@ -38,6 +50,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     CreateUser: {
 		Name: "CreateUser", 
 		Group: "User",
+		Sensitive: true,
 	},
     ListUser: {
 		Name: "ListUser", 
@@ -50,6 +63,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     DeleteUser: {
 		Name: "DeleteUser", 
 		Group: "User",
+		Sensitive: true,
 	},
     UpdateUsers: {
 		Name: "UpdateUsers", 
@@ -58,10 +72,51 @@ var ApiMap = map[Endpoint]EndpointInfo{
     UpdateUser: {
 		Name: "UpdateUser", 
 		Group: "User",
+		Sensitive: true,
 	},
     ResetPassword: {
 		Name: "ResetPassword", 
 		Group: "User",
+		Sensitive: true,
+	},
+    UnlockUser: {
+		Name: "UnlockUser", 
+		Group: "User",
+	},
+    ResetUserSecondFactor: {
+		Name: "ResetUserSecondFactor", 
+		Group: "User",
+		Sensitive: true,
+	},
+    GetUserSessions: {
+		Name: "GetUserSessions", 
+		Group: "User",
+	},
+    RevokeUserSession: {
+		Name: "RevokeUserSession", 
+		Group: "User",
+		Sensitive: true,
+	},
+    RevokeUserSessions: {
+		Name: "RevokeUserSessions", 
+		Group: "User",
+		Sensitive: true,
+	},
+    ImportUsers: {
+		Name: "ImportUsers", 
+		Group: "User",
+	},
+    GetUserImports: {
+		Name: "GetUserImports", 
+		Group: "User",
+	},
+    GetUserImport: {
+		Name: "GetUserImport", 
+		Group: "User",
+	},
+    ExportUsers: {
+		Name: "ExportUsers", 
+		Group: "User",
 	},
     CheckId: {
 		Name: "CheckId", 
@@ -75,6 +130,26 @@ var ApiMap = map[Endpoint]EndpointInfo{
 		Name: "GetPermissionsByAccountId", 
 		Group: "User",
 	},
+    CreateInvitation: {
+		Name: "CreateInvitation", 
+		Group: "Invitation",
+	},
+    GetInvitations: {
+		Name: "GetInvitations", 
+		Group: "Invitation",
+	},
+    GetInvitation: {
+		Name: "GetInvitation", 
+		Group: "Invitation",
+	},
+    ResendInvitation: {
+		Name: "ResendInvitation", 
+		Group: "Invitation",
+	},
+    RevokeInvitation: {
+		Name: "RevokeInvitation", 
+		Group: "Invitation",
+	},
     GetMyProfile: {
 		Name: "GetMyProfile", 
 		Group: "MyProfile",
@@ -86,6 +161,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     UpdateMyPassword: {
 		Name: "UpdateMyPassword", 
 		Group: "MyProfile",
+		Sensitive: true,
 	},
     RenewPasswordExpiredDate: {
 		Name: "RenewPasswordExpiredDate", 
@@ -94,6 +170,58 @@ var ApiMap = map[Endpoint]EndpointInfo{
     DeleteMyProfile: {
 		Name: "DeleteMyProfile", 
 		Group: "MyProfile",
+		Sensitive: true,
+	},
+    GetMyNotificationPreference: {
+		Name: "GetMyNotificationPreference", 
+		Group: "MyProfile",
+	},
+    UpdateMyNotificationPreference: {
+		Name: "UpdateMyNotificationPreference", 
+		Group: "MyProfile",
+	},
+    GetMyApiTokens: {
+		Name: "GetMyApiTokens", 
+		Group: "MyProfile",
+	},
+    CreateMyApiToken: {
+		Name: "CreateMyApiToken", 
+		Group: "MyProfile",
+		Sensitive: true,
+	},
+    RevokeMyApiToken: {
+		Name: "RevokeMyApiToken", 
+		Group: "MyProfile",
+	},
+    GetMySecondFactor: {
+		Name: "GetMySecondFactor", 
+		Group: "MyProfile",
+	},
+    CreateMySecondFactor: {
+		Name: "CreateMySecondFactor", 
+		Group: "MyProfile",
+	},
+    VerifyMySecondFactor: {
+		Name: "VerifyMySecondFactor", 
+		Group: "MyProfile",
+	},
+    RegenerateMyRecoveryCodes: {
+		Name: "RegenerateMyRecoveryCodes", 
+		Group: "MyProfile",
+	},
+    DeleteMySecondFactor: {
+		Name: "DeleteMySecondFactor", 
+		Group: "MyProfile",
+		Sensitive: true,
+	},
+    GetMySessions: {
+		Name: "GetMySessions", 
+		Group: "MyProfile",
+	},
+    RevokeMySession: {
+		Name: "RevokeMySession", 
+		Group: "MyProfile",
+		Sensitive: true,
 	},
     Admin_CreateOrganization: {
 		Name: "Admin_CreateOrganization", 
@@ -102,6 +230,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     Admin_DeleteOrganization: {
 		Name: "Admin_DeleteOrganization", 
 		Group: "Organization",
+		Sensitive: true,
 	},
     GetOrganizations: {
 		Name: "GetOrganizations", 
@@ -118,11 +247,21 @@ var ApiMap = map[Endpoint]EndpointInfo{
     UpdateOrganization: {
 		Name: "UpdateOrganization", 
 		Group: "Organization",
+		Sensitive: true,
 	},
     UpdatePrimaryCluster: {
 		Name: "UpdatePrimaryCluster", 
 		Group: "Organization",
 	},
+    GetSecurityPolicy: {
+		Name: "GetSecurityPolicy", 
+		Group: "Organization",
+	},
+    UpdateSecurityPolicy: {
+		Name: "UpdateSecurityPolicy", 
+		Group: "Organization",
+		Sensitive: true,
+	},
     CreateCluster: {
 		Name: "CreateCluster", 
 		Group: "Cluster",
@@ -158,10 +297,12 @@ var ApiMap = map[Endpoint]EndpointInfo{
     CreateBootstrapKubeconfig: {
 		Name: "CreateBootstrapKubeconfig", 
 		Group: "Cluster",
+		Sensitive: true,
 	},
     GetBootstrapKubeconfig: {
 		Name: "GetBootstrapKubeconfig", 
 		Group: "Cluster",
+		Sensitive: true,
 	},
     GetNodes: {
 		Name: "GetNodes", 
@@ -339,6 +480,10 @@ var ApiMap = map[Endpoint]EndpointInfo{
 		Name: "RemoveOrganizationStackTemplates", 
 		Group: "StackTemplate",
 	},
+    GetOrganizationCloudServices: {
+		Name: "GetOrganizationCloudServices", 
+		Group: "StackTemplate",
+	},
     CreateDashboard: {
 		Name: "CreateDashboard", 
 		Group: "Dashboard",
@@ -467,6 +612,118 @@ var ApiMap = map[Endpoint]EndpointInfo{
 		Name: "MakeDefaultSystemNotificationRules", 
 		Group: "SystemNotificationRule",
 	},
+    CreateSystemNotificationCredential: {
+		Name: "CreateSystemNotificationCredential", 
+		Group: "SystemNotificationCredential",
+	},
+    GetSystemNotificationCredentials: {
+		Name: "GetSystemNotificationCredentials", 
+		Group: "SystemNotificationCredential",
+	},
+    DeleteSystemNotificationCredential: {
+		Name: "DeleteSystemNotificationCredential", 
+		Group: "SystemNotificationCredential",
+	},
+    RotateSystemNotificationCredential: {
+		Name: "RotateSystemNotificationCredential", 
+		Group: "SystemNotificationCredential",
+	},
+    CreateNotificationChannel: {
+		Name: "CreateNotificationChannel", 
+		Group: "NotificationChannel",
+	},
+    GetNotificationChannels: {
+		Name: "GetNotificationChannels", 
+		Group: "NotificationChannel",
+	},
+    GetNotificationChannel: {
+		Name: "GetNotificationChannel", 
+		Group: "NotificationChannel",
+	},
+    UpdateNotificationChannel: {
+		Name: "UpdateNotificationChannel", 
+		Group: "NotificationChannel",
+	},
+    DeleteNotificationChannel: {
+		Name: "DeleteNotificationChannel", 
+		Group: "NotificationChannel",
+	},
+    TestNotificationChannel: {
+		Name: "TestNotificationChannel", 
+		Group: "NotificationChannel",
+	},
+    GetNotificationDeliveries: {
+		Name: "GetNotificationDeliveries", 
+		Group: "NotificationChannel",
+	},
+    CreateEscalationPolicy: {
+		Name: "CreateEscalationPolicy", 
+		Group: "EscalationPolicy",
+	},
+    GetEscalationPolicies: {
+		Name: "GetEscalationPolicies", 
+		Group: "EscalationPolicy",
+	},
+    GetEscalationPolicy: {
+		Name: "GetEscalationPolicy", 
+		Group: "EscalationPolicy",
+	},
+    UpdateEscalationPolicy: {
+		Name: "UpdateEscalationPolicy", 
+		Group: "EscalationPolicy",
+	},
+    DeleteEscalationPolicy: {
+		Name: "DeleteEscalationPolicy", 
+		Group: "EscalationPolicy",
+	},
+    CreateSystemNotificationSilence: {
+		Name: "CreateSystemNotificationSilence", 
+		Group: "SystemNotificationSilence",
+	},
+    GetSystemNotificationSilences: {
+		Name: "GetSystemNotificationSilences", 
+		Group: "SystemNotificationSilence",
+	},
+    GetSystemNotificationSilence: {
+		Name: "GetSystemNotificationSilence", 
+		Group: "SystemNotificationSilence",
+	},
+    UpdateSystemNotificationSilence: {
+		Name: "UpdateSystemNotificationSilence", 
+		Group: "SystemNotificationSilence",
+	},
+    DeleteSystemNotificationSilence: {
+		Name: "DeleteSystemNotificationSilence", 
+		Group: "SystemNotificationSilence",
+	},
+    CreateOnCallSchedule: {
+		Name: "CreateOnCallSchedule", 
+		Group: "OnCallSchedule",
+	},
+    GetOnCallSchedules: {
+		Name: "GetOnCallSchedules", 
+		Group: "OnCallSchedule",
+	},
+    GetOnCallSchedule: {
+		Name: "GetOnCallSchedule", 
+		Group: "OnCallSchedule",
+	},
+    UpdateOnCallSchedule: {
+		Name: "UpdateOnCallSchedule", 
+		Group: "OnCallSchedule",
+	},
+    DeleteOnCallSchedule: {
+		Name: "DeleteOnCallSchedule", 
+		Group: "OnCallSchedule",
+	},
+    Admin_GetMailOutboxes: {
+		Name: "Admin_GetMailOutboxes", 
+		Group: "MailOutbox",
+	},
+    Admin_ResendMailOutbox: {
+		Name: "Admin_ResendMailOutbox", 
+		Group: "MailOutbox",
+	},
     CreateSystemNotification: {
 		Name: "CreateSystemNotification", 
 		Group: "SystemNotification",
@@ -491,6 +748,10 @@ var ApiMap = map[Endpoint]EndpointInfo{
 		Name: "CreateSystemNotificationAction", 
 		Group: "SystemNotification",
 	},
+    CreateNotificationStreamTicket: {
+		Name: "CreateNotificationStreamTicket", 
+		Group: "SystemNotification",
+	},
     GetPolicyNotifications: {
 		Name: "GetPolicyNotifications", 
 		Group: "PolicyNotification",
@@ -530,6 +791,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     GetStackKubeconfig: {
 		Name: "GetStackKubeconfig", 
 		Group: "Stack",
+		Sensitive: true,
 	},
     GetStackStatus: {
 		Name: "GetStackStatus", 
@@ -634,6 +896,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     GetProjectKubeconfig: {
 		Name: "GetProjectKubeconfig", 
 		Group: "Project",
+		Sensitive: true,
 	},
     GetProjectNamespaceK8sResources: {
 		Name: "GetProjectNamespaceK8sResources", 
@@ -642,6 +905,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     GetProjectNamespaceKubeconfig: {
 		Name: "GetProjectNamespaceKubeconfig", 
 		Group: "Project",
+		Sensitive: true,
 	},
     GetAudits: {
 		Name: "GetAudits", 
@@ -655,9 +919,30 @@ var ApiMap = map[Endpoint]EndpointInfo{
 		Name: "DeleteAudit", 
 		Group: "Audit",
 	},
+    VerifyAudits: {
+		Name: "VerifyAudits", 
+		Group: "Audit",
+	},
+    ExportAudits: {
+		Name: "ExportAudits", 
+		Group: "Audit",
+	},
+    Admin_GetRetentionPolicies: {
+		Name: "Admin_GetRetentionPolicies", 
+		Group: "Retention",
+	},
+    Admin_UpdateRetentionPolicies: {
+		Name: "Admin_UpdateRetentionPolicies", 
+		Group: "Retention",
+	},
+    Admin_GetRetentionStatus: {
+		Name: "Admin_GetRetentionStatus", 
+		Group: "Retention",
+	},
     CreateTksRole: {
 		Name: "CreateTksRole", 
 		Group: "Role",
+		Sensitive: true,
 	},
     ListTksRoles: {
 		Name: "ListTksRoles", 
@@ -670,10 +955,12 @@ var ApiMap = map[Endpoint]EndpointInfo{
     DeleteTksRole: {
 		Name: "DeleteTksRole", 
 		Group: "Role",
+		Sensitive: true,
 	},
     UpdateTksRole: {
 		Name: "UpdateTksRole", 
 		Group: "Role",
+		Sensitive: true,
 	},
     GetPermissionsByRoleId: {
 		Name: "GetPermissionsByRoleId", 
@@ -682,6 +969,7 @@ var ApiMap = map[Endpoint]EndpointInfo{
     UpdatePermissionsByRoleId: {
 		Name: "UpdatePermissionsByRoleId", 
 		Group: "Role",
+		Sensitive: true,
 	},
     IsRoleNameExisted: {
 		Name: "IsRoleNameExisted", 
@@ -703,9 +991,110 @@ var ApiMap = map[Endpoint]EndpointInfo{
 		Name: "GetPermissionTemplates", 
 		Group: "Permission",
 	},
+    CreateServiceAccount: {
+		Name: "CreateServiceAccount", 
+		Group: "ServiceAccount",
+	},
+    GetServiceAccounts: {
+		Name: "GetServiceAccounts", 
+		Group: "ServiceAccount",
+	},
+    GetServiceAccount: {
+		Name: "GetServiceAccount", 
+		Group: "ServiceAccount",
+	},
+    UpdateServiceAccount: {
+		Name: "UpdateServiceAccount", 
+		Group: "ServiceAccount",
+	},
+    DeleteServiceAccount: {
+		Name: "DeleteServiceAccount", 
+		Group: "ServiceAccount",
+	},
+    GetServiceAccountTokens: {
+		Name: "GetServiceAccountTokens", 
+		Group: "ServiceAccount",
+	},
+    CreateServiceAccountToken: {
+		Name: "CreateServiceAccountToken", 
+		Group: "ServiceAccount",
+		Sensitive: true,
+	},
+    RevokeServiceAccountToken: {
+		Name: "RevokeServiceAccountToken", 
+		Group: "ServiceAccount",
+	},
+    CreateIdentityProvider: {
+		Name: "CreateIdentityProvider", 
+		Group: "IdentityProvider",
+		Sensitive: true,
+	},
+    GetIdentityProviders: {
+		Name: "GetIdentityProviders", 
+		Group: "IdentityProvider",
+	},
+    GetIdentityProvider: {
+		Name: "GetIdentityProvider", 
+		Group: "IdentityProvider",
+	},
+    UpdateIdentityProvider: {
+		Name: "UpdateIdentityProvider", 
+		Group: "IdentityProvider",
+		Sensitive: true,
+	},
+    DeleteIdentityProvider: {
+		Name: "DeleteIdentityProvider", 
+		Group: "IdentityProvider",
+		Sensitive: true,
+	},
+    ScimGetServiceProviderConfig: {
+		Name: "ScimGetServiceProviderConfig", 
+		Group: "Scim",
+	},
+    ScimGetUsers: {
+		Name: "ScimGetUsers", 
+		Group: "Scim",
+	},
+    ScimGetUser: {
+		Name: "ScimGetUser", 
+		Group: "Scim",
+	},
+    ScimCreateUser: {
+		Name: "ScimCreateUser", 
+		Group: "Scim",
+	},
+    ScimReplaceUser: {
+		Name: "ScimReplaceUser", 
+		Group: "Scim",
+	},
+    ScimPatchUser: {
+		Name: "ScimPatchUser", 
+		Group: "Scim",
+	},
+    ScimDeleteUser: {
+		Name: "ScimDeleteUser", 
+		Group: "Scim",
+	},
+    ScimGetGroups: {
+		Name: "ScimGetGroups", 
+		Group: "Scim",
+	},
+    ScimGetGroup: {
+		Name: "ScimGetGroup", 
+		Group: "Scim",
+	},
+    ScimReplaceGroup: {
+		Name: "ScimReplaceGroup", 
+		Group: "Scim",
+	},
+    ScimPatchGroup: {
+		Name: "ScimPatchGroup", 
+		Group: "Scim",
+	},
     Admin_CreateUser: {
 		Name: "Admin_CreateUser", 
 		Group: "Admin_User",
+		Sensitive: true,
 	},
     Admin_ListUser: {
 		Name: "Admin_ListUser", 
@@ -718,10 +1107,25 @@ var ApiMap = map[Endpoint]EndpointInfo{
     Admin_DeleteUser: {
 		Name: "Admin_DeleteUser", 
 		Group: "Admin_User",
+		Sensitive: true,
 	},
     Admin_UpdateUser: {
 		Name: "Admin_UpdateUser", 
 		Group: "Admin_User",
+		Sensitive: true,
+	},
+    Admin_CreateImpersonation: {
+		Name: "Admin_CreateImpersonation", 
+		Group: "Impersonation",
+		Sensitive: true,
+	},
+    Admin_GetImpersonations: {
+		Name: "Admin_GetImpersonations", 
+		Group: "Impersonation",
+	},
+    Admin_StopImpersonation: {
+		Name: "Admin_StopImpersonation", 
+		Group: "Impersonation",
 	},
     Admin_ListTksRoles: {
 		Name: "Admin_ListTksRoles", 
@@ -952,6 +1356,12 @@ func (e Endpoint) String() string {
 	switch e {
 	case Login:
 		return "Login"
+	case LoginSecondFactor:
+		return "LoginSecondFactor"
+	case LoginWithIdentityProvider:
+		return "LoginWithIdentityProvider"
+	case GetLoginIdentityProviders:
+		return "GetLoginIdentityProviders"
 	case Logout:
 		return "Logout"
 	case RefreshToken:
@@ -980,12 +1390,40 @@ func (e Endpoint) String() string {
 		return "UpdateUser"
 	case ResetPassword:
 		return "ResetPassword"
+	case UnlockUser:
+		return "UnlockUser"
+	case ResetUserSecondFactor:
+		return "ResetUserSecondFactor"
+	case GetUserSessions:
+		return "GetUserSessions"
+	case RevokeUserSession:
+		return "RevokeUserSession"
+	case RevokeUserSessions:
+		return "RevokeUserSessions"
+	case ImportUsers:
+		return "ImportUsers"
+	case GetUserImports:
+		return "GetUserImports"
+	case GetUserImport:
+		return "GetUserImport"
+	case ExportUsers:
+		return "ExportUsers"
 	case CheckId:
 		return "CheckId"
 	case CheckEmail:
 		return "CheckEmail"
 	case GetPermissionsByAccountId:
 		return "GetPermissionsByAccountId"
+	case CreateInvitation:
+		return "CreateInvitation"
+	case GetInvitations:
+		return "GetInvitations"
+	case GetInvitation:
+		return "GetInvitation"
+	case ResendInvitation:
+		return "ResendInvitation"
+	case RevokeInvitation:
+		return "RevokeInvitation"
 	case GetMyProfile:
 		return "GetMyProfile"
 	case UpdateMyProfile:
@@ -996,6 +1434,30 @@ func (e Endpoint) String() string {
 		return "RenewPasswordExpiredDate"
 	case DeleteMyProfile:
 		return "DeleteMyProfile"
+	case GetMyNotificationPreference:
+		return "GetMyNotificationPreference"
+	case UpdateMyNotificationPreference:
+		return "UpdateMyNotificationPreference"
+	case GetMyApiTokens:
+		return "GetMyApiTokens"
+	case CreateMyApiToken:
+		return "CreateMyApiToken"
+	case RevokeMyApiToken:
+		return "RevokeMyApiToken"
+	case GetMySecondFactor:
+		return "GetMySecondFactor"
+	case CreateMySecondFactor:
+		return "CreateMySecondFactor"
+	case VerifyMySecondFactor:
+		return "VerifyMySecondFactor"
+	case RegenerateMyRecoveryCodes:
+		return "RegenerateMyRecoveryCodes"
+	case DeleteMySecondFactor:
+		return "DeleteMySecondFactor"
+	case GetMySessions:
+		return "GetMySessions"
+	case RevokeMySession:
+		return "RevokeMySession"
 	case Admin_CreateOrganization:
 		return "Admin_CreateOrganization"
 	case Admin_DeleteOrganization:
@@ -1010,6 +1472,10 @@ func (e Endpoint) String() string {
 		return "UpdateOrganization"
 	case UpdatePrimaryCluster:
 		return "UpdatePrimaryCluster"
+	case GetSecurityPolicy:
+		return "GetSecurityPolicy"
+	case UpdateSecurityPolicy:
+		return "UpdateSecurityPolicy"
 	case CreateCluster:
 		return "CreateCluster"
 	case GetClusters:
@@ -1118,6 +1584,8 @@ func (e Endpoint) String() string {
 		return "AddOrganizationStackTemplates"
 	case RemoveOrganizationStackTemplates:
 		return "RemoveOrganizationStackTemplates"
+	case GetOrganizationCloudServices:
+		return "GetOrganizationCloudServices"
 	case CreateDashboard:
 		return "CreateDashboard"
 	case GetDashboard:
@@ -1182,6 +1650,62 @@ func (e Endpoint) String() string {
 		return "UpdateSystemNotificationRule"
 	case MakeDefaultSystemNotificationRules:
 		return "MakeDefaultSystemNotificationRules"
+	case CreateSystemNotificationCredential:
+		return "CreateSystemNotificationCredential"
+	case GetSystemNotificationCredentials:
+		return "GetSystemNotificationCredentials"
+	case DeleteSystemNotificationCredential:
+		return "DeleteSystemNotificationCredential"
+	case RotateSystemNotificationCredential:
+		return "RotateSystemNotificationCredential"
+	case CreateNotificationChannel:
+		return "CreateNotificationChannel"
+	case GetNotificationChannels:
+		return "GetNotificationChannels"
+	case GetNotificationChannel:
+		return "GetNotificationChannel"
+	case UpdateNotificationChannel:
+		return "UpdateNotificationChannel"
+	case DeleteNotificationChannel:
+		return "DeleteNotificationChannel"
+	case TestNotificationChannel:
+		return "TestNotificationChannel"
+	case GetNotificationDeliveries:
+		return "GetNotificationDeliveries"
+	case CreateEscalationPolicy:
+		return "CreateEscalationPolicy"
+	case GetEscalationPolicies:
+		return "GetEscalationPolicies"
+	case GetEscalationPolicy:
+		return "GetEscalationPolicy"
+	case UpdateEscalationPolicy:
+		return "UpdateEscalationPolicy"
+	case DeleteEscalationPolicy:
+		return "DeleteEscalationPolicy"
+	case CreateSystemNotificationSilence:
+		return "CreateSystemNotificationSilence"
+	case GetSystemNotificationSilences:
+		return "GetSystemNotificationSilences"
+	case GetSystemNotificationSilence:
+		return "GetSystemNotificationSilence"
+	case UpdateSystemNotificationSilence:
+		return "UpdateSystemNotificationSilence"
+	case DeleteSystemNotificationSilence:
+		return "DeleteSystemNotificationSilence"
+	case CreateOnCallSchedule:
+		return "CreateOnCallSchedule"
+	case GetOnCallSchedules:
+		return "GetOnCallSchedules"
+	case GetOnCallSchedule:
+		return "GetOnCallSchedule"
+	case UpdateOnCallSchedule:
+		return "UpdateOnCallSchedule"
+	case DeleteOnCallSchedule:
+		return "DeleteOnCallSchedule"
+	case Admin_GetMailOutboxes:
+		return "Admin_GetMailOutboxes"
+	case Admin_ResendMailOutbox:
+		return "Admin_ResendMailOutbox"
 	case CreateSystemNotification:
 		return "CreateSystemNotification"
 	case GetSystemNotifications:
@@ -1194,6 +1718,8 @@ func (e Endpoint) String() string {
 		return "UpdateSystemNotification"
 	case CreateSystemNotificationAction:
 		return "CreateSystemNotificationAction"
+	case CreateNotificationStreamTicket:
+		return "CreateNotificationStreamTicket"
 	case GetPolicyNotifications:
 		return "GetPolicyNotifications"
 	case GetPolicyNotification:
@@ -1276,6 +1802,16 @@ func (e Endpoint) String() string {
 		return "GetAudit"
 	case DeleteAudit:
 		return "DeleteAudit"
+	case VerifyAudits:
+		return "VerifyAudits"
+	case ExportAudits:
+		return "ExportAudits"
+	case Admin_GetRetentionPolicies:
+		return "Admin_GetRetentionPolicies"
+	case Admin_UpdateRetentionPolicies:
+		return "Admin_UpdateRetentionPolicies"
+	case Admin_GetRetentionStatus:
+		return "Admin_GetRetentionStatus"
 	case CreateTksRole:
 		return "CreateTksRole"
 	case ListTksRoles:
@@ -1300,6 +1836,54 @@ func (e Endpoint) String() string {
 		return "RemoveUsersFromRole"
 	case GetPermissionTemplates:
 		return "GetPermissionTemplates"
+	case CreateServiceAccount:
+		return "CreateServiceAccount"
+	case GetServiceAccounts:
+		return "GetServiceAccounts"
+	case GetServiceAccount:
+		return "GetServiceAccount"
+	case UpdateServiceAccount:
+		return "UpdateServiceAccount"
+	case DeleteServiceAccount:
+		return "DeleteServiceAccount"
+	case GetServiceAccountTokens:
+		return "GetServiceAccountTokens"
+	case CreateServiceAccountToken:
+		return "CreateServiceAccountToken"
+	case RevokeServiceAccountToken:
+		return "RevokeServiceAccountToken"
+	case CreateIdentityProvider:
+		return "CreateIdentityProvider"
+	case GetIdentityProviders:
+		return "GetIdentityProviders"
+	case GetIdentityProvider:
+		return "GetIdentityProvider"
+	case UpdateIdentityProvider:
+		return "UpdateIdentityProvider"
+	case DeleteIdentityProvider:
+		return "DeleteIdentityProvider"
+	case ScimGetServiceProviderConfig:
+		return "ScimGetServiceProviderConfig"
+	case ScimGetUsers:
+		return "ScimGetUsers"
+	case ScimGetUser:
+		return "ScimGetUser"
+	case ScimCreateUser:
+		return "ScimCreateUser"
+	case ScimReplaceUser:
+		return "ScimReplaceUser"
+	case ScimPatchUser:
+		return "ScimPatchUser"
+	case ScimDeleteUser:
+		return "ScimDeleteUser"
+	case ScimGetGroups:
+		return "ScimGetGroups"
+	case ScimGetGroup:
+		return "ScimGetGroup"
+	case ScimReplaceGroup:
+		return "ScimReplaceGroup"
+	case ScimPatchGroup:
+		return "ScimPatchGroup"
 	case Admin_CreateUser:
 		return "Admin_CreateUser"
 	case Admin_ListUser:
@@ -1310,6 +1894,12 @@ func (e Endpoint) String() string {
 		return "Admin_DeleteUser"
 	case Admin_UpdateUser:
 		return "Admin_UpdateUser"
+	case Admin_CreateImpersonation:
+		return "Admin_CreateImpersonation"
+	case Admin_GetImpersonations:
+		return "Admin_GetImpersonations"
+	case Admin_StopImpersonation:
+		return "Admin_StopImpersonation"
 	case Admin_ListTksRoles:
 		return "Admin_ListTksRoles"
 	case Admin_GetTksRole:
@@ -1430,6 +2020,12 @@ func GetEndpoint(name string) Endpoint {
 	switch name {
 	case "Login":
 		return Login
+	case "LoginSecondFactor":
+		return LoginSecondFactor
+	case "LoginWithIdentityProvider":
+		return LoginWithIdentityProvider
+	case "GetLoginIdentityProviders":
+		return GetLoginIdentityProviders
 	case "Logout":
 		return Logout
 	case "RefreshToken":
@@ -1458,12 +2054,40 @@ func GetEndpoint(name string) Endpoint {
 		return UpdateUser
 	case "ResetPassword":
 		return ResetPassword
+	case "UnlockUser":
+		return UnlockUser
+	case "ResetUserSecondFactor":
+		return ResetUserSecondFactor
+	case "GetUserSessions":
+		return GetUserSessions
+	case "RevokeUserSession":
+		return RevokeUserSession
+	case "RevokeUserSessions":
+		return RevokeUserSessions
+	case "ImportUsers":
+		return ImportUsers
+	case "GetUserImports":
+		return GetUserImports
+	case "GetUserImport":
+		return GetUserImport
+	case "ExportUsers":
+		return ExportUsers
 	case "CheckId":
 		return CheckId
 	case "CheckEmail":
 		return CheckEmail
 	case "GetPermissionsByAccountId":
 		return GetPermissionsByAccountId
+	case "CreateInvitation":
+		return CreateInvitation
+	case "GetInvitations":
+		return GetInvitations
+	case "GetInvitation":
+		return GetInvitation
+	case "ResendInvitation":
+		return ResendInvitation
+	case "RevokeInvitation":
+		return RevokeInvitation
 	case "GetMyProfile":
 		return GetMyProfile
 	case "UpdateMyProfile":
@@ -1474,6 +2098,30 @@ func GetEndpoint(name string) Endpoint {
 		return RenewPasswordExpiredDate
 	case "DeleteMyProfile":
 		return DeleteMyProfile
+	case "GetMyNotificationPreference":
+		return GetMyNotificationPreference
+	case "UpdateMyNotificationPreference":
+		return UpdateMyNotificationPreference
+	case "GetMyApiTokens":
+		return GetMyApiTokens
+	case "CreateMyApiToken":
+		return CreateMyApiToken
+	case "RevokeMyApiToken":
+		return RevokeMyApiToken
+	case "GetMySecondFactor":
+		return GetMySecondFactor
+	case "CreateMySecondFactor":
+		return CreateMySecondFactor
+	case "VerifyMySecondFactor":
+		return VerifyMySecondFactor
+	case "RegenerateMyRecoveryCodes":
+		return RegenerateMyRecoveryCodes
+	case "DeleteMySecondFactor":
+		return DeleteMySecondFactor
+	case "GetMySessions":
+		return GetMySessions
+	case "RevokeMySession":
+		return RevokeMySession
 	case "Admin_CreateOrganization":
 		return Admin_CreateOrganization
 	case "Admin_DeleteOrganization":
@@ -1488,6 +2136,10 @@ func GetEndpoint(name string) Endpoint {
 		return UpdateOrganization
 	case "UpdatePrimaryCluster":
 		return UpdatePrimaryCluster
+	case "GetSecurityPolicy":
+		return GetSecurityPolicy
+	case "UpdateSecurityPolicy":
+		return UpdateSecurityPolicy
 	case "CreateCluster":
 		return CreateCluster
 	case "GetClusters":
@@ -1596,6 +2248,8 @@ func GetEndpoint(name string) Endpoint {
 		return AddOrganizationStackTemplates
 	case "RemoveOrganizationStackTemplates":
 		return RemoveOrganizationStackTemplates
+	case "GetOrganizationCloudServices":
+		return GetOrganizationCloudServices
 	case "CreateDashboard":
 		return CreateDashboard
 	case "GetDashboard":
@@ -1660,6 +2314,62 @@ func GetEndpoint(name string) Endpoint {
 		return UpdateSystemNotificationRule
 	case "MakeDefaultSystemNotificationRules":
 		return MakeDefaultSystemNotificationRules
+	case "CreateSystemNotificationCredential":
+		return CreateSystemNotificationCredential
+	case "GetSystemNotificationCredentials":
+		return GetSystemNotificationCredentials
+	case "DeleteSystemNotificationCredential":
+		return DeleteSystemNotificationCredential
+	case "RotateSystemNotificationCredential":
+		return RotateSystemNotificationCredential
+	case "CreateNotificationChannel":
+		return CreateNotificationChannel
+	case "GetNotificationChannels":
+		return GetNotificationChannels
+	case "GetNotificationChannel":
+		return GetNotificationChannel
+	case "UpdateNotificationChannel":
+		return UpdateNotificationChannel
+	case "DeleteNotificationChannel":
+		return DeleteNotificationChannel
+	case "TestNotificationChannel":
+		return TestNotificationChannel
+	case "GetNotificationDeliveries":
+		return GetNotificationDeliveries
+	case "CreateEscalationPolicy":
+		return CreateEscalationPolicy
+	case "GetEscalationPolicies":
+		return GetEscalationPolicies
+	case "GetEscalationPolicy":
+		return GetEscalationPolicy
+	case "UpdateEscalationPolicy":
+		return UpdateEscalationPolicy
+	case "DeleteEscalationPolicy":
+		return DeleteEscalationPolicy
+	case "CreateSystemNotificationSilence":
+		return CreateSystemNotificationSilence
+	case "GetSystemNotificationSilences":
+		return GetSystemNotificationSilences
+	case "GetSystemNotificationSilence":
+		return GetSystemNotificationSilence
+	case "UpdateSystemNotificationSilence":
+		return UpdateSystemNotificationSilence
+	case "DeleteSystemNotificationSilence":
+		return DeleteSystemNotificationSilence
+	case "CreateOnCallSchedule":
+		return CreateOnCallSchedule
+	case "GetOnCallSchedules":
+		return GetOnCallSchedules
+	case "GetOnCallSchedule":
+		return GetOnCallSchedule
+	case "UpdateOnCallSchedule":
+		return UpdateOnCallSchedule
+	case "DeleteOnCallSchedule":
+		return DeleteOnCallSchedule
+	case "Admin_GetMailOutboxes":
+		return Admin_GetMailOutboxes
+	case "Admin_ResendMailOutbox":
+		return Admin_ResendMailOutbox
 	case "CreateSystemNotification":
 		return CreateSystemNotification
 	case "GetSystemNotifications":
@@ -1672,6 +2382,8 @@ func GetEndpoint(name string) Endpoint {
 		return UpdateSystemNotification
 	case "CreateSystemNotificationAction":
 		return CreateSystemNotificationAction
+	case "CreateNotificationStreamTicket":
+		return CreateNotificationStreamTicket
 	case "GetPolicyNotifications":
 		return GetPolicyNotifications
 	case "GetPolicyNotification":
@@ -1754,6 +2466,16 @@ func GetEndpoint(name string) Endpoint {
 		return GetAudit
 	case "DeleteAudit":
 		return DeleteAudit
+	case "VerifyAudits":
+		return VerifyAudits
+	case "ExportAudits":
+		return ExportAudits
+	case "Admin_GetRetentionPolicies":
+		return Admin_GetRetentionPolicies
+	case "Admin_UpdateRetentionPolicies":
+		return Admin_UpdateRetentionPolicies
+	case "Admin_GetRetentionStatus":
+		return Admin_GetRetentionStatus
 	case "CreateTksRole":
 		return CreateTksRole
 	case "ListTksRoles":
@@ -1778,6 +2500,54 @@ func GetEndpoint(name string) Endpoint {
 		return RemoveUsersFromRole
 	case "GetPermissionTemplates":
 		return GetPermissionTemplates
+	case "CreateServiceAccount":
+		return CreateServiceAccount
+	case "GetServiceAccounts":
+		return GetServiceAccounts
+	case "GetServiceAccount":
+		return GetServiceAccount
+	case "UpdateServiceAccount":
+		return UpdateServiceAccount
+	case "DeleteServiceAccount":
+		return DeleteServiceAccount
+	case "GetServiceAccountTokens":
+		return GetServiceAccountTokens
+	case "CreateServiceAccountToken":
+		return CreateServiceAccountToken
+	case "RevokeServiceAccountToken":
+		return RevokeServiceAccountToken
+	case "CreateIdentityProvider":
+		return CreateIdentityProvider
+	case "GetIdentityProviders":
+		return GetIdentityProviders
+	case "GetIdentityProvider":
+		return GetIdentityProvider
+	case "UpdateIdentityProvider":
+		return UpdateIdentityProvider
+	case "DeleteIdentityProvider":
+		return DeleteIdentityProvider
+	case "ScimGetServiceProviderConfig":
+		return ScimGetServiceProviderConfig
+	case "ScimGetUsers":
+		return ScimGetUsers
+	case "ScimGetUser":
+		return ScimGetUser
+	case "ScimCreateUser":
+		return ScimCreateUser
+	case "ScimReplaceUser":
+		return ScimReplaceUser
+	case "ScimPatchUser":
+		return ScimPatchUser
+	case "ScimDeleteUser":
+		return ScimDeleteUser
+	case "ScimGetGroups":
+		return ScimGetGroups
+	case "ScimGetGroup":
+		return ScimGetGroup
+	case "ScimReplaceGroup":
+		return ScimReplaceGroup
+	case "ScimPatchGroup":
+		return ScimPatchGroup
 	case "Admin_CreateUser":
 		return Admin_CreateUser
 	case "Admin_ListUser":
@@ -1788,6 +2558,12 @@ func GetEndpoint(name string) Endpoint {
 		return Admin_DeleteUser
 	case "Admin_UpdateUser":
 		return Admin_UpdateUser
+	case "Admin_CreateImpersonation":
+		return Admin_CreateImpersonation
+	case "Admin_GetImpersonations":
+		return Admin_GetImpersonations
+	case "Admin_StopImpersonation":
+		return Admin_StopImpersonation
 	case "Admin_ListTksRoles":
 		return Admin_ListTksRoles
 	case "Admin_GetTksRole":
@@ -0,0 +1,175 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type ApiTokenHandler struct {
+	usecase usecase.IApiTokenUsecase
+}
+
+func NewApiTokenHandler(h usecase.Usecase) *ApiTokenHandler {
+	return &ApiTokenHandler{
+		usecase: h.ApiToken,
+	}
+}
+
+// GetMyApiTokens godoc
+//
+//	@Tags			My-profile
+//	@Summary		Get my api tokens
+//	@Description	Get personal api tokens
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetApiTokensResponse
+//	@Router			/organizations/{organizationId}/my-profile/api-tokens [get]
+//	@Security		JWT
+func (h *ApiTokenHandler) GetMyApiTokens(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	apiTokens, err := h.usecase.FetchByUserId(r.Context(), requestUserInfo.GetUserId(), pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := toGetApiTokensResponse(r, apiTokens)
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// CreateMyApiToken godoc
+//
+//	@Tags			My-profile
+//	@Summary		Create my api token
+//	@Description	Create personal api token. The token is returned only once.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string							true	"organizationId"
+//	@Param			body			body		domain.CreateApiTokenRequest	true	"create api token request"
+//	@Success		200				{object}	domain.CreateApiTokenResponse
+//	@Router			/organizations/{organizationId}/my-profile/api-tokens [post]
+//	@Security		JWT
+func (h *ApiTokenHandler) CreateMyApiToken(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	input := domain.CreateApiTokenRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.ApiToken
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	userId := requestUserInfo.GetUserId()
+	dto.OrganizationId = requestUserInfo.GetOrganizationId()
+	dto.UserId = &userId
+
+	id, token, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateApiTokenResponse{
+		ID:    id.String(),
+		Token: token,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// RevokeMyApiToken godoc
+//
+//	@Tags			My-profile
+//	@Summary		Revoke my api token
+//	@Description	Revoke personal api token
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			apiTokenId		path		string	true	"apiTokenId"
+//	@Success		200				{object}	domain.RevokeApiTokenResponse
+//	@Router			/organizations/{organizationId}/my-profile/api-tokens/{apiTokenId} [delete]
+//	@Security		JWT
+func (h *ApiTokenHandler) RevokeMyApiToken(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	apiTokenId, err := apiTokenVar(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	apiToken, err := h.usecase.RevokeByUser(r.Context(), requestUserInfo.GetUserId(), apiTokenId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.RevokeApiTokenResponse{
+		ID:   apiToken.ID.String(),
+		Name: apiToken.Name,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func toGetApiTokensResponse(r *http.Request, apiTokens []model.ApiToken) (out domain.GetApiTokensResponse) {
+	out.ApiTokens = make([]domain.ApiTokenResponse, len(apiTokens))
+	for i, apiToken := range apiTokens {
+		if err := serializer.Map(r.Context(), apiToken, &out.ApiTokens[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+	return out
+}
+
+func apiTokenVar(r *http.Request) (apiTokenId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	strId, ok := vars["apiTokenId"]
+	if !ok {
+		return uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid apiTokenId"), "C_INVALID_API_TOKEN_ID", "")
+	}
+	apiTokenId, err = uuid.Parse(strId)
+	if err != nil {
+		return uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_API_TOKEN_ID", "")
+	}
+	return apiTokenId, nil
+}
@@ -1,11 +1,17 @@
 package http
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/pagination"
 	"github.com/openinfradev/tks-api/internal/serializer"
 	"github.com/openinfradev/tks-api/internal/usecase"
@@ -68,9 +74,7 @@ func (h *AuditHandler) GetAudits(w http.ResponseWriter, r *http.Request) {
 	var out domain.GetAuditsResponse
 	out.Audits = make([]domain.AuditResponse, len(audits))
 	for i, audit := range audits {
-		if err := serializer.Map(r.Context(), audit, &out.Audits[i]); err != nil {
-			log.Info(r.Context(), err)
-		}
+		out.Audits[i] = toAuditResponse(r, audit)
 	}
 
 	if out.Pagination, err = pg.Response(r.Context()); err != nil {
@@ -113,9 +117,7 @@ func (h *AuditHandler) GetAudit(w http.ResponseWriter, r *http.Request) {
 	log.Info(r.Context(), audit)
 
 	var out domain.GetAuditResponse
-	if err := serializer.Map(r.Context(), audit, &out.Audit); err != nil {
-		log.Info(r.Context(), err)
-	}
+	out.Audit = toAuditResponse(r, audit)
 
 	ResponseJSON(w, r, http.StatusOK, out)
 
@@ -124,8 +126,8 @@ func (h *AuditHandler) GetAudit(w http.ResponseWriter, r *http.Request) {
 // DeleteAudit godoc
 //
 //	@Tags			Audits
-//	@Summary		Delete Audit 'NOT IMPLEMENTED'
-//	@Description	Delete Audit
+//	@Summary		Delete Audit 'NOT ALLOWED'
+//	@Description	Audits are append-only. They are archived and purged by the retention.
 //	@Accept			json
 //	@Produce		json
 //	@Param			auditId	path		string	true	"auditId"
@@ -133,5 +135,242 @@ func (h *AuditHandler) GetAudit(w http.ResponseWriter, r *http.Request) {
 //	@Router			/admin/audits/{auditId} [delete]
 //	@Security		JWT
 func (h *AuditHandler) DeleteAudit(w http.ResponseWriter, r *http.Request) {
-	ErrorJSON(w, r, fmt.Errorf("need implementation"))
+	ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("audits are append-only"), "AU_APPEND_ONLY", ""))
+}
+
+// VerifyAudits godoc
+//
+//	@Tags			Audits
+//	@Summary		Verify Audits
+//	@Description	Walk the hash chain of the audits of the organization and report the first broken link.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	query		string	false	"organizationId. the organization of the user if empty"
+//	@Success		200				{object}	domain.VerifyAuditsResponse
+//	@Router			/admin/audits/verification [get]
+//	@Security		JWT
+func (h *AuditHandler) VerifyAudits(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.usecase.Verify(r.Context(), r.URL.Query().Get("organizationId"))
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.VerifyAuditsResponse
+	if err := serializer.Map(r.Context(), verification, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	if verification.BrokenAuditId != nil {
+		out.BrokenAuditId = verification.BrokenAuditId.String()
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// ExportAudits godoc
+//
+//	@Tags			Audits
+//	@Summary		Export Audits
+//	@Description	Export the audits in the date range with the filters in csv or json lines. The range is the last 30 days by default and up to 1 year.
+//	@Accept			json
+//	@Produce		text/csv,application/x-ndjson
+//	@Param			format		query		string		false	"csv (default) or jsonl"
+//	@Param			startDate	query		string		false	"RFC3339 or yyyy-mm-dd. inclusive"
+//	@Param			endDate		query		string		false	"RFC3339 or yyyy-mm-dd. exclusive"
+//	@Param			filter		query		[]string	false	"filters"
+//	@Param			or			query		[]string	false	"filters"
+//	@Success		200			{object}	nil
+//	@Router			/admin/audits/export [get]
+//	@Security		JWT
+func (h *AuditHandler) ExportAudits(w http.ResponseWriter, r *http.Request) {
+	urlParams := r.URL.Query()
+	format := urlParams.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "jsonl" {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid format %s", format), "AU_INVALID_FORMAT", ""))
+		return
+	}
+
+	endDate := time.Now()
+	if value := urlParams.Get("endDate"); value != "" {
+		parsed, err := parseAuditDate(value)
+		if err != nil {
+			ErrorJSON(w, r, httpErrors.NewBadRequestError(err, "AU_INVALID_DATE_RANGE", ""))
+			return
+		}
+		endDate = parsed
+	}
+	startDate := endDate.AddDate(0, 0, -30)
+	if value := urlParams.Get("startDate"); value != "" {
+		parsed, err := parseAuditDate(value)
+		if err != nil {
+			ErrorJSON(w, r, httpErrors.NewBadRequestError(err, "AU_INVALID_DATE_RANGE", ""))
+			return
+		}
+		startDate = parsed
+	}
+	pg := pagination.NewPagination(&urlParams)
+
+	// 첫 배치를 쓰기 전에 실패하면 오류를 응답한다. 이후의 실패는 응답이 이미 시작되어 기록만 한다.
+	var writer auditExportWriter
+	err := h.usecase.Export(r.Context(), pg, startDate, endDate, func(audits []model.Audit) error {
+		if writer == nil {
+			writer = newAuditExportWriter(w, format, startDate, endDate)
+		}
+		return writer.Write(toAuditResponses(r, audits))
+	})
+	if err != nil {
+		if writer == nil {
+			ErrorJSON(w, r, err)
+			return
+		}
+		log.Error(r.Context(), err)
+		return
+	}
+	if writer == nil {
+		writer = newAuditExportWriter(w, format, startDate, endDate)
+		if err := writer.Write(nil); err != nil {
+			log.Error(r.Context(), err)
+		}
+	}
+}
+
+type auditExportWriter interface {
+	Write(audits []domain.AuditResponse) error
+}
+
+func newAuditExportWriter(w http.ResponseWriter, format string, startDate time.Time, endDate time.Time) auditExportWriter {
+	filename := fmt.Sprintf("audits-%s-%s", startDate.Format("20060102"), endDate.Format("20060102"))
+	if format == "jsonl" {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.jsonl", filename))
+		w.WriteHeader(http.StatusOK)
+		return &auditJsonLinesWriter{w: w}
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
+	w.WriteHeader(http.StatusOK)
+	return &auditCsvWriter{w: w, csv: csv.NewWriter(w)}
+}
+
+var auditExportCsvHeader = []string{
+	"createdAt", "organizationId", "organizationName", "sequence", "userAccountId", "userName", "userRoles",
+	"impersonatorAccountId", "clientIP", "group", "endpoint", "method", "path", "resourceType", "resourceId",
+	"statusCode", "result", "requestId", "message", "messageEn", "description", "changes", "hash",
+}
+
+type auditCsvWriter struct {
+	w             http.ResponseWriter
+	csv           *csv.Writer
+	headerWritten bool
+}
+
+func (e *auditCsvWriter) Write(audits []domain.AuditResponse) error {
+	if !e.headerWritten {
+		if err := e.csv.Write(auditExportCsvHeader); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+	for _, audit := range audits {
+		changes, _ := json.Marshal(audit.Changes)
+		record := []string{
+			audit.CreatedAt.Format(time.RFC3339Nano),
+			audit.OrganizationId,
+			audit.OrganizationName,
+			strconv.FormatInt(audit.Sequence, 10),
+			audit.UserAccountId,
+			audit.UserName,
+			audit.UserRoles,
+			audit.ImpersonatorAccountId,
+			audit.ClientIP,
+			audit.Group,
+			audit.Endpoint,
+			audit.Method,
+			audit.Path,
+			audit.ResourceType,
+			audit.ResourceId,
+			strconv.Itoa(audit.StatusCode),
+			audit.Result,
+			audit.RequestId,
+			audit.Message,
+			audit.MessageEn,
+			audit.Description,
+			string(changes),
+			audit.Hash,
+		}
+		for i := range record {
+			record[i] = escapeCsvFormula(record[i])
+		}
+		if err := e.csv.Write(record); err != nil {
+			return err
+		}
+	}
+	e.csv.Flush()
+	flushResponse(e.w)
+	return e.csv.Error()
+}
+
+// escapeCsvFormula prefixes the cell which spreadsheets evaluate as a formula with a quote.
+// 요청 경로나 메시지처럼 사용자가 넣은 값이 기록되므로 내보낸 파일을 열 때 수식이 실행되지 않도록 한다.
+func escapeCsvFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+type auditJsonLinesWriter struct {
+	w http.ResponseWriter
+}
+
+func (e *auditJsonLinesWriter) Write(audits []domain.AuditResponse) error {
+	encoder := json.NewEncoder(e.w)
+	for _, audit := range audits {
+		if err := encoder.Encode(audit); err != nil {
+			return err
+		}
+	}
+	flushResponse(e.w)
+	return nil
+}
+
+func flushResponse(w http.ResponseWriter) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func parseAuditDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+func toAuditResponses(r *http.Request, audits []model.Audit) []domain.AuditResponse {
+	out := make([]domain.AuditResponse, len(audits))
+	for i, audit := range audits {
+		out[i] = toAuditResponse(r, audit)
+	}
+	return out
+}
+
+func toAuditResponse(r *http.Request, audit model.Audit) (out domain.AuditResponse) {
+	if err := serializer.Map(r.Context(), audit, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	if audit.UserId != nil {
+		out.UserId = audit.UserId.String()
+	}
+	if audit.ImpersonatorId != nil {
+		out.ImpersonatorId = audit.ImpersonatorId.String()
+	}
+	out.Changes = make([]domain.AuditChangeResponse, len(audit.Changes))
+	for i, change := range audit.Changes {
+		out.Changes[i] = domain.AuditChangeResponse{Field: change.Field, Before: change.Before, After: change.After}
+	}
+	return
 }
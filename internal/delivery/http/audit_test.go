@@ -0,0 +1,48 @@
+package http
+
+import (
+	"encoding/csv"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openinfradev/tks-api/pkg/domain"
+)
+
+func TestAuditCsvWriterEscapesFormula(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{name: "plain", message: "created user", want: "created user"},
+		{name: "empty", message: "", want: ""},
+		{name: "equals", message: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{name: "plus", message: "+1+1", want: "'+1+1"},
+		{name: "minus", message: "-1+1", want: "'-1+1"},
+		{name: "at", message: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "tab", message: "\t=1+1", want: "'\t=1+1"},
+		{name: "formula in the middle", message: "user =1+1", want: "user =1+1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			writer := &auditCsvWriter{w: w, csv: csv.NewWriter(w)}
+			if err := writer.Write([]domain.AuditResponse{{Message: tc.message}}); err != nil {
+				t.Fatal(err)
+			}
+
+			records, err := csv.NewReader(w.Body).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != 2 {
+				t.Fatalf("expected header and a record, got %d records", len(records))
+			}
+			for i, column := range auditExportCsvHeader {
+				if column == "message" && records[1][i] != tc.want {
+					t.Errorf("expected %q, got %q", tc.want, records[1][i])
+				}
+			}
+		})
+	}
+}
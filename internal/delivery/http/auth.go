@@ -17,6 +17,9 @@ import (
 
 type IAuthHandler interface {
 	Login(w http.ResponseWriter, r *http.Request)
+	LoginSecondFactor(w http.ResponseWriter, r *http.Request)
+	LoginWithIdentityProvider(w http.ResponseWriter, r *http.Request)
+	GetLoginIdentityProviders(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
 	RefreshToken(w http.ResponseWriter, r *http.Request)
 	FindId(w http.ResponseWriter, r *http.Request)
@@ -28,16 +31,18 @@ type IAuthHandler interface {
 	//Authenticate(next http.Handler) http.Handler
 }
 type AuthHandler struct {
-	usecase        usecase.IAuthUsecase
-	auditUsecase   usecase.IAuditUsecase
-	projectUsecase usecase.IProjectUsecase
+	usecase                 usecase.IAuthUsecase
+	auditUsecase            usecase.IAuditUsecase
+	projectUsecase          usecase.IProjectUsecase
+	identityProviderUsecase usecase.IIdentityProviderUsecase
 }
 
 func NewAuthHandler(h usecase.Usecase) IAuthHandler {
 	return &AuthHandler{
-		usecase:        h.Auth,
-		auditUsecase:   h.Audit,
-		projectUsecase: h.Project,
+		usecase:                 h.Auth,
+		auditUsecase:            h.Audit,
+		projectUsecase:          h.Project,
+		identityProviderUsecase: h.IdentityProvider,
 	}
 }
 
@@ -59,7 +64,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.usecase.Login(r.Context(), input.AccountId, input.Password, input.OrganizationId)
+	user, err := h.usecase.Login(r.Context(), input.AccountId, input.Password, input.OrganizationId, audit.GetClientIpAddress(w, r))
 	if err != nil {
 		errorResponse, _ := httpErrors.ErrorResponse(err)
 		_, _ = h.auditUsecase.Create(r.Context(), model.Audit{
@@ -74,17 +79,23 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		log.Errorf(r.Context(), "error is :%s(%T)", err.Error(), err)
 		ErrorJSON(w, r, err)
 		return
-	} else {
-		_, _ = h.auditUsecase.Create(r.Context(), model.Audit{
-			OrganizationId: input.OrganizationId,
-			Group:          "Auth",
-			Message:        fmt.Sprintf("[%s]님이 로그인 하였습니다.", input.AccountId),
-			Description:    "",
-			ClientIP:       audit.GetClientIpAddress(w, r),
-			UserId:         &user.ID,
-		})
 	}
 
+	// 2단계 인증이 남아 있으면 토큰을 발급하지 않고 2단계 인증용 토큰만 전달한다.
+	if user.SecondFactorToken != "" {
+		ResponseJSON(w, r, http.StatusOK, toSecondFactorRequiredResponse(user))
+		return
+	}
+
+	_, _ = h.auditUsecase.Create(r.Context(), model.Audit{
+		OrganizationId: input.OrganizationId,
+		Group:          "Auth",
+		Message:        fmt.Sprintf("[%s]님이 로그인 하였습니다.", input.AccountId),
+		Description:    "",
+		ClientIP:       audit.GetClientIpAddress(w, r),
+		UserId:         &user.ID,
+	})
+
 	var cookies []*http.Cookie
 	if targetCookies, err := h.usecase.SingleSignIn(r.Context(), input.OrganizationId, input.AccountId, input.Password); err != nil {
 		log.Errorf(r.Context(), "error is :%s(%T)", err.Error(), err)
@@ -98,8 +109,150 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var out domain.LoginResponse
-	if err = serializer.Map(r.Context(), user, &out.User); err != nil {
+	ResponseJSON(w, r, http.StatusOK, toLoginResponse(r, user))
+}
+
+// LoginSecondFactor godoc
+//
+//	@Tags			Auth
+//	@Summary		login with second factor
+//	@Description	Complete the login with the second factor token of the login response and the TOTP code or a recovery code
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.LoginSecondFactorRequest	true	"second factor"
+//	@Success		200		{object}	domain.LoginResponse			"user detail"
+//	@Router			/auth/login/second-factor [post]
+func (h *AuthHandler) LoginSecondFactor(w http.ResponseWriter, r *http.Request) {
+	input := domain.LoginSecondFactorRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	user, err := h.usecase.LoginSecondFactor(r.Context(), input.SecondFactorToken, input.Code, audit.GetClientIpAddress(w, r))
+	if err != nil {
+		errorResponse, _ := httpErrors.ErrorResponse(err)
+		_, _ = h.auditUsecase.Create(r.Context(), model.Audit{
+			OrganizationId: user.Organization.ID,
+			Group:          "Auth",
+			Message:        fmt.Sprintf("[%s]님이 2단계 인증에 실패하였습니다.", user.AccountId),
+			Description:    errorResponse.Text(),
+			ClientIP:       audit.GetClientIpAddress(w, r),
+			UserId:         nil,
+			UserAccountId:  user.AccountId,
+		})
+		log.Errorf(r.Context(), "error is :%s(%T)", err.Error(), err)
+		ErrorJSON(w, r, err)
+		return
+	}
+	_, _ = h.auditUsecase.Create(r.Context(), model.Audit{
+		OrganizationId: user.Organization.ID,
+		Group:          "Auth",
+		Message:        fmt.Sprintf("[%s]님이 2단계 인증으로 로그인 하였습니다.", user.AccountId),
+		Description:    "",
+		ClientIP:       audit.GetClientIpAddress(w, r),
+		UserId:         &user.ID,
+	})
+
+	// 비밀번호가 없으므로 keycloak SSO 쿠키는 발급하지 않는다.
+	ResponseJSON(w, r, http.StatusOK, toLoginResponse(r, user))
+}
+
+// LoginWithIdentityProvider godoc
+//
+//	@Tags			Auth
+//	@Summary		login with identity provider
+//	@Description	Login with the authorization code returned from keycloak after the login through the identity provider (kc_idp_hint=alias). The user is created on the first login.
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.LoginWithIdentityProviderRequest	true	"authorization code"
+//	@Success		200		{object}	domain.LoginResponse					"user detail"
+//	@Router			/auth/login/identity-provider [post]
+func (h *AuthHandler) LoginWithIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	input := domain.LoginWithIdentityProviderRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	user, err := h.usecase.LoginWithIdentityProvider(r.Context(), input.OrganizationId, input.Code, input.RedirectUri, audit.GetClientIpAddress(w, r))
+	if err != nil {
+		errorResponse, _ := httpErrors.ErrorResponse(err)
+		_, _ = h.auditUsecase.Create(r.Context(), model.Audit{
+			OrganizationId: input.OrganizationId,
+			Group:          "Auth",
+			Message:        "외부 인증 서버를 통한 로그인에 실패하였습니다.",
+			Description:    errorResponse.Text(),
+			ClientIP:       audit.GetClientIpAddress(w, r),
+			UserId:         nil,
+		})
+		log.Errorf(r.Context(), "error is :%s(%T)", err.Error(), err)
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if user.SecondFactorToken != "" {
+		ResponseJSON(w, r, http.StatusOK, toSecondFactorRequiredResponse(user))
+		return
+	}
+
+	_, _ = h.auditUsecase.Create(r.Context(), model.Audit{
+		OrganizationId: input.OrganizationId,
+		Group:          "Auth",
+		Message:        fmt.Sprintf("[%s]님이 외부 인증 서버를 통해 로그인 하였습니다.", user.AccountId),
+		Description:    "",
+		ClientIP:       audit.GetClientIpAddress(w, r),
+		UserId:         &user.ID,
+	})
+
+	ResponseJSON(w, r, http.StatusOK, toLoginResponse(r, user))
+}
+
+// GetLoginIdentityProviders godoc
+//
+//	@Tags			Auth
+//	@Summary		Get identity providers for login
+//	@Description	Get the enabled identity providers (OIDC, SAML) of the organization shown on the login page
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	query		string	true	"organizationId"
+//	@Success		200				{object}	domain.GetLoginIdentityProvidersResponse
+//	@Router			/auth/identity-providers [get]
+func (h *AuthHandler) GetLoginIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	organizationId := r.URL.Query().Get("organizationId")
+	if organizationId == "" {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	identityProviders, err := h.identityProviderUsecase.FetchForLogin(r.Context(), organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetLoginIdentityProvidersResponse
+	out.IdentityProviders = make([]domain.LoginIdentityProviderResponse, len(identityProviders))
+	for i, identityProvider := range identityProviders {
+		out.IdentityProviders[i] = domain.LoginIdentityProviderResponse{
+			Alias:       identityProvider.Alias,
+			Type:        string(identityProvider.Type),
+			DisplayName: identityProvider.DisplayName,
+		}
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func toSecondFactorRequiredResponse(user model.User) (out domain.LoginResponse) {
+	out.SecondFactorRequired = true
+	out.SecondFactorToken = user.SecondFactorToken
+	return out
+}
+
+func toLoginResponse(r *http.Request, user model.User) (out domain.LoginResponse) {
+	if err := serializer.Map(r.Context(), user, &out.User); err != nil {
 		log.Error(r.Context(), err)
 	}
 	for _, role := range user.Roles {
@@ -108,8 +261,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 			Name: role.Name,
 		})
 	}
-
-	ResponseJSON(w, r, http.StatusOK, out)
+	return out
 }
 
 // Logout godoc
@@ -181,6 +333,7 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 //	@Param			body	body		domain.FindIdRequest	true	"Request body for finding the account ID including {organization ID, email, username, 6 digit code}"
 //	@Success		200		{object}	domain.FindIdResponse
 //	@Failure		400		{object}	httpErrors.RestError
+//	@Failure		429		{object}	httpErrors.RestError
 //	@Router			/auth/find-id/verification [post]
 func (h *AuthHandler) FindId(w http.ResponseWriter, r *http.Request) {
 	input := domain.FindIdRequest{}
@@ -190,7 +343,7 @@ func (h *AuthHandler) FindId(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accountId, err := h.usecase.FindId(r.Context(), input.Code, input.Email, input.UserName, input.OrganizationId)
+	accountId, err := h.usecase.FindId(r.Context(), input.Code, input.Email, input.UserName, input.OrganizationId, audit.GetClientIpAddress(w, r))
 	if err != nil {
 		log.Errorf(r.Context(), "error is :%s(%T)", err.Error(), err)
 
@@ -213,6 +366,7 @@ func (h *AuthHandler) FindId(w http.ResponseWriter, r *http.Request) {
 //	@Param			body	body	domain.FindPasswordRequest	true	"Request body for finding the password including {organization ID, email, username, Account ID, 6 digit code}"
 //	@Success		200
 //	@Failure		400	{object}	httpErrors.RestError
+//	@Failure		429	{object}	httpErrors.RestError
 //	@Router			/auth/find-password/verification [post]
 func (h *AuthHandler) FindPassword(w http.ResponseWriter, r *http.Request) {
 	input := domain.FindPasswordRequest{}
@@ -222,7 +376,7 @@ func (h *AuthHandler) FindPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.usecase.FindPassword(r.Context(), input.Code, input.AccountId, input.Email, input.UserName, input.OrganizationId)
+	err = h.usecase.FindPassword(r.Context(), input.Code, input.AccountId, input.Email, input.UserName, input.OrganizationId, audit.GetClientIpAddress(w, r))
 	if err != nil {
 		log.Errorf(r.Context(), "error is :%s(%T)", err.Error(), err)
 		ErrorJSON(w, r, err)
@@ -0,0 +1,302 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type EscalationPolicyHandler struct {
+	usecase usecase.IEscalationPolicyUsecase
+}
+
+func NewEscalationPolicyHandler(h usecase.Usecase) *EscalationPolicyHandler {
+	return &EscalationPolicyHandler{
+		usecase: h.EscalationPolicy,
+	}
+}
+
+// CreateEscalationPolicy godoc
+//
+//	@Tags			EscalationPolicies
+//	@Summary		Create EscalationPolicy
+//	@Description	Create EscalationPolicy. While a critical system notification is not taken, the tiers are notified in order.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string									true	"organizationId"
+//	@Param			body			body		domain.CreateEscalationPolicyRequest	true	"create escalation policy request"
+//	@Success		200				{object}	domain.CreateEscalationPolicyResponse
+//	@Router			/organizations/{organizationId}/escalation-policies [post]
+//	@Security		JWT
+func (h *EscalationPolicyHandler) CreateEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateEscalationPolicyRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.EscalationPolicy
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+	if dto.Tiers, err = makeEscalationTiers(input.Tiers); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	id, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateEscalationPolicyResponse{
+		ID: id.String(),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetEscalationPolicies godoc
+//
+//	@Tags			EscalationPolicies
+//	@Summary		Get EscalationPolicies
+//	@Description	Get EscalationPolicies
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetEscalationPoliciesResponse
+//	@Router			/organizations/{organizationId}/escalation-policies [get]
+//	@Security		JWT
+func (h *EscalationPolicyHandler) GetEscalationPolicies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	policies, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetEscalationPoliciesResponse
+	out.EscalationPolicies = make([]domain.EscalationPolicyResponse, len(policies))
+	for i, policy := range policies {
+		out.EscalationPolicies[i] = makeEscalationPolicyResponse(r, policy)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetEscalationPolicy godoc
+//
+//	@Tags			EscalationPolicies
+//	@Summary		Get EscalationPolicy
+//	@Description	Get EscalationPolicy
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			escalationPolicyId	path		string	true	"escalationPolicyId"
+//	@Success		200					{object}	domain.GetEscalationPolicyResponse
+//	@Router			/organizations/{organizationId}/escalation-policies/{escalationPolicyId} [get]
+//	@Security		JWT
+func (h *EscalationPolicyHandler) GetEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	organizationId, escalationPolicyId, err := escalationPolicyVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	policy, err := h.usecase.Get(r.Context(), organizationId, escalationPolicyId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetEscalationPolicyResponse
+	out.EscalationPolicy = makeEscalationPolicyResponse(r, policy)
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateEscalationPolicy godoc
+//
+//	@Tags			EscalationPolicies
+//	@Summary		Update EscalationPolicy
+//	@Description	Update EscalationPolicy. The tiers are replaced with the requested tiers.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string									true	"organizationId"
+//	@Param			escalationPolicyId	path		string									true	"escalationPolicyId"
+//	@Param			body				body		domain.UpdateEscalationPolicyRequest	true	"update escalation policy request"
+//	@Success		200					{object}	nil
+//	@Router			/organizations/{organizationId}/escalation-policies/{escalationPolicyId} [put]
+//	@Security		JWT
+func (h *EscalationPolicyHandler) UpdateEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	organizationId, escalationPolicyId, err := escalationPolicyVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.UpdateEscalationPolicyRequest{}
+	err = UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.EscalationPolicy
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.ID = escalationPolicyId
+	dto.OrganizationId = organizationId
+	if dto.Tiers, err = makeEscalationTiers(input.Tiers); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Update(r.Context(), dto); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// DeleteEscalationPolicy godoc
+//
+//	@Tags			EscalationPolicies
+//	@Summary		Delete EscalationPolicy
+//	@Description	Delete EscalationPolicy. The policy is also removed from the system notification rules.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			escalationPolicyId	path		string	true	"escalationPolicyId"
+//	@Success		200					{object}	nil
+//	@Router			/organizations/{organizationId}/escalation-policies/{escalationPolicyId} [delete]
+//	@Security		JWT
+func (h *EscalationPolicyHandler) DeleteEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	organizationId, escalationPolicyId, err := escalationPolicyVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Delete(r.Context(), organizationId, escalationPolicyId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+func escalationPolicyVars(r *http.Request) (organizationId string, escalationPolicyId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["escalationPolicyId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid escalationPolicyId"), "C_INVALID_ESCALATION_POLICY_ID", "")
+	}
+	escalationPolicyId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_ESCALATION_POLICY_ID", "")
+	}
+
+	return organizationId, escalationPolicyId, nil
+}
+
+// parseEscalationPolicyId returns nil if strId is empty, which means no escalation.
+func parseEscalationPolicyId(strId string) (*uuid.UUID, error) {
+	if strId == "" {
+		return nil, nil
+	}
+	escalationPolicyId, err := uuid.Parse(strId)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_ESCALATION_POLICY_ID", "")
+	}
+	return &escalationPolicyId, nil
+}
+
+func makeEscalationTiers(tiers []domain.EscalationTierRequest) ([]model.EscalationTier, error) {
+	out := make([]model.EscalationTier, len(tiers))
+	for i, tier := range tiers {
+		out[i] = model.EscalationTier{
+			Level:                  i,
+			DelayMinutes:           tier.DelayMinutes,
+			TargetUserIds:          tier.TargetUserIds,
+			NotificationChannelIds: tier.NotificationChannelIds,
+		}
+		if tier.OnCallScheduleId != "" {
+			onCallScheduleId, err := uuid.Parse(tier.OnCallScheduleId)
+			if err != nil {
+				return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_ON_CALL_SCHEDULE_ID", "")
+			}
+			out[i].OnCallScheduleId = &onCallScheduleId
+		}
+	}
+	return out, nil
+}
+
+func makeEscalationPolicyResponse(r *http.Request, policy model.EscalationPolicy) (out domain.EscalationPolicyResponse) {
+	if err := serializer.Map(r.Context(), policy, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	out.Tiers = make([]domain.EscalationTierResponse, len(policy.Tiers))
+	for i, tier := range policy.Tiers {
+		if err := serializer.Map(r.Context(), tier, &out.Tiers[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+		out.Tiers[i].TargetUsers = make([]domain.SimpleUserResponse, len(tier.TargetUsers))
+		for j, targetUser := range tier.TargetUsers {
+			if err := serializer.Map(r.Context(), targetUser, &out.Tiers[i].TargetUsers[j]); err != nil {
+				log.Info(r.Context(), err)
+			}
+		}
+		out.Tiers[i].NotificationChannels = make([]domain.SimpleNotificationChannelResponse, len(tier.NotificationChannels))
+		for j, channel := range tier.NotificationChannels {
+			if err := serializer.Map(r.Context(), channel, &out.Tiers[i].NotificationChannels[j]); err != nil {
+				log.Info(r.Context(), err)
+			}
+		}
+	}
+	return out
+}
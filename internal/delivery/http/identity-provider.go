@@ -0,0 +1,286 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type IdentityProviderHandler struct {
+	usecase usecase.IIdentityProviderUsecase
+}
+
+func NewIdentityProviderHandler(h usecase.Usecase) *IdentityProviderHandler {
+	return &IdentityProviderHandler{
+		usecase: h.IdentityProvider,
+	}
+}
+
+// CreateIdentityProvider godoc
+//
+//	@Tags			IdentityProviders
+//	@Summary		Create IdentityProvider
+//	@Description	Federate the corporate directory (LDAP/AD, OIDC, SAML) to the organization. The users are created on their first login with the roles of the role mappings.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string									true	"organizationId"
+//	@Param			body			body		domain.CreateIdentityProviderRequest	true	"create identity provider request"
+//	@Success		200				{object}	domain.CreateIdentityProviderResponse
+//	@Router			/organizations/{organizationId}/identity-providers [post]
+//	@Security		JWT
+func (h *IdentityProviderHandler) CreateIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateIdentityProviderRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.IdentityProvider
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+	dto.Type = model.IdentityProviderType(input.Type)
+	dto.Config = input.Config
+	dto.RoleMappings = toIdentityProviderRoleMappings(input.RoleMappings)
+
+	id, err := h.usecase.Create(r.Context(), dto, input.Secret)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateIdentityProviderResponse{
+		ID: id.String(),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetIdentityProviders godoc
+//
+//	@Tags			IdentityProviders
+//	@Summary		Get IdentityProviders
+//	@Description	Get IdentityProviders
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetIdentityProvidersResponse
+//	@Router			/organizations/{organizationId}/identity-providers [get]
+//	@Security		JWT
+func (h *IdentityProviderHandler) GetIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	identityProviders, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetIdentityProvidersResponse
+	out.IdentityProviders = make([]domain.IdentityProviderResponse, len(identityProviders))
+	for i, identityProvider := range identityProviders {
+		out.IdentityProviders[i] = toIdentityProviderResponse(r, identityProvider)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetIdentityProvider godoc
+//
+//	@Tags			IdentityProviders
+//	@Summary		Get IdentityProvider
+//	@Description	Get IdentityProvider. The secret is not returned.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			identityProviderId	path		string	true	"identityProviderId"
+//	@Success		200					{object}	domain.GetIdentityProviderResponse
+//	@Router			/organizations/{organizationId}/identity-providers/{identityProviderId} [get]
+//	@Security		JWT
+func (h *IdentityProviderHandler) GetIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	organizationId, identityProviderId, err := identityProviderVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	identityProvider, err := h.usecase.Get(r.Context(), organizationId, identityProviderId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.GetIdentityProviderResponse{
+		IdentityProvider: toIdentityProviderResponse(r, identityProvider),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateIdentityProvider godoc
+//
+//	@Tags			IdentityProviders
+//	@Summary		Update IdentityProvider
+//	@Description	Update IdentityProvider. The alias and the type can not be changed.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string									true	"organizationId"
+//	@Param			identityProviderId	path		string									true	"identityProviderId"
+//	@Param			body				body		domain.UpdateIdentityProviderRequest	true	"update identity provider request"
+//	@Success		200					{object}	nil
+//	@Router			/organizations/{organizationId}/identity-providers/{identityProviderId} [put]
+//	@Security		JWT
+func (h *IdentityProviderHandler) UpdateIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	organizationId, identityProviderId, err := identityProviderVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.UpdateIdentityProviderRequest{}
+	err = UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.IdentityProvider
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.ID = identityProviderId
+	dto.OrganizationId = organizationId
+	dto.Config = input.Config
+	dto.RoleMappings = toIdentityProviderRoleMappings(input.RoleMappings)
+
+	if err = h.usecase.Update(r.Context(), dto, input.Secret); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// DeleteIdentityProvider godoc
+//
+//	@Tags			IdentityProviders
+//	@Summary		Delete IdentityProvider
+//	@Description	Delete IdentityProvider. The users created from it are not deleted.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			identityProviderId	path		string	true	"identityProviderId"
+//	@Success		200					{object}	domain.DeleteIdentityProviderResponse
+//	@Router			/organizations/{organizationId}/identity-providers/{identityProviderId} [delete]
+//	@Security		JWT
+func (h *IdentityProviderHandler) DeleteIdentityProvider(w http.ResponseWriter, r *http.Request) {
+	organizationId, identityProviderId, err := identityProviderVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	identityProvider, err := h.usecase.Delete(r.Context(), organizationId, identityProviderId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.DeleteIdentityProviderResponse{
+		ID:    identityProvider.ID.String(),
+		Alias: identityProvider.Alias,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func toIdentityProviderRoleMappings(inputs []domain.IdentityProviderRoleMappingRequest) []model.IdentityProviderRoleMapping {
+	mappings := make([]model.IdentityProviderRoleMapping, len(inputs))
+	for i, input := range inputs {
+		mappings[i] = model.IdentityProviderRoleMapping{
+			Type:          model.IdentityProviderRoleMappingType(input.Type),
+			AttributeName: input.AttributeName,
+			Value:         input.Value,
+			RoleId:        input.RoleId,
+		}
+	}
+	return mappings
+}
+
+func toIdentityProviderResponse(r *http.Request, identityProvider model.IdentityProvider) (out domain.IdentityProviderResponse) {
+	if err := serializer.Map(r.Context(), identityProvider, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.Type = string(identityProvider.Type)
+	out.Config = identityProvider.Config
+	if identityProvider.DefaultRole != nil {
+		out.DefaultRole = &domain.SimpleRoleResponse{
+			ID:   identityProvider.DefaultRole.ID,
+			Name: identityProvider.DefaultRole.Name,
+		}
+	}
+	out.RoleMappings = make([]domain.IdentityProviderRoleMappingResponse, len(identityProvider.RoleMappings))
+	for i, mapping := range identityProvider.RoleMappings {
+		out.RoleMappings[i] = domain.IdentityProviderRoleMappingResponse{
+			Type:          string(mapping.Type),
+			AttributeName: mapping.AttributeName,
+			Value:         mapping.Value,
+			Role: domain.SimpleRoleResponse{
+				ID:   mapping.Role.ID,
+				Name: mapping.Role.Name,
+			},
+		}
+	}
+	return out
+}
+
+func identityProviderVars(r *http.Request) (organizationId string, identityProviderId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["identityProviderId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid identityProviderId"), "C_INVALID_IDENTITY_PROVIDER_ID", "")
+	}
+	identityProviderId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_IDENTITY_PROVIDER_ID", "")
+	}
+
+	return organizationId, identityProviderId, nil
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type ImpersonationHandler struct {
+	usecase usecase.IImpersonationUsecase
+}
+
+func NewImpersonationHandler(h usecase.Usecase) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		usecase: h.Impersonation,
+	}
+}
+
+// CreateImpersonation godoc
+//
+//	@Tags			Impersonations
+//	@Summary		Impersonate user. ADMIN ONLY
+//	@Description	Issue the short-lived token acting as the user for support. The admins of the organization are notified, and every audit record by the token has the impersonator. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string								true	"organizationId"
+//	@Param			accountId		path		string								true	"accountId"
+//	@Param			body			body		domain.CreateImpersonationRequest	true	"create impersonation request"
+//	@Success		200				{object}	domain.CreateImpersonationResponse
+//	@Router			/admin/organizations/{organizationId}/users/{accountId}/impersonation [post]
+//	@Security		JWT
+func (h *ImpersonationHandler) CreateImpersonation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+	accountId, ok := vars["accountId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid accountId"), "C_INVALID_ACCOUNT_ID", ""))
+		return
+	}
+
+	input := domain.CreateImpersonationRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	impersonation, token, err := h.usecase.Start(r.Context(), organizationId, accountId, input.Reason)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateImpersonationResponse{
+		Impersonation: toImpersonationResponse(r, impersonation, time.Now()),
+		Token:         token,
+		ExpiredAt:     impersonation.ExpiredAt,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetImpersonations godoc
+//
+//	@Tags			Impersonations
+//	@Summary		Get impersonations. ADMIN ONLY
+//	@Description	Get the impersonations of all organizations. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			pageSize	query		string		false	"pageSize"
+//	@Param			pageNumber	query		string		false	"pageNumber"
+//	@Param			soertColumn	query		string		false	"sortColumn"
+//	@Param			sortOrder	query		string		false	"sortOrder"
+//	@Param			filters		query		[]string	false	"filters"
+//	@Success		200			{object}	domain.GetImpersonationsResponse
+//	@Router			/admin/impersonations [get]
+//	@Security		JWT
+func (h *ImpersonationHandler) GetImpersonations(w http.ResponseWriter, r *http.Request) {
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+
+	impersonations, err := h.usecase.Fetch(r.Context(), pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	var out domain.GetImpersonationsResponse
+	out.Impersonations = make([]domain.ImpersonationResponse, len(impersonations))
+	for i, impersonation := range impersonations {
+		out.Impersonations[i] = toImpersonationResponse(r, impersonation, now)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// StopImpersonation godoc
+//
+//	@Tags			Impersonations
+//	@Summary		Stop impersonation. ADMIN ONLY
+//	@Description	Stop the impersonation. The token of the impersonation is rejected from now on. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			impersonationId	path		string	true	"impersonationId"
+//	@Success		200				{object}	domain.StopImpersonationResponse
+//	@Router			/admin/impersonations/{impersonationId} [delete]
+//	@Security		JWT
+func (h *ImpersonationHandler) StopImpersonation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	strId, ok := vars["impersonationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid impersonationId"), "C_INVALID_IMPERSONATION_ID", ""))
+		return
+	}
+	impersonationId, err := uuid.Parse(strId)
+	if err != nil {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_IMPERSONATION_ID", ""))
+		return
+	}
+
+	impersonation, err := h.usecase.Stop(r.Context(), impersonationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.StopImpersonationResponse{
+		ID:             impersonation.ID.String(),
+		OrganizationId: impersonation.OrganizationId,
+	}
+	if impersonation.User != nil {
+		out.AccountId = impersonation.User.AccountId
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func toImpersonationResponse(r *http.Request, impersonation model.Impersonation, now time.Time) (out domain.ImpersonationResponse) {
+	if err := serializer.Map(r.Context(), impersonation, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.Status = string(impersonation.CurrentStatus(now))
+	return
+}
@@ -0,0 +1,384 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type InvitationHandler struct {
+	usecase             usecase.IInvitationUsecase
+	secondFactorUsecase usecase.ISecondFactorUsecase
+	users               UserHandler
+}
+
+func NewInvitationHandler(h usecase.Usecase) *InvitationHandler {
+	return &InvitationHandler{
+		usecase:             h.Invitation,
+		secondFactorUsecase: h.SecondFactor,
+		users: UserHandler{
+			usecase:           h.User,
+			authUsecase:       h.Auth,
+			roleUsecase:       h.Role,
+			permissionUsecase: h.Permission,
+			stackUsecase:      h.Stack,
+		},
+	}
+}
+
+// CreateInvitation godoc
+//
+//	@Tags			Invitations
+//	@Summary		Create invitation
+//	@Description	Invite a user by email with the roles and the project memberships. The invitee sets the account id and the password from the link of the mail.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string							true	"organizationId"
+//	@Param			body			body		domain.CreateInvitationRequest	true	"create invitation request"
+//	@Success		200				{object}	domain.CreateInvitationResponse
+//	@Router			/organizations/{organizationId}/invitations [post]
+//	@Security		JWT
+func (h *InvitationHandler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateInvitationRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.Invitation
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+	dto.Roles = make([]model.Role, len(input.RoleIds))
+	for i, roleId := range input.RoleIds {
+		dto.Roles[i] = model.Role{ID: roleId}
+	}
+	dto.Projects = make([]model.InvitationProject, len(input.Projects))
+	for i, project := range input.Projects {
+		dto.Projects[i] = model.InvitationProject{
+			ProjectId:     project.ProjectId,
+			ProjectRoleId: project.ProjectRoleId,
+		}
+	}
+
+	id, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateInvitationResponse{
+		ID: id.String(),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetInvitations godoc
+//
+//	@Tags			Invitations
+//	@Summary		Get invitations
+//	@Description	Get invitations of the organization. Use the filter of the status to get the pending invitations.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetInvitationsResponse
+//	@Router			/organizations/{organizationId}/invitations [get]
+//	@Security		JWT
+func (h *InvitationHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	invitations, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	var out domain.GetInvitationsResponse
+	out.Invitations = make([]domain.InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		out.Invitations[i] = toInvitationResponse(r, invitation, now)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetInvitation godoc
+//
+//	@Tags			Invitations
+//	@Summary		Get invitation
+//	@Description	Get invitation
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			invitationId	path		string	true	"invitationId"
+//	@Success		200				{object}	domain.GetInvitationResponse
+//	@Router			/organizations/{organizationId}/invitations/{invitationId} [get]
+//	@Security		JWT
+func (h *InvitationHandler) GetInvitation(w http.ResponseWriter, r *http.Request) {
+	organizationId, invitationId, err := invitationVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	invitation, err := h.usecase.Get(r.Context(), organizationId, invitationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetInvitationResponse
+	out.Invitation = toInvitationResponse(r, invitation, time.Now())
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// ResendInvitation godoc
+//
+//	@Tags			Invitations
+//	@Summary		Resend invitation
+//	@Description	Mail a new link of the pending invitation. The expiration is extended and the link sent before is no longer valid.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			invitationId	path		string	true	"invitationId"
+//	@Success		200				{object}	domain.ResendInvitationResponse
+//	@Router			/organizations/{organizationId}/invitations/{invitationId}/resend [post]
+//	@Security		JWT
+func (h *InvitationHandler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	organizationId, invitationId, err := invitationVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Resend(r.Context(), organizationId, invitationId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	invitation, err := h.usecase.Get(r.Context(), organizationId, invitationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+	out := domain.ResendInvitationResponse{
+		ID:    invitation.ID.String(),
+		Email: invitation.Email,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// RevokeInvitation godoc
+//
+//	@Tags			Invitations
+//	@Summary		Revoke invitation
+//	@Description	Revoke the pending invitation. The link of the invitation is no longer valid.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			invitationId	path		string	true	"invitationId"
+//	@Success		200				{object}	domain.RevokeInvitationResponse
+//	@Router			/organizations/{organizationId}/invitations/{invitationId} [delete]
+//	@Security		JWT
+func (h *InvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	organizationId, invitationId, err := invitationVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	invitation, err := h.usecase.Get(r.Context(), organizationId, invitationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+	if err = h.usecase.Revoke(r.Context(), organizationId, invitationId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.RevokeInvitationResponse{
+		ID:    invitation.ID.String(),
+		Email: invitation.Email,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetInvitationByToken godoc
+//
+//	@Tags			Auth
+//	@Summary		Get invitation by token
+//	@Description	Get the pending invitation of the link for the invitee
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	query		string	true	"token"
+//	@Success		200		{object}	domain.GetInvitationByTokenResponse
+//	@Router			/auth/invitations [get]
+func (h *InvitationHandler) GetInvitationByToken(w http.ResponseWriter, r *http.Request) {
+	invitation, err := h.usecase.GetByToken(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.GetInvitationByTokenResponse{
+		OrganizationId:   invitation.OrganizationId,
+		OrganizationName: invitation.Organization.Name,
+		Email:            invitation.Email,
+		Name:             invitation.Name,
+		Roles:            make([]domain.SimpleRoleResponse, len(invitation.Roles)),
+		ExpiredAt:        invitation.ExpiredAt,
+	}
+	if invitation.User != nil {
+		out.AccountId = invitation.User.AccountId
+	}
+	for i, role := range invitation.Roles {
+		out.Roles[i] = domain.SimpleRoleResponse{ID: role.ID, Name: role.Name}
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// AcceptInvitation godoc
+//
+//	@Tags			Auth
+//	@Summary		Accept invitation
+//	@Description	Create the account of the invitee with the account id and the password. If enrollSecondFactor is set, the secret of the second factor is returned and it is enabled after the code is verified on login.
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.AcceptInvitationRequest	true	"accept invitation request"
+//	@Success		200		{object}	domain.AcceptInvitationResponse
+//	@Router			/auth/invitations/acceptance [post]
+func (h *InvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	input := domain.AcceptInvitationRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	dto := model.User{
+		AccountId:  input.AccountId,
+		Password:   input.Password,
+		Name:       input.Name,
+		Department: input.Department,
+	}
+	user, err := h.usecase.Accept(r.Context(), input.Token, dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+	organizationId := user.Organization.ID
+
+	// Sync ClusterAdmin Permission to Keycloak
+	stacks, err := h.users.stackUsecase.Fetch(r.Context(), organizationId, nil)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+	stackIds := make([]string, 0, len(stacks))
+	for _, stack := range stacks {
+		stackIds = append(stackIds, stack.ID.String())
+	}
+	if err = h.users.syncKeycloakWithClusterAdminPermission(r.Context(), organizationId, stackIds, []model.User{user}); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.AcceptInvitationResponse{
+		OrganizationId: organizationId,
+		AccountId:      user.AccountId,
+	}
+	if input.EnrollSecondFactor {
+		secret, provisioningUri, err := h.secondFactorUsecase.Enroll(r.Context(), user.ID)
+		if err != nil {
+			// 가입은 완료되었으므로 로그인 후 다시 등록할 수 있다.
+			log.Errorf(r.Context(), "failed to enroll second factor of invited user %s: %v", user.AccountId, err)
+		} else {
+			out.SecondFactor = &domain.CreateMySecondFactorResponse{
+				Secret:          secret,
+				ProvisioningUri: provisioningUri,
+			}
+		}
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func toInvitationResponse(r *http.Request, invitation model.Invitation, now time.Time) (out domain.InvitationResponse) {
+	if err := serializer.Map(r.Context(), invitation, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.Status = string(invitation.CurrentStatus(now))
+	out.Roles = make([]domain.SimpleRoleResponse, len(invitation.Roles))
+	for i, role := range invitation.Roles {
+		out.Roles[i] = domain.SimpleRoleResponse{ID: role.ID, Name: role.Name}
+	}
+	out.Projects = make([]domain.InvitationProjectResponse, len(invitation.Projects))
+	for i, project := range invitation.Projects {
+		out.Projects[i] = domain.InvitationProjectResponse{
+			ProjectId:     project.ProjectId,
+			ProjectRoleId: project.ProjectRoleId,
+		}
+		if project.Project != nil {
+			out.Projects[i].ProjectName = project.Project.Name
+		}
+		if project.ProjectRole != nil {
+			out.Projects[i].ProjectRoleName = project.ProjectRole.Name
+		}
+	}
+	return out
+}
+
+func invitationVars(r *http.Request) (organizationId string, invitationId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["invitationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid invitationId"), "C_INVALID_INVITATION_ID", "")
+	}
+	invitationId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_INVITATION_ID", "")
+	}
+	return organizationId, invitationId, nil
+}
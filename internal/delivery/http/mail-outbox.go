@@ -0,0 +1,107 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type MailOutboxHandler struct {
+	usecase usecase.IMailOutboxUsecase
+}
+
+func NewMailOutboxHandler(h usecase.Usecase) *MailOutboxHandler {
+	return &MailOutboxHandler{
+		usecase: h.MailOutbox,
+	}
+}
+
+// GetMailOutboxes godoc
+//
+//	@Tags			MailOutboxes
+//	@Summary		Get MailOutboxes. ADMIN ONLY
+//	@Description	Get outbound mails and their delivery status. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			pageSize	query		string		false	"pageSize"
+//	@Param			pageNumber	query		string		false	"pageNumber"
+//	@Param			soertColumn	query		string		false	"sortColumn"
+//	@Param			sortOrder	query		string		false	"sortOrder"
+//	@Param			filters		query		[]string	false	"filters"
+//	@Success		200			{object}	domain.GetMailOutboxesResponse
+//	@Router			/admin/mail-outboxes [get]
+//	@Security		JWT
+func (h *MailOutboxHandler) GetMailOutboxes(w http.ResponseWriter, r *http.Request) {
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	for i, filter := range pg.GetFilters() {
+		if filter.Column == "status" {
+			for j, value := range filter.Values {
+				var s domain.MailOutboxStatus
+				pg.GetFilters()[i].Values[j] = strconv.Itoa(int(s.FromString(value)))
+			}
+		}
+	}
+
+	mailOutboxes, err := h.usecase.Fetch(r.Context(), pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetMailOutboxesResponse
+	out.MailOutboxes = make([]domain.MailOutboxResponse, len(mailOutboxes))
+	for i, mailOutbox := range mailOutboxes {
+		if err := serializer.Map(r.Context(), mailOutbox, &out.MailOutboxes[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// ResendMailOutbox godoc
+//
+//	@Tags			MailOutboxes
+//	@Summary		Resend MailOutbox. ADMIN ONLY
+//	@Description	Resend the sent or dead mail. The mail carrying a credential (password, code, invitation) can not be resent. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			mailOutboxId	path		string	true	"mailOutboxId"
+//	@Success		200				{object}	nil
+//	@Router			/admin/mail-outboxes/{mailOutboxId}/resend [post]
+//	@Security		JWT
+func (h *MailOutboxHandler) ResendMailOutbox(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	strId, ok := vars["mailOutboxId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid mailOutboxId"), "C_INVALID_MAIL_OUTBOX_ID", ""))
+		return
+	}
+	mailOutboxId, err := uuid.Parse(strId)
+	if err != nil {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_MAIL_OUTBOX_ID", ""))
+		return
+	}
+
+	if err = h.usecase.Resend(r.Context(), mailOutboxId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
@@ -0,0 +1,326 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type NotificationChannelHandler struct {
+	usecase usecase.INotificationChannelUsecase
+}
+
+func NewNotificationChannelHandler(h usecase.Usecase) *NotificationChannelHandler {
+	return &NotificationChannelHandler{
+		usecase: h.NotificationChannel,
+	}
+}
+
+// CreateNotificationChannel godoc
+//
+//	@Tags			NotificationChannels
+//	@Summary		Create NotificationChannel
+//	@Description	Create NotificationChannel ( SLACK, MATTERMOST, TEAMS, WEBHOOK )
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string									true	"organizationId"
+//	@Param			body			body		domain.CreateNotificationChannelRequest	true	"create notification channel request"
+//	@Success		200				{object}	domain.CreateNotificationChannelResponse
+//	@Router			/organizations/{organizationId}/notification-channels [post]
+//	@Security		JWT
+func (h *NotificationChannelHandler) CreateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateNotificationChannelRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.NotificationChannel
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+
+	id, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateNotificationChannelResponse{
+		ID: id.String(),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetNotificationChannels godoc
+//
+//	@Tags			NotificationChannels
+//	@Summary		Get NotificationChannels
+//	@Description	Get NotificationChannels
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetNotificationChannelsResponse
+//	@Router			/organizations/{organizationId}/notification-channels [get]
+//	@Security		JWT
+func (h *NotificationChannelHandler) GetNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	channels, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetNotificationChannelsResponse
+	out.NotificationChannels = make([]domain.NotificationChannelResponse, len(channels))
+	for i, channel := range channels {
+		if err := serializer.Map(r.Context(), channel, &out.NotificationChannels[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetNotificationChannel godoc
+//
+//	@Tags			NotificationChannels
+//	@Summary		Get NotificationChannel
+//	@Description	Get NotificationChannel
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId			path		string	true	"organizationId"
+//	@Param			notificationChannelId	path		string	true	"notificationChannelId"
+//	@Success		200						{object}	domain.GetNotificationChannelResponse
+//	@Router			/organizations/{organizationId}/notification-channels/{notificationChannelId} [get]
+//	@Security		JWT
+func (h *NotificationChannelHandler) GetNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	organizationId, notificationChannelId, err := notificationChannelVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	channel, err := h.usecase.Get(r.Context(), organizationId, notificationChannelId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetNotificationChannelResponse
+	if err := serializer.Map(r.Context(), channel, &out.NotificationChannel); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateNotificationChannel godoc
+//
+//	@Tags			NotificationChannels
+//	@Summary		Update NotificationChannel
+//	@Description	Update NotificationChannel
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId			path		string									true	"organizationId"
+//	@Param			notificationChannelId	path		string									true	"notificationChannelId"
+//	@Param			body					body		domain.UpdateNotificationChannelRequest	true	"update notification channel request"
+//	@Success		200						{object}	nil
+//	@Router			/organizations/{organizationId}/notification-channels/{notificationChannelId} [put]
+//	@Security		JWT
+func (h *NotificationChannelHandler) UpdateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	organizationId, notificationChannelId, err := notificationChannelVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.UpdateNotificationChannelRequest{}
+	err = UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.NotificationChannel
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.ID = notificationChannelId
+	dto.OrganizationId = organizationId
+
+	if err = h.usecase.Update(r.Context(), dto); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// DeleteNotificationChannel godoc
+//
+//	@Tags			NotificationChannels
+//	@Summary		Delete NotificationChannel
+//	@Description	Delete NotificationChannel. The channel is also removed from the system notification rules.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId			path		string	true	"organizationId"
+//	@Param			notificationChannelId	path		string	true	"notificationChannelId"
+//	@Success		200						{object}	nil
+//	@Router			/organizations/{organizationId}/notification-channels/{notificationChannelId} [delete]
+//	@Security		JWT
+func (h *NotificationChannelHandler) DeleteNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	organizationId, notificationChannelId, err := notificationChannelVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Delete(r.Context(), organizationId, notificationChannelId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// TestNotificationChannel godoc
+//
+//	@Tags			NotificationChannels
+//	@Summary		Test NotificationChannel
+//	@Description	Send test message to the NotificationChannel
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId			path		string	true	"organizationId"
+//	@Param			notificationChannelId	path		string	true	"notificationChannelId"
+//	@Success		200						{object}	domain.TestNotificationChannelResponse
+//	@Router			/organizations/{organizationId}/notification-channels/{notificationChannelId}/test [post]
+//	@Security		JWT
+func (h *NotificationChannelHandler) TestNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	organizationId, notificationChannelId, err := notificationChannelVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	result, err := h.usecase.Test(r.Context(), organizationId, notificationChannelId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.TestNotificationChannelResponse
+	if err := serializer.Map(r.Context(), result, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetNotificationDeliveries godoc
+//
+//	@Tags			NotificationChannels
+//	@Summary		Get NotificationDeliveries
+//	@Description	Get delivery results of system notifications to email and notification channels
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetNotificationDeliveriesResponse
+//	@Router			/organizations/{organizationId}/notification-deliveries [get]
+//	@Security		JWT
+func (h *NotificationChannelHandler) GetNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	deliveries, err := h.usecase.FetchDeliveries(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetNotificationDeliveriesResponse
+	out.NotificationDeliveries = make([]domain.NotificationDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		if err := serializer.Map(r.Context(), delivery, &out.NotificationDeliveries[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+		if delivery.SystemNotificationRuleId != nil {
+			out.NotificationDeliveries[i].SystemNotificationRuleId = delivery.SystemNotificationRuleId.String()
+		}
+		if delivery.NotificationChannelId != nil {
+			out.NotificationDeliveries[i].NotificationChannelId = delivery.NotificationChannelId.String()
+		}
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func notificationChannelVars(r *http.Request) (organizationId string, notificationChannelId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["notificationChannelId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid notificationChannelId"), "C_INVALID_NOTIFICATION_CHANNEL_ID", "")
+	}
+	notificationChannelId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_NOTIFICATION_CHANNEL_ID", "")
+	}
+
+	return organizationId, notificationChannelId, nil
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+type NotificationPreferenceHandler struct {
+	usecase usecase.INotificationPreferenceUsecase
+}
+
+func NewNotificationPreferenceHandler(h usecase.Usecase) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{
+		usecase: h.NotificationPreference,
+	}
+}
+
+// GetMyNotificationPreference godoc
+//
+//	@Tags			My-profile
+//	@Summary		Get my notification preference
+//	@Description	Get my notification preference. The default preference receives every notification by email.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.GetMyNotificationPreferenceResponse
+//	@Router			/organizations/{organizationId}/my-profile/notification-preference [get]
+//	@Security		JWT
+func (h *NotificationPreferenceHandler) GetMyNotificationPreference(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	preference, err := h.usecase.Get(r.Context(), requestUserInfo.GetUserId())
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetMyNotificationPreferenceResponse
+	out.NotificationPreference = makeNotificationPreferenceResponse(r, preference)
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateMyNotificationPreference godoc
+//
+//	@Tags			My-profile
+//	@Summary		Update my notification preference
+//	@Description	Update my notification preference. Critical notifications are sent during the quiet hours.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string										true	"organizationId"
+//	@Param			body			body		domain.UpdateMyNotificationPreferenceRequest	true	"update notification preference request"
+//	@Success		200				{object}	domain.UpdateMyNotificationPreferenceResponse
+//	@Router			/organizations/{organizationId}/my-profile/notification-preference [put]
+//	@Security		JWT
+func (h *NotificationPreferenceHandler) UpdateMyNotificationPreference(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	input := domain.UpdateMyNotificationPreferenceRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.NotificationPreference
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.UserId = requestUserInfo.GetUserId()
+
+	err = h.usecase.Update(r.Context(), requestUserInfo.GetOrganizationId(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	preference, err := h.usecase.Get(r.Context(), dto.UserId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.UpdateMyNotificationPreferenceResponse
+	out.NotificationPreference = makeNotificationPreferenceResponse(r, preference)
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// 메일의 수신 거부 링크는 확인 화면만 보여주고, 수신 거부는 화면의 POST 요청으로 처리한다.
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head><meta charset="utf-8"><title>TKS 알림 수신 거부</title></head>
+<body>
+{{- if .Done }}
+<p>수신 거부 처리되었습니다.</p>
+{{- else }}
+<p>{{ .AccountId }} 계정의 알림 메일 수신을 거부하시겠습니까?</p>
+<form method="post">
+<input type="hidden" name="token" value="{{ .Token }}">
+<button type="submit">수신 거부</button>
+</form>
+{{- end }}
+</body>
+</html>`))
+
+// GetUnsubscribe godoc
+//
+//	@Tags			NotificationPreferences
+//	@Summary		Show the unsubscribe confirmation page
+//	@Description	The unsubscribe link of the notification mails. It shows the confirmation page only, and the preference is changed by the POST of the page.
+//	@Produce		html
+//	@Param			token	query	string	true	"signed unsubscribe token"
+//	@Success		200
+//	@Router			/notification-preferences/unsubscribe [get]
+func (h *NotificationPreferenceHandler) GetUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("token not found"), "NP_INVALID_UNSUBSCRIBE_TOKEN", ""))
+		return
+	}
+
+	user, err := h.usecase.CheckUnsubscribeToken(r.Context(), token)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	writeUnsubscribePage(w, r, map[string]interface{}{
+		"AccountId": user.AccountId,
+		"Token":     token,
+	})
+}
+
+// Unsubscribe godoc
+//
+//	@Tags			NotificationPreferences
+//	@Summary		Unsubscribe notification mails
+//	@Description	Unsubscribe the notification mails. No authentication is required but the signed token. It also serves the one-click unsubscribe of the mail clients.
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			token	query		string	true	"signed unsubscribe token"
+//	@Success		200		{object}	domain.UnsubscribeNotificationResponse
+//	@Router			/notification-preferences/unsubscribe [post]
+func (h *NotificationPreferenceHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+	if token == "" {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("token not found"), "NP_INVALID_UNSUBSCRIBE_TOKEN", ""))
+		return
+	}
+
+	if err := h.usecase.Unsubscribe(r.Context(), token); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	// 확인 화면에서 제출한 요청은 결과 화면으로 응답한다.
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		writeUnsubscribePage(w, r, map[string]interface{}{"Done": true})
+		return
+	}
+
+	out := domain.UnsubscribeNotificationResponse{
+		Message: "수신 거부 처리되었습니다.",
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func writeUnsubscribePage(w http.ResponseWriter, r *http.Request, data map[string]interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := unsubscribePage.Execute(w, data); err != nil {
+		log.Error(r.Context(), err)
+	}
+}
+
+func makeNotificationPreferenceResponse(r *http.Request, preference model.NotificationPreference) (out domain.NotificationPreferenceResponse) {
+	if err := serializer.Map(r.Context(), preference, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.UnsubscribedRules = make([]domain.SimpleSystemNotificationRuleResponse, len(preference.UnsubscribedRules))
+	for i, rule := range preference.UnsubscribedRules {
+		if err := serializer.Map(r.Context(), rule, &out.UnsubscribedRules[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+	return out
+}
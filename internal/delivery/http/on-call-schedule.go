@@ -0,0 +1,257 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type OnCallScheduleHandler struct {
+	usecase usecase.IOnCallScheduleUsecase
+}
+
+func NewOnCallScheduleHandler(h usecase.Usecase) *OnCallScheduleHandler {
+	return &OnCallScheduleHandler{
+		usecase: h.OnCallSchedule,
+	}
+}
+
+// CreateOnCallSchedule godoc
+//
+//	@Tags			OnCallSchedules
+//	@Summary		Create OnCallSchedule
+//	@Description	Create OnCallSchedule. The members take the on-call duty in order, every rotationDays (default 7) from startAt.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string								true	"organizationId"
+//	@Param			body			body		domain.CreateOnCallScheduleRequest	true	"create on-call schedule request"
+//	@Success		200				{object}	domain.CreateOnCallScheduleResponse
+//	@Router			/organizations/{organizationId}/on-call-schedules [post]
+//	@Security		JWT
+func (h *OnCallScheduleHandler) CreateOnCallSchedule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateOnCallScheduleRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.OnCallSchedule
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+
+	id, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateOnCallScheduleResponse{
+		ID: id.String(),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetOnCallSchedules godoc
+//
+//	@Tags			OnCallSchedules
+//	@Summary		Get OnCallSchedules
+//	@Description	Get OnCallSchedules with the current on-call member
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetOnCallSchedulesResponse
+//	@Router			/organizations/{organizationId}/on-call-schedules [get]
+//	@Security		JWT
+func (h *OnCallScheduleHandler) GetOnCallSchedules(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	schedules, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetOnCallSchedulesResponse
+	out.OnCallSchedules = make([]domain.OnCallScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		out.OnCallSchedules[i] = makeOnCallScheduleResponse(r, schedule)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetOnCallSchedule godoc
+//
+//	@Tags			OnCallSchedules
+//	@Summary		Get OnCallSchedule
+//	@Description	Get OnCallSchedule with the current on-call member
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			onCallScheduleId	path		string	true	"onCallScheduleId"
+//	@Success		200					{object}	domain.GetOnCallScheduleResponse
+//	@Router			/organizations/{organizationId}/on-call-schedules/{onCallScheduleId} [get]
+//	@Security		JWT
+func (h *OnCallScheduleHandler) GetOnCallSchedule(w http.ResponseWriter, r *http.Request) {
+	organizationId, onCallScheduleId, err := onCallScheduleVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	schedule, err := h.usecase.Get(r.Context(), organizationId, onCallScheduleId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetOnCallScheduleResponse
+	out.OnCallSchedule = makeOnCallScheduleResponse(r, schedule)
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateOnCallSchedule godoc
+//
+//	@Tags			OnCallSchedules
+//	@Summary		Update OnCallSchedule
+//	@Description	Update OnCallSchedule. The members are replaced with the requested members.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string								true	"organizationId"
+//	@Param			onCallScheduleId	path		string								true	"onCallScheduleId"
+//	@Param			body				body		domain.UpdateOnCallScheduleRequest	true	"update on-call schedule request"
+//	@Success		200					{object}	nil
+//	@Router			/organizations/{organizationId}/on-call-schedules/{onCallScheduleId} [put]
+//	@Security		JWT
+func (h *OnCallScheduleHandler) UpdateOnCallSchedule(w http.ResponseWriter, r *http.Request) {
+	organizationId, onCallScheduleId, err := onCallScheduleVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.UpdateOnCallScheduleRequest{}
+	err = UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.OnCallSchedule
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.ID = onCallScheduleId
+	dto.OrganizationId = organizationId
+
+	if err = h.usecase.Update(r.Context(), dto); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// DeleteOnCallSchedule godoc
+//
+//	@Tags			OnCallSchedules
+//	@Summary		Delete OnCallSchedule
+//	@Description	Delete OnCallSchedule. The schedule is also removed from the escalation tiers.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			onCallScheduleId	path		string	true	"onCallScheduleId"
+//	@Success		200					{object}	nil
+//	@Router			/organizations/{organizationId}/on-call-schedules/{onCallScheduleId} [delete]
+//	@Security		JWT
+func (h *OnCallScheduleHandler) DeleteOnCallSchedule(w http.ResponseWriter, r *http.Request) {
+	organizationId, onCallScheduleId, err := onCallScheduleVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Delete(r.Context(), organizationId, onCallScheduleId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+func onCallScheduleVars(r *http.Request) (organizationId string, onCallScheduleId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["onCallScheduleId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid onCallScheduleId"), "C_INVALID_ON_CALL_SCHEDULE_ID", "")
+	}
+	onCallScheduleId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_ON_CALL_SCHEDULE_ID", "")
+	}
+
+	return organizationId, onCallScheduleId, nil
+}
+
+func makeOnCallScheduleResponse(r *http.Request, schedule model.OnCallSchedule) (out domain.OnCallScheduleResponse) {
+	if err := serializer.Map(r.Context(), schedule, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	out.Members = make([]domain.SimpleUserResponse, len(schedule.Members))
+	for i, member := range schedule.Members {
+		if err := serializer.Map(r.Context(), member.User, &out.Members[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+
+	if user, ok := schedule.OnCallAt(time.Now()); ok {
+		if err := serializer.Map(r.Context(), user, &out.CurrentOnCall); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+type RetentionHandler struct {
+	usecase usecase.IRetentionUsecase
+}
+
+func NewRetentionHandler(h usecase.Usecase) *RetentionHandler {
+	return &RetentionHandler{
+		usecase: h.Retention,
+	}
+}
+
+// GetRetentionPolicies godoc
+//
+//	@Tags			Retention
+//	@Summary		Get retention policies. ADMIN ONLY
+//	@Description	Get the retention policies of all data types of the organization. retentionDays 0 means forever. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.GetRetentionPoliciesResponse
+//	@Router			/admin/organizations/{organizationId}/retention-policies [get]
+//	@Security		JWT
+func (h *RetentionHandler) GetRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	policies, err := h.usecase.GetPolicies(r.Context(), organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetRetentionPoliciesResponse
+	out.RetentionPolicies = toRetentionPolicyResponses(r, policies)
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateRetentionPolicies godoc
+//
+//	@Tags			Retention
+//	@Summary		Update retention policies. ADMIN ONLY
+//	@Description	Update the retention policies of the data types of the organization. The expired data is archived if archive is set, and purged by the retention job. Audits must be archived and kept at least 365 days. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string									true	"organizationId"
+//	@Param			body			body		domain.UpdateRetentionPoliciesRequest	true	"update retention policies request"
+//	@Success		200				{object}	domain.UpdateRetentionPoliciesResponse
+//	@Router			/admin/organizations/{organizationId}/retention-policies [put]
+//	@Security		JWT
+func (h *RetentionHandler) UpdateRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.UpdateRetentionPoliciesRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	dtos := make([]model.RetentionPolicy, len(input.RetentionPolicies))
+	for i, policy := range input.RetentionPolicies {
+		if err = serializer.Map(r.Context(), policy, &dtos[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+
+	if err = h.usecase.UpdatePolicies(r.Context(), organizationId, dtos); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	policies, err := h.usecase.GetPolicies(r.Context(), organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.UpdateRetentionPoliciesResponse
+	out.RetentionPolicies = toRetentionPolicyResponses(r, policies)
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetRetentionStatus godoc
+//
+//	@Tags			Retention
+//	@Summary		Get retention status. ADMIN ONLY
+//	@Description	Get the next run of the retention job and the results of its last run. ADMIN ONLY
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	query		string	false	"organizationId"
+//	@Success		200				{object}	domain.GetRetentionStatusResponse
+//	@Router			/admin/retention [get]
+//	@Security		JWT
+func (h *RetentionHandler) GetRetentionStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.usecase.GetStatus(r.Context(), r.URL.Query().Get("organizationId"))
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetRetentionStatusResponse
+	if err = serializer.Map(r.Context(), status, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.Runs = make([]domain.RetentionRunResponse, len(status.Runs))
+	for i, run := range status.Runs {
+		if err = serializer.Map(r.Context(), run, &out.Runs[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func toRetentionPolicyResponses(r *http.Request, policies []model.RetentionPolicy) []domain.RetentionPolicyResponse {
+	out := make([]domain.RetentionPolicyResponse, len(policies))
+	for i, policy := range policies {
+		if err := serializer.Map(r.Context(), policy, &out[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+	return out
+}
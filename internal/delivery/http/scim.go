@@ -0,0 +1,590 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const (
+	scimContentType       = "application/scim+json; charset=utf-8"
+	scimDefaultCount      = 100
+	scimMaxCount          = 200
+	scimResourceTypeUser  = "User"
+	scimResourceTypeGroup = "Group"
+)
+
+// SCIM 오류 응답의 scimType (RFC 7644 3.12)
+var scimTypes = map[string]string{
+	"SCIM_INVALID_FILTER": "invalidFilter",
+	"SCIM_INVALID_PATH":   "invalidPath",
+	"SCIM_INVALID_VALUE":  "invalidValue",
+	"SCIM_MUTABILITY":     "mutability",
+	"SCIM_UNIQUENESS":     "uniqueness",
+}
+
+type ScimHandler struct {
+	usecase usecase.IScimUsecase
+	users   UserHandler
+}
+
+func NewScimHandler(h usecase.Usecase) *ScimHandler {
+	return &ScimHandler{
+		usecase: h.Scim,
+		users: UserHandler{
+			usecase:           h.User,
+			authUsecase:       h.Auth,
+			roleUsecase:       h.Role,
+			permissionUsecase: h.Permission,
+			stackUsecase:      h.Stack,
+		},
+	}
+}
+
+// ScimGetServiceProviderConfig godoc
+//
+//	@Tags			Scim
+//	@Summary		Get SCIM ServiceProviderConfig
+//	@Description	Get the SCIM 2.0 features supported by the provisioning endpoint
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.ScimServiceProviderConfigResponse
+//	@Router			/organizations/{organizationId}/scim/v2/ServiceProviderConfig [get]
+//	@Security		JWT
+func (h *ScimHandler) ScimGetServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	out := domain.ScimServiceProviderConfigResponse{
+		Schemas:        []string{domain.ScimServiceProviderConfigSchema},
+		Patch:          domain.ScimSupported{Supported: true},
+		Filter:         domain.ScimFilterSupported{Supported: true, MaxResults: scimMaxCount},
+		ChangePassword: domain.ScimSupported{Supported: false},
+		Sort:           domain.ScimSupported{Supported: false},
+		Etag:           domain.ScimSupported{Supported: false},
+		AuthenticationSchemes: []domain.ScimAuthenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "Provisioning Token",
+				Description: "API token of the service account which is allowed only for the Scim endpoint group",
+				Primary:     true,
+			},
+		},
+	}
+	scimResponseJSON(w, r, http.StatusOK, out)
+}
+
+// ScimGetUsers godoc
+//
+//	@Tags			Scim
+//	@Summary		Get SCIM Users
+//	@Description	Get the users of the organization as SCIM resources. The filter supports eq, ne, co, sw, ew, pr joined with and / or.
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			filter			query		string	false	"filter. ex) userName eq \"hong\""
+//	@Param			startIndex		query		int		false	"1-based start index"
+//	@Param			count			query		int		false	"count"
+//	@Success		200				{object}	domain.ScimUserListResponse
+//	@Router			/organizations/{organizationId}/scim/v2/Users [get]
+//	@Security		JWT
+func (h *ScimHandler) ScimGetUsers(w http.ResponseWriter, r *http.Request) {
+	organizationId, ok := mux.Vars(r)["organizationId"]
+	if !ok {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	startIndex, count := scimPageParams(r)
+	users, total, err := h.usecase.GetUsers(r.Context(), organizationId, r.URL.Query().Get("filter"), startIndex, count)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.ScimUserListResponse{
+		Schemas:      []string{domain.ScimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(users),
+		Resources:    make([]domain.ScimUser, len(users)),
+	}
+	for i, user := range users {
+		out.Resources[i] = toScimUser(organizationId, user)
+	}
+	scimResponseJSON(w, r, http.StatusOK, out)
+}
+
+// ScimGetUser godoc
+//
+//	@Tags			Scim
+//	@Summary		Get SCIM User
+//	@Description	Get SCIM User
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			userId			path		string	true	"userId"
+//	@Success		200				{object}	domain.ScimUser
+//	@Router			/organizations/{organizationId}/scim/v2/Users/{userId} [get]
+//	@Security		JWT
+func (h *ScimHandler) ScimGetUser(w http.ResponseWriter, r *http.Request) {
+	organizationId, userId, err := scimUserVars(r)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	user, err := h.usecase.GetUser(r.Context(), organizationId, userId)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	scimResponseJSON(w, r, http.StatusOK, toScimUser(organizationId, user))
+}
+
+// ScimCreateUser godoc
+//
+//	@Tags			Scim
+//	@Summary		Create SCIM User
+//	@Description	Provision the user with the default role. The temporary password is mailed if the password is not given.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string			true	"organizationId"
+//	@Param			body			body		domain.ScimUser	true	"SCIM user"
+//	@Success		201				{object}	domain.ScimUser
+//	@Router			/organizations/{organizationId}/scim/v2/Users [post]
+//	@Security		JWT
+func (h *ScimHandler) ScimCreateUser(w http.ResponseWriter, r *http.Request) {
+	organizationId, ok := mux.Vars(r)["organizationId"]
+	if !ok {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.ScimUser{}
+	if err := UnmarshalRequestInput(r, &input); err != nil {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(err, "SCIM_INVALID_VALUE", ""))
+		return
+	}
+
+	dto := fromScimUser(organizationId, input)
+	dto.Password = input.Password
+	user, err := h.usecase.CreateUser(r.Context(), dto, input.Active == nil || *input.Active)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.syncClusterAdminPermission(r.Context(), organizationId, []model.User{user}); err != nil {
+		log.Error(r.Context(), err)
+	}
+	scimResponseJSON(w, r, http.StatusCreated, toScimUser(organizationId, user))
+}
+
+// ScimReplaceUser godoc
+//
+//	@Tags			Scim
+//	@Summary		Replace SCIM User
+//	@Description	Replace the attributes of the user. The userName can not be changed, and active false disables the user and revokes the sessions.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string			true	"organizationId"
+//	@Param			userId			path		string			true	"userId"
+//	@Param			body			body		domain.ScimUser	true	"SCIM user"
+//	@Success		200				{object}	domain.ScimUser
+//	@Router			/organizations/{organizationId}/scim/v2/Users/{userId} [put]
+//	@Security		JWT
+func (h *ScimHandler) ScimReplaceUser(w http.ResponseWriter, r *http.Request) {
+	organizationId, userId, err := scimUserVars(r)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.ScimUser{}
+	if err = UnmarshalRequestInput(r, &input); err != nil {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(err, "SCIM_INVALID_VALUE", ""))
+		return
+	}
+
+	dto := fromScimUser(organizationId, input)
+	dto.ID = userId
+	user, err := h.usecase.ReplaceUser(r.Context(), dto, input.Active == nil || *input.Active)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	scimResponseJSON(w, r, http.StatusOK, toScimUser(organizationId, user))
+}
+
+// ScimPatchUser godoc
+//
+//	@Tags			Scim
+//	@Summary		Patch SCIM User
+//	@Description	Patch the user. The replace of active to false deprovisions the user : the user is disabled and the sessions are revoked.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string					true	"organizationId"
+//	@Param			userId			path		string					true	"userId"
+//	@Param			body			body		domain.ScimPatchRequest	true	"SCIM patch request"
+//	@Success		200				{object}	domain.ScimUser
+//	@Router			/organizations/{organizationId}/scim/v2/Users/{userId} [patch]
+//	@Security		JWT
+func (h *ScimHandler) ScimPatchUser(w http.ResponseWriter, r *http.Request) {
+	organizationId, userId, err := scimUserVars(r)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.ScimPatchRequest{}
+	if err = UnmarshalRequestInput(r, &input); err != nil {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(err, "SCIM_INVALID_VALUE", ""))
+		return
+	}
+
+	user, err := h.usecase.PatchUser(r.Context(), organizationId, userId, input.Operations)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	scimResponseJSON(w, r, http.StatusOK, toScimUser(organizationId, user))
+}
+
+// ScimDeleteUser godoc
+//
+//	@Tags			Scim
+//	@Summary		Delete SCIM User
+//	@Description	Deprovision the user. The sessions and the api tokens of the user are revoked, and the user is deleted.
+//	@Param			organizationId	path	string	true	"organizationId"
+//	@Param			userId			path	string	true	"userId"
+//	@Success		204
+//	@Router			/organizations/{organizationId}/scim/v2/Users/{userId} [delete]
+//	@Security		JWT
+func (h *ScimHandler) ScimDeleteUser(w http.ResponseWriter, r *http.Request) {
+	organizationId, userId, err := scimUserVars(r)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.DeleteUser(r.Context(), organizationId, userId); err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ScimGetGroups godoc
+//
+//	@Tags			Scim
+//	@Summary		Get SCIM Groups
+//	@Description	Get the roles of the organization as SCIM groups. The members are the users who have the role.
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			filter			query		string	false	"filter. ex) displayName eq \"admin\""
+//	@Param			startIndex		query		int		false	"1-based start index"
+//	@Param			count			query		int		false	"count"
+//	@Success		200				{object}	domain.ScimGroupListResponse
+//	@Router			/organizations/{organizationId}/scim/v2/Groups [get]
+//	@Security		JWT
+func (h *ScimHandler) ScimGetGroups(w http.ResponseWriter, r *http.Request) {
+	organizationId, ok := mux.Vars(r)["organizationId"]
+	if !ok {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	startIndex, count := scimPageParams(r)
+	roles, members, total, err := h.usecase.GetGroups(r.Context(), organizationId, r.URL.Query().Get("filter"), startIndex, count)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.ScimGroupListResponse{
+		Schemas:      []string{domain.ScimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(roles),
+		Resources:    make([]domain.ScimGroup, len(roles)),
+	}
+	for i, role := range roles {
+		out.Resources[i] = toScimGroup(organizationId, role, members[role.ID])
+	}
+	scimResponseJSON(w, r, http.StatusOK, out)
+}
+
+// ScimGetGroup godoc
+//
+//	@Tags			Scim
+//	@Summary		Get SCIM Group
+//	@Description	Get SCIM Group
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			groupId			path		string	true	"role id"
+//	@Success		200				{object}	domain.ScimGroup
+//	@Router			/organizations/{organizationId}/scim/v2/Groups/{groupId} [get]
+//	@Security		JWT
+func (h *ScimHandler) ScimGetGroup(w http.ResponseWriter, r *http.Request) {
+	organizationId, groupId, err := scimGroupVars(r)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	role, members, err := h.usecase.GetGroup(r.Context(), organizationId, groupId)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	scimResponseJSON(w, r, http.StatusOK, toScimGroup(organizationId, role, members))
+}
+
+// ScimReplaceGroup godoc
+//
+//	@Tags			Scim
+//	@Summary		Replace SCIM Group
+//	@Description	Replace the members of the role. The role can not be renamed.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string				true	"organizationId"
+//	@Param			groupId			path		string				true	"role id"
+//	@Param			body			body		domain.ScimGroup	true	"SCIM group"
+//	@Success		200				{object}	domain.ScimGroup
+//	@Router			/organizations/{organizationId}/scim/v2/Groups/{groupId} [put]
+//	@Security		JWT
+func (h *ScimHandler) ScimReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	organizationId, groupId, err := scimGroupVars(r)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.ScimGroup{}
+	if err = UnmarshalRequestInput(r, &input); err != nil {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(err, "SCIM_INVALID_VALUE", ""))
+		return
+	}
+
+	memberIds := make([]string, len(input.Members))
+	for i, member := range input.Members {
+		memberIds[i] = member.Value
+	}
+	changed, err := h.usecase.ReplaceGroup(r.Context(), organizationId, groupId, input.DisplayName, memberIds)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	h.respondGroup(w, r, organizationId, groupId, changed)
+}
+
+// ScimPatchGroup godoc
+//
+//	@Tags			Scim
+//	@Summary		Patch SCIM Group
+//	@Description	Add or remove the members of the role. The role is granted to or revoked from the users.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string					true	"organizationId"
+//	@Param			groupId			path		string					true	"role id"
+//	@Param			body			body		domain.ScimPatchRequest	true	"SCIM patch request"
+//	@Success		200				{object}	domain.ScimGroup
+//	@Router			/organizations/{organizationId}/scim/v2/Groups/{groupId} [patch]
+//	@Security		JWT
+func (h *ScimHandler) ScimPatchGroup(w http.ResponseWriter, r *http.Request) {
+	organizationId, groupId, err := scimGroupVars(r)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.ScimPatchRequest{}
+	if err = UnmarshalRequestInput(r, &input); err != nil {
+		scimErrorJSON(w, r, httpErrors.NewBadRequestError(err, "SCIM_INVALID_VALUE", ""))
+		return
+	}
+
+	changed, err := h.usecase.PatchGroup(r.Context(), organizationId, groupId, input.Operations)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	h.respondGroup(w, r, organizationId, groupId, changed)
+}
+
+func (h *ScimHandler) respondGroup(w http.ResponseWriter, r *http.Request, organizationId string, groupId string, changed []model.User) {
+	if err := h.syncClusterAdminPermission(r.Context(), organizationId, changed); err != nil {
+		log.Error(r.Context(), err)
+	}
+
+	role, members, err := h.usecase.GetGroup(r.Context(), organizationId, groupId)
+	if err != nil {
+		scimErrorJSON(w, r, err)
+		return
+	}
+	scimResponseJSON(w, r, http.StatusOK, toScimGroup(organizationId, role, members))
+}
+
+// syncClusterAdminPermission applies the cluster admin permissions of the changed roles to keycloak, like the user api.
+func (h *ScimHandler) syncClusterAdminPermission(ctx context.Context, organizationId string, users []model.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	stacks, err := h.users.stackUsecase.Fetch(ctx, organizationId, nil)
+	if err != nil {
+		return err
+	}
+	stackIds := make([]string, 0, len(stacks))
+	for _, stack := range stacks {
+		stackIds = append(stackIds, stack.ID.String())
+	}
+	return h.users.syncKeycloakWithClusterAdminPermission(ctx, organizationId, stackIds, users)
+}
+
+func scimUserVars(r *http.Request) (organizationId string, userId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+	userId, err = uuid.Parse(vars["userId"])
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewNotFoundError(fmt.Errorf("invalid userId %s", vars["userId"]), "SCIM_NOT_EXISTED_USER", "")
+	}
+	return organizationId, userId, nil
+}
+
+func scimGroupVars(r *http.Request) (organizationId string, groupId string, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", "", httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+	groupId, ok = vars["groupId"]
+	if !ok {
+		return "", "", httpErrors.NewNotFoundError(fmt.Errorf("invalid groupId"), "SCIM_NOT_EXISTED_GROUP", "")
+	}
+	return organizationId, groupId, nil
+}
+
+// scimPageParams returns the 1-based startIndex and the count limited to scimMaxCount.
+func scimPageParams(r *http.Request) (startIndex int, count int) {
+	startIndex, count = 1, scimDefaultCount
+	if v, err := strconv.Atoi(r.URL.Query().Get("startIndex")); err == nil && v > 0 {
+		startIndex = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && v >= 0 {
+		count = v
+	}
+	if count > scimMaxCount {
+		count = scimMaxCount
+	}
+	return startIndex, count
+}
+
+func scimLocation(organizationId string, resource string, id string) string {
+	return fmt.Sprintf("%s%s/organizations/%s/scim/v2/%s/%s", internal.API_PREFIX, internal.API_VERSION, organizationId, resource, id)
+}
+
+func toScimUser(organizationId string, user model.User) domain.ScimUser {
+	active := !user.Disabled
+	out := domain.ScimUser{
+		Schemas:     []string{domain.ScimUserSchema, domain.ScimEnterpriseUserSchema},
+		ID:          user.ID.String(),
+		ExternalId:  user.ExternalId,
+		UserName:    user.AccountId,
+		Name:        &domain.ScimName{Formatted: user.Name},
+		DisplayName: user.Name,
+		Active:      &active,
+		Groups:      make([]domain.ScimMultiValue, len(user.Roles)),
+		EnterpriseUser: &domain.ScimEnterpriseUser{
+			Department: user.Department,
+		},
+		Meta: &domain.ScimMeta{
+			ResourceType: scimResourceTypeUser,
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     scimLocation(organizationId, "Users", user.ID.String()),
+		},
+	}
+	if user.Email != "" {
+		out.Emails = []domain.ScimMultiValue{{Value: user.Email, Type: "work", Primary: true}}
+	}
+	for i, role := range user.Roles {
+		out.Groups[i] = domain.ScimMultiValue{Value: role.ID, Display: role.Name}
+	}
+	return out
+}
+
+func fromScimUser(organizationId string, in domain.ScimUser) model.User {
+	out := model.User{
+		AccountId:    in.UserName,
+		Name:         in.DisplayName,
+		ExternalId:   in.ExternalId,
+		Organization: model.Organization{ID: organizationId},
+	}
+	if out.Name == "" && in.Name != nil {
+		out.Name = in.Name.Formatted
+		if out.Name == "" {
+			out.Name = strings.TrimSpace(in.Name.GivenName + " " + in.Name.FamilyName)
+		}
+	}
+	for i, email := range in.Emails {
+		if i == 0 || email.Primary {
+			out.Email = email.Value
+		}
+	}
+	if in.EnterpriseUser != nil {
+		out.Department = in.EnterpriseUser.Department
+	}
+	return out
+}
+
+func toScimGroup(organizationId string, role model.Role, members []model.User) domain.ScimGroup {
+	out := domain.ScimGroup{
+		Schemas:     []string{domain.ScimGroupSchema},
+		ID:          role.ID,
+		DisplayName: role.Name,
+		Members:     make([]domain.ScimMultiValue, len(members)),
+		Meta: &domain.ScimMeta{
+			ResourceType: scimResourceTypeGroup,
+			Created:      role.CreatedAt,
+			LastModified: role.UpdatedAt,
+			Location:     scimLocation(organizationId, "Groups", role.ID),
+		},
+	}
+	for i, member := range members {
+		out.Members[i] = domain.ScimMultiValue{Value: member.ID.String(), Display: member.AccountId}
+	}
+	return out
+}
+
+func scimResponseJSON(w http.ResponseWriter, r *http.Request, httpStatus int, data interface{}) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(httpStatus)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Error(r.Context(), err)
+	}
+}
+
+// scimErrorJSON responds the error in the SCIM error schema, so that the provisioning clients can handle it.
+func scimErrorJSON(w http.ResponseWriter, r *http.Request, err error) {
+	log.Errorf(r.Context(), "error is :%s(%T)", err.Error(), err)
+	restError, status := httpErrors.ErrorResponse(err)
+	out := domain.ScimErrorResponse{
+		Schemas:  []string{domain.ScimErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimTypes[restError.Code()],
+		Detail:   restError.Text(),
+	}
+	scimResponseJSON(w, r, status, out)
+}
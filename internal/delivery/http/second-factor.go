@@ -0,0 +1,238 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+)
+
+type SecondFactorHandler struct {
+	usecase usecase.ISecondFactorUsecase
+}
+
+func NewSecondFactorHandler(h usecase.Usecase) *SecondFactorHandler {
+	return &SecondFactorHandler{
+		usecase: h.SecondFactor,
+	}
+}
+
+// GetMySecondFactor godoc
+//
+//	@Tags			My-profile
+//	@Summary		Get my second factor
+//	@Description	Get the status of my second factor (TOTP)
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.GetMySecondFactorResponse
+//	@Router			/organizations/{organizationId}/my-profile/second-factor [get]
+//	@Security		JWT
+func (h *SecondFactorHandler) GetMySecondFactor(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	secondFactor, err := h.usecase.Get(r.Context(), requestUserInfo.GetUserId())
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+	required, err := h.usecase.IsRequired(r.Context(), requestUserInfo.GetOrganizationId())
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetMySecondFactorResponse
+	out.SecondFactor = domain.SecondFactorResponse{
+		Enabled:                secondFactor.Enabled,
+		EnabledAt:              secondFactor.EnabledAt,
+		RemainingRecoveryCodes: len(secondFactor.RecoveryCodeHashes),
+		Required:               required,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// CreateMySecondFactor godoc
+//
+//	@Tags			My-profile
+//	@Summary		Enroll my second factor
+//	@Description	Issue a new TOTP secret. The second factor is enabled after the code is verified.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.CreateMySecondFactorResponse
+//	@Router			/organizations/{organizationId}/my-profile/second-factor [post]
+//	@Security		JWT
+func (h *SecondFactorHandler) CreateMySecondFactor(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	secret, provisioningUri, err := h.usecase.Enroll(r.Context(), requestUserInfo.GetUserId())
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateMySecondFactorResponse{
+		Secret:          secret,
+		ProvisioningUri: provisioningUri,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// VerifyMySecondFactor godoc
+//
+//	@Tags			My-profile
+//	@Summary		Verify my second factor
+//	@Description	Enable the enrolled second factor with the code. The recovery codes are returned only once.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string								true	"organizationId"
+//	@Param			body			body		domain.VerifyMySecondFactorRequest	true	"verify second factor request"
+//	@Success		200				{object}	domain.VerifyMySecondFactorResponse
+//	@Router			/organizations/{organizationId}/my-profile/second-factor/verification [post]
+//	@Security		JWT
+func (h *SecondFactorHandler) VerifyMySecondFactor(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	input := domain.VerifyMySecondFactorRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	recoveryCodes, err := h.usecase.Verify(r.Context(), requestUserInfo.GetUserId(), input.Code)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.VerifyMySecondFactorResponse{
+		RecoveryCodes: recoveryCodes,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// RegenerateMyRecoveryCodes godoc
+//
+//	@Tags			My-profile
+//	@Summary		Regenerate my recovery codes
+//	@Description	Replace the recovery codes of my second factor. The previous codes are no longer valid.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string									true	"organizationId"
+//	@Param			body			body		domain.RegenerateMyRecoveryCodesRequest	true	"regenerate recovery codes request"
+//	@Success		200				{object}	domain.RegenerateMyRecoveryCodesResponse
+//	@Router			/organizations/{organizationId}/my-profile/second-factor/recovery-codes [post]
+//	@Security		JWT
+func (h *SecondFactorHandler) RegenerateMyRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	input := domain.RegenerateMyRecoveryCodesRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	recoveryCodes, err := h.usecase.RegenerateRecoveryCodes(r.Context(), requestUserInfo.GetUserId(), input.Code)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.RegenerateMyRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// DeleteMySecondFactor godoc
+//
+//	@Tags			My-profile
+//	@Summary		Delete my second factor
+//	@Description	Disable my second factor with the current code or a recovery code
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path	string								true	"organizationId"
+//	@Param			body			body	domain.DeleteMySecondFactorRequest	true	"delete second factor request"
+//	@Success		200
+//	@Router			/organizations/{organizationId}/my-profile/second-factor [delete]
+//	@Security		JWT
+func (h *SecondFactorHandler) DeleteMySecondFactor(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	input := domain.DeleteMySecondFactorRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Delete(r.Context(), requestUserInfo.GetUserId(), input.Code); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// ResetUserSecondFactor godoc
+//
+//	@Tags			Users
+//	@Summary		Reset user's second factor
+//	@Description	Remove the second factor of the user who lost the device. The user enrolls again after the next login.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			accountId		path		string	true	"accountId"
+//	@Success		200				{object}	domain.ResetUserSecondFactorResponse
+//	@Router			/organizations/{organizationId}/users/{accountId}/second-factor [delete]
+//	@Security		JWT
+func (h *SecondFactorHandler) ResetUserSecondFactor(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	accountId, ok := vars["accountId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("accountId not found in path"), "C_INVALID_ACCOUNT_ID", ""))
+		return
+	}
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("organizationId not found in path"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	if err := h.usecase.ResetByAccountId(r.Context(), accountId, organizationId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.ResetUserSecondFactorResponse{
+		AccountId: accountId,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+type SecurityPolicyHandler struct {
+	usecase usecase.ISecurityPolicyUsecase
+}
+
+func NewSecurityPolicyHandler(h usecase.Usecase) *SecurityPolicyHandler {
+	return &SecurityPolicyHandler{
+		usecase: h.SecurityPolicy,
+	}
+}
+
+// GetSecurityPolicy godoc
+//
+//	@Tags			Organizations
+//	@Summary		Get security policy
+//	@Description	Get the password and session policy of the organization
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.GetSecurityPolicyResponse
+//	@Router			/organizations/{organizationId}/security-policy [get]
+//	@Security		JWT
+func (h *SecurityPolicyHandler) GetSecurityPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	securityPolicy, err := h.usecase.Get(r.Context(), organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetSecurityPolicyResponse
+	if err = serializer.Map(r.Context(), securityPolicy, &out.SecurityPolicy); err != nil {
+		log.Info(r.Context(), err)
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateSecurityPolicy godoc
+//
+//	@Tags			Organizations
+//	@Summary		Update security policy
+//	@Description	Update the password and session policy of the organization. The policy is applied to the keycloak realm, and passwordExpiryDays 0 means never.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string								true	"organizationId"
+//	@Param			body			body		domain.UpdateSecurityPolicyRequest	true	"update security policy request"
+//	@Success		200				{object}	domain.UpdateSecurityPolicyResponse
+//	@Router			/organizations/{organizationId}/security-policy [put]
+//	@Security		JWT
+func (h *SecurityPolicyHandler) UpdateSecurityPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.UpdateSecurityPolicyRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.SecurityPolicy
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+
+	if err = h.usecase.Update(r.Context(), dto); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	securityPolicy, err := h.usecase.Get(r.Context(), organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.UpdateSecurityPolicyResponse
+	if err = serializer.Map(r.Context(), securityPolicy, &out.SecurityPolicy); err != nil {
+		log.Info(r.Context(), err)
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
@@ -0,0 +1,419 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type ServiceAccountHandler struct {
+	usecase         usecase.IServiceAccountUsecase
+	apiTokenUsecase usecase.IApiTokenUsecase
+	roleUsecase     usecase.IRoleUsecase
+}
+
+func NewServiceAccountHandler(h usecase.Usecase) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		usecase:         h.ServiceAccount,
+		apiTokenUsecase: h.ApiToken,
+		roleUsecase:     h.Role,
+	}
+}
+
+// CreateServiceAccount godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Create ServiceAccount
+//	@Description	Create service account for automations. It has roles like a user, and authenticates with api tokens.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string								true	"organizationId"
+//	@Param			body			body		domain.CreateServiceAccountRequest	true	"create service account request"
+//	@Success		200				{object}	domain.CreateServiceAccountResponse
+//	@Router			/organizations/{organizationId}/service-accounts [post]
+//	@Security		JWT
+func (h *ServiceAccountHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateServiceAccountRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.ServiceAccount
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+	if dto.Roles, err = h.getRoles(r, organizationId, input.Roles); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	id, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateServiceAccountResponse{
+		ID: id.String(),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetServiceAccounts godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Get ServiceAccounts
+//	@Description	Get ServiceAccounts
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetServiceAccountsResponse
+//	@Router			/organizations/{organizationId}/service-accounts [get]
+//	@Security		JWT
+func (h *ServiceAccountHandler) GetServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	serviceAccounts, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetServiceAccountsResponse
+	out.ServiceAccounts = make([]domain.ServiceAccountResponse, len(serviceAccounts))
+	for i, serviceAccount := range serviceAccounts {
+		out.ServiceAccounts[i] = toServiceAccountResponse(r, serviceAccount)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetServiceAccount godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Get ServiceAccount
+//	@Description	Get ServiceAccount
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			serviceAccountId	path		string	true	"serviceAccountId"
+//	@Success		200					{object}	domain.GetServiceAccountResponse
+//	@Router			/organizations/{organizationId}/service-accounts/{serviceAccountId} [get]
+//	@Security		JWT
+func (h *ServiceAccountHandler) GetServiceAccount(w http.ResponseWriter, r *http.Request) {
+	organizationId, serviceAccountId, err := serviceAccountVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	serviceAccount, err := h.usecase.Get(r.Context(), organizationId, serviceAccountId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.GetServiceAccountResponse{
+		ServiceAccount: toServiceAccountResponse(r, serviceAccount),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateServiceAccount godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Update ServiceAccount
+//	@Description	Update description and roles of ServiceAccount
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string								true	"organizationId"
+//	@Param			serviceAccountId	path		string								true	"serviceAccountId"
+//	@Param			body				body		domain.UpdateServiceAccountRequest	true	"update service account request"
+//	@Success		200					{object}	nil
+//	@Router			/organizations/{organizationId}/service-accounts/{serviceAccountId} [put]
+//	@Security		JWT
+func (h *ServiceAccountHandler) UpdateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	organizationId, serviceAccountId, err := serviceAccountVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.UpdateServiceAccountRequest{}
+	err = UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.ServiceAccount
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.ID = serviceAccountId
+	dto.OrganizationId = organizationId
+	if dto.Roles, err = h.getRoles(r, organizationId, input.Roles); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Update(r.Context(), dto); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// DeleteServiceAccount godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Delete ServiceAccount
+//	@Description	Delete ServiceAccount with its api tokens
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			serviceAccountId	path		string	true	"serviceAccountId"
+//	@Success		200					{object}	domain.DeleteServiceAccountResponse
+//	@Router			/organizations/{organizationId}/service-accounts/{serviceAccountId} [delete]
+//	@Security		JWT
+func (h *ServiceAccountHandler) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	organizationId, serviceAccountId, err := serviceAccountVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	serviceAccount, err := h.usecase.Delete(r.Context(), organizationId, serviceAccountId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.DeleteServiceAccountResponse{
+		ID:   serviceAccount.ID.String(),
+		Name: serviceAccount.Name,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetServiceAccountTokens godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Get api tokens of ServiceAccount
+//	@Description	Get api tokens of ServiceAccount
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string		true	"organizationId"
+//	@Param			serviceAccountId	path		string		true	"serviceAccountId"
+//	@Param			pageSize			query		string		false	"pageSize"
+//	@Param			pageNumber			query		string		false	"pageNumber"
+//	@Param			soertColumn			query		string		false	"sortColumn"
+//	@Param			sortOrder			query		string		false	"sortOrder"
+//	@Param			filters				query		[]string	false	"filters"
+//	@Success		200					{object}	domain.GetApiTokensResponse
+//	@Router			/organizations/{organizationId}/service-accounts/{serviceAccountId}/tokens [get]
+//	@Security		JWT
+func (h *ServiceAccountHandler) GetServiceAccountTokens(w http.ResponseWriter, r *http.Request) {
+	organizationId, serviceAccountId, err := serviceAccountVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if _, err = h.usecase.Get(r.Context(), organizationId, serviceAccountId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	apiTokens, err := h.apiTokenUsecase.FetchByServiceAccountId(r.Context(), serviceAccountId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := toGetApiTokensResponse(r, apiTokens)
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// CreateServiceAccountToken godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Create api token of ServiceAccount
+//	@Description	Create api token of ServiceAccount. The token is returned only once.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string							true	"organizationId"
+//	@Param			serviceAccountId	path		string							true	"serviceAccountId"
+//	@Param			body				body		domain.CreateApiTokenRequest	true	"create api token request"
+//	@Success		200					{object}	domain.CreateApiTokenResponse
+//	@Router			/organizations/{organizationId}/service-accounts/{serviceAccountId}/tokens [post]
+//	@Security		JWT
+func (h *ServiceAccountHandler) CreateServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	organizationId, serviceAccountId, err := serviceAccountVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.CreateApiTokenRequest{}
+	err = UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if _, err = h.usecase.Get(r.Context(), organizationId, serviceAccountId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.ApiToken
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+	dto.ServiceAccountId = &serviceAccountId
+
+	id, token, err := h.apiTokenUsecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateApiTokenResponse{
+		ID:    id.String(),
+		Token: token,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// RevokeServiceAccountToken godoc
+//
+//	@Tags			ServiceAccounts
+//	@Summary		Revoke api token of ServiceAccount
+//	@Description	Revoke api token of ServiceAccount
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId		path		string	true	"organizationId"
+//	@Param			serviceAccountId	path		string	true	"serviceAccountId"
+//	@Param			apiTokenId			path		string	true	"apiTokenId"
+//	@Success		200					{object}	domain.RevokeApiTokenResponse
+//	@Router			/organizations/{organizationId}/service-accounts/{serviceAccountId}/tokens/{apiTokenId} [delete]
+//	@Security		JWT
+func (h *ServiceAccountHandler) RevokeServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	organizationId, serviceAccountId, err := serviceAccountVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+	apiTokenId, err := apiTokenVar(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if _, err = h.usecase.Get(r.Context(), organizationId, serviceAccountId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	apiToken, err := h.apiTokenUsecase.RevokeByServiceAccount(r.Context(), serviceAccountId, apiTokenId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.RevokeApiTokenResponse{
+		ID:   apiToken.ID.String(),
+		Name: apiToken.Name,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func (h *ServiceAccountHandler) getRoles(r *http.Request, organizationId string, inputRoles []domain.UserCreationRole) ([]model.Role, error) {
+	roles := make([]model.Role, 0, len(inputRoles))
+	for _, role := range inputRoles {
+		v, err := h.roleUsecase.GetTksRole(r.Context(), organizationId, *role.ID)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, *v)
+	}
+	return roles, nil
+}
+
+func toServiceAccountResponse(r *http.Request, serviceAccount model.ServiceAccount) (out domain.ServiceAccountResponse) {
+	if err := serializer.Map(r.Context(), serviceAccount, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.Roles = make([]domain.SimpleRoleResponse, 0, len(serviceAccount.Roles))
+	for _, role := range serviceAccount.Roles {
+		out.Roles = append(out.Roles, domain.SimpleRoleResponse{
+			ID:   role.ID,
+			Name: role.Name,
+		})
+	}
+	return out
+}
+
+func serviceAccountVars(r *http.Request) (organizationId string, serviceAccountId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["serviceAccountId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid serviceAccountId"), "C_INVALID_SERVICE_ACCOUNT_ID", "")
+	}
+	serviceAccountId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_SERVICE_ACCOUNT_ID", "")
+	}
+
+	return organizationId, serviceAccountId, nil
+}
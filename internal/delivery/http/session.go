@@ -0,0 +1,213 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+)
+
+type SessionHandler struct {
+	usecase usecase.ISessionUsecase
+}
+
+func NewSessionHandler(h usecase.Usecase) *SessionHandler {
+	return &SessionHandler{
+		usecase: h.Session,
+	}
+}
+
+// GetMySessions godoc
+//
+//	@Tags			My-profile
+//	@Summary		Get my sessions
+//	@Description	Get my active sessions
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.GetMySessionsResponse
+//	@Router			/organizations/{organizationId}/my-profile/sessions [get]
+//	@Security		JWT
+func (h *SessionHandler) GetMySessions(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	sessions, err := h.usecase.Fetch(r.Context(), requestUserInfo.GetOrganizationId(), requestUserInfo.GetUserId())
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetMySessionsResponse
+	out.Sessions = toSessionResponses(r, sessions)
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// RevokeMySession godoc
+//
+//	@Tags			My-profile
+//	@Summary		Revoke my session
+//	@Description	Sign out one of my sessions
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path	string	true	"organizationId"
+//	@Param			sessionId		path	string	true	"sessionId"
+//	@Success		200
+//	@Router			/organizations/{organizationId}/my-profile/sessions/{sessionId} [delete]
+//	@Security		JWT
+func (h *SessionHandler) RevokeMySession(w http.ResponseWriter, r *http.Request) {
+	requestUserInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found in request"), "A_INVALID_TOKEN", ""))
+		return
+	}
+
+	vars := mux.Vars(r)
+	sessionId, ok := vars["sessionId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("sessionId not found in path"), "C_INVALID_SESSION_ID", ""))
+		return
+	}
+
+	if err := h.usecase.Revoke(r.Context(), requestUserInfo.GetOrganizationId(), requestUserInfo.GetUserId(), sessionId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// GetUserSessions godoc
+//
+//	@Tags			Users
+//	@Summary		Get user's sessions
+//	@Description	Get active sessions of the user
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			accountId		path		string	true	"accountId"
+//	@Success		200				{object}	domain.GetUserSessionsResponse
+//	@Router			/organizations/{organizationId}/users/{accountId}/sessions [get]
+//	@Security		JWT
+func (h *SessionHandler) GetUserSessions(w http.ResponseWriter, r *http.Request) {
+	accountId, organizationId, err := accountPathParams(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	sessions, err := h.usecase.FetchByAccountId(r.Context(), accountId, organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetUserSessionsResponse
+	out.Sessions = toSessionResponses(r, sessions)
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// RevokeUserSession godoc
+//
+//	@Tags			Users
+//	@Summary		Revoke user's session
+//	@Description	Sign out the session of the user. Every token issued to the user until now is rejected.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			accountId		path		string	true	"accountId"
+//	@Param			sessionId		path		string	true	"sessionId"
+//	@Success		200				{object}	domain.RevokeUserSessionResponse
+//	@Router			/organizations/{organizationId}/users/{accountId}/sessions/{sessionId} [delete]
+//	@Security		JWT
+func (h *SessionHandler) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	accountId, organizationId, err := accountPathParams(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+	sessionId, ok := mux.Vars(r)["sessionId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("sessionId not found in path"), "C_INVALID_SESSION_ID", ""))
+		return
+	}
+
+	if err = h.usecase.RevokeByAccountId(r.Context(), accountId, organizationId, sessionId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.RevokeUserSessionResponse{
+		AccountId: accountId,
+		SessionId: sessionId,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// RevokeUserSessions godoc
+//
+//	@Tags			Users
+//	@Summary		Revoke all sessions of the user
+//	@Description	Sign out every session of the user. Every token issued to the user until now is rejected.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			accountId		path		string	true	"accountId"
+//	@Success		200				{object}	domain.RevokeUserSessionResponse
+//	@Router			/organizations/{organizationId}/users/{accountId}/sessions [delete]
+//	@Security		JWT
+func (h *SessionHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	accountId, organizationId, err := accountPathParams(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.RevokeAllByAccountId(r.Context(), accountId, organizationId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.RevokeUserSessionResponse{
+		AccountId: accountId,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func accountPathParams(r *http.Request) (accountId string, organizationId string, err error) {
+	vars := mux.Vars(r)
+	accountId, ok := vars["accountId"]
+	if !ok {
+		return "", "", httpErrors.NewBadRequestError(fmt.Errorf("accountId not found in path"), "C_INVALID_ACCOUNT_ID", "")
+	}
+	organizationId, ok = vars["organizationId"]
+	if !ok {
+		return "", "", httpErrors.NewBadRequestError(fmt.Errorf("organizationId not found in path"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+	return accountId, organizationId, nil
+}
+
+func toSessionResponses(r *http.Request, sessions []model.Session) []domain.SessionResponse {
+	currentSessionId, _ := request.SessionFrom(r.Context())
+
+	out := make([]domain.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		out[i] = domain.SessionResponse{
+			ID:             session.ID,
+			IpAddress:      session.IpAddress,
+			Clients:        session.Clients,
+			StartedAt:      session.StartedAt,
+			LastAccessedAt: session.LastAccessedAt,
+			Current:        session.ID == currentSessionId,
+		}
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const streamHeartbeatInterval = 30 * time.Second
+
+type StreamHandler struct {
+	usecase usecase.IStreamUsecase
+}
+
+func NewStreamHandler(h usecase.Usecase) *StreamHandler {
+	return &StreamHandler{
+		usecase: h.Stream,
+	}
+}
+
+// CreateNotificationStreamTicket godoc
+//
+//	@Tags			Stream
+//	@Summary		Create notification stream ticket
+//	@Description	Issue the one-time ticket to connect the notification stream. The ticket expires in 30 seconds.
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Success		200				{object}	domain.CreateNotificationStreamTicketResponse
+//	@Router			/organizations/{organizationId}/notification-stream/tickets [post]
+//	@Security		JWT
+func (h *StreamHandler) CreateNotificationStreamTicket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	ticket, expiredAt, err := h.usecase.IssueTicket(r.Context(), organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateNotificationStreamTicketResponse{
+		Ticket:    ticket,
+		ExpiredAt: expiredAt,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetNotificationStream godoc
+//
+//	@Tags			Stream
+//	@Summary		Get notification stream
+//	@Description	Server-Sent Events of the organization. The events are SYSTEM_NOTIFICATION_CREATED, SYSTEM_NOTIFICATION_UPDATED, CLUSTER_STATUS_CHANGED and STACK_STATUS_CHANGED.
+//	@Description	The browser EventSource can not set the Authorization header, so the stream is authorized by the one-time ticket from the ticket API.
+//	@Produce		text/event-stream
+//	@Param			organizationId	path	string	true	"organizationId"
+//	@Param			ticket			query	string	true	"one-time stream ticket"
+//	@Success		200
+//	@Router			/organizations/{organizationId}/notification-stream [get]
+func (h *StreamHandler) GetNotificationStream(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	if err := h.usecase.ConsumeTicket(r.Context(), organizationId, r.URL.Query().Get("ticket")); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("streaming is not supported"), "", ""))
+		return
+	}
+
+	events, cancel := h.usecase.Subscribe(r.Context(), organizationId)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			// proxy 가 유휴 연결을 끊지 않도록 주석 라인을 보낸다.
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Error(r.Context(), err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type SystemNotificationCredentialHandler struct {
+	usecase usecase.ISystemNotificationCredentialUsecase
+}
+
+func NewSystemNotificationCredentialHandler(h usecase.Usecase) *SystemNotificationCredentialHandler {
+	return &SystemNotificationCredentialHandler{
+		usecase: h.SystemNotificationCredential,
+	}
+}
+
+// CreateSystemNotificationCredential godoc
+//
+//	@Tags			SystemNotificationCredentials
+//	@Summary		Create SystemNotificationCredential
+//	@Description	Create webhook credential for alertmanager. The secret is returned only once.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string											true	"organizationId"
+//	@Param			body			body		domain.CreateSystemNotificationCredentialRequest	true	"create system notification credential request"
+//	@Success		200				{object}	domain.CreateSystemNotificationCredentialResponse
+//	@Router			/organizations/{organizationId}/system-notification-credentials [post]
+//	@Security		JWT
+func (h *SystemNotificationCredentialHandler) CreateSystemNotificationCredential(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateSystemNotificationCredentialRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.SystemNotificationCredential
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+
+	id, secret, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateSystemNotificationCredentialResponse{
+		ID:     id.String(),
+		Secret: secret,
+		Token:  id.String() + "." + secret,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetSystemNotificationCredentials godoc
+//
+//	@Tags			SystemNotificationCredentials
+//	@Summary		Get SystemNotificationCredentials
+//	@Description	Get SystemNotificationCredentials
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetSystemNotificationCredentialsResponse
+//	@Router			/organizations/{organizationId}/system-notification-credentials [get]
+//	@Security		JWT
+func (h *SystemNotificationCredentialHandler) GetSystemNotificationCredentials(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	credentials, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetSystemNotificationCredentialsResponse
+	out.SystemNotificationCredentials = make([]domain.SystemNotificationCredentialResponse, len(credentials))
+	for i, credential := range credentials {
+		if err := serializer.Map(r.Context(), credential, &out.SystemNotificationCredentials[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// DeleteSystemNotificationCredential godoc
+//
+//	@Tags			SystemNotificationCredentials
+//	@Summary		Delete SystemNotificationCredential
+//	@Description	Delete SystemNotificationCredential
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId					path		string	true	"organizationId"
+//	@Param			systemNotificationCredentialId	path		string	true	"systemNotificationCredentialId"
+//	@Success		200								{object}	nil
+//	@Router			/organizations/{organizationId}/system-notification-credentials/{systemNotificationCredentialId} [delete]
+//	@Security		JWT
+func (h *SystemNotificationCredentialHandler) DeleteSystemNotificationCredential(w http.ResponseWriter, r *http.Request) {
+	organizationId, systemNotificationCredentialId, err := systemNotificationCredentialVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Delete(r.Context(), organizationId, systemNotificationCredentialId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// RotateSystemNotificationCredential godoc
+//
+//	@Tags			SystemNotificationCredentials
+//	@Summary		Rotate SystemNotificationCredential
+//	@Description	Issue new secret. The previous secret is still valid for 24 hours.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId					path		string	true	"organizationId"
+//	@Param			systemNotificationCredentialId	path		string	true	"systemNotificationCredentialId"
+//	@Success		200								{object}	domain.RotateSystemNotificationCredentialResponse
+//	@Router			/organizations/{organizationId}/system-notification-credentials/{systemNotificationCredentialId}/rotate [put]
+//	@Security		JWT
+func (h *SystemNotificationCredentialHandler) RotateSystemNotificationCredential(w http.ResponseWriter, r *http.Request) {
+	organizationId, systemNotificationCredentialId, err := systemNotificationCredentialVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	secret, err := h.usecase.Rotate(r.Context(), organizationId, systemNotificationCredentialId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.RotateSystemNotificationCredentialResponse{
+		ID:     systemNotificationCredentialId.String(),
+		Secret: secret,
+		Token:  systemNotificationCredentialId.String() + "." + secret,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func systemNotificationCredentialVars(r *http.Request) (organizationId string, systemNotificationCredentialId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["systemNotificationCredentialId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid systemNotificationCredentialId"), "C_INVALID_SYSTEM_NOTIFICATION_CREDENTIAL_ID", "")
+	}
+	systemNotificationCredentialId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_SYSTEM_NOTIFICATION_CREDENTIAL_ID", "")
+	}
+
+	return organizationId, systemNotificationCredentialId, nil
+}
@@ -60,6 +60,10 @@ func (h *SystemNotificationRuleHandler) CreateSystemNotificationRule(w http.Resp
 		log.Info(r.Context(), err)
 	}
 	dto.OrganizationId = organizationId
+	if dto.EscalationPolicyId, err = parseEscalationPolicyId(input.EscalationPolicyId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
 
 	if !dto.SystemNotificationCondition.EnablePortal {
 		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid EnablePortal"), "SNR_INVALID_ENABLE_PORTAL", ""))
@@ -133,6 +137,13 @@ func (h *SystemNotificationRuleHandler) GetSystemNotificationRules(w http.Respon
 			}
 		}
 
+		out.SystemNotificationRules[i].NotificationChannels = make([]domain.SimpleNotificationChannelResponse, len(systemNotificationRule.NotificationChannels))
+		for j, channel := range systemNotificationRule.NotificationChannels {
+			if err := serializer.Map(r.Context(), channel, &out.SystemNotificationRules[i].NotificationChannels[j]); err != nil {
+				log.Info(r.Context(), err)
+			}
+		}
+
 		err = json.Unmarshal(systemNotificationRule.SystemNotificationCondition.Parameter, &out.SystemNotificationRules[i].SystemNotificationCondition.Parameters)
 		if err != nil {
 			log.Error(r.Context(), err)
@@ -190,6 +201,13 @@ func (h *SystemNotificationRuleHandler) GetSystemNotificationRule(w http.Respons
 		}
 	}
 
+	out.SystemNotificationRule.NotificationChannels = make([]domain.SimpleNotificationChannelResponse, len(systemNotificationRule.NotificationChannels))
+	for i, channel := range systemNotificationRule.NotificationChannels {
+		if err := serializer.Map(r.Context(), channel, &out.SystemNotificationRule.NotificationChannels[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+	}
+
 	err = json.Unmarshal(systemNotificationRule.SystemNotificationCondition.Parameter, &out.SystemNotificationRule.SystemNotificationCondition.Parameters)
 	if err != nil {
 		log.Error(r.Context(), err)
@@ -243,6 +261,10 @@ func (h *SystemNotificationRuleHandler) UpdateSystemNotificationRule(w http.Resp
 	}
 	dto.OrganizationId = organizationId
 	dto.ID = systemNotificationRuleId
+	if dto.EscalationPolicyId, err = parseEscalationPolicyId(input.EscalationPolicyId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
 
 	if !dto.SystemNotificationCondition.EnablePortal {
 		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid EnablePortal"), "SNR_INVALID_ENABLE_PORTAL", ""))
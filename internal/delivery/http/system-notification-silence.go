@@ -0,0 +1,269 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type SystemNotificationSilenceHandler struct {
+	usecase usecase.ISystemNotificationSilenceUsecase
+}
+
+func NewSystemNotificationSilenceHandler(h usecase.Usecase) *SystemNotificationSilenceHandler {
+	return &SystemNotificationSilenceHandler{
+		usecase: h.SystemNotificationSilence,
+	}
+}
+
+// CreateSystemNotificationSilence godoc
+//
+//	@Tags			SystemNotificationSilences
+//	@Summary		Create SystemNotificationSilence
+//	@Description	Create SystemNotificationSilence. The matched system notifications are recorded but not delivered during the period. The empty matchers match everything.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string											true	"organizationId"
+//	@Param			body			body		domain.CreateSystemNotificationSilenceRequest	true	"create silence request"
+//	@Success		200				{object}	domain.CreateSystemNotificationSilenceResponse
+//	@Router			/organizations/{organizationId}/system-notification-silences [post]
+//	@Security		JWT
+func (h *SystemNotificationSilenceHandler) CreateSystemNotificationSilence(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	input := domain.CreateSystemNotificationSilenceRequest{}
+	err := UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.SystemNotificationSilence
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.OrganizationId = organizationId
+	if dto.SystemNotificationRuleId, err = parseSilenceRuleId(input.SystemNotificationRuleId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	id, err := h.usecase.Create(r.Context(), dto)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.CreateSystemNotificationSilenceResponse{
+		ID: id.String(),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetSystemNotificationSilences godoc
+//
+//	@Tags			SystemNotificationSilences
+//	@Summary		Get SystemNotificationSilences
+//	@Description	Get SystemNotificationSilences
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetSystemNotificationSilencesResponse
+//	@Router			/organizations/{organizationId}/system-notification-silences [get]
+//	@Security		JWT
+func (h *SystemNotificationSilenceHandler) GetSystemNotificationSilences(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	silences, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	var out domain.GetSystemNotificationSilencesResponse
+	out.SystemNotificationSilences = make([]domain.SystemNotificationSilenceResponse, len(silences))
+	for i, silence := range silences {
+		if err := serializer.Map(r.Context(), silence, &out.SystemNotificationSilences[i]); err != nil {
+			log.Info(r.Context(), err)
+		}
+		out.SystemNotificationSilences[i].Status = silence.StatusAt(now)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetSystemNotificationSilence godoc
+//
+//	@Tags			SystemNotificationSilences
+//	@Summary		Get SystemNotificationSilence
+//	@Description	Get SystemNotificationSilence
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId				path		string	true	"organizationId"
+//	@Param			systemNotificationSilenceId	path		string	true	"systemNotificationSilenceId"
+//	@Success		200							{object}	domain.GetSystemNotificationSilenceResponse
+//	@Router			/organizations/{organizationId}/system-notification-silences/{systemNotificationSilenceId} [get]
+//	@Security		JWT
+func (h *SystemNotificationSilenceHandler) GetSystemNotificationSilence(w http.ResponseWriter, r *http.Request) {
+	organizationId, systemNotificationSilenceId, err := systemNotificationSilenceVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	silence, err := h.usecase.Get(r.Context(), organizationId, systemNotificationSilenceId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetSystemNotificationSilenceResponse
+	if err := serializer.Map(r.Context(), silence, &out.SystemNotificationSilence); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.SystemNotificationSilence.Status = silence.StatusAt(time.Now())
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// UpdateSystemNotificationSilence godoc
+//
+//	@Tags			SystemNotificationSilences
+//	@Summary		Update SystemNotificationSilence
+//	@Description	Update SystemNotificationSilence. To end the silence early, set endsAt to now.
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId				path		string											true	"organizationId"
+//	@Param			systemNotificationSilenceId	path		string											true	"systemNotificationSilenceId"
+//	@Param			body						body		domain.UpdateSystemNotificationSilenceRequest	true	"update silence request"
+//	@Success		200							{object}	nil
+//	@Router			/organizations/{organizationId}/system-notification-silences/{systemNotificationSilenceId} [put]
+//	@Security		JWT
+func (h *SystemNotificationSilenceHandler) UpdateSystemNotificationSilence(w http.ResponseWriter, r *http.Request) {
+	organizationId, systemNotificationSilenceId, err := systemNotificationSilenceVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	input := domain.UpdateSystemNotificationSilenceRequest{}
+	err = UnmarshalRequestInput(r, &input)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var dto model.SystemNotificationSilence
+	if err = serializer.Map(r.Context(), input, &dto); err != nil {
+		log.Info(r.Context(), err)
+	}
+	dto.ID = systemNotificationSilenceId
+	dto.OrganizationId = organizationId
+	if dto.SystemNotificationRuleId, err = parseSilenceRuleId(input.SystemNotificationRuleId); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	if err = h.usecase.Update(r.Context(), dto); err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
+// DeleteSystemNotificationSilence godoc
+//
+//	@Tags			SystemNotificationSilences
+//	@Summary		Delete SystemNotificationSilence
+//	@Description	Delete SystemNotificationSilence
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId				path		string	true	"organizationId"
+//	@Param			systemNotificationSilenceId	path		string	true	"systemNotificationSilenceId"
+//	@Success		200							{object}	domain.DeleteSystemNotificationSilenceResponse
+//	@Router			/organizations/{organizationId}/system-notification-silences/{systemNotificationSilenceId} [delete]
+//	@Security		JWT
+func (h *SystemNotificationSilenceHandler) DeleteSystemNotificationSilence(w http.ResponseWriter, r *http.Request) {
+	organizationId, systemNotificationSilenceId, err := systemNotificationSilenceVars(r)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	silence, err := h.usecase.Delete(r.Context(), organizationId, systemNotificationSilenceId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.DeleteSystemNotificationSilenceResponse{
+		ID:      silence.ID.String(),
+		Comment: silence.Comment,
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+func systemNotificationSilenceVars(r *http.Request) (organizationId string, systemNotificationSilenceId uuid.UUID, err error) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", "")
+	}
+
+	strId, ok := vars["systemNotificationSilenceId"]
+	if !ok {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid systemNotificationSilenceId"), "C_INVALID_SYSTEM_NOTIFICATION_SILENCE_ID", "")
+	}
+	systemNotificationSilenceId, err = uuid.Parse(strId)
+	if err != nil {
+		return "", uuid.Nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_SYSTEM_NOTIFICATION_SILENCE_ID", "")
+	}
+
+	return organizationId, systemNotificationSilenceId, nil
+}
+
+// parseSilenceRuleId returns nil if strId is empty, which matches every rule.
+func parseSilenceRuleId(strId string) (*uuid.UUID, error) {
+	if strId == "" {
+		return nil, nil
+	}
+	systemNotificationRuleId, err := uuid.Parse(strId)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_SYSTEM_NOTIFICATION_RULE_ID", "")
+	}
+	return &systemNotificationRuleId, nil
+}
@@ -21,12 +21,14 @@ import (
 )
 
 type SystemNotificationHandler struct {
-	usecase usecase.ISystemNotificationUsecase
+	usecase           usecase.ISystemNotificationUsecase
+	credentialUsecase usecase.ISystemNotificationCredentialUsecase
 }
 
 func NewSystemNotificationHandler(h usecase.Usecase) *SystemNotificationHandler {
 	return &SystemNotificationHandler{
-		usecase: h.SystemNotification,
+		usecase:           h.SystemNotification,
+		credentialUsecase: h.SystemNotificationCredential,
 	}
 }
 
@@ -34,13 +36,16 @@ func NewSystemNotificationHandler(h usecase.Usecase) *SystemNotificationHandler
 //
 //	@Tags			SystemNotifications
 //	@Summary		Create systemNotification. ADMIN ONLY
-//	@Description	Create systemNotification. ADMIN ONLY
+//	@Description	Create systemNotification. The request must be authenticated by a system notification credential. ( Bearer token or HMAC signature ) The bearer token has no replay protection unless the timestamp and nonce are given.
 //	@Accept			json
 //	@Produce		json
-//	@Param			organizationId	path		string	true	"organizationId"
-//	@Success		200				{object}	nil
-//	@Router			/system-api/organizations/{organizationId}/system-notifications [post]
-//	@Security		JWT
+//	@Param			Authorization		header		string	false	"Bearer <credentialId>.<secret>"
+//	@Param			X-TKS-Credential-Id	header		string	false	"credentialId"
+//	@Param			X-TKS-Timestamp		header		string	false	"unix timestamp"
+//	@Param			X-TKS-Nonce			header		string	false	"nonce"
+//	@Param			X-TKS-Signature		header		string	false	"hex(HMAC-SHA256(secret, timestamp.nonce.body))"
+//	@Success		200					{object}	nil
+//	@Router			/system-api/system-notifications [post]
 func (h *SystemNotificationHandler) CreateSystemNotification(w http.ResponseWriter, r *http.Request) {
 
 	/*
@@ -57,6 +62,12 @@ func (h *SystemNotificationHandler) CreateSystemNotification(w http.ResponseWrit
 	bodyString := string(bodyBytes)
 	log.Info(r.Context(), bodyString)
 
+	credential, err := h.credentialUsecase.Authenticate(r.Context(), r.Header, bodyBytes)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
 	// 외부로부터(systemNotification manager) 오는 데이터이므로, dto 변환없이 by-pass 처리한다.
 	input := domain.CreateSystemNotificationRequest{}
 	err = UnmarshalRequestInput(r, &input)
@@ -65,7 +76,7 @@ func (h *SystemNotificationHandler) CreateSystemNotification(w http.ResponseWrit
 		return
 	}
 
-	err = h.usecase.Create(r.Context(), input)
+	err = h.usecase.Create(r.Context(), credential, input)
 	if err != nil {
 		ErrorJSON(w, r, err)
 		return
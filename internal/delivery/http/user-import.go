@@ -0,0 +1,383 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/serializer"
+	"github.com/openinfradev/tks-api/internal/usecase"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// csv 의 역할, 프로젝트 목록은 ';' 로 구분한다.
+const userImportListSeparator = ";"
+
+var userExportCsvHeader = []string{"accountId", "name", "email", "department", "description", "roles", "projects", "createdAt"}
+
+type UserImportHandler struct {
+	usecase usecase.IUserImportUsecase
+}
+
+func NewUserImportHandler(h usecase.Usecase) *UserImportHandler {
+	return &UserImportHandler{
+		usecase: h.UserImport,
+	}
+}
+
+// ImportUsers godoc
+//
+//	@Tags			Users
+//	@Summary		Import users
+//	@Description	Validate every row and create the users in the background. Send the csv with the content type text/csv, or the json.
+//	@Description	The csv has the header of accountId,name,email,department,description,roles and the roles are the names separated by ';'.
+//	@Description	Nothing is created if a row is invalid. Each created user is invited by mail to set the password.
+//	@Accept			json,text/csv
+//	@Produce		json
+//	@Param			organizationId	path		string						true	"organizationId"
+//	@Param			body			body		domain.ImportUsersRequest	true	"import users request"
+//	@Success		202				{object}	domain.ImportUsersResponse
+//	@Failure		400				{object}	domain.ImportUsersResponse
+//	@Router			/organizations/{organizationId}/user-imports [post]
+//	@Security		JWT
+func (h *UserImportHandler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(err, "UI_INVALID_FILE", ""))
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	var inputs []domain.ImportUserRequest
+	var lines []int
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		inputs, lines, err = parseImportUsersCsv(body)
+	} else {
+		inputs, lines, err = parseImportUsersJson(body)
+	}
+	if err != nil {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(err, "UI_INVALID_FILE", ""))
+		return
+	}
+
+	rows := make([]model.UserImportRow, len(inputs))
+	for i, input := range inputs {
+		rows[i] = model.UserImportRow{
+			Line:        lines[i],
+			AccountId:   input.AccountId,
+			Name:        input.Name,
+			Email:       input.Email,
+			Department:  input.Department,
+			Description: input.Description,
+			Roles:       input.Roles,
+		}
+		// 형식 오류는 여기서 표시하고 나머지 검증은 usecase 에서 한다.
+		if err := ValidateDomainObject(input); err != nil {
+			rows[i].Status = model.UserImportRowStatusInvalid
+			rows[i].Message = err.Error()
+			var restErr httpErrors.IRestError
+			if errors.As(err, &restErr) && restErr.Text() != "" {
+				rows[i].Message = restErr.Text()
+			}
+		}
+	}
+
+	job, err := h.usecase.Import(r.Context(), organizationId, rows)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.ImportUsersResponse{
+		UserImport: toUserImportResponse(r, job),
+		Rows:       toUserImportRowResponses(job.Rows),
+	}
+	if job.Status == model.UserImportStatusRejected {
+		ResponseJSON(w, r, http.StatusBadRequest, out)
+		return
+	}
+	ResponseJSON(w, r, http.StatusAccepted, out)
+}
+
+// GetUserImports godoc
+//
+//	@Tags			Users
+//	@Summary		Get user imports
+//	@Description	Get user imports of the organization
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string		true	"organizationId"
+//	@Param			pageSize		query		string		false	"pageSize"
+//	@Param			pageNumber		query		string		false	"pageNumber"
+//	@Param			soertColumn		query		string		false	"sortColumn"
+//	@Param			sortOrder		query		string		false	"sortOrder"
+//	@Param			filters			query		[]string	false	"filters"
+//	@Success		200				{object}	domain.GetUserImportsResponse
+//	@Router			/organizations/{organizationId}/user-imports [get]
+//	@Security		JWT
+func (h *UserImportHandler) GetUserImports(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	urlParams := r.URL.Query()
+	pg := pagination.NewPagination(&urlParams)
+	jobs, err := h.usecase.Fetch(r.Context(), organizationId, pg)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.GetUserImportsResponse
+	out.UserImports = make([]domain.UserImportResponse, len(jobs))
+	for i, job := range jobs {
+		out.UserImports[i] = toUserImportResponse(r, job)
+	}
+
+	if out.Pagination, err = pg.Response(r.Context()); err != nil {
+		log.Info(r.Context(), err)
+	}
+
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// GetUserImport godoc
+//
+//	@Tags			Users
+//	@Summary		Get user import
+//	@Description	Get the progress and the result of each row of the user import
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			userImportId	path		string	true	"userImportId"
+//	@Success		200				{object}	domain.GetUserImportResponse
+//	@Router			/organizations/{organizationId}/user-imports/{userImportId} [get]
+//	@Security		JWT
+func (h *UserImportHandler) GetUserImport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+	strId, ok := vars["userImportId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("invalid userImportId"), "C_INVALID_USER_IMPORT_ID", ""))
+		return
+	}
+	jobId, err := uuid.Parse(strId)
+	if err != nil {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(errors.Wrap(err, "Failed to parse uuid %s"), "C_INVALID_USER_IMPORT_ID", ""))
+		return
+	}
+
+	job, err := h.usecase.Get(r.Context(), organizationId, jobId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	out := domain.GetUserImportResponse{
+		UserImport: toUserImportResponse(r, job),
+		Rows:       toUserImportRowResponses(job.Rows),
+	}
+	ResponseJSON(w, r, http.StatusOK, out)
+}
+
+// ExportUsers godoc
+//
+//	@Tags			Users
+//	@Summary		Export users
+//	@Description	Export every user of the organization with the roles and the project memberships. The csv can be imported again.
+//	@Accept			json
+//	@Produce		json,text/csv
+//	@Param			organizationId	path		string	true	"organizationId"
+//	@Param			format			query		string	false	"csv or json (default)"
+//	@Success		200				{object}	domain.ExportUsersResponse
+//	@Router			/organizations/{organizationId}/user-exports [get]
+//	@Security		JWT
+func (h *UserImportHandler) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("Invalid organizationId"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	users, err := h.usecase.Export(r.Context(), organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	var out domain.ExportUsersResponse
+	out.Users = make([]domain.ExportUserResponse, len(users))
+	for i, user := range users {
+		out.Users[i] = domain.ExportUserResponse{
+			AccountId:   user.User.AccountId,
+			Name:        user.User.Name,
+			Email:       user.User.Email,
+			Department:  user.User.Department,
+			Description: user.User.Description,
+			Roles:       make([]string, len(user.User.Roles)),
+			Projects:    make([]domain.ExportUserProjectResponse, len(user.Projects)),
+			CreatedAt:   user.User.CreatedAt,
+		}
+		for j, role := range user.User.Roles {
+			out.Users[i].Roles[j] = role.Name
+		}
+		for j, project := range user.Projects {
+			out.Users[i].Projects[j] = domain.ExportUserProjectResponse{
+				ProjectId:       project.ProjectId,
+				ProjectName:     project.ProjectName,
+				ProjectRoleName: project.ProjectRoleName,
+			}
+		}
+	}
+
+	if r.URL.Query().Get("format") != "csv" {
+		ResponseJSON(w, r, http.StatusOK, out)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=users-%s.csv", organizationId))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	records := [][]string{userExportCsvHeader}
+	for _, user := range out.Users {
+		projects := make([]string, len(user.Projects))
+		for i, project := range user.Projects {
+			projects[i] = project.ProjectName + ":" + project.ProjectRoleName
+		}
+		records = append(records, []string{
+			user.AccountId,
+			user.Name,
+			user.Email,
+			user.Department,
+			user.Description,
+			strings.Join(user.Roles, userImportListSeparator),
+			strings.Join(projects, userImportListSeparator),
+			user.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		log.Error(r.Context(), err)
+	}
+}
+
+// parseImportUsersCsv reads the rows by the header. The columns not for the import (e.g. projects of the export) are ignored.
+func parseImportUsersCsv(body []byte) (inputs []domain.ImportUserRequest, lines []int, err error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read the header")
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"accountid", "email", "roles"} {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("column %s is required", name)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		value := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		var roles []string
+		for _, role := range strings.Split(value("roles"), userImportListSeparator) {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+
+		inputs = append(inputs, domain.ImportUserRequest{
+			AccountId:   value("accountid"),
+			Name:        value("name"),
+			Email:       value("email"),
+			Department:  value("department"),
+			Description: value("description"),
+			Roles:       roles,
+		})
+		lines = append(lines, line)
+	}
+	return inputs, lines, nil
+}
+
+func parseImportUsersJson(body []byte) (inputs []domain.ImportUserRequest, lines []int, err error) {
+	var input domain.ImportUsersRequest
+	if err = json.Unmarshal(body, &input); err != nil {
+		return nil, nil, err
+	}
+	lines = make([]int, len(input.Users))
+	for i := range input.Users {
+		lines[i] = i + 1
+	}
+	return input.Users, lines, nil
+}
+
+func toUserImportResponse(r *http.Request, job model.UserImportJob) (out domain.UserImportResponse) {
+	if err := serializer.Map(r.Context(), job, &out); err != nil {
+		log.Info(r.Context(), err)
+	}
+	out.Status = string(job.Status)
+	return out
+}
+
+func toUserImportRowResponses(rows []model.UserImportRow) []domain.UserImportRowResponse {
+	out := make([]domain.UserImportRowResponse, len(rows))
+	for i, row := range rows {
+		out[i] = domain.UserImportRowResponse{
+			Line:        row.Line,
+			AccountId:   row.AccountId,
+			Name:        row.Name,
+			Email:       row.Email,
+			Department:  row.Department,
+			Description: row.Description,
+			Roles:       row.Roles,
+			Status:      string(row.Status),
+			Message:     row.Message,
+		}
+	}
+	return out
+}
@@ -25,6 +25,7 @@ type IUserHandler interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	UpdateUsers(w http.ResponseWriter, r *http.Request)
 	ResetPassword(w http.ResponseWriter, r *http.Request)
+	Unlock(w http.ResponseWriter, r *http.Request)
 
 	GetMyProfile(w http.ResponseWriter, r *http.Request)
 	UpdateMyProfile(w http.ResponseWriter, r *http.Request)
@@ -495,6 +496,40 @@ func (u UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	ResponseJSON(w, r, http.StatusOK, nil)
 }
 
+// Unlock godoc
+//
+//	@Tags			Users
+//	@Summary		Unlock user locked by login failures
+//	@Description	Unlock user locked by repeated login failures and clear the failure count
+//	@Accept			json
+//	@Produce		json
+//	@Param			organizationId	path	string	true	"organizationId"
+//	@Param			accountId		path	string	true	"accountId"
+//	@Success		200
+//	@Router			/organizations/{organizationId}/users/{accountId}/unlock [put]
+//	@Security		JWT
+func (u UserHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	accountId, ok := vars["accountId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("accountId not found in path"), "C_INVALID_ACCOUNT_ID", ""))
+		return
+	}
+	organizationId, ok := vars["organizationId"]
+	if !ok {
+		ErrorJSON(w, r, httpErrors.NewBadRequestError(fmt.Errorf("organizationId not found in path"), "C_INVALID_ORGANIZATION_ID", ""))
+		return
+	}
+
+	err := u.usecase.UnlockByAccountId(r.Context(), accountId, organizationId)
+	if err != nil {
+		ErrorJSON(w, r, err)
+		return
+	}
+
+	ResponseJSON(w, r, http.StatusOK, nil)
+}
+
 // GetMyProfile godoc
 //
 //	@Tags			My-profile
@@ -936,7 +971,7 @@ func (u UserHandler) Admin_Create(w http.ResponseWriter, r *http.Request) {
 		ID: organizationId,
 	}
 
-	user.Password = u.usecase.GenerateRandomPassword(r.Context())
+	user.Password = u.usecase.GenerateRandomPassword(r.Context(), organizationId)
 
 	resUser, err := u.usecase.Create(r.Context(), &user)
 	if err != nil {
@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// EncryptWithSecret encrypts the data by AES-GCM with the key derived from second-factor-secret. It fails if the secret is not configured.
+func EncryptWithSecret(data []byte) (string, error) {
+	gcm, err := secretCipher(viper.GetString("second-factor-secret"))
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(gcm.Seal(nonce, nonce, data, nil)), nil
+}
+
+// DecryptWithSecret decrypts the data encrypted by EncryptWithSecret.
+// The data encrypted with jwt-secret before second-factor-secret is introduced is also decrypted, and legacy is true to encrypt it again.
+func DecryptWithSecret(encrypted string) (data []byte, legacy bool, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(encrypted)
+	if err != nil {
+		return nil, false, err
+	}
+	gcm, err := secretCipher(viper.GetString("second-factor-secret"))
+	if err != nil {
+		return nil, false, err
+	}
+	if data, err = openWithCipher(gcm, b); err == nil {
+		return data, false, nil
+	}
+
+	legacyGcm, legacyErr := secretCipher(viper.GetString("jwt-secret"))
+	if legacyErr != nil {
+		return nil, false, err
+	}
+	if data, legacyErr = openWithCipher(legacyGcm, b); legacyErr != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func openWithCipher(gcm cipher.AEAD, b []byte) ([]byte, error) {
+	if len(b) < gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted data")
+	}
+	return gcm.Open(nil, b[:gcm.NonceSize()], b[gcm.NonceSize():], nil)
+}
+
+func secretCipher(secret string) (cipher.AEAD, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("secret is not configured")
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SignWithSecret signs the data by HMAC-SHA256 with audit-signing-secret. It fails if the secret is not configured.
+func SignWithSecret(data []byte) (string, error) {
+	secret := viper.GetString("audit-signing-secret")
+	if secret == "" {
+		return "", fmt.Errorf("audit-signing-secret is not configured")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifySignatureWithSecret reports whether the signature is made by audit-signing-secret. It is false if the secret is not configured.
+func VerifySignatureWithSecret(data []byte, signature string) bool {
+	secret := viper.GetString("audit-signing-secret")
+	if secret == "" {
+		return false
+	}
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,83 @@
+package helper_test
+
+import (
+	"testing"
+
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/spf13/viper"
+)
+
+func TestSignWithSecret(t *testing.T) {
+	data := []byte(`["org","42","hash"]`)
+
+	// 감사 로그 서명 키가 없으면 jwt-secret 으로 대신 서명하지 않는다.
+	viper.Set("jwt-secret", "jwt-secret")
+	viper.Set("audit-signing-secret", "")
+	if _, err := helper.SignWithSecret(data); err == nil {
+		t.Errorf("SignWithSecret() without audit-signing-secret succeeded, want error")
+	}
+
+	viper.Set("audit-signing-secret", "audit-secret")
+	defer viper.Set("audit-signing-secret", "")
+	signature, err := helper.SignWithSecret(data)
+	if err != nil {
+		t.Fatalf("SignWithSecret() error = %v", err)
+	}
+	if !helper.VerifySignatureWithSecret(data, signature) {
+		t.Errorf("VerifySignatureWithSecret() = false, want true")
+	}
+	if helper.VerifySignatureWithSecret([]byte(`["org","43","hash"]`), signature) {
+		t.Errorf("VerifySignatureWithSecret() of tampered data = true, want false")
+	}
+
+	viper.Set("audit-signing-secret", "other-secret")
+	if helper.VerifySignatureWithSecret(data, signature) {
+		t.Errorf("VerifySignatureWithSecret() with other secret = true, want false")
+	}
+}
+
+func TestEncryptWithSecret(t *testing.T) {
+	data := []byte("totp-secret")
+	viper.Set("jwt-secret", "jwt-secret")
+
+	// 2단계 인증 키가 없으면 jwt-secret 으로 대신 암호화하지 않는다.
+	viper.Set("second-factor-secret", "")
+	if _, err := helper.EncryptWithSecret(data); err == nil {
+		t.Errorf("EncryptWithSecret() without second-factor-secret succeeded, want error")
+	}
+	// second-factor-secret 도입 전에는 jwt-secret 으로 암호화하였다.
+	viper.Set("second-factor-secret", "jwt-secret")
+	legacyEncrypted, err := helper.EncryptWithSecret(data)
+	if err != nil {
+		t.Fatalf("EncryptWithSecret() error = %v", err)
+	}
+
+	viper.Set("second-factor-secret", "second-factor-secret")
+	defer viper.Set("second-factor-secret", "")
+	encrypted, err := helper.EncryptWithSecret(data)
+	if err != nil {
+		t.Fatalf("EncryptWithSecret() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		encrypted  string
+		wantLegacy bool
+		wantErr    bool
+	}{
+		{"second factor secret", encrypted, false, false},
+		{"legacy jwt secret", legacyEncrypted, true, false},
+		{"tampered", encrypted[:len(encrypted)-2] + "AA", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, legacy, err := helper.DecryptWithSecret(tt.encrypted)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecryptWithSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (string(got) != string(data) || legacy != tt.wantLegacy) {
+				t.Errorf("DecryptWithSecret() = %s, %v, want %s, %v", got, legacy, data, tt.wantLegacy)
+			}
+		})
+	}
+}
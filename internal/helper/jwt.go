@@ -53,3 +53,111 @@ func RetrieveClaims(token *jwt.Token) (map[string]interface{}, error) {
 	}
 	return claims, nil
 }
+
+// tokenSigningKey returns token-secret which signs the tokens issued by tks-api itself. It fails if the secret is not configured.
+// jwt-secret 은 기본값이 공개되어 있으므로 사용하지 않는다.
+func tokenSigningKey() ([]byte, error) {
+	secret := viper.GetString("token-secret")
+	if secret == "" {
+		return nil, fmt.Errorf("token-secret is not configured")
+	}
+	return []byte(secret), nil
+}
+
+// verifyPurposeToken returns the claims of the token signed by token-secret for the purpose.
+func verifyPurposeToken(tokenString string, purpose string) (jwt.MapClaims, error) {
+	signingKey, err := tokenSigningKey()
+	if err != nil {
+		return nil, err
+	}
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if _, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return signingKey, nil
+	}); err != nil {
+		return nil, err
+	}
+	if p, _ := claims["Purpose"].(string); p != purpose {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return claims, nil
+}
+
+// CreateUnsubscribeToken signs the one-click unsubscribe link of the user.
+// The notifications of the rule are unsubscribed, or every mail when ruleId is empty.
+func CreateUnsubscribeToken(userId string, ruleId string) (string, error) {
+	signingKey, err := tokenSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"Purpose": "unsubscribe",
+		"ID":      userId,
+		"RuleId":  ruleId,
+		"exp":     time.Now().Add(time.Hour * 24 * 90).Unix(),
+	})
+	return token.SignedString(signingKey)
+}
+
+func VerifyUnsubscribeToken(tokenString string) (userId string, ruleId string, err error) {
+	claims, err := verifyPurposeToken(tokenString, "unsubscribe")
+	if err != nil {
+		return "", "", err
+	}
+	userId, _ = claims["ID"].(string)
+	ruleId, _ = claims["RuleId"].(string)
+	return userId, ruleId, nil
+}
+
+// CreateImpersonationToken signs the token acting as the user. The claims of the user follow the keycloak token,
+// and the impersonator claim shows who is acting.
+func CreateImpersonationToken(impersonationId string, userId string, accountId string, organizationId string,
+	impersonatorId string, impersonatorAccountId string, impersonatorOrganizationId string, expiredAt time.Time) (string, error) {
+	signingKey, err := tokenSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"Purpose":            "impersonation",
+		"jti":                impersonationId,
+		"sid":                "impersonation:" + impersonationId,
+		"sub":                userId,
+		"preferred_username": accountId,
+		"organization":       organizationId,
+		"impersonator": map[string]string{
+			"sub":                impersonatorId,
+			"preferred_username": impersonatorAccountId,
+			"organization":       impersonatorOrganizationId,
+		},
+		"iat": time.Now().Unix(),
+		"exp": expiredAt.Unix(),
+	})
+	return token.SignedString(signingKey)
+}
+
+// VerifyImpersonationToken returns the impersonation id of the token signed by CreateImpersonationToken.
+func VerifyImpersonationToken(tokenString string) (impersonationId string, err error) {
+	claims, err := verifyPurposeToken(tokenString, "impersonation")
+	if err != nil {
+		return "", err
+	}
+	impersonationId, _ = claims["jti"].(string)
+	return impersonationId, nil
+}
+
+// IsImpersonationToken reports whether the bearer token is an impersonation token instead of a keycloak token.
+// The signature is not verified.
+func IsImpersonationToken(tokenString string) bool {
+	token, err := StringToTokenWithoutVerification(tokenString)
+	if err != nil {
+		return false
+	}
+	claims, err := RetrieveClaims(token)
+	if err != nil {
+		return false
+	}
+	purpose, _ := claims["Purpose"].(string)
+	return purpose == "impersonation"
+}
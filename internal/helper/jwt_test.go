@@ -0,0 +1,75 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/spf13/viper"
+)
+
+func TestPurposeToken(t *testing.T) {
+	viper.Set("jwt-secret", "tks-api-secret")
+	viper.Set("token-secret", "token-secret")
+	defer viper.Set("token-secret", "")
+
+	unsubscribeToken, err := helper.CreateUnsubscribeToken("user", "rule")
+	if err != nil {
+		t.Fatalf("CreateUnsubscribeToken() error = %v", err)
+	}
+	impersonationToken, err := helper.CreateImpersonationToken("impersonation", "user", "account", "org",
+		"admin", "admin", "master", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CreateImpersonationToken() error = %v", err)
+	}
+	// 공개된 jwt-secret 으로 서명한 토큰은 거부한다.
+	forgedToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"Purpose": "impersonation",
+		"jti":     "impersonation",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("tks-api-secret"))
+	expiredToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"Purpose": "impersonation",
+		"jti":     "impersonation",
+		"exp":     time.Now().Add(-time.Hour).Unix(),
+	}).SignedString([]byte("token-secret"))
+
+	tests := []struct {
+		name                  string
+		token                 string
+		wantUnsubscribe       bool
+		wantImpersonation     bool
+		wantImpersonationId   string
+		wantUnsubscribeUserId string
+	}{
+		{name: "unsubscribe token", token: unsubscribeToken, wantUnsubscribe: true, wantUnsubscribeUserId: "user"},
+		{name: "impersonation token", token: impersonationToken, wantImpersonation: true, wantImpersonationId: "impersonation"},
+		{name: "signed with jwt-secret", token: forgedToken},
+		{name: "expired", token: expiredToken},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			userId, _, err := helper.VerifyUnsubscribeToken(tc.token)
+			if tc.wantUnsubscribe != (err == nil) || userId != tc.wantUnsubscribeUserId {
+				t.Errorf("VerifyUnsubscribeToken() = %q, %v", userId, err)
+			}
+			impersonationId, err := helper.VerifyImpersonationToken(tc.token)
+			if tc.wantImpersonation != (err == nil) || impersonationId != tc.wantImpersonationId {
+				t.Errorf("VerifyImpersonationToken() = %q, %v", impersonationId, err)
+			}
+		})
+	}
+
+	viper.Set("token-secret", "")
+	if _, err := helper.CreateUnsubscribeToken("user", "rule"); err == nil {
+		t.Errorf("CreateUnsubscribeToken() without token-secret succeeded, want error")
+	}
+	if _, err := helper.CreateImpersonationToken("impersonation", "user", "account", "org",
+		"admin", "admin", "master", time.Now().Add(time.Hour)); err == nil {
+		t.Errorf("CreateImpersonationToken() without token-secret succeeded, want error")
+	}
+	if _, err := helper.VerifyImpersonationToken(impersonationToken); err == nil {
+		t.Errorf("VerifyImpersonationToken() without token-secret succeeded, want error")
+	}
+}
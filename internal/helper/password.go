@@ -29,6 +29,39 @@ func GenerateRandomString(length int) string {
 	return string(b)
 }
 
+// GenerateRandomPassword makes a random string which contains at least one of uppercase, lowercase, digit and special character.
+func GenerateRandomPassword(length int) string {
+	classes := [][]rune{
+		[]rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ"),
+		[]rune("abcdefghijklmnopqrstuvwxyz"),
+		[]rune("0123456789"),
+		[]rune("!@#$%^&*"),
+	}
+	var letters []rune
+	for _, class := range classes {
+		letters = append(letters, class...)
+	}
+
+	b := make([]rune, length)
+	for i := range b {
+		if i < len(classes) {
+			b[i] = randomRune(classes[i])
+		} else {
+			b[i] = randomRune(letters)
+		}
+	}
+	// 문자 종류가 항상 같은 위치에 오지 않도록 섞는다.
+	for i := len(b) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			panic(err)
+		}
+		j := n.Int64()
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
 func randomRune(chars []rune) rune {
 	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
 	if err != nil {
@@ -0,0 +1,36 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal/helper"
+)
+
+// RFC 6238 Appendix B 의 SHA1 테스트 값 (하위 6자리)
+func TestValidateTotp(t *testing.T) {
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	tests := []struct {
+		at   int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		step, ok := helper.ValidateTotp(secret, tt.code, time.Unix(tt.at, 0))
+		if !ok {
+			t.Errorf("ValidateTotp() at %d with %s failed", tt.at, tt.code)
+			continue
+		}
+		if step != tt.at/30 {
+			t.Errorf("ValidateTotp() step = %d, want %d", step, tt.at/30)
+		}
+	}
+
+	if _, ok := helper.ValidateTotp(secret, "287082", time.Unix(59+90, 0)); ok {
+		t.Errorf("ValidateTotp() accepted the code out of the skew")
+	}
+}
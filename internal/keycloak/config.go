@@ -5,6 +5,8 @@ type Config struct {
 	ClientSecret  string
 	AdminId       string
 	AdminPassword string
+	// IssuerAddress is the address in the iss claim when the clients reach keycloak by another address. Address is used if empty.
+	IssuerAddress string
 }
 
 const (
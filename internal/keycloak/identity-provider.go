@@ -0,0 +1,292 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const (
+	ldapProviderType       = "org.keycloak.storage.UserStorageProvider"
+	ldapMapperProviderType = "org.keycloak.storage.ldap.mappers.LDAPStorageMapper"
+
+	// keycloak 은 이 값으로 갱신된 secret 을 기존 값으로 유지한다.
+	unchangedSecret = "**********"
+
+	// tks-api 가 관리하는 mapper 의 이름 접두사
+	tksMapperPrefix = "tks-"
+)
+
+var (
+	oidcConfigKeys = []string{"authorizationUrl", "tokenUrl", "userInfoUrl", "jwksUrl", "issuer", "logoutUrl", "clientId", "defaultScope"}
+	samlConfigKeys = []string{"singleSignOnServiceUrl", "singleLogoutServiceUrl", "idpEntityId", "nameIDPolicyFormat", "signingCertificate"}
+	ldapConfigKeys = []string{"vendor", "connectionUrl", "usersDn", "bindDn", "usernameLDAPAttribute", "rdnLDAPAttribute", "uuidLDAPAttribute", "userObjectClasses", "customUserSearchFilter"}
+)
+
+// CreateIdentityProvider registers the identity provider on the realm of the organization, and returns the keycloak id of it.
+func (k *Keycloak) CreateIdentityProvider(ctx context.Context, organizationId string, provider model.IdentityProvider, secret string) (string, error) {
+	token := k.adminCliToken
+
+	if provider.Type == model.IdentityProviderTypeLdap {
+		realm, err := k.client.GetRealm(context.Background(), token.AccessToken, organizationId)
+		if err != nil {
+			return "", err
+		}
+		component := reflectLdapComponent(provider, secret)
+		component.ParentID = realm.ID
+		componentId, err := k.client.CreateComponent(context.Background(), token.AccessToken, organizationId, component)
+		if err != nil {
+			return "", err
+		}
+		provider.KeycloakId = componentId
+		if err = k.ensureLdapMappers(ctx, token, organizationId, provider); err != nil {
+			return "", err
+		}
+		return componentId, nil
+	}
+
+	if _, err := k.client.CreateIdentityProvider(context.Background(), token.AccessToken, organizationId, reflectIdentityProvider(provider, secret)); err != nil {
+		return "", err
+	}
+	if err := k.ensureIdentityProviderMappers(ctx, token, organizationId, provider); err != nil {
+		return "", err
+	}
+	created, err := k.client.GetIdentityProvider(context.Background(), token.AccessToken, organizationId, provider.Alias)
+	if err != nil {
+		return "", err
+	}
+	return gocloak.PString(created.InternalID), nil
+}
+
+// UpdateIdentityProvider applies the provider to keycloak. The secret is not changed if it is empty.
+func (k *Keycloak) UpdateIdentityProvider(ctx context.Context, organizationId string, provider model.IdentityProvider, secret string) error {
+	token := k.adminCliToken
+	if secret == "" {
+		secret = unchangedSecret
+	}
+
+	if provider.Type == model.IdentityProviderTypeLdap {
+		component, err := k.client.GetComponent(context.Background(), token.AccessToken, organizationId, provider.KeycloakId)
+		if err != nil {
+			return err
+		}
+		updated := reflectLdapComponent(provider, secret)
+		updated.ID = component.ID
+		updated.ParentID = component.ParentID
+		if err = k.client.UpdateComponent(context.Background(), token.AccessToken, organizationId, updated); err != nil {
+			return err
+		}
+		return k.ensureLdapMappers(ctx, token, organizationId, provider)
+	}
+
+	if err := k.client.UpdateIdentityProvider(context.Background(), token.AccessToken, organizationId, provider.Alias, reflectIdentityProvider(provider, secret)); err != nil {
+		return err
+	}
+	return k.ensureIdentityProviderMappers(ctx, token, organizationId, provider)
+}
+
+// DeleteIdentityProvider removes the provider from the realm. The users imported from LDAP are removed with it by keycloak.
+func (k *Keycloak) DeleteIdentityProvider(ctx context.Context, organizationId string, provider model.IdentityProvider) error {
+	token := k.adminCliToken
+	if provider.Type == model.IdentityProviderTypeLdap {
+		return k.client.DeleteComponent(context.Background(), token.AccessToken, organizationId, provider.KeycloakId)
+	}
+	return k.client.DeleteIdentityProvider(context.Background(), token.AccessToken, organizationId, provider.Alias)
+}
+
+// LoginWithAuthorizationCode exchanges the authorization code issued after the login through the identity provider (broker).
+func (k *Keycloak) LoginWithAuthorizationCode(ctx context.Context, organizationId string, code string, redirectUri string) (*model.User, error) {
+	JWTToken, err := k.client.GetToken(context.Background(), organizationId, gocloak.TokenOptions{
+		ClientID:     gocloak.StringP(DefaultClientID),
+		ClientSecret: gocloak.StringP(k.config.ClientSecret),
+		GrantType:    gocloak.StringP("authorization_code"),
+		Code:         gocloak.StringP(code),
+		RedirectURI:  gocloak.StringP(redirectUri),
+	})
+	if err != nil {
+		log.Error(ctx, err)
+		return nil, err
+	}
+	return &model.User{Token: JWTToken.AccessToken, RefreshToken: JWTToken.RefreshToken}, nil
+}
+
+// GetFederatedIdentityAliases returns the aliases of the identity providers linked to the user.
+func (k *Keycloak) GetFederatedIdentityAliases(ctx context.Context, organizationId string, userId string) ([]string, error) {
+	token := k.adminCliToken
+	identities, err := k.client.GetUserFederatedIdentities(context.Background(), token.AccessToken, organizationId, userId)
+	if err != nil {
+		return nil, err
+	}
+	var aliases []string
+	for _, identity := range identities {
+		aliases = append(aliases, gocloak.PString(identity.IdentityProvider))
+	}
+	return aliases, nil
+}
+
+// ensureIdentityProviderMappers recreates the mappers which import the groups and the attributes used by the role mappings.
+func (k *Keycloak) ensureIdentityProviderMappers(ctx context.Context, token *gocloak.JWT, realm string, provider model.IdentityProvider) error {
+	mappers, err := k.client.GetIdentityProviderMappers(context.Background(), token.AccessToken, realm, provider.Alias)
+	if err != nil {
+		return err
+	}
+	for _, mapper := range mappers {
+		if !strings.HasPrefix(gocloak.PString(mapper.Name), tksMapperPrefix) {
+			continue
+		}
+		if err = k.client.DeleteIdentityProviderMapper(context.Background(), token.AccessToken, realm, provider.Alias, gocloak.PString(mapper.ID)); err != nil {
+			return err
+		}
+	}
+
+	mapperType, sourceKey := "oidc-user-attribute-idp-mapper", "claim"
+	if provider.Type == model.IdentityProviderTypeSaml {
+		mapperType, sourceKey = "saml-user-attribute-idp-mapper", "attribute.name"
+	}
+	attributes := map[string]string{model.IdentityProviderGroupsAttribute: provider.GroupsAttribute()}
+	for _, name := range provider.AttributeNames() {
+		attributes[name] = name
+	}
+	for userAttribute, source := range attributes {
+		_, err = k.client.CreateIdentityProviderMapper(context.Background(), token.AccessToken, realm, provider.Alias, gocloak.IdentityProviderMapper{
+			Name:                   gocloak.StringP(tksMapperPrefix + userAttribute),
+			IdentityProviderAlias:  gocloak.StringP(provider.Alias),
+			IdentityProviderMapper: gocloak.StringP(mapperType),
+			Config: &map[string]string{
+				"syncMode":       "FORCE",
+				sourceKey:        source,
+				"user.attribute": userAttribute,
+			},
+		})
+		if err != nil {
+			log.Error(ctx, "Creating identity provider mapper is failed", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureLdapMappers recreates the LDAP mappers which read the groups and the attributes used by the role mappings.
+func (k *Keycloak) ensureLdapMappers(ctx context.Context, token *gocloak.JWT, realm string, provider model.IdentityProvider) error {
+	mappers, err := k.client.GetComponentsWithParams(context.Background(), token.AccessToken, realm, gocloak.GetComponentsParams{
+		ParentID: gocloak.StringP(provider.KeycloakId),
+	})
+	if err != nil {
+		return err
+	}
+	for _, mapper := range mappers {
+		if !strings.HasPrefix(gocloak.PString(mapper.Name), tksMapperPrefix) {
+			continue
+		}
+		if err = k.client.DeleteComponent(context.Background(), token.AccessToken, realm, gocloak.PString(mapper.ID)); err != nil {
+			return err
+		}
+	}
+
+	attributes := map[string]string{model.IdentityProviderGroupsAttribute: provider.GroupsAttribute()}
+	for _, name := range provider.AttributeNames() {
+		attributes[name] = name
+	}
+	for userAttribute, ldapAttribute := range attributes {
+		_, err = k.client.CreateComponent(context.Background(), token.AccessToken, realm, gocloak.Component{
+			Name:         gocloak.StringP(tksMapperPrefix + userAttribute),
+			ProviderID:   gocloak.StringP("user-attribute-ldap-mapper"),
+			ProviderType: gocloak.StringP(ldapMapperProviderType),
+			ParentID:     gocloak.StringP(provider.KeycloakId),
+			ComponentConfig: &map[string][]string{
+				"ldap.attribute":              {ldapAttribute},
+				"user.model.attribute":        {userAttribute},
+				"read.only":                   {"true"},
+				"always.read.value.from.ldap": {"true"},
+				"is.mandatory.in.ldap":        {"false"},
+			},
+		})
+		if err != nil {
+			log.Error(ctx, "Creating LDAP mapper is failed", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func reflectIdentityProvider(provider model.IdentityProvider, secret string) gocloak.IdentityProviderRepresentation {
+	config := map[string]string{
+		"syncMode": "FORCE",
+	}
+	providerId := "oidc"
+	keys := oidcConfigKeys
+	if provider.Type == model.IdentityProviderTypeSaml {
+		providerId = "saml"
+		keys = samlConfigKeys
+		config["principalType"] = "SUBJECT"
+		config["postBindingResponse"] = "true"
+		config["postBindingAuthnRequest"] = "true"
+		if provider.Config["signingCertificate"] != "" {
+			config["validateSignature"] = "true"
+		}
+	} else {
+		config["clientAuthMethod"] = "client_secret_post"
+		config["clientSecret"] = secret
+		if provider.Config["jwksUrl"] != "" {
+			config["useJwksUrl"] = "true"
+			config["validateSignature"] = "true"
+		}
+	}
+	for _, key := range keys {
+		if value, ok := provider.Config[key]; ok {
+			config[key] = value
+		}
+	}
+
+	return gocloak.IdentityProviderRepresentation{
+		Alias:       gocloak.StringP(provider.Alias),
+		DisplayName: gocloak.StringP(provider.DisplayName),
+		ProviderID:  gocloak.StringP(providerId),
+		Enabled:     gocloak.BoolP(provider.Enabled),
+		TrustEmail:  gocloak.BoolP(true),
+		StoreToken:  gocloak.BoolP(false),
+		Config:      &config,
+	}
+}
+
+func reflectLdapComponent(provider model.IdentityProvider, secret string) gocloak.Component {
+	config := map[string][]string{
+		"enabled":           {fmt.Sprintf("%t", provider.Enabled)},
+		"vendor":            {"other"},
+		"bindCredential":    {secret},
+		"authType":          {"simple"},
+		"editMode":          {"READ_ONLY"},
+		"searchScope":       {"2"},
+		"importEnabled":     {"true"},
+		"syncRegistrations": {"false"},
+		"trustEmail":        {"true"},
+		"pagination":        {"true"},
+	}
+	if provider.Config["vendor"] == "ad" {
+		config["usernameLDAPAttribute"] = []string{"sAMAccountName"}
+		config["rdnLDAPAttribute"] = []string{"cn"}
+		config["uuidLDAPAttribute"] = []string{"objectGUID"}
+		config["userObjectClasses"] = []string{"person, organizationalPerson, user"}
+	} else {
+		config["usernameLDAPAttribute"] = []string{"uid"}
+		config["rdnLDAPAttribute"] = []string{"uid"}
+		config["uuidLDAPAttribute"] = []string{"entryUUID"}
+		config["userObjectClasses"] = []string{"inetOrgPerson, organizationalPerson"}
+	}
+	for _, key := range ldapConfigKeys {
+		if value, ok := provider.Config[key]; ok && value != "" {
+			config[key] = []string{value}
+		}
+	}
+
+	return gocloak.Component{
+		Name:            gocloak.StringP(provider.Alias),
+		ProviderID:      gocloak.StringP("ldap"),
+		ProviderType:    gocloak.StringP(ldapProviderType),
+		ComponentConfig: &config,
+	}
+}
@@ -0,0 +1,181 @@
+package keycloak
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const (
+	// jwksCacheTTL 이 지나면 realm 의 key 를 다시 가져온다.
+	jwksCacheTTL = 1 * time.Hour
+	// key rotation 으로 모르는 kid 가 오면 다시 가져오되, 잘못된 토큰으로 keycloak 을 두드리지 않도록 간격을 둔다.
+	jwksMinRefreshInterval = 30 * time.Second
+	jwksFetchTimeout       = 5 * time.Second
+)
+
+type realmKeySet struct {
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// realmKeyCache serializes the jwks refresh of a realm so that a slow realm does not block the others.
+type realmKeyCache struct {
+	mu     sync.Mutex
+	keySet *realmKeySet
+}
+
+// ParseAccessToken verifies the signature of the access token with the cached JWKS of the realm,
+// and then checks the expiry, the issuer and the audience without a round-trip to keycloak.
+func (k *Keycloak) ParseAccessToken(ctx context.Context, accessToken string, organizationId string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}))
+	_, err := parser.ParseWithClaims(accessToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, fmt.Errorf("kid is not found in token header")
+		}
+		return k.getSigningKey(ctx, organizationId, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyIssuer(k.issuer(organizationId), true) {
+		return nil, fmt.Errorf("invalid issuer %v", claims["iss"])
+	}
+	// keycloak 은 aud 에 client 를 넣지 않는 경우가 있어 azp 도 허용한다.
+	if azp, _ := claims["azp"].(string); azp != DefaultClientID && !claims.VerifyAudience(DefaultClientID, true) {
+		return nil, fmt.Errorf("invalid audience %v", claims["aud"])
+	}
+
+	return claims, nil
+}
+
+func (k *Keycloak) issuer(organizationId string) string {
+	address := k.config.IssuerAddress
+	if address == "" {
+		address = k.config.Address
+	}
+	return strings.TrimSuffix(address, "/") + "/realms/" + organizationId
+}
+
+func (k *Keycloak) realmKeyCacheOf(organizationId string) *realmKeyCache {
+	k.keySetsMu.Lock()
+	defer k.keySetsMu.Unlock()
+
+	cache, ok := k.keySets[organizationId]
+	if !ok {
+		cache = &realmKeyCache{}
+		k.keySets[organizationId] = cache
+	}
+	return cache
+}
+
+func (k *Keycloak) getSigningKey(ctx context.Context, organizationId string, kid string) (*rsa.PublicKey, error) {
+	// 같은 realm 의 요청만 fetch 를 기다리고, 기다린 요청은 먼저 가져온 key 를 그대로 쓴다.
+	cache := k.realmKeyCacheOf(organizationId)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	keySet := cache.keySet
+	if keySet != nil && time.Since(keySet.fetchedAt) < jwksCacheTTL {
+		if key, ok := keySet.keys[kid]; ok {
+			return key, nil
+		}
+		if time.Since(keySet.fetchedAt) < jwksMinRefreshInterval {
+			return nil, fmt.Errorf("unknown kid %s", kid)
+		}
+	}
+
+	newKeySet, err := k.fetchKeySet(ctx, organizationId)
+	if err != nil {
+		// keycloak 이 잠시 응답하지 않더라도 가지고 있는 key 로 계속 검증한다.
+		if keySet != nil {
+			log.Warnf(ctx, "failed to refresh jwks of %s. use the cached keys. err : %v", organizationId, err)
+			keySet.fetchedAt = time.Now().Add(jwksMinRefreshInterval - jwksCacheTTL)
+			if key, ok := keySet.keys[kid]; ok {
+				return key, nil
+			}
+		} else {
+			// 존재하지 않는 realm 으로 map 이 자라지 않도록 한 번도 가져오지 못한 realm 은 지운다.
+			k.keySetsMu.Lock()
+			if k.keySets[organizationId] == cache {
+				delete(k.keySets, organizationId)
+			}
+			k.keySetsMu.Unlock()
+		}
+		return nil, err
+	}
+	cache.keySet = newKeySet
+
+	key, ok := newKeySet.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %s", kid)
+	}
+	return key, nil
+}
+
+func (k *Keycloak) fetchKeySet(ctx context.Context, organizationId string) (*realmKeySet, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	var certs gocloak.CertResponse
+	resp, err := k.client.RestyClient().R().
+		SetContext(ctx).
+		SetResult(&certs).
+		Get(strings.TrimSuffix(k.config.Address, "/") + "/realms/" + organizationId + "/protocol/openid-connect/certs")
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get jwks of %s. status : %d", organizationId, resp.StatusCode())
+	}
+
+	keySet := &realmKeySet{
+		keys:      make(map[string]*rsa.PublicKey),
+		fetchedAt: time.Now(),
+	}
+	if certs.Keys == nil {
+		return keySet, nil
+	}
+	for _, cert := range *certs.Keys {
+		if cert.Kid == nil || cert.Kty == nil || *cert.Kty != "RSA" || cert.N == nil || cert.E == nil {
+			continue
+		}
+		if cert.Use != nil && *cert.Use != "sig" {
+			continue
+		}
+		key, err := rsaPublicKey(*cert.N, *cert.E)
+		if err != nil {
+			log.Warnf(ctx, "invalid jwk %s of %s. err : %v", *cert.Kid, organizationId, err)
+			continue
+		}
+		keySet.keys[*cert.Kid] = key
+	}
+	return keySet, nil
+}
+
+func rsaPublicKey(n string, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, err
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, err
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
@@ -0,0 +1,253 @@
+package keycloak
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const testOrganizationId = "org1"
+
+type jwksServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	keys     map[string]*rsa.PublicKey
+	failing  atomic.Bool
+	requests atomic.Int32
+}
+
+func newJwksServer(t *testing.T, keys map[string]*rsa.PublicKey) *jwksServer {
+	s := &jwksServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		if r.URL.Path != "/realms/"+testOrganizationId+"/protocol/openid-connect/certs" || s.failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		certs := []gocloak.CertResponseKey{}
+		for kid, key := range s.keys {
+			certs = append(certs, gocloak.CertResponseKey{
+				Kid: gocloak.StringP(kid),
+				Kty: gocloak.StringP("RSA"),
+				Use: gocloak.StringP("sig"),
+				N:   gocloak.StringP(base64.RawURLEncoding.EncodeToString(key.N.Bytes())),
+				E:   gocloak.StringP(base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gocloak.CertResponse{Keys: &certs})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) setKeys(keys map[string]*rsa.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func newTestKeycloak(address string) *Keycloak {
+	return &Keycloak{
+		config:  &Config{Address: address},
+		client:  gocloak.NewClient(address),
+		keySets: make(map[string]*realmKeyCache),
+	}
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestRsaPublicKey(t *testing.T) {
+	key := generateKey(t)
+
+	tests := []struct {
+		name    string
+		n       string
+		e       string
+		wantErr bool
+	}{
+		{name: "valid", n: base64.RawURLEncoding.EncodeToString(key.N.Bytes()), e: "AQAB"},
+		{name: "invalid modulus", n: "!!!", e: "AQAB", wantErr: true},
+		{name: "invalid exponent", n: base64.RawURLEncoding.EncodeToString(key.N.Bytes()), e: "AQAB==", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := rsaPublicKey(tc.n, tc.e)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.N.Cmp(key.N) != 0 || got.E != key.E {
+				t.Errorf("decoded key does not match. got e %d", got.E)
+			}
+		})
+	}
+}
+
+func TestParseAccessToken(t *testing.T) {
+	key := generateKey(t)
+	otherKey := generateKey(t)
+	server := newJwksServer(t, map[string]*rsa.PublicKey{"kid1": &key.PublicKey})
+	k := newTestKeycloak(server.URL)
+	issuer := server.URL + "/realms/" + testOrganizationId
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss": issuer,
+			"azp": DefaultClientID,
+			"exp": time.Now().Add(time.Minute).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		key     *rsa.PrivateKey
+		kid     string
+		claims  func(c jwt.MapClaims)
+		wantErr bool
+	}{
+		{name: "valid", key: key, kid: "kid1"},
+		{name: "audience instead of azp", key: key, kid: "kid1", claims: func(c jwt.MapClaims) {
+			c["azp"] = "other"
+			c["aud"] = []string{"account", DefaultClientID}
+		}},
+		{name: "invalid issuer", key: key, kid: "kid1", claims: func(c jwt.MapClaims) {
+			c["iss"] = server.URL + "/realms/org2"
+		}, wantErr: true},
+		{name: "invalid audience", key: key, kid: "kid1", claims: func(c jwt.MapClaims) {
+			c["azp"] = "other"
+			c["aud"] = "account"
+		}, wantErr: true},
+		{name: "expired", key: key, kid: "kid1", claims: func(c jwt.MapClaims) {
+			c["exp"] = time.Now().Add(-time.Minute).Unix()
+		}, wantErr: true},
+		{name: "invalid signature", key: otherKey, kid: "kid1", wantErr: true},
+		{name: "unknown kid", key: key, kid: "kid2", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := validClaims()
+			if tc.claims != nil {
+				tc.claims(claims)
+			}
+			_, err := k.ParseAccessToken(context.Background(), signToken(t, tc.key, tc.kid, claims), testOrganizationId)
+			if tc.wantErr != (err != nil) {
+				t.Errorf("wantErr %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetSigningKeyRefresh(t *testing.T) {
+	key := generateKey(t)
+	rotatedKey := generateKey(t)
+
+	tests := []struct {
+		name string
+		// fetchedAgo 만큼 지난 것으로 만든 뒤 rotate 여부와 keycloak 장애를 적용한다.
+		fetchedAgo   time.Duration
+		rotate       bool
+		failing      bool
+		kid          string
+		wantErr      bool
+		wantRequests int32
+	}{
+		{name: "cached key", fetchedAgo: 0, kid: "kid1", wantRequests: 1},
+		{name: "unknown kid refreshes", fetchedAgo: jwksMinRefreshInterval + time.Second, rotate: true, kid: "kid2", wantRequests: 2},
+		{name: "unknown kid is throttled", fetchedAgo: time.Second, rotate: true, kid: "kid2", wantErr: true, wantRequests: 1},
+		{name: "expired cache refreshes", fetchedAgo: jwksCacheTTL + time.Second, kid: "kid1", wantRequests: 2},
+		{name: "stale key on keycloak failure", fetchedAgo: jwksCacheTTL + time.Second, failing: true, kid: "kid1", wantRequests: 2},
+		{name: "unknown kid on keycloak failure", fetchedAgo: jwksMinRefreshInterval + time.Second, failing: true, kid: "kid2", wantErr: true, wantRequests: 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newJwksServer(t, map[string]*rsa.PublicKey{"kid1": &key.PublicKey})
+			k := newTestKeycloak(server.URL)
+			if _, err := k.getSigningKey(context.Background(), testOrganizationId, "kid1"); err != nil {
+				t.Fatal(err)
+			}
+
+			k.keySets[testOrganizationId].keySet.fetchedAt = time.Now().Add(-tc.fetchedAgo)
+			if tc.rotate {
+				server.setKeys(map[string]*rsa.PublicKey{"kid1": &key.PublicKey, "kid2": &rotatedKey.PublicKey})
+			}
+			server.failing.Store(tc.failing)
+
+			_, err := k.getSigningKey(context.Background(), testOrganizationId, tc.kid)
+			if tc.wantErr != (err != nil) {
+				t.Errorf("wantErr %v, got %v", tc.wantErr, err)
+			}
+			if got := server.requests.Load(); got != tc.wantRequests {
+				t.Errorf("expected %d jwks requests, got %d", tc.wantRequests, got)
+			}
+		})
+	}
+}
+
+func TestGetSigningKeyFailureThrottle(t *testing.T) {
+	key := generateKey(t)
+	server := newJwksServer(t, map[string]*rsa.PublicKey{"kid1": &key.PublicKey})
+	k := newTestKeycloak(server.URL)
+	if _, err := k.getSigningKey(context.Background(), testOrganizationId, "kid1"); err != nil {
+		t.Fatal(err)
+	}
+	k.keySets[testOrganizationId].keySet.fetchedAt = time.Now().Add(-jwksCacheTTL)
+	server.failing.Store(true)
+
+	// 장애 중에는 jwksMinRefreshInterval 동안 다시 가져오지 않고 가지고 있는 key 를 쓴다.
+	for i := 0; i < 3; i++ {
+		if _, err := k.getSigningKey(context.Background(), testOrganizationId, "kid1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := server.requests.Load(); got != 2 {
+		t.Errorf("expected 2 jwks requests, got %d", got)
+	}
+}
+
+func TestGetSigningKeyUnknownRealm(t *testing.T) {
+	server := newJwksServer(t, nil)
+	k := newTestKeycloak(server.URL)
+
+	if _, err := k.getSigningKey(context.Background(), "unknown", "kid1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(k.keySets) != 0 {
+		t.Errorf("expected no cached realm, got %d", len(k.keySets))
+	}
+}
@@ -5,10 +5,15 @@ import (
 	"crypto/tls"
 	"fmt"
 	"github.com/spf13/viper"
+	"sort"
+	"strings"
+	"sync"
 
 	"time"
 
 	"github.com/Nerzal/gocloak/v13"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/openinfradev/tks-api/internal"
 	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
 	"github.com/openinfradev/tks-api/pkg/log"
@@ -19,13 +24,21 @@ type IKeycloak interface {
 
 	LoginAdmin(ctx context.Context, accountId string, password string) (*model.User, error)
 	Login(ctx context.Context, accountId string, password string, organizationId string) (*model.User, error)
+	LoginWithAuthorizationCode(ctx context.Context, organizationId string, code string, redirectUri string) (*model.User, error)
+	RefreshToken(ctx context.Context, organizationId string, refreshToken string) (*model.User, error)
 	Logout(ctx context.Context, sessionId string, organizationId string) error
+	LogoutAllSessions(ctx context.Context, userId string, organizationId string) error
 
 	CreateRealm(ctx context.Context, organizationId string) (string, error)
 	GetRealm(ctx context.Context, organizationId string) (*model.Organization, error)
 	GetRealms(ctx context.Context) ([]*model.Organization, error)
 	DeleteRealm(ctx context.Context, organizationId string) error
-	UpdateRealm(ctx context.Context, organizationId string, organizationConfig model.Organization) error
+	UpdateRealm(ctx context.Context, organizationId string, securityPolicy model.SecurityPolicy) error
+
+	CreateIdentityProvider(ctx context.Context, organizationId string, provider model.IdentityProvider, secret string) (string, error)
+	UpdateIdentityProvider(ctx context.Context, organizationId string, provider model.IdentityProvider, secret string) error
+	DeleteIdentityProvider(ctx context.Context, organizationId string, provider model.IdentityProvider) error
+	GetFederatedIdentityAliases(ctx context.Context, organizationId string, userId string) ([]string, error)
 
 	CreateClient(ctx context.Context, organizationId string, clientName string, clientSecret string, redirectURIs *[]string) (string, error)
 	CreateClientProtocolMapper(ctx context.Context, realm string, clientId string, mapper gocloak.ProtocolMapperRepresentation) (string, error)
@@ -37,6 +50,7 @@ type IKeycloak interface {
 	GetUsers(ctx context.Context, organizationId string) ([]*gocloak.User, error)
 	DeleteUser(ctx context.Context, organizationId string, userAccountId string) error
 	UpdateUser(ctx context.Context, organizationId string, user *gocloak.User) error
+	UpdateUserEnabled(ctx context.Context, organizationId string, userId string, enabled bool) error
 	JoinGroup(ctx context.Context, organizationId string, userId string, groupName string) error
 	LeaveGroup(ctx context.Context, organizationId string, userId string, groupName string) error
 	CreateGroup(ctx context.Context, organizationId string, groupName string) (string, error)
@@ -49,13 +63,17 @@ type IKeycloak interface {
 	UnassignClientRoleToUser(ctx context.Context, organizationId string, userId string, clientName string, roleName string) error
 
 	VerifyAccessToken(ctx context.Context, token string, organizationId string) (bool, error)
-	GetSessions(ctx context.Context, userId string, organizationId string) (*[]string, error)
+	ParseAccessToken(ctx context.Context, token string, organizationId string) (jwt.MapClaims, error)
+	GetSessions(ctx context.Context, userId string, organizationId string) ([]model.Session, error)
 	SetClientScopeRolesToOptionalToTksClient(ctx context.Context, organizationId string) error
 }
 type Keycloak struct {
 	config        *Config
 	client        *gocloak.GoCloak
 	adminCliToken *gocloak.JWT
+
+	keySetsMu sync.Mutex
+	keySets   map[string]*realmKeyCache
 }
 
 func (k *Keycloak) CreateGroup(ctx context.Context, organizationId string, groupName string) (string, error) {
@@ -130,12 +148,23 @@ func (k *Keycloak) Login(ctx context.Context, accountId string, password string,
 		log.Error(ctx, err)
 		return nil, err
 	}
-	return &model.User{Token: JWTToken.AccessToken}, nil
+	return &model.User{Token: JWTToken.AccessToken, RefreshToken: JWTToken.RefreshToken}, nil
+}
+
+// RefreshToken reissues the token of the session. The claims reflect the current groups of the user.
+func (k *Keycloak) RefreshToken(ctx context.Context, organizationId string, refreshToken string) (*model.User, error) {
+	JWTToken, err := k.client.RefreshToken(context.Background(), refreshToken, DefaultClientID, k.config.ClientSecret, organizationId)
+	if err != nil {
+		log.Error(ctx, err)
+		return nil, err
+	}
+	return &model.User{Token: JWTToken.AccessToken, RefreshToken: JWTToken.RefreshToken}, nil
 }
 
 func New(config *Config) IKeycloak {
 	return &Keycloak{
-		config: config,
+		config:  config,
+		keySets: make(map[string]*realmKeyCache),
 	}
 }
 
@@ -236,6 +265,8 @@ func (k *Keycloak) InitializeKeycloak(ctx context.Context) error {
 		}
 	}
 
+	k.ensureBruteForceDetection(ctx, token)
+
 	// Todo: 현재 30초마다 갱신하도록 함. 최적화 요소 확인 및 개선 필요
 	_ = getRefreshTokenExpiredDuration(k.adminCliToken)
 	go func() {
@@ -261,7 +292,9 @@ func (k *Keycloak) CreateRealm(ctx context.Context, organizationId string) (stri
 	//TODO implement me
 	token := k.adminCliToken
 
-	realmUUID, err := k.client.CreateRealm(context.Background(), token.AccessToken, defaultRealmSetting(organizationId))
+	realm := defaultRealmSetting(organizationId)
+	setBruteForceDetection(&realm)
+	realmUUID, err := k.client.CreateRealm(context.Background(), token.AccessToken, realm)
 	if err != nil {
 		return "", err
 	}
@@ -328,9 +361,10 @@ func (k *Keycloak) GetRealms(ctx context.Context) ([]*model.Organization, error)
 	return organization, nil
 }
 
-func (k *Keycloak) UpdateRealm(ctx context.Context, organizationId string, organizationConfig model.Organization) error {
+// UpdateRealm applies the password and session policy to the realm of the organization.
+func (k *Keycloak) UpdateRealm(ctx context.Context, organizationId string, securityPolicy model.SecurityPolicy) error {
 	token := k.adminCliToken
-	realm := k.reflectRealmRepresentation(organizationConfig)
+	realm := k.reflectRealmRepresentation(organizationId, securityPolicy)
 	err := k.client.UpdateRealm(context.Background(), token.AccessToken, *realm)
 	if err != nil {
 		return err
@@ -406,7 +440,9 @@ func (k *Keycloak) DeleteRealm(ctx context.Context, organizationId string) error
 
 func (k *Keycloak) CreateUser(ctx context.Context, organizationId string, user *gocloak.User) (string, error) {
 	token := k.adminCliToken
-	user.Enabled = gocloak.BoolP(true)
+	if user.Enabled == nil {
+		user.Enabled = gocloak.BoolP(true)
+	}
 	uuid, err := k.client.CreateUser(context.Background(), token.AccessToken, organizationId, *user)
 	if err != nil {
 		return "", err
@@ -450,7 +486,10 @@ func (k *Keycloak) GetUsers(ctx context.Context, organizationId string) ([]*gocl
 
 func (k *Keycloak) UpdateUser(ctx context.Context, organizationId string, user *gocloak.User) error {
 	token := k.adminCliToken
-	user.Enabled = gocloak.BoolP(true)
+	// 비활성화된 사용자를 keycloak 에서 조회하여 갱신하는 경우 다시 활성화되지 않도록 지정되지 않은 경우에만 활성화한다.
+	if user.Enabled == nil {
+		user.Enabled = gocloak.BoolP(true)
+	}
 	err := k.client.UpdateUser(context.Background(), token.AccessToken, organizationId, *user)
 	if err != nil {
 		return err
@@ -459,6 +498,21 @@ func (k *Keycloak) UpdateUser(ctx context.Context, organizationId string, user *
 	return nil
 }
 
+func (k *Keycloak) UpdateUserEnabled(ctx context.Context, organizationId string, userId string, enabled bool) error {
+	token := k.adminCliToken
+	user, err := k.client.GetUserByID(context.Background(), token.AccessToken, organizationId, userId)
+	if err != nil {
+		log.Errorf(ctx, "error is :%s(%T)", err.Error(), err)
+		return httpErrors.NewNotFoundError(err, "", "")
+	}
+	user.Enabled = gocloak.BoolP(enabled)
+	if err = k.client.UpdateUser(context.Background(), token.AccessToken, organizationId, *user); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (k *Keycloak) DeleteUser(ctx context.Context, organizationId string, userAccountId string) error {
 	token := k.adminCliToken
 	u, err := k.GetUser(ctx, organizationId, userAccountId)
@@ -474,7 +528,7 @@ func (k *Keycloak) DeleteUser(ctx context.Context, organizationId string, userAc
 }
 
 func (k *Keycloak) VerifyAccessToken(ctx context.Context, token string, organizationId string) (bool, error) {
-	rptResult, err := k.client.RetrospectToken(context.Background(), token, DefaultClientID, k.config.ClientSecret, organizationId)
+	rptResult, err := k.client.RetrospectToken(ctx, token, DefaultClientID, k.config.ClientSecret, organizationId)
 	if err != nil {
 		return false, err
 	}
@@ -485,7 +539,7 @@ func (k *Keycloak) VerifyAccessToken(ctx context.Context, token string, organiza
 	return true, nil
 }
 
-func (k *Keycloak) GetSessions(ctx context.Context, userId string, organizationId string) (*[]string, error) {
+func (k *Keycloak) GetSessions(ctx context.Context, userId string, organizationId string) ([]model.Session, error) {
 	token := k.adminCliToken
 	sessions, err := k.client.GetUserSessions(context.Background(), token.AccessToken, organizationId, userId)
 	if err != nil {
@@ -493,12 +547,29 @@ func (k *Keycloak) GetSessions(ctx context.Context, userId string, organizationI
 		return nil, err
 	}
 
-	var sessionIds []string
+	out := make([]model.Session, 0, len(sessions))
 	for _, session := range sessions {
-		sessionIds = append(sessionIds, *session.ID)
+		if session.ID == nil {
+			continue
+		}
+		var clients []string
+		if session.Clients != nil {
+			for _, clientName := range *session.Clients {
+				clients = append(clients, clientName)
+			}
+			sort.Strings(clients)
+		}
+		out = append(out, model.Session{
+			ID:             *session.ID,
+			UserId:         gocloak.PString(session.UserID),
+			IpAddress:      gocloak.PString(session.IPAddress),
+			Clients:        clients,
+			StartedAt:      time.UnixMilli(gocloak.PInt64(session.Start)),
+			LastAccessedAt: time.UnixMilli(gocloak.PInt64(session.LastAccess)),
+		})
 	}
 
-	return &sessionIds, nil
+	return out, nil
 }
 
 func (k *Keycloak) Logout(ctx context.Context, sessionId string, organizationId string) error {
@@ -511,6 +582,16 @@ func (k *Keycloak) Logout(ctx context.Context, sessionId string, organizationId
 	return nil
 }
 
+func (k *Keycloak) LogoutAllSessions(ctx context.Context, userId string, organizationId string) error {
+	token := k.adminCliToken
+	err := k.client.LogoutAllSessions(context.Background(), token.AccessToken, organizationId, userId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (k *Keycloak) JoinGroup(ctx context.Context, organizationId string, userId string, groupName string) error {
 	token := k.adminCliToken
 	groups, err := k.client.GetGroups(context.Background(), token.AccessToken, organizationId, gocloak.GetGroupsParams{
@@ -933,10 +1014,79 @@ func (k *Keycloak) reflectOrganization(org gocloak.RealmRepresentation) *model.O
 	}
 }
 
-func (k *Keycloak) reflectRealmRepresentation(org model.Organization) *gocloak.RealmRepresentation {
-	return &gocloak.RealmRepresentation{
-		Realm: gocloak.StringP(org.Name),
+func (k *Keycloak) reflectRealmRepresentation(organizationId string, securityPolicy model.SecurityPolicy) *gocloak.RealmRepresentation {
+	idleTimeout := securityPolicy.SessionIdleTimeoutMinutes * 60
+	maxLifespan := securityPolicy.SessionMaxLifespanMinutes * 60
+	accessTokenLifespan := AccessTokenLifespan
+	if accessTokenLifespan > maxLifespan {
+		accessTokenLifespan = maxLifespan
+	}
+
+	realm := &gocloak.RealmRepresentation{
+		Realm:                 gocloak.StringP(organizationId),
+		PasswordPolicy:        gocloak.StringP(passwordPolicy(securityPolicy)),
+		AccessTokenLifespan:   gocloak.IntP(accessTokenLifespan),
+		SsoSessionIdleTimeout: gocloak.IntP(idleTimeout),
+		SsoSessionMaxLifespan: gocloak.IntP(maxLifespan),
+	}
+	setBruteForceDetection(realm)
+	return realm
+}
+
+// setBruteForceDetection locks the account in keycloak with the same thresholds as tks-api,
+// so the logins which do not go through tks-api (identity provider, direct grant) are also limited.
+// master realm 은 tks-api 의 관리자 계정이 잠기지 않도록 설정하지 않는다.
+func setBruteForceDetection(realm *gocloak.RealmRepresentation) {
+	realm.BruteForceProtected = gocloak.BoolP(true)
+	realm.PermanentLockout = gocloak.BoolP(false)
+	realm.FailureFactor = gocloak.IntP(internal.AccountLockThreshold)
+	realm.WaitIncrementSeconds = gocloak.IntP(int(internal.AccountLockDuration.Seconds()))
+	realm.MaxFailureWaitSeconds = gocloak.IntP(int(internal.AccountLockDuration.Seconds()))
+	realm.MaxDeltaTimeSeconds = gocloak.IntP(int(internal.LoginFailureWindow.Seconds()))
+}
+
+// ensureBruteForceDetection applies the brute force detection to the realms created before it is enabled.
+func (k *Keycloak) ensureBruteForceDetection(ctx context.Context, token *gocloak.JWT) {
+	realms, err := k.client.GetRealms(context.Background(), token.AccessToken)
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	for _, r := range realms {
+		if r.Realm == nil || *r.Realm == DefaultMasterRealm {
+			continue
+		}
+		realm := gocloak.RealmRepresentation{Realm: r.Realm}
+		setBruteForceDetection(&realm)
+		if err := k.client.UpdateRealm(context.Background(), token.AccessToken, realm); err != nil {
+			log.Errorf(ctx, "failed to enable brute force detection of realm %s: %v", *r.Realm, err)
+		}
+	}
+}
+
+// passwordPolicy makes the password policy string of keycloak.
+// 비밀번호 만료는 keycloak 의 forceExpiredPasswordChange 대신 tks-api 에서 처리한다. (PasswordFilter)
+func passwordPolicy(securityPolicy model.SecurityPolicy) string {
+	var policies []string
+	if securityPolicy.PasswordMinLength > 0 {
+		policies = append(policies, fmt.Sprintf("length(%d)", securityPolicy.PasswordMinLength))
+	}
+	if securityPolicy.PasswordRequireUppercase {
+		policies = append(policies, "upperCase(1)")
+	}
+	if securityPolicy.PasswordRequireLowercase {
+		policies = append(policies, "lowerCase(1)")
+	}
+	if securityPolicy.PasswordRequireDigit {
+		policies = append(policies, "digits(1)")
+	}
+	if securityPolicy.PasswordRequireSpecial {
+		policies = append(policies, "specialChars(1)")
+	}
+	if securityPolicy.PasswordHistoryDepth > 0 {
+		policies = append(policies, fmt.Sprintf("passwordHistory(%d)", securityPolicy.PasswordHistoryDepth))
 	}
+	return strings.Join(policies, " and ")
 }
 
 var defaultProtocolTksMapper = []gocloak.ProtocolMapperRepresentation{
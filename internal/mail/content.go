@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"html/template"
+	"time"
 
 	"github.com/openinfradev/tks-api/pkg/log"
 )
@@ -62,6 +63,38 @@ func MakeTemporaryPasswordMessage(ctx context.Context, to, organizationId, accou
 	return m, nil
 }
 
+func MakeInvitationMessage(ctx context.Context, to, organizationId, organizationName, invitationUrl string, expiredAt time.Time) (*MessageInfo, error) {
+	subject := "[TKS] 조직에 초대되었습니다."
+
+	tmpl, err := template.ParseFS(templateFS, "contents/invitation.html")
+	if err != nil {
+		log.Errorf(ctx, "failed to parse template, %v", err)
+		return nil, err
+	}
+
+	data := map[string]string{
+		"OrganizationId":   organizationId,
+		"OrganizationName": organizationName,
+		"InvitationUrl":    invitationUrl,
+		"ExpiredAt":        expiredAt.Format("2006-01-02 15:04 MST"),
+	}
+
+	var tpl bytes.Buffer
+	if err := tmpl.Execute(&tpl, data); err != nil {
+		log.Errorf(ctx, "failed to execute template, %v", err)
+		return nil, err
+	}
+
+	m := &MessageInfo{
+		From:    from,
+		To:      []string{to},
+		Subject: subject,
+		Body:    tpl.String(),
+	}
+
+	return m, nil
+}
+
 func MakeGeneratingOrganizationMessage(
 	ctx context.Context,
 	organizationId string, organizationName string,
@@ -98,7 +131,7 @@ func MakeGeneratingOrganizationMessage(
 	return m, nil
 }
 
-func MakeSystemNotificationMessage(ctx context.Context, organizationId string, title string, content string, to []string) (*MessageInfo, error) {
+func MakeSystemNotificationMessage(ctx context.Context, organizationId string, title string, content string, unsubscribeUrl string, to []string) (*MessageInfo, error) {
 	tmpl, err := template.ParseFS(templateFS, "contents/system_notification.html")
 	if err != nil {
 		log.Errorf(ctx, "failed to parse template, %v", err)
@@ -109,6 +142,47 @@ func MakeSystemNotificationMessage(ctx context.Context, organizationId string, t
 		"OrganizationId": organizationId,
 		"Title":          title,
 		"Content":        content,
+		"UnsubscribeUrl": unsubscribeUrl,
+	}
+
+	var tpl bytes.Buffer
+	if err := tmpl.Execute(&tpl, data); err != nil {
+		log.Errorf(ctx, "failed to execute template, %v", err)
+		return nil, err
+	}
+
+	m := &MessageInfo{
+		From:    from,
+		To:      to,
+		Subject: title,
+		Body:    tpl.String(),
+	}
+
+	return m, nil
+}
+
+// DigestGroup summarizes the systemNotifications of the same alert on a stack.
+type DigestGroup struct {
+	StackName   string
+	AlertName   string
+	Severity    string
+	Count       int
+	LastFiredAt time.Time
+}
+
+func MakeSystemNotificationDigestMessage(ctx context.Context, organizationId string, title string, period string, groups []DigestGroup, unsubscribeUrl string, to []string) (*MessageInfo, error) {
+	tmpl, err := template.ParseFS(templateFS, "contents/system_notification_digest.html")
+	if err != nil {
+		log.Errorf(ctx, "failed to parse template, %v", err)
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"OrganizationId": organizationId,
+		"Title":          title,
+		"Period":         period,
+		"Groups":         groups,
+		"UnsubscribeUrl": unsubscribeUrl,
 	}
 
 	var tpl bytes.Buffer
@@ -54,16 +54,18 @@ func (s *SmtpMailer) SendMail(ctx context.Context) error {
 	s.client.SetBody("text/html", s.message.Body)
 	d := NewDialer(s.Host, s.Port, s.Username, s.Password)
 
+	var lastErr error
 	for _, to := range s.message.To {
 		s.client.SetHeader("To", to)
 
 		if err := d.DialAndSend(s.client); err != nil {
 			log.Errorf(ctx, "failed to send email, %v", err)
+			lastErr = err
 			continue
 		}
 	}
 
-	return nil
+	return lastErr
 }
 
 func Initialize(ctx context.Context) error {
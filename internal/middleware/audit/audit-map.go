@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
 	"github.com/openinfradev/tks-api/pkg/domain"
@@ -165,6 +167,38 @@ var auditMap = map[internalApi.Endpoint]fnAudit{
 		} else {
 			return "시스템알림설정을 삭제하는데 실패하였습니다. ", errorText(ctx, out)
 		}
+	}, internalApi.CreateSystemNotificationSilence: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.CreateSystemNotificationSilenceRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		period := fmt.Sprintf("%s ~ %s", input.StartsAt.Format(time.RFC3339), input.EndsAt.Format(time.RFC3339))
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("알림 무음 설정 [%s]을 생성하였습니다. (%s)", input.Comment, period), ""
+		} else {
+			return fmt.Sprintf("알림 무음 설정 [%s]을 생성하는데 실패하였습니다.", input.Comment), errorText(ctx, out)
+		}
+	}, internalApi.UpdateSystemNotificationSilence: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.UpdateSystemNotificationSilenceRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		period := fmt.Sprintf("%s ~ %s", input.StartsAt.Format(time.RFC3339), input.EndsAt.Format(time.RFC3339))
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("알림 무음 설정 [%s]을 수정하였습니다. (%s)", input.Comment, period), ""
+		} else {
+			return fmt.Sprintf("알림 무음 설정 [%s]을 수정하는데 실패하였습니다.", input.Comment), errorText(ctx, out)
+		}
+	}, internalApi.DeleteSystemNotificationSilence: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.DeleteSystemNotificationSilenceResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("알림 무음 설정 [%s]을 삭제하였습니다.", output.Comment), ""
+		} else {
+			return "알림 무음 설정을 삭제하는데 실패하였습니다. ", errorText(ctx, out)
+		}
 	}, internalApi.CreatePolicyTemplate: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
 		input := domain.CreatePolicyTemplateRequest{}
 		if err := json.Unmarshal(in, &input); err != nil {
@@ -185,9 +219,246 @@ var auditMap = map[internalApi.Endpoint]fnAudit{
 		} else {
 			return fmt.Sprintf("정책 [%s]을 생성하는데 실패하였습니다.", input.PolicyName), errorText(ctx, out)
 		}
+	}, internalApi.CreateServiceAccount: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.CreateServiceAccountRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("서비스 계정 [%s]를 생성하였습니다.", input.Name), ""
+		} else {
+			return fmt.Sprintf("서비스 계정 [%s]을 생성하는데 실패하였습니다.", input.Name), errorText(ctx, out)
+		}
+	}, internalApi.DeleteServiceAccount: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.DeleteServiceAccountResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("서비스 계정 [%s]를 삭제하였습니다.", output.Name), ""
+		} else {
+			return "서비스 계정을 삭제하는데 실패하였습니다. ", errorText(ctx, out)
+		}
+	}, internalApi.UpdateSecurityPolicy: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.UpdateSecurityPolicyRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		if isSuccess(statusCode) {
+			return "보안 정책을 변경하였습니다.", fmt.Sprintf("비밀번호 만료 %d일, 최소 길이 %d, 이력 %d개, 세션 유휴 %d분, 세션 최대 %d분, 2단계 인증 필수 %t",
+				input.PasswordExpiryDays, input.PasswordMinLength, input.PasswordHistoryDepth, input.SessionIdleTimeoutMinutes, input.SessionMaxLifespanMinutes, input.RequireSecondFactor)
+		} else {
+			return "보안 정책을 변경하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.CreateServiceAccountToken: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		return createApiTokenAudit(ctx, out, in, statusCode, "서비스 계정의 ")
+	}, internalApi.RevokeServiceAccountToken: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		return revokeApiTokenAudit(ctx, out, statusCode, "서비스 계정의 ")
+	}, internalApi.CreateMyApiToken: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		return createApiTokenAudit(ctx, out, in, statusCode, "개인 ")
+	}, internalApi.RevokeMyApiToken: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		return revokeApiTokenAudit(ctx, out, statusCode, "개인 ")
+	}, internalApi.VerifyMySecondFactor: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			return "2단계 인증을 등록하였습니다.", ""
+		} else {
+			return "2단계 인증을 등록하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.RegenerateMyRecoveryCodes: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			return "2단계 인증 복구 코드를 재발급하였습니다.", ""
+		} else {
+			return "2단계 인증 복구 코드를 재발급하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.DeleteMySecondFactor: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			return "2단계 인증을 해제하였습니다.", ""
+		} else {
+			return "2단계 인증을 해제하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.ResetUserSecondFactor: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.ResetUserSecondFactorResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("사용자 [%s]의 2단계 인증을 초기화하였습니다.", output.AccountId), ""
+		} else {
+			return "사용자의 2단계 인증을 초기화하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.RevokeMySession: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			return "세션을 로그아웃하였습니다.", ""
+		} else {
+			return "세션을 로그아웃하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.RevokeUserSession: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.RevokeUserSessionResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("사용자 [%s]의 세션 [%s]을 강제 로그아웃하였습니다.", output.AccountId, output.SessionId), ""
+		} else {
+			return "사용자의 세션을 강제 로그아웃하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.RevokeUserSessions: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.RevokeUserSessionResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("사용자 [%s]의 모든 세션을 강제 로그아웃하였습니다.", output.AccountId), ""
+		} else {
+			return "사용자의 모든 세션을 강제 로그아웃하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.ImportUsers: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		output := domain.ImportUsersResponse{}
+		if err := json.Unmarshal(out, &output); err != nil {
+			log.Error(ctx, err)
+		}
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("사용자 %d명의 일괄 등록을 요청하였습니다.", output.UserImport.TotalCount), ""
+		} else if output.UserImport.ID != "" {
+			return fmt.Sprintf("사용자 %d명 중 %d명의 검증에 실패하여 일괄 등록이 거부되었습니다.", output.UserImport.TotalCount, output.UserImport.FailedCount), ""
+		} else {
+			return "사용자 일괄 등록을 요청하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.CreateInvitation: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.CreateInvitationRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("[%s]에게 초대장을 발송하였습니다.", input.Email), ""
+		} else {
+			return fmt.Sprintf("[%s]에게 초대장을 발송하는데 실패하였습니다.", input.Email), errorText(ctx, out)
+		}
+	}, internalApi.ResendInvitation: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.ResendInvitationResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("[%s]에게 초대장을 재발송하였습니다.", output.Email), ""
+		} else {
+			return "초대장을 재발송하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.RevokeInvitation: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.RevokeInvitationResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("[%s]에게 발송한 초대장을 취소하였습니다.", output.Email), ""
+		} else {
+			return "초대장을 취소하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.ExportAudits: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			return "감사 로그를 내보냈습니다.", ""
+		} else {
+			return "감사 로그를 내보내는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.Admin_CreateImpersonation: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.CreateImpersonationRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		if isSuccess(statusCode) {
+			output := domain.CreateImpersonationResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("사용자 [%s]로 대리 접속을 시작하였습니다. 사유 : %s", output.Impersonation.User.AccountId, input.Reason), ""
+		} else {
+			return "사용자 대리 접속을 시작하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.Admin_StopImpersonation: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.StopImpersonationResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("사용자 [%s]의 대리 접속을 종료하였습니다.", output.AccountId), ""
+		} else {
+			return "사용자 대리 접속을 종료하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.Admin_UpdateRetentionPolicies: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.UpdateRetentionPoliciesRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		policies := make([]string, len(input.RetentionPolicies))
+		for i, policy := range input.RetentionPolicies {
+			policies[i] = fmt.Sprintf("%s %d일", policy.DataType, policy.RetentionDays)
+		}
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("데이터 보존 정책 [%s]을 수정하였습니다.", strings.Join(policies, ", ")), ""
+		} else {
+			return "데이터 보존 정책을 수정하는데 실패하였습니다.", errorText(ctx, out)
+		}
+	}, internalApi.CreateIdentityProvider: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.CreateIdentityProviderRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("외부 인증 서버 [%s]를 등록하였습니다.", input.Alias), fmt.Sprintf("유형 : %s, 역할 매핑 %d개", input.Type, len(input.RoleMappings))
+		} else {
+			return fmt.Sprintf("외부 인증 서버 [%s]를 등록하는데 실패하였습니다.", input.Alias), errorText(ctx, out)
+		}
+	}, internalApi.UpdateIdentityProvider: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		input := domain.UpdateIdentityProviderRequest{}
+		if err := json.Unmarshal(in, &input); err != nil {
+			log.Error(ctx, err)
+		}
+		if isSuccess(statusCode) {
+			return fmt.Sprintf("외부 인증 서버 [%s]를 변경하였습니다.", input.DisplayName), fmt.Sprintf("사용 %t, 역할 매핑 %d개, secret 변경 %t", input.Enabled, len(input.RoleMappings), input.Secret != "")
+		} else {
+			return fmt.Sprintf("외부 인증 서버 [%s]를 변경하는데 실패하였습니다.", input.DisplayName), errorText(ctx, out)
+		}
+	}, internalApi.DeleteIdentityProvider: func(ctx context.Context, out []byte, in []byte, statusCode int) (message string, description string) {
+		if isSuccess(statusCode) {
+			output := domain.DeleteIdentityProviderResponse{}
+			if err := json.Unmarshal(out, &output); err != nil {
+				log.Error(ctx, err)
+			}
+			return fmt.Sprintf("외부 인증 서버 [%s]를 삭제하였습니다.", output.Alias), ""
+		} else {
+			return "외부 인증 서버를 삭제하는데 실패하였습니다.", errorText(ctx, out)
+		}
 	},
 }
 
+func createApiTokenAudit(ctx context.Context, out []byte, in []byte, statusCode int, owner string) (message string, description string) {
+	input := domain.CreateApiTokenRequest{}
+	if err := json.Unmarshal(in, &input); err != nil {
+		log.Error(ctx, err)
+	}
+	if isSuccess(statusCode) {
+		output := domain.CreateApiTokenResponse{}
+		if err := json.Unmarshal(out, &output); err != nil {
+			log.Error(ctx, err)
+		}
+		return fmt.Sprintf("%sAPI 토큰 [%s]를 발급하였습니다.", owner, input.Name), fmt.Sprintf("ID : %s, API 그룹 : %v", output.ID, input.EndpointGroups)
+	} else {
+		return fmt.Sprintf("%sAPI 토큰 [%s]을 발급하는데 실패하였습니다.", owner, input.Name), errorText(ctx, out)
+	}
+}
+
+func revokeApiTokenAudit(ctx context.Context, out []byte, statusCode int, owner string) (message string, description string) {
+	if isSuccess(statusCode) {
+		output := domain.RevokeApiTokenResponse{}
+		if err := json.Unmarshal(out, &output); err != nil {
+			log.Error(ctx, err)
+		}
+		return fmt.Sprintf("%sAPI 토큰 [%s]를 폐기하였습니다.", owner, output.Name), ""
+	} else {
+		return fmt.Sprintf("%sAPI 토큰을 폐기하는데 실패하였습니다. ", owner), errorText(ctx, out)
+	}
+}
+
 func errorText(ctx context.Context, out []byte) string {
 	var e httpErrors.RestError
 	if err := json.NewDecoder(bytes.NewBuffer(out)).Decode(&e); err != nil {
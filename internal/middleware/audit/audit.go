@@ -2,32 +2,49 @@ package audit
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal"
 	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
 	"github.com/openinfradev/tks-api/internal/middleware/logging"
 	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/repository"
 	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/spf13/viper"
 )
 
 type Interface interface {
 	WithAudit(endpoint internalApi.Endpoint, handler http.Handler) http.Handler
 }
 
+// 조회 API 이지만 자격 증명이나 kubeconfig 를 내려받으므로 기록한다.
+var credentialDownloadEndpoints = map[internalApi.Endpoint]struct{}{
+	internalApi.GetBootstrapKubeconfig:           {},
+	internalApi.GetStackKubeconfig:               {},
+	internalApi.GetProjectKubeconfig:             {},
+	internalApi.GetProjectNamespaceKubeconfig:    {},
+	internalApi.GetSystemNotificationCredentials: {},
+}
+
 type defaultAudit struct {
 	repo     repository.IAuditRepository
 	userRepo repository.IUserRepository
+	repos    repository.Repository
 }
 
 func NewDefaultAudit(repo repository.Repository) *defaultAudit {
 	return &defaultAudit{
 		repo:     repo.Audit,
 		userRepo: repo.User,
+		repos:    repo,
 	}
 }
 
@@ -39,10 +56,29 @@ func (a *defaultAudit) WithAudit(endpoint internalApi.Endpoint, handler http.Han
 			return
 		}
 		userId := user.GetUserId()
+		apiToken, byApiToken := request.ApiTokenFrom(r.Context())
+		impersonator := user.GetImpersonator()
 
-		lrw := logging.NewLoggingResponseWriter(w)
-		handler.ServeHTTP(lrw, r)
-		statusCode := lrw.GetStatusCode()
+		var body []byte
+		if r.Body != nil {
+			var err error
+			if body, err = io.ReadAll(r.Body); err != nil {
+				log.Error(r.Context(), err)
+			}
+			r.Body = io.NopCloser(bytes.NewBuffer(body))
+		}
+
+		_, custom := auditMap[endpoint]
+		_, download := credentialDownloadEndpoints[endpoint]
+		mutating := r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions
+		// workarround pingtoken, stream ticket
+		if endpoint == internalApi.VerifyToken || endpoint == internalApi.CreateNotificationStreamTicket {
+			custom, mutating = false, false
+		}
+		if !custom && !download && !mutating && !byApiToken && impersonator == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
 
 		vars := mux.Vars(r)
 		organizationId, ok := vars["organizationId"]
@@ -50,63 +86,214 @@ func (a *defaultAudit) WithAudit(endpoint internalApi.Endpoint, handler http.Han
 			organizationId = user.GetOrganizationId()
 		}
 
-		message, description := "", ""
-		if fn, ok := auditMap[endpoint]; ok {
-			// workarround pingtoken
-			if endpoint != internalApi.VerifyToken {
-				body, err := io.ReadAll(r.Body)
-				if err != nil {
-					log.Error(r.Context(), err)
-				}
-				message, description = fn(r.Context(), lrw.GetBody().Bytes(), body, statusCode)
-				r.Body = io.NopCloser(bytes.NewBuffer(body))
+		target := resolveResource(r)
+		var before interface{}
+		if mutating {
+			before = a.snapshot(r.Context(), organizationId, target)
+		}
 
-				u, err := a.userRepo.GetByUuid(r.Context(), userId)
-				if err != nil {
-					log.Error(r.Context(), err)
-					return
-				}
+		lrw := logging.NewLoggingResponseWriter(w)
+		handler.ServeHTTP(lrw, r)
+		statusCode := lrw.GetStatusCode()
 
-				userRoles := ""
-				for i, role := range u.Roles {
-					if i > 0 {
-						userRoles = userRoles + ","
-					}
-					userRoles = userRoles + role.Name
-				}
+		dto := model.Audit{
+			OrganizationId: organizationId,
+			Group:          internalApi.ApiMap[endpoint].Group,
+			ClientIP:       GetClientIpAddress(w, r),
+			Endpoint:       endpoint.String(),
+			Method:         r.Method,
+			Path:           r.URL.Path,
+			ResourceType:   target.Type,
+			ResourceId:     target.Id,
+			StatusCode:     statusCode,
+			Result:         model.AuditResultFailure,
+		}
+		if requestId, ok := r.Context().Value(internal.ContextKeyRequestID).(string); ok {
+			dto.RequestId = requestId
+		}
 
-				dto := model.Audit{
-					OrganizationId:   organizationId,
-					OrganizationName: u.Organization.Name,
-					Group:            internalApi.ApiMap[endpoint].Group,
-					Message:          message,
-					Description:      description,
-					ClientIP:         GetClientIpAddress(w, r),
-					UserId:           &u.ID,
-					UserAccountId:    u.AccountId,
-					UserName:         u.Name,
-					UserRoles:        userRoles,
-				}
-				if _, err := a.repo.Create(r.Context(), dto); err != nil {
-					log.Error(r.Context(), err)
+		if isSuccess(statusCode) {
+			dto.Result = model.AuditResultSuccess
+			if mutating {
+				var after interface{}
+				response := parseBody(lrw.GetBody().Bytes())
+				switch {
+				case r.Method == http.MethodDelete && target.SubCollection == "":
+					after = nil
+				case target.SubCollection != "" && idOf(response) != "":
+					// 하위 컬렉션에 생성된 리소스
+					dto.ResourceType, dto.ResourceId = target.SubCollection, idOf(response)
+					before, after = nil, response
+				case before != nil:
+					after = a.snapshot(r.Context(), organizationId, target)
+				default:
+					// 조회 API 가 없는 리소스는 요청한 값을 변경 후로 기록한다.
+					after = parseBody(body)
 				}
+				dto.Changes = diff(before, after)
 			}
 		}
 
+		message, description := "", ""
+		if fn, ok := auditMap[endpoint]; ok && custom {
+			message, description = fn(r.Context(), lrw.GetBody().Bytes(), body, statusCode)
+		}
+
+		// API 토큰을 사용한 요청은 모두 기록한다.
+		if byApiToken {
+			usage := fmt.Sprintf("API 토큰 [%s]으로 [%s]을 호출하였습니다. (status : %d)", apiToken.Name, endpoint.String(), statusCode)
+			if message == "" {
+				message = usage
+			} else {
+				description = strings.TrimSpace(usage + " " + description)
+			}
+		}
+
+		// 관리자 대리 접속으로 한 요청은 모두 기록한다.
+		if impersonator != nil {
+			usage := fmt.Sprintf("관리자 [%s]가 대리 접속으로 [%s]을 호출하였습니다. (status : %d)", impersonator.AccountId, endpoint.String(), statusCode)
+			if message == "" {
+				message = usage
+			} else {
+				description = strings.TrimSpace(usage + " " + description)
+			}
+		}
+
+		derivedMessage, derivedMessageEn := describe(dto)
+		if message == "" {
+			message = derivedMessage
+		}
+		dto.Message = message
+		dto.MessageEn = derivedMessageEn
+		dto.Description = description
+
+		if byApiToken && apiToken.ServiceAccount != nil {
+			serviceAccount := apiToken.ServiceAccount
+			dto.OrganizationName = serviceAccount.Organization.Name
+			dto.UserId = &serviceAccount.ID
+			dto.UserAccountId = serviceAccount.AccountId()
+			dto.UserName = serviceAccount.Name
+			dto.UserRoles = roleNames(serviceAccount.Roles)
+		} else {
+			// 사용자 정보를 읽지 못해도 토큰의 정보로 기록을 남긴다.
+			dto.UserId = &userId
+			dto.UserAccountId = user.GetAccountId()
+			if u, err := a.userRepo.GetByUuid(r.Context(), userId); err != nil {
+				log.Errorf(r.Context(), "failed to get the user [%s] of the audit. %v", userId, err)
+			} else {
+				dto.OrganizationName = u.Organization.Name
+				dto.UserAccountId = u.AccountId
+				dto.UserName = u.Name
+				dto.UserRoles = roleNames(u.Roles)
+			}
+		}
+
+		if impersonator != nil {
+			dto.ImpersonatorId = &impersonator.UserId
+			dto.ImpersonatorAccountId = impersonator.AccountId
+			dto.ImpersonatorOrganizationId = impersonator.OrganizationId
+		}
+
+		if _, err := a.repo.Create(r.Context(), dto); err != nil {
+			log.Error(r.Context(), err)
+		}
 	})
 }
 
+// describe derives the messages from the structured fields in korean and english.
+func describe(dto model.Audit) (message string, messageEn string) {
+	target, targetEn := "", ""
+	if dto.ResourceType != "" {
+		target = fmt.Sprintf("%s [%s] 에 ", dto.ResourceType, dto.ResourceId)
+		targetEn = fmt.Sprintf(" on %s [%s]", dto.ResourceType, dto.ResourceId)
+	}
+	if dto.Result == model.AuditResultSuccess {
+		return fmt.Sprintf("%s%s 을(를) 수행하였습니다.", target, dto.Endpoint),
+			fmt.Sprintf("Performed %s%s.", dto.Endpoint, targetEn)
+	}
+	return fmt.Sprintf("%s%s 을(를) 수행하는데 실패하였습니다. (status : %d)", target, dto.Endpoint, dto.StatusCode),
+		fmt.Sprintf("Failed to perform %s%s. (status : %d)", dto.Endpoint, targetEn, dto.StatusCode)
+}
+
+func roleNames(roles []model.Role) string {
+	userRoles := ""
+	for i, role := range roles {
+		if i > 0 {
+			userRoles = userRoles + ","
+		}
+		userRoles = userRoles + role.Name
+	}
+	return userRoles
+}
+
 var X_FORWARDED_FOR = "X-Forwarded-For"
 
+var (
+	trustedProxiesOnce sync.Once
+	trustedProxies     []*net.IPNet
+)
+
+// GetClientIpAddress returns the address of the client. X-Forwarded-For is used only if the request comes through
+// the trusted proxies (trusted-proxies), because the client can set it to any value.
 func GetClientIpAddress(w http.ResponseWriter, r *http.Request) string {
-	xforward := r.Header.Get(X_FORWARDED_FOR)
-	if xforward != "" {
-		return xforward
-	}
+	trustedProxiesOnce.Do(func() {
+		trustedProxies = parseTrustedProxies(viper.GetString("trusted-proxies"))
+	})
+	return clientIpAddress(r, trustedProxies)
+}
 
+func clientIpAddress(r *http.Request, proxies []*net.IPNet) string {
 	clientAddr, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
-		return clientAddr
+		return ""
+	}
+
+	// 신뢰하는 proxy 가 추가한 주소만 오른쪽부터 따라간다. 그 앞의 주소는 클라이언트가 임의로 넣을 수 있다.
+	hops := strings.Split(strings.Join(r.Header.Values(X_FORWARDED_FOR), ","), ",")
+	for i := len(hops) - 1; i >= 0 && isTrustedProxy(clientAddr, proxies); i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		clientAddr = hop
+	}
+	return clientAddr
+}
+
+func isTrustedProxy(addr string, proxies []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses the comma separated CIDRs or addresses.
+func parseTrustedProxies(value string) []*net.IPNet {
+	var proxies []*net.IPNet
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			if strings.Contains(s, ":") {
+				s += "/128"
+			} else {
+				s += "/32"
+			}
+		}
+		_, proxy, err := net.ParseCIDR(s)
+		if err != nil {
+			log.Errorf(context.Background(), "invalid trusted proxy %s: %v", s, err)
+			continue
+		}
+		proxies = append(proxies, proxy)
 	}
-	return ""
+	return proxies
 }
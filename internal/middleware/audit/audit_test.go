@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClientIpAddress(t *testing.T) {
+	proxies := parseTrustedProxies("10.0.0.0/8, 192.168.0.1, fd00::1")
+
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		xForwardedFor []string
+		want          string
+	}{
+		{"no proxy", "203.0.113.1:1234", nil, "203.0.113.1"},
+		{"untrusted remote ignores header", "203.0.113.1:1234", []string{"198.51.100.1"}, "203.0.113.1"},
+		{"trusted proxy", "10.0.0.1:1234", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"trusted proxy without header", "10.0.0.1:1234", nil, "10.0.0.1"},
+		{"spoofed leftmost address", "10.0.0.1:1234", []string{"1.1.1.1, 198.51.100.1"}, "198.51.100.1"},
+		{"chain of trusted proxies", "10.0.0.1:1234", []string{"198.51.100.1, 192.168.0.1"}, "198.51.100.1"},
+		{"multiple headers", "10.0.0.1:1234", []string{"1.1.1.1", "198.51.100.1"}, "198.51.100.1"},
+		{"invalid hop", "10.0.0.1:1234", []string{"unknown"}, "10.0.0.1"},
+		{"trusted ipv6 proxy", "[fd00::1]:1234", []string{"2001:db8::1"}, "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodPost, "/api/1.0/auth/login", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.xForwardedFor {
+				r.Header.Add(X_FORWARDED_FOR, v)
+			}
+			if got := clientIpAddress(r, proxies); got != tt.want {
+				t.Errorf("clientIpAddress() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
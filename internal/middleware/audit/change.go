@@ -0,0 +1,192 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+const (
+	redactedValue = "******"
+	// 변경 내역이 지나치게 커지지 않도록 필드 수를 제한한다.
+	maxChanges = 100
+)
+
+// 필드 이름에 포함되면 값을 기록하지 않는다. (소문자 기준)
+var sensitiveFieldKeywords = []string{
+	"password",
+	"secret",
+	"token",
+	"credential",
+	"kubeconfig",
+	"privatekey",
+	"accesskey",
+	"recoverycode",
+	"otp",
+}
+
+// resource is the target of the request resolved from the path template of the route.
+type resource struct {
+	Type string
+	Id   string
+	// 하위 컬렉션에 대한 POST 요청. 생성인지 동작인지는 응답을 보고 판단한다.
+	SubCollection string
+}
+
+// resolveResource takes the last path variable as the target resource, e.g. stacks/{stackId} of
+// /organizations/{organizationId}/stacks/{stackId}/favorite.
+func resolveResource(r *http.Request) resource {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return resource{}
+	}
+	tpl, err := route.GetPathTemplate()
+	if err != nil {
+		return resource{}
+	}
+	tplSegments := strings.Split(strings.Trim(tpl, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(tplSegments) != len(pathSegments) {
+		return resource{}
+	}
+
+	lastVar := -1
+	for i, segment := range tplSegments {
+		if strings.HasPrefix(segment, "{") {
+			lastVar = i
+		}
+	}
+
+	var out resource
+	if lastVar > 0 {
+		name := strings.SplitN(strings.Trim(tplSegments[lastVar], "{}"), ":", 2)[0]
+		out.Type = tplSegments[lastVar-1]
+		out.Id = mux.Vars(r)[name]
+	}
+	if last := len(tplSegments) - 1; lastVar < last && r.Method == http.MethodPost {
+		out.SubCollection = tplSegments[last]
+		// 조직 아래 컬렉션에 대한 요청은 조직이 아닌 컬렉션의 리소스를 생성한다.
+		if out.Type == "" || out.Type == "organizations" {
+			out = resource{Type: out.SubCollection, SubCollection: out.SubCollection}
+		}
+	}
+	return out
+}
+
+// parseBody returns the redacted json body. The single wrapping key of the response such as {"stack": {...}} is removed.
+func parseBody(body []byte) interface{} {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil
+	}
+	var out interface{}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil
+	}
+	if m, ok := out.(map[string]interface{}); ok && len(m) == 1 {
+		for _, v := range m {
+			if inner, ok := v.(map[string]interface{}); ok {
+				out = inner
+			}
+		}
+	}
+	return redact(out)
+}
+
+// redact replaces the values of the sensitive fields.
+func redact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			if isSensitiveField(key) {
+				out[key] = redactedValue
+				continue
+			}
+			out[key] = redact(value)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, value := range t {
+			out[i] = redact(value)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isSensitiveField(key string) bool {
+	key = strings.ToLower(key)
+	for _, keyword := range sensitiveFieldKeywords {
+		if strings.Contains(key, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// idOf returns the id of the created resource in the response.
+func idOf(v interface{}) string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	id, _ := m["id"].(string)
+	return id
+}
+
+// diff compares the fields of before and after. Arrays are compared as a whole.
+func diff(before interface{}, after interface{}) []model.AuditChange {
+	beforeFields, afterFields := map[string]interface{}{}, map[string]interface{}{}
+	flatten("", before, beforeFields)
+	flatten("", after, afterFields)
+
+	fields := make([]string, 0, len(beforeFields)+len(afterFields))
+	for field := range beforeFields {
+		fields = append(fields, field)
+	}
+	for field := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []model.AuditChange{}
+	for _, field := range fields {
+		b, a := beforeFields[field], afterFields[field]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		if len(changes) == maxChanges {
+			break
+		}
+		changes = append(changes, model.AuditChange{Field: field, Before: b, After: a})
+	}
+	return changes
+}
+
+func flatten(prefix string, v interface{}, out map[string]interface{}) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		if v != nil || prefix != "" {
+			out[prefix] = v
+		}
+		return
+	}
+	for key, value := range m {
+		field := key
+		if prefix != "" {
+			field = prefix + "." + key
+		}
+		flatten(field, value, out)
+	}
+}
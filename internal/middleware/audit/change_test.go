@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+func TestParseBodyRedactsAndUnwraps(t *testing.T) {
+	got := parseBody([]byte(`{"cloudAccount": {"id": "c-1", "accessKeyId": "AKIA", "secretAccessKey": "s", "tags": [{"token": "t"}]}}`))
+	want := map[string]interface{}{
+		"id":              "c-1",
+		"accessKeyId":     redactedValue,
+		"secretAccessKey": redactedValue,
+		"tags":            []interface{}{map[string]interface{}{"token": redactedValue}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseBody() = %v, want %v", got, want)
+	}
+
+	if got := parseBody([]byte(`not json`)); got != nil {
+		t.Errorf("parseBody() = %v, want nil", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := map[string]interface{}{
+		"name":        "old",
+		"description": "same",
+		"stack":       map[string]interface{}{"status": "RUNNING"},
+		"roles":       []interface{}{"admin"},
+	}
+	after := map[string]interface{}{
+		"name":        "new",
+		"description": "same",
+		"stack":       map[string]interface{}{"status": "DELETING"},
+		"roles":       []interface{}{"admin"},
+		"favorited":   true,
+	}
+	want := []model.AuditChange{
+		{Field: "favorited", Before: nil, After: true},
+		{Field: "name", Before: "old", After: "new"},
+		{Field: "stack.status", Before: "RUNNING", After: "DELETING"},
+	}
+	if got := diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diff() = %v, want %v", got, want)
+	}
+
+	if got := diff(before, nil); len(got) != 4 {
+		t.Errorf("diff() of deleted resource = %v, want 4 changes", got)
+	}
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+)
+
+// snapshotLoader reads the resource from the repository to compare it before and after the change.
+type snapshotLoader func(ctx context.Context, repo repository.Repository, organizationId string, id string) (interface{}, error)
+
+// 변경 전후를 기록할 리소스. 리소스 종류는 경로에서 마지막 변수 앞의 세그먼트이다.
+// 목록에 없는 리소스는 요청한 값을 변경 후로 기록한다.
+var snapshotLoaders = map[string]snapshotLoader{
+	"organizations": func(ctx context.Context, repo repository.Repository, _ string, id string) (interface{}, error) {
+		return repo.Organization.Get(ctx, id)
+	},
+	"users": func(ctx context.Context, repo repository.Repository, organizationId string, id string) (interface{}, error) {
+		return repo.User.Get(ctx, id, organizationId)
+	},
+	"roles": func(ctx context.Context, repo repository.Repository, organizationId string, id string) (interface{}, error) {
+		return repo.Role.GetTksRole(ctx, organizationId, id)
+	},
+	"projects": func(ctx context.Context, repo repository.Repository, organizationId string, id string) (interface{}, error) {
+		return repo.Project.GetProjectById(ctx, organizationId, id)
+	},
+	"stacks": func(ctx context.Context, repo repository.Repository, _ string, id string) (interface{}, error) {
+		return repo.Cluster.Get(ctx, domain.ClusterId(id))
+	},
+	"clusters": func(ctx context.Context, repo repository.Repository, _ string, id string) (interface{}, error) {
+		return repo.Cluster.Get(ctx, domain.ClusterId(id))
+	},
+	"app-groups": func(ctx context.Context, repo repository.Repository, _ string, id string) (interface{}, error) {
+		return repo.AppGroup.Get(ctx, domain.AppGroupId(id))
+	},
+	"cloud-accounts": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.CloudAccount.Get(ctx, id)
+	}),
+	"stack-templates": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.StackTemplate.Get(ctx, id)
+	}),
+	"policy-templates": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.PolicyTemplate.GetByID(ctx, id)
+	}),
+	"system-notification-templates": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.SystemNotificationTemplate.Get(ctx, id)
+	}),
+	"system-notification-rules": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.SystemNotificationRule.Get(ctx, id)
+	}),
+	"system-notification-silences": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.SystemNotificationSilence.Get(ctx, id)
+	}),
+	"system-notification-credentials": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.SystemNotificationCredential.Get(ctx, id)
+	}),
+	"system-notifications": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.SystemNotification.Get(ctx, id)
+	}),
+	"notification-channels": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.NotificationChannel.Get(ctx, id)
+	}),
+	"escalation-policies": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.EscalationPolicy.Get(ctx, id)
+	}),
+	"on-call-schedules": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.OnCallSchedule.Get(ctx, id)
+	}),
+	"identity-providers": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.IdentityProvider.Get(ctx, id)
+	}),
+	"service-accounts": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.ServiceAccount.Get(ctx, id)
+	}),
+	"api-tokens": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.ApiToken.Get(ctx, id)
+	}),
+	"tokens": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.ApiToken.Get(ctx, id)
+	}),
+	"invitations": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.Invitation.Get(ctx, id)
+	}),
+	"impersonations": byUuid(func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error) {
+		return repo.Impersonation.Get(ctx, id)
+	}),
+}
+
+func byUuid(load func(ctx context.Context, repo repository.Repository, id uuid.UUID) (interface{}, error)) snapshotLoader {
+	return func(ctx context.Context, repo repository.Repository, _ string, id string) (interface{}, error) {
+		resourceId, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		return load(ctx, repo, resourceId)
+	}
+}
+
+// snapshot reads the resource from the repository and returns it as the redacted json object.
+// It returns nil if the resource is not in snapshotLoaders or can not be read.
+func (a *defaultAudit) snapshot(ctx context.Context, organizationId string, target resource) interface{} {
+	load, ok := snapshotLoaders[target.Type]
+	if !ok || target.Id == "" {
+		return nil
+	}
+	out, err := load(ctx, a.repos, organizationId, target.Id)
+	if err != nil {
+		return nil
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		return nil
+	}
+	return parseBody(body)
+}
@@ -0,0 +1,102 @@
+package apitoken
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/authenticator"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+// 매 요청마다 DB 를 갱신하지 않도록 마지막 사용 시간은 이 간격보다 오래된 경우에만 기록한다.
+const lastUsedAtUpdateInterval = time.Minute
+
+type apiTokenAuthenticator struct {
+	repo repository.Repository
+}
+
+func NewApiTokenAuthenticator(repo repository.Repository) *apiTokenAuthenticator {
+	return &apiTokenAuthenticator{
+		repo: repo,
+	}
+}
+
+func (a *apiTokenAuthenticator) AuthenticateRequest(r *http.Request) (*authenticator.Response, bool, error) {
+	authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
+	parts := strings.SplitN(authHeader, " ", 3)
+	if len(parts) < 2 || strings.ToLower(parts[0]) != "bearer" || !helper.IsApiToken(parts[1]) {
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("authorizer header is invalid"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+	token := parts[1]
+
+	apiToken, err := a.repo.ApiToken.GetByHash(r.Context(), helper.HashApiToken(token))
+	if err != nil {
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("api token is not found"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+
+	now := time.Now()
+	if !apiToken.IsUsable(now) {
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("api token is expired or revoked"), "A_EXPIRED_TOKEN", "토큰이 만료되었습니다.")
+	}
+
+	var userInfo *user.DefaultInfo
+	switch {
+	case apiToken.ServiceAccount != nil:
+		userInfo = &user.DefaultInfo{
+			UserId:                  apiToken.ServiceAccount.ID,
+			AccountId:               apiToken.ServiceAccount.AccountId(),
+			OrganizationId:          apiToken.OrganizationId,
+			RoleOrganizationMapping: roleOrganizationMapping(apiToken.OrganizationId, apiToken.ServiceAccount.Roles),
+			RoleProjectMapping:      map[string]string{},
+		}
+	case apiToken.User != nil:
+		if apiToken.User.OrganizationId != apiToken.OrganizationId {
+			return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("organization of api token is mismatched"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+		}
+		// 비활성화된 사용자의 토큰은 폐기하지 않았더라도 거부한다.
+		if apiToken.User.Disabled {
+			return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("owner of api token is disabled"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+		}
+		userInfo = &user.DefaultInfo{
+			UserId:                  apiToken.User.ID,
+			AccountId:               apiToken.User.AccountId,
+			OrganizationId:          apiToken.OrganizationId,
+			RoleOrganizationMapping: roleOrganizationMapping(apiToken.OrganizationId, apiToken.User.Roles),
+			RoleProjectMapping:      map[string]string{},
+		}
+	default:
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("owner of api token is not found"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+
+	if apiToken.LastUsedAt == nil || now.Sub(*apiToken.LastUsedAt) > lastUsedAtUpdateInterval {
+		if err := a.repo.ApiToken.UpdateLastUsedAt(r.Context(), apiToken.ID, now); err != nil {
+			log.Warnf(r.Context(), "failed to update last used time of api token %s. err : %v", apiToken.ID, err)
+		}
+	}
+
+	*r = *(r.WithContext(request.WithToken(r.Context(), token)))
+	*r = *(r.WithContext(request.WithSession(r.Context(), "apitoken:"+apiToken.ID.String())))
+	*r = *(r.WithContext(request.WithApiToken(r.Context(), &apiToken)))
+
+	return &authenticator.Response{User: userInfo}, true, nil
+}
+
+// roleOrganizationMapping 은 keycloak 토큰의 tks-role 과 같은 형태로 만든다. 여러 role 중 admin 을 우선한다.
+func roleOrganizationMapping(organizationId string, roles []model.Role) map[string]string {
+	mapping := make(map[string]string)
+	for _, role := range roles {
+		if mapping[organizationId] == user.AdminRole {
+			break
+		}
+		mapping[organizationId] = role.Name
+	}
+	return mapping
+}
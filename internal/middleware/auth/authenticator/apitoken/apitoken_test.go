@@ -0,0 +1,95 @@
+package apitoken
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"gorm.io/gorm"
+)
+
+type fakeApiTokenRepository struct {
+	repository.IApiTokenRepository
+	tokens map[string]model.ApiToken
+}
+
+func (r *fakeApiTokenRepository) GetByHash(ctx context.Context, tokenHash string) (model.ApiToken, error) {
+	apiToken, ok := r.tokens[tokenHash]
+	if !ok {
+		return model.ApiToken{}, gorm.ErrRecordNotFound
+	}
+	return apiToken, nil
+}
+
+func (r *fakeApiTokenRepository) UpdateLastUsedAt(ctx context.Context, apiTokenId uuid.UUID, at time.Time) error {
+	return nil
+}
+
+func TestAuthenticateRequest(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		apiToken model.ApiToken
+		wantCode string
+	}{
+		{
+			name:     "user token",
+			apiToken: model.ApiToken{OrganizationId: "org1", User: &model.User{OrganizationId: "org1"}},
+		},
+		{
+			name:     "service account token",
+			apiToken: model.ApiToken{OrganizationId: "org1", ServiceAccount: &model.ServiceAccount{}},
+		},
+		{
+			name:     "disabled user",
+			apiToken: model.ApiToken{OrganizationId: "org1", User: &model.User{OrganizationId: "org1", Disabled: true}},
+			wantCode: "A_INVALID_TOKEN",
+		},
+		{
+			name:     "user of other organization",
+			apiToken: model.ApiToken{OrganizationId: "org1", User: &model.User{OrganizationId: "org2"}},
+			wantCode: "A_INVALID_TOKEN",
+		},
+		{
+			name:     "revoked",
+			apiToken: model.ApiToken{OrganizationId: "org1", User: &model.User{OrganizationId: "org1"}, RevokedAt: &revokedAt},
+			wantCode: "A_EXPIRED_TOKEN",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := helper.GenerateApiToken()
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.apiToken.ID = uuid.New()
+			a := NewApiTokenAuthenticator(repository.Repository{
+				ApiToken: &fakeApiTokenRepository{tokens: map[string]model.ApiToken{helper.HashApiToken(token): tc.apiToken}},
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+			_, ok, err := a.AuthenticateRequest(r)
+			if tc.wantCode == "" {
+				if !ok || err != nil {
+					t.Errorf("expected authenticated, got %v", err)
+				}
+				return
+			}
+			if ok || err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := err.(httpErrors.IRestError).Code(); code != tc.wantCode {
+				t.Errorf("expected %s, got %s", tc.wantCode, code)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package authenticator
 
 import (
 	"fmt"
+	"github.com/openinfradev/tks-api/internal/helper"
 	"github.com/openinfradev/tks-api/internal/repository"
 	"net/http"
+	"strings"
 
 	internalHttp "github.com/openinfradev/tks-api/internal/delivery/http"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
@@ -20,33 +22,26 @@ type Request interface {
 }
 
 type defaultAuthenticator struct {
-	kcAuth     Request
-	customAuth Request
-	repo       repository.Repository
+	kcAuth            Request
+	customAuth        Request
+	apiTokenAuth      Request
+	impersonationAuth Request
+	repo              repository.Repository
 }
 
-func NewAuthenticator(kc Request, repo repository.Repository, c Request) *defaultAuthenticator {
+func NewAuthenticator(kc Request, repo repository.Repository, c Request, apiToken Request, impersonation Request) *defaultAuthenticator {
 	return &defaultAuthenticator{
-		kcAuth:     kc,
-		repo:       repo,
-		customAuth: c,
+		kcAuth:            kc,
+		repo:              repo,
+		customAuth:        c,
+		apiTokenAuth:      apiToken,
+		impersonationAuth: impersonation,
 	}
 }
 
 func (a *defaultAuthenticator) WithAuthentication(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		resp, ok, err := a.kcAuth.AuthenticateRequest(r)
-		if !ok {
-			log.Error(r.Context(), err)
-			internalHttp.ErrorJSON(w, r, err)
-			return
-		}
-		if err != nil {
-			internalHttp.ErrorJSON(w, r, err)
-			return
-		}
-
-		_, ok, err = a.customAuth.AuthenticateRequest(r)
+		resp, ok, err := a.authenticate(r)
 		if !ok {
 			internalHttp.ErrorJSON(w, r, err)
 			return
@@ -73,6 +68,42 @@ func (a *defaultAuthenticator) WithAuthentication(handler http.Handler) http.Han
 	})
 }
 
+// authenticate verifies the api token or the impersonation token alone, or the keycloak token with the custom authenticator.
+func (a *defaultAuthenticator) authenticate(r *http.Request) (*Response, bool, error) {
+	if isApiTokenRequest(r) {
+		return a.apiTokenAuth.AuthenticateRequest(r)
+	}
+	if isImpersonationRequest(r) {
+		return a.impersonationAuth.AuthenticateRequest(r)
+	}
+
+	resp, ok, err := a.kcAuth.AuthenticateRequest(r)
+	if !ok {
+		log.Error(r.Context(), err)
+		return nil, false, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, ok, err = a.customAuth.AuthenticateRequest(r); !ok {
+		return nil, false, err
+	}
+	return resp, true, nil
+}
+
+// isApiTokenRequest reports whether the bearer token is an api token instead of a keycloak token.
+func isApiTokenRequest(r *http.Request) bool {
+	parts := strings.SplitN(strings.TrimSpace(r.Header.Get("Authorization")), " ", 3)
+	return len(parts) >= 2 && strings.ToLower(parts[0]) == "bearer" && helper.IsApiToken(parts[1])
+}
+
+// isImpersonationRequest reports whether the bearer token is an impersonation token instead of a keycloak token.
+func isImpersonationRequest(r *http.Request) bool {
+	parts := strings.SplitN(strings.TrimSpace(r.Header.Get("Authorization")), " ", 3)
+	return len(parts) >= 2 && strings.ToLower(parts[0]) == "bearer" && helper.IsImpersonationToken(parts[1])
+}
+
 type Response struct {
 	User user.Info
 }
@@ -59,6 +59,17 @@ func (a *CustomAuthenticator) AuthenticateRequest(r *http.Request) (*authenticat
 		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("userId is not found"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
 	}
 
+	// 종료된 세션의 access token 은 만료 전이라도 거부한다.
+	if sessionId, ok := claims["sid"].(string); ok {
+		revoked, err := a.repo.Auth.IsSessionRevoked(r.Context(), sessionId)
+		if err != nil {
+			return nil, false, httpErrors.NewUnauthorizedError(err, "C_INTERNAL_ERROR", "")
+		}
+		if revoked {
+			return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("session is revoked"), "A_EXPIRED_TOKEN", "토큰이 만료되었습니다.")
+		}
+	}
+
 	expiredTime, err := a.repo.Auth.GetExpiredTimeOnToken(r.Context(), organizationId, userId)
 	if expiredTime == nil {
 		return nil, true, nil
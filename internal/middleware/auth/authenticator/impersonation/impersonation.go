@@ -0,0 +1,88 @@
+package impersonation
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/authenticator"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+)
+
+type impersonationAuthenticator struct {
+	repo repository.Repository
+}
+
+func NewImpersonationAuthenticator(repo repository.Repository) *impersonationAuthenticator {
+	return &impersonationAuthenticator{
+		repo: repo,
+	}
+}
+
+func (a *impersonationAuthenticator) AuthenticateRequest(r *http.Request) (*authenticator.Response, bool, error) {
+	authHeader := strings.TrimSpace(r.Header.Get("Authorization"))
+	parts := strings.SplitN(authHeader, " ", 3)
+	if len(parts) < 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("authorizer header is invalid"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+	token := parts[1]
+
+	id, err := helper.VerifyImpersonationToken(token)
+	if err != nil {
+		return nil, false, httpErrors.NewUnauthorizedError(err, "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+	impersonationId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, false, httpErrors.NewUnauthorizedError(err, "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+
+	// 토큰 서명이 유효하더라도 종료된 대리 접속의 토큰은 거부한다.
+	impersonation, err := a.repo.Impersonation.Get(r.Context(), impersonationId)
+	if err != nil {
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("impersonation is not found"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+	if impersonation.CurrentStatus(time.Now()) != model.ImpersonationStatusActive {
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("impersonation is stopped or expired"), "A_EXPIRED_TOKEN", "토큰이 만료되었습니다.")
+	}
+	if impersonation.User == nil || impersonation.Impersonator == nil || impersonation.User.Disabled {
+		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("user of impersonation is not found"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+
+	userInfo := &user.DefaultInfo{
+		UserId:                  impersonation.User.ID,
+		AccountId:               impersonation.User.AccountId,
+		OrganizationId:          impersonation.OrganizationId,
+		RoleOrganizationMapping: roleOrganizationMapping(impersonation.OrganizationId, impersonation.User.Roles),
+		RoleProjectMapping:      map[string]string{},
+		Impersonator: &user.Impersonator{
+			ImpersonationId: impersonation.ID,
+			UserId:          impersonation.Impersonator.ID,
+			AccountId:       impersonation.Impersonator.AccountId,
+			OrganizationId:  impersonation.Impersonator.OrganizationId,
+		},
+	}
+
+	*r = *(r.WithContext(request.WithToken(r.Context(), token)))
+	*r = *(r.WithContext(request.WithSession(r.Context(), "impersonation:"+impersonation.ID.String())))
+
+	return &authenticator.Response{User: userInfo}, true, nil
+}
+
+// roleOrganizationMapping 은 keycloak 토큰의 tks-role 과 같은 형태로 만든다. 여러 role 중 admin 을 우선한다.
+func roleOrganizationMapping(organizationId string, roles []model.Role) map[string]string {
+	mapping := make(map[string]string)
+	for _, role := range roles {
+		if mapping[organizationId] == user.AdminRole {
+			break
+		}
+		mapping[organizationId] = role.Name
+	}
+	return mapping
+}
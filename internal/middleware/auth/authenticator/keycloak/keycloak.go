@@ -1,27 +1,44 @@
 package keycloak
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/openinfradev/tks-api/internal/helper"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
 	"github.com/openinfradev/tks-api/internal/keycloak"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/authenticator"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
 	"github.com/openinfradev/tks-api/pkg/log"
+	gcache "github.com/patrickmn/go-cache"
+)
+
+const (
+	// 활성 토큰의 introspection 결과는 짧게만 캐시하여 로그아웃된 토큰이 오래 통과하지 않도록 한다.
+	activeTokenCacheTTL       = 30 * time.Second
+	tokenIntrospectionTimeout = 3 * time.Second
 )
 
 type keycloakAuthenticator struct {
 	kc keycloak.IKeycloak
+	// introspection 결과. key 는 토큰의 sha256 이다.
+	introspections *gcache.Cache
 }
 
 func NewKeycloakAuthenticator(kc keycloak.IKeycloak) *keycloakAuthenticator {
 	return &keycloakAuthenticator{
-		kc: kc,
+		kc:             kc,
+		introspections: gcache.New(activeTokenCacheTTL, 10*time.Minute),
 	}
 }
 
@@ -64,7 +81,17 @@ func (a *keycloakAuthenticator) AuthenticateToken(r *http.Request, token string)
 		return nil, false, httpErrors.NewUnauthorizedError(fmt.Errorf("organization is not found in token"), "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
 	}
 
-	isActive, err := a.kc.VerifyAccessToken(r.Context(), token, organizationId)
+	claims, err = a.kc.ParseAccessToken(r.Context(), token, organizationId)
+	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, false, httpErrors.NewUnauthorizedError(err, "A_EXPIRED_TOKEN", "토큰이 만료되었습니다.")
+		}
+		log.Errorf(r.Context(), "failed to parse access token: %v", err)
+		return nil, false, httpErrors.NewUnauthorizedError(err, "A_INVALID_TOKEN", "토큰이 유효하지 않습니다.")
+	}
+
+	isActive, err := a.isActive(r, token, organizationId, claims)
 	if err != nil {
 		log.Errorf(r.Context(), "failed to verify access token: %v", err)
 		return nil, false, httpErrors.NewUnauthorizedError(err, "C_INTERNAL_ERROR", "")
@@ -140,3 +167,41 @@ func (a *keycloakAuthenticator) AuthenticateToken(r *http.Request, token string)
 
 	return &authenticator.Response{User: userInfo}, true, nil
 }
+
+// isActive checks the revocation of the token whose signature is already verified.
+// The result of introspection is cached, and sensitive endpoints always introspect.
+func (a *keycloakAuthenticator) isActive(r *http.Request, token string, organizationId string, claims jwt.MapClaims) (bool, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	endpoint, _ := request.EndpointFrom(r.Context())
+	// ApiMap 에 Sensitive 로 표시된 엔드포인트는 폐기된 토큰을 즉시 거부하도록 항상 introspection 한다.
+	sensitive := internalApi.ApiMap[endpoint].Sensitive
+	if !sensitive {
+		if isActive, ok := a.introspections.Get(key); ok {
+			return isActive.(bool), nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), tokenIntrospectionTimeout)
+	defer cancel()
+	isActive, err := a.kc.VerifyAccessToken(ctx, token, organizationId)
+	if err != nil {
+		if sensitive {
+			return false, err
+		}
+		// 서명이 검증된 토큰이므로 keycloak 이 잠시 느리거나 응답하지 않아도 요청은 처리한다.
+		log.Warnf(r.Context(), "failed to introspect access token. accept the verified token. err : %v", err)
+		return true, nil
+	}
+
+	if isActive {
+		a.introspections.Set(key, true, activeTokenCacheTTL)
+	} else if exp, ok := claims["exp"].(float64); ok {
+		// 폐기된 토큰은 만료될 때까지 기억한다.
+		if ttl := time.Until(time.Unix(int64(exp), 0)); ttl > 0 {
+			a.introspections.Set(key, false, ttl)
+		}
+	}
+	return isActive, nil
+}
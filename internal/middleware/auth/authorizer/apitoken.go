@@ -0,0 +1,53 @@
+package authorizer
+
+import (
+	"fmt"
+	"net/http"
+
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	internalHttp "github.com/openinfradev/tks-api/internal/delivery/http"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+)
+
+// API 토큰으로 새로운 토큰을 발급하면 폐기된 토큰의 권한이 계속 이어질 수 있으므로 막는다.
+var tokenIssuingEndpoints = map[internalApi.Endpoint]struct{}{
+	internalApi.CreateMyApiToken:          {},
+	internalApi.CreateServiceAccountToken: {},
+}
+
+// ApiTokenFilter limits the requests authenticated by api token to the endpoint groups of the token.
+func ApiTokenFilter(handler http.Handler, repo repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoint, ok := request.EndpointFrom(r.Context())
+		if !ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("endpoint not found"), "", ""))
+			return
+		}
+
+		// SCIM 엔드포인트는 외부 프로비저닝 시스템 전용이므로 프로비저닝 토큰으로만 호출할 수 있다.
+		apiToken, ok := request.ApiTokenFrom(r.Context())
+		if internalApi.ApiMap[endpoint].Group == model.ScimEndpointGroup && (!ok || !apiToken.IsProvisioningToken()) {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("endpoint %s requires provisioning token", endpoint), "AT_PROVISIONING_TOKEN_REQUIRED", ""))
+			return
+		}
+		if !ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if _, ok := tokenIssuingEndpoints[endpoint]; ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("api token can not issue api token"), "AT_CANNOT_CREATE_WITH_API_TOKEN", ""))
+			return
+		}
+
+		if !apiToken.AllowsGroup(internalApi.ApiMap[endpoint].Group) {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("endpoint %s is not allowed by api token", endpoint), "AT_NOT_ALLOWED_ENDPOINT", ""))
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -19,8 +19,11 @@ func NewDefaultAuthorization(repo repository.Repository) *defaultAuthorization {
 		repo: repo,
 	}
 	d.addFilters(PasswordFilter)
+	d.addFilters(SecondFactorFilter)
+	d.addFilters(ApiTokenFilter)
+	d.addFilters(ImpersonationFilter)
 	//d.addFilters(RBACFilter)
-	//d.addFilters(RBACFilterWithEndpoint)
+	d.addFilters(RBACFilterWithEndpoint)
 	d.addFilters(AdminApiFilter)
 
 	return d
@@ -0,0 +1,56 @@
+package authorizer
+
+import (
+	"fmt"
+	"net/http"
+
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	internalHttp "github.com/openinfradev/tks-api/internal/delivery/http"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+)
+
+// 대리 접속 중에는 사용자의 인증 수단을 바꾸거나 대리 접속보다 오래 유지되는 권한을 만들 수 없다.
+var impersonationBlockedEndpoints = map[internalApi.Endpoint]struct{}{
+	internalApi.Logout:                    {},
+	internalApi.UpdateMyPassword:          {},
+	internalApi.DeleteMyProfile:           {},
+	internalApi.CreateMyApiToken:          {},
+	internalApi.CreateServiceAccountToken: {},
+	internalApi.CreateMySecondFactor:      {},
+	internalApi.VerifyMySecondFactor:      {},
+	internalApi.RegenerateMyRecoveryCodes: {},
+	internalApi.DeleteMySecondFactor:      {},
+	internalApi.RevokeMySession:           {},
+	internalApi.Admin_CreateImpersonation: {},
+	internalApi.Admin_GetImpersonations:   {},
+	internalApi.Admin_StopImpersonation:   {},
+}
+
+// ImpersonationFilter rejects the requests by impersonation token to the endpoints managing the credentials of the user.
+func ImpersonationFilter(handler http.Handler, repo repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestUserInfo, ok := request.UserFrom(r.Context())
+		if !ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found"), "", ""))
+			return
+		}
+		if requestUserInfo.GetImpersonator() == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		endpoint, ok := request.EndpointFrom(r.Context())
+		if !ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("endpoint not found"), "", ""))
+			return
+		}
+		if _, ok := impersonationBlockedEndpoints[endpoint]; ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("endpoint %s is not allowed while impersonating", endpoint), "IMP_NOT_ALLOWED_ENDPOINT", ""))
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
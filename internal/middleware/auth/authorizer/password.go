@@ -6,10 +6,12 @@ import (
 
 	"github.com/openinfradev/tks-api/internal"
 	internalHttp "github.com/openinfradev/tks-api/internal/delivery/http"
-	"github.com/openinfradev/tks-api/internal/helper"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/repository"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
 )
 
 func PasswordFilter(handler http.Handler, repo repository.Repository) http.Handler {
@@ -20,24 +22,38 @@ func PasswordFilter(handler http.Handler, repo repository.Repository) http.Handl
 			return
 		}
 
+		// 서비스 계정은 비밀번호가 없다.
+		if apiToken, ok := request.ApiTokenFrom(r.Context()); ok && apiToken.IsServiceAccountToken() {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		// 대리 접속은 관리자 본인의 인증을 거쳐 시작되므로 사용자의 인증 정책을 적용하지 않는다.
+		if requestUserInfo.GetImpersonator() != nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
 		storedUser, err := repo.User.GetByUuid(r.Context(), requestUserInfo.GetUserId())
 		if err != nil {
 			internalHttp.ErrorJSON(w, r, err)
 			return
 		}
-		//TODO: TKS control plane 동작을 위해, master 조직의 admin 계정은 비밀번호 변경 기간을 무시하도록 함.
-		if storedUser.Organization.ID == "master" && storedUser.AccountId == "admin" {
-			handler.ServeHTTP(w, r)
-			return
+		securityPolicy, err := repo.SecurityPolicy.Get(r.Context(), storedUser.Organization.ID)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				internalHttp.ErrorJSON(w, r, err)
+				return
+			}
+			securityPolicy = model.NewSecurityPolicy(storedUser.Organization.ID)
 		}
-		if helper.IsDurationExpired(storedUser.PasswordUpdatedAt, internal.PasswordExpiredDuration) {
+		if securityPolicy.IsUserPasswordExpired(storedUser) {
 			allowedUrl := []string{
 				internal.API_PREFIX + internal.API_VERSION + "/organizations/" + requestUserInfo.GetOrganizationId() + "/my-profile" + "/password",
 				internal.API_PREFIX + internal.API_VERSION + "/organizations/" + requestUserInfo.GetOrganizationId() + "/my-profile" + "/next-password-change",
 				internal.API_PREFIX + internal.API_VERSION + "/auth/logout",
 			}
 			if !(urlContains(allowedUrl, r.URL.Path) && r.Method == http.MethodPut) {
-				internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("password expired"), "", ""))
+				internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("password expired"), "A_EXPIRED_PASSWORD", ""))
 				return
 			}
 		}
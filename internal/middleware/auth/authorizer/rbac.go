@@ -1,14 +1,21 @@
 package authorizer
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/openinfradev/tks-api/internal"
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
 	internalHttp "github.com/openinfradev/tks-api/internal/delivery/http"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	internalRole "github.com/openinfradev/tks-api/internal/middleware/auth/role"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/repository"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
 	"github.com/openinfradev/tks-api/pkg/log"
@@ -59,39 +66,126 @@ func RBACFilter(handler http.Handler, repo repository.Repository) http.Handler {
 
 func RBACFilterWithEndpoint(handler http.Handler, repo repository.Repository) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		//requestEndpointInfo, ok := request.EndpointFrom(r.Context())
-		//if !ok {
-		//	internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("endpoint not found"), "", ""))
-		//	return
-		//}
-		//
-		//requestUserInfo, ok := request.UserFrom(r.Context())
-		//if !ok {
-		//	internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found"), "", ""))
-		//	return
-		//}
-		//
-		//// if requestEndpointInfo.String() is one of ProjectEndpoints, print true
-		//if internalApi.ApiMap[requestEndpointInfo].Group == "Project" && requestEndpointInfo != internalApi.CreateProject {
-		//	log.Infof("RBACFilterWithEndpoint: %s is ProjectEndpoint", requestEndpointInfo.String())
-		//	vars := mux.Vars(r)
-		//	var projectRole string
-		//	if projectId, ok := vars["projectId"]; ok {
-		//		projectRole = requestUserInfo.GetRoleProjectMapping()[projectId]
-		//	} else {
-		//		log.Warn("RBACFilterWithEndpoint: projectId not found. Passing through unsafely.")
-		//	}
-		//	if !internalRole.IsRoleAllowed(requestEndpointInfo, internalRole.StrToRole(projectRole)) {
-		//		internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("permission denied"), "", ""))
-		//		return
-		//
-		//	}
-		//}
+		requestEndpointInfo, ok := request.EndpointFrom(r.Context())
+		if !ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("endpoint not found"), "", ""))
+			return
+		}
+
+		requestUserInfo, ok := request.UserFrom(r.Context())
+		if !ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found"), "", ""))
+			return
+		}
+
+		organizationId := requestUserInfo.GetOrganizationId()
+		isOrganizationAdmin := requestUserInfo.GetRoleOrganizationMapping()[organizationId] == user.AdminRole
+
+		// master 조직의 admin 은 모든 endpoint 에 접근 가능
+		if organizationId == "master" && isOrganizationAdmin {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		// Organization Filter
+		vars := mux.Vars(r)
+		orgId, ok := vars["organizationId"]
+		if ok && orgId != organizationId {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("permission denied"), "", ""))
+			return
+		}
+
+		endpointName := internalApi.ApiMap[requestEndpointInfo].Name
+		switch {
+		case commonEndpoints.Contains(endpointName), organizationMemberEndpoints.Contains(endpointName):
+		case adminEndpoints.Contains(endpointName):
+			if !isOrganizationAdmin {
+				internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("permission denied"), "", ""))
+				return
+			}
+		case permissionEndpoints.Contains(endpointName):
+			allowed, err := isEndpointAllowed(r.Context(), repo, requestUserInfo.GetUserId(), endpointName)
+			if err != nil {
+				internalHttp.ErrorJSON(w, r, err)
+				return
+			}
+			if !allowed {
+				internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("permission denied"), "", ""))
+				return
+			}
+		default:
+			// 어느 권한에도 등록되지 않은 endpoint 는 master 조직의 admin 만 호출할 수 있다.
+			log.Warnf(r.Context(), "RBACFilterWithEndpoint: %s is not registered on any permission.", endpointName)
+			internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("permission denied"), "", ""))
+			return
+		}
+
+		// Project Filter
+		if projectId, ok := vars["projectId"]; ok {
+			project, err := repo.Project.GetProjectById(r.Context(), orgId, projectId)
+			if err != nil {
+				internalHttp.ErrorJSON(w, r, err)
+				return
+			}
+			if project == nil {
+				internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("project %s is not in organization %s", projectId, orgId), "", ""))
+				return
+			}
+		}
+		if projectId, ok := vars["projectId"]; ok && !isOrganizationAdmin {
+			pm, err := repo.Project.GetProjectMemberByUserId(r.Context(), projectId, requestUserInfo.GetUserId().String())
+			if err != nil {
+				internalHttp.ErrorJSON(w, r, err)
+				return
+			}
+			if pm == nil || pm.ProjectRole == nil ||
+				!internalRole.IsRoleAllowed(requestEndpointInfo, internalRole.StrToRole(pm.ProjectRole.Name)) {
+				internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("permission denied"), "", ""))
+				return
+			}
+		}
 
 		handler.ServeHTTP(w, r)
 	})
 }
 
+func isEndpointAllowed(ctx context.Context, repo repository.Repository, userId uuid.UUID, endpointName string) (bool, error) {
+	roles, err := principalRoles(ctx, repo, userId)
+	if err != nil {
+		return false, err
+	}
+
+	roleIds := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleIds = append(roleIds, role.ID)
+	}
+
+	endpoints, err := repo.Permission.ListAllowedEndpoints(ctx, roleIds)
+	if err != nil {
+		return false, err
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.Name == endpointName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// principalRoles returns the roles of the service account for its api token, otherwise the roles of the user.
+func principalRoles(ctx context.Context, repo repository.Repository, userId uuid.UUID) ([]model.Role, error) {
+	if apiToken, ok := request.ApiTokenFrom(ctx); ok && apiToken.ServiceAccount != nil {
+		return apiToken.ServiceAccount.Roles, nil
+	}
+
+	storedUser, err := repo.User.GetByUuid(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return storedUser.Roles, nil
+}
+
 func AdminApiFilter(handler http.Handler, repo repository.Repository) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestUserInfo, ok := request.UserFrom(r.Context())
@@ -167,3 +261,37 @@ func RequestOrganizationValidationFilter(handler http.Handler, repo repository.R
 //
 //	return true
 //}
+
+var (
+	permissionTemplate = model.NewAdminPermissionSet()
+
+	commonEndpoints     = edgeEndpointSet(permissionTemplate.Common)
+	adminEndpoints      = edgeEndpointSet(permissionTemplate.Admin)
+	permissionEndpoints = edgeEndpointSet(
+		permissionTemplate.Dashboard,
+		permissionTemplate.Stack,
+		permissionTemplate.Policy,
+		permissionTemplate.ProjectManagement,
+		permissionTemplate.Notification,
+		permissionTemplate.Configuration,
+	)
+
+	// organizationMemberEndpoints are registered on the admin permission but needed by every member of the organization
+	organizationMemberEndpoints = mapset.NewSet(
+		internalApi.ApiMap[internalApi.GetOrganization].Name,
+		internalApi.ApiMap[internalApi.CheckId].Name,
+		internalApi.ApiMap[internalApi.CheckEmail].Name,
+	)
+)
+
+func edgeEndpointSet(roots ...*model.Permission) mapset.Set[string] {
+	set := mapset.NewSet[string]()
+	for _, root := range roots {
+		for _, permission := range model.GetEdgePermission(root, nil, nil) {
+			for _, endpoint := range permission.Endpoints {
+				set.Add(endpoint.Name)
+			}
+		}
+	}
+	return set
+}
@@ -0,0 +1,131 @@
+package authorizer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+)
+
+type fakeUserRepository struct {
+	repository.IUserRepository
+	roles []model.Role
+}
+
+func (r *fakeUserRepository) GetByUuid(ctx context.Context, userId uuid.UUID) (model.User, error) {
+	return model.User{ID: userId, Roles: r.roles}, nil
+}
+
+type fakePermissionRepository struct {
+	repository.IPermissionRepository
+	allowed map[string][]string
+}
+
+func (r *fakePermissionRepository) ListAllowedEndpoints(ctx context.Context, roleIds []string) ([]*model.Endpoint, error) {
+	var out []*model.Endpoint
+	for _, roleId := range roleIds {
+		for _, name := range r.allowed[roleId] {
+			out = append(out, &model.Endpoint{Name: name})
+		}
+	}
+	return out, nil
+}
+
+type fakeProjectRepository struct {
+	repository.IProjectRepository
+	// projectId -> organizationId
+	projects map[string]string
+	// projectId -> project role name of the request user
+	members map[string]string
+}
+
+func (r *fakeProjectRepository) GetProjectById(ctx context.Context, organizationId string, projectId string) (*model.Project, error) {
+	if r.projects[projectId] != organizationId {
+		return nil, nil
+	}
+	return &model.Project{ID: projectId, OrganizationId: organizationId}, nil
+}
+
+func (r *fakeProjectRepository) GetProjectMemberByUserId(ctx context.Context, projectId string, projectUserId string) (*model.ProjectMember, error) {
+	roleName, ok := r.members[projectId]
+	if !ok {
+		return nil, nil
+	}
+	return &model.ProjectMember{ProjectId: projectId, ProjectRole: &model.ProjectRole{Name: roleName}}, nil
+}
+
+func TestEndpointsAreClassified(t *testing.T) {
+	for endpoint, info := range internalApi.ApiMap {
+		name := info.Name
+		if !commonEndpoints.Contains(name) && !adminEndpoints.Contains(name) &&
+			!permissionEndpoints.Contains(name) && !organizationMemberEndpoints.Contains(name) {
+			t.Errorf("endpoint %s (%d) is not registered on any permission", name, endpoint)
+		}
+	}
+}
+
+func TestRBACFilterWithEndpoint(t *testing.T) {
+	repo := repository.Repository{
+		User: &fakeUserRepository{roles: []model.Role{{ID: "role-1"}}},
+		Permission: &fakePermissionRepository{allowed: map[string][]string{
+			"role-1": {internalApi.ApiMap[internalApi.GetProject].Name, internalApi.ApiMap[internalApi.GetStacks].Name},
+		}},
+		Project: &fakeProjectRepository{
+			projects: map[string]string{"p1": "org1", "p2": "org2"},
+			members:  map[string]string{"p1": "project-leader"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		organizationId string
+		role           string
+		endpoint       internalApi.Endpoint
+		vars           map[string]string
+		want           int
+	}{
+		{"master admin calls any endpoint", "master", user.AdminRole, internalApi.Admin_GetUser, map[string]string{"organizationId": "org1"}, http.StatusOK},
+		{"master user can not access other organization", "master", "user", internalApi.GetStacks, map[string]string{"organizationId": "org1"}, http.StatusForbidden},
+		{"admin calls admin endpoint", "org1", user.AdminRole, internalApi.UpdateSecurityPolicy, map[string]string{"organizationId": "org1"}, http.StatusOK},
+		{"admin can not access other organization", "org1", user.AdminRole, internalApi.UpdateSecurityPolicy, map[string]string{"organizationId": "org2"}, http.StatusForbidden},
+		{"user can not call admin endpoint", "org1", "user", internalApi.UpdateSecurityPolicy, map[string]string{"organizationId": "org1"}, http.StatusForbidden},
+		{"user calls common endpoint", "org1", "user", internalApi.GetMyProfile, map[string]string{"organizationId": "org1"}, http.StatusOK},
+		{"user calls allowed endpoint", "org1", "user", internalApi.GetStacks, map[string]string{"organizationId": "org1"}, http.StatusOK},
+		{"user calls not allowed endpoint", "org1", "user", internalApi.CreateStack, map[string]string{"organizationId": "org1"}, http.StatusForbidden},
+		{"unregistered endpoint is denied", "org1", user.AdminRole, internalApi.Endpoint(-1), map[string]string{"organizationId": "org1"}, http.StatusForbidden},
+		{"project member calls project endpoint", "org1", "user", internalApi.GetProject, map[string]string{"organizationId": "org1", "projectId": "p1"}, http.StatusOK},
+		{"project of other organization is denied", "org1", user.AdminRole, internalApi.GetProject, map[string]string{"organizationId": "org1", "projectId": "p2"}, http.StatusForbidden},
+		{"not project member is denied", "org2", "user", internalApi.GetProject, map[string]string{"organizationId": "org2", "projectId": "p2"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RBACFilterWithEndpoint(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}), repo)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx := request.WithUser(r.Context(), &user.DefaultInfo{
+				UserId:                  uuid.New(),
+				OrganizationId:          tt.organizationId,
+				RoleOrganizationMapping: map[string]string{tt.organizationId: tt.role},
+			})
+			ctx = request.WithEndpoint(ctx, tt.endpoint)
+			r = mux.SetURLVars(r.WithContext(ctx), tt.vars)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package authorizer
+
+import (
+	"fmt"
+	"net/http"
+
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	internalHttp "github.com/openinfradev/tks-api/internal/delivery/http"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// 2단계 인증 등록 전에도 등록을 마칠 수 있도록 허용하는 API
+// 비밀번호가 만료된 경우 PasswordFilter 에 막히지 않도록 비밀번호 변경 API 도 허용한다.
+var secondFactorEnrollmentEndpoints = map[internalApi.Endpoint]struct{}{
+	internalApi.Logout:                   {},
+	internalApi.RefreshToken:             {},
+	internalApi.VerifyToken:              {},
+	internalApi.GetMyProfile:             {},
+	internalApi.UpdateMyPassword:         {},
+	internalApi.RenewPasswordExpiredDate: {},
+	internalApi.GetMySecondFactor:        {},
+	internalApi.CreateMySecondFactor:     {},
+	internalApi.VerifyMySecondFactor:     {},
+}
+
+// 2단계 인증을 마치지 않은 세션에서도 허용하는 API
+var secondFactorSessionEndpoints = map[internalApi.Endpoint]struct{}{
+	internalApi.Logout:       {},
+	internalApi.RefreshToken: {},
+	internalApi.VerifyToken:  {},
+}
+
+// SecondFactorFilter rejects the requests of the session which has not passed the second factor of the user,
+// and the requests of the user who has not enrolled the second factor required by the security policy.
+// keycloak 은 비밀번호만으로 토큰을 발급하므로 2단계 인증 여부는 세션(sid)으로 확인한다.
+func SecondFactorFilter(handler http.Handler, repo repository.Repository) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestUserInfo, ok := request.UserFrom(r.Context())
+		if !ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("user not found"), "", ""))
+			return
+		}
+
+		// 서비스 계정은 2단계 인증을 사용하지 않는다.
+		apiToken, byApiToken := request.ApiTokenFrom(r.Context())
+		if byApiToken && apiToken.IsServiceAccountToken() {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		// 대리 접속은 관리자 본인의 인증을 거쳐 시작되므로 사용자의 인증 정책을 적용하지 않는다.
+		if requestUserInfo.GetImpersonator() != nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		endpoint, ok := request.EndpointFrom(r.Context())
+		if !ok {
+			internalHttp.ErrorJSON(w, r, httpErrors.NewInternalServerError(fmt.Errorf("endpoint not found"), "", ""))
+			return
+		}
+
+		secondFactor, err := repo.SecondFactor.Get(r.Context(), requestUserInfo.GetUserId())
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			internalHttp.ErrorJSON(w, r, err)
+			return
+		}
+		if err == nil && secondFactor.Enabled {
+			// 개인 API 토큰은 2단계 인증을 마친 세션에서 발급된다.
+			if _, ok := secondFactorSessionEndpoints[endpoint]; ok || byApiToken {
+				handler.ServeHTTP(w, r)
+				return
+			}
+			sessionId, _ := request.SessionFrom(r.Context())
+			verified, err := repo.SecondFactor.IsSessionVerified(r.Context(), requestUserInfo.GetUserId(), sessionId)
+			if err != nil {
+				internalHttp.ErrorJSON(w, r, err)
+				return
+			}
+			if !verified {
+				internalHttp.ErrorJSON(w, r, httpErrors.NewUnauthorizedError(fmt.Errorf("second factor is not verified in session %s", sessionId), "A_SECOND_FACTOR_NOT_VERIFIED", ""))
+				return
+			}
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if _, ok := secondFactorEnrollmentEndpoints[endpoint]; ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		organizationId := requestUserInfo.GetOrganizationId()
+		securityPolicy, err := repo.SecurityPolicy.Get(r.Context(), organizationId)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				internalHttp.ErrorJSON(w, r, err)
+				return
+			}
+			securityPolicy = model.NewSecurityPolicy(organizationId)
+		}
+		if !securityPolicy.RequireSecondFactor {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		internalHttp.ErrorJSON(w, r, httpErrors.NewForbiddenError(fmt.Errorf("second factor is required"), "A_SECOND_FACTOR_REQUIRED", ""))
+	})
+}
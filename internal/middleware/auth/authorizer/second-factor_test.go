@@ -0,0 +1,118 @@
+package authorizer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"gorm.io/gorm"
+)
+
+type fakeSecondFactorRepository struct {
+	repository.ISecondFactorRepository
+	// userId -> enabled
+	secondFactors map[uuid.UUID]bool
+	// sessionId -> userId
+	sessions map[string]uuid.UUID
+}
+
+func (r *fakeSecondFactorRepository) Get(ctx context.Context, userId uuid.UUID) (model.SecondFactor, error) {
+	enabled, ok := r.secondFactors[userId]
+	if !ok {
+		return model.SecondFactor{}, gorm.ErrRecordNotFound
+	}
+	return model.SecondFactor{UserId: userId, Enabled: enabled}, nil
+}
+
+func (r *fakeSecondFactorRepository) IsSessionVerified(ctx context.Context, userId uuid.UUID, sessionId string) (bool, error) {
+	verifiedUserId, ok := r.sessions[sessionId]
+	return ok && verifiedUserId == userId, nil
+}
+
+type fakeSecurityPolicyRepository struct {
+	repository.ISecurityPolicyRepository
+	// organizationId -> RequireSecondFactor
+	required map[string]bool
+}
+
+func (r *fakeSecurityPolicyRepository) Get(ctx context.Context, organizationId string) (model.SecurityPolicy, error) {
+	required, ok := r.required[organizationId]
+	if !ok {
+		return model.SecurityPolicy{}, gorm.ErrRecordNotFound
+	}
+	policy := model.NewSecurityPolicy(organizationId)
+	policy.RequireSecondFactor = required
+	return policy, nil
+}
+
+func TestSecondFactorFilter(t *testing.T) {
+	enabledUser, enrolledUser, otherUser := uuid.New(), uuid.New(), uuid.New()
+	serviceAccountId := uuid.New()
+	repo := repository.Repository{
+		SecondFactor: &fakeSecondFactorRepository{
+			secondFactors: map[uuid.UUID]bool{enabledUser: true, enrolledUser: false},
+			sessions:      map[string]uuid.UUID{"verified": enabledUser, "other": otherUser},
+		},
+		SecurityPolicy: &fakeSecurityPolicyRepository{required: map[string]bool{"required": true, "optional": false}},
+	}
+
+	tests := []struct {
+		name           string
+		userId         uuid.UUID
+		organizationId string
+		sessionId      string
+		endpoint       internalApi.Endpoint
+		apiToken       *model.ApiToken
+		impersonator   *user.Impersonator
+		want           int
+	}{
+		{"verified session", enabledUser, "optional", "verified", internalApi.GetStacks, nil, nil, http.StatusOK},
+		{"session without second factor", enabledUser, "optional", "password-only", internalApi.GetStacks, nil, nil, http.StatusUnauthorized},
+		{"session verified by other user", enabledUser, "optional", "other", internalApi.GetStacks, nil, nil, http.StatusUnauthorized},
+		{"session without second factor on enrollment endpoint", enabledUser, "optional", "password-only", internalApi.UpdateMyPassword, nil, nil, http.StatusUnauthorized},
+		{"session without second factor logs out", enabledUser, "optional", "password-only", internalApi.Logout, nil, nil, http.StatusOK},
+		{"personal api token", enabledUser, "optional", "", internalApi.GetStacks, &model.ApiToken{}, nil, http.StatusOK},
+		{"service account token", otherUser, "required", "", internalApi.GetStacks, &model.ApiToken{ServiceAccountId: &serviceAccountId}, nil, http.StatusOK},
+		{"impersonation", enabledUser, "required", "password-only", internalApi.GetStacks, nil, &user.Impersonator{}, http.StatusOK},
+		{"not required", otherUser, "optional", "password-only", internalApi.GetStacks, nil, nil, http.StatusOK},
+		{"no security policy", otherUser, "none", "password-only", internalApi.GetStacks, nil, nil, http.StatusOK},
+		{"required but not enrolled", otherUser, "required", "password-only", internalApi.GetStacks, nil, nil, http.StatusForbidden},
+		{"required but not verified enrollment", enrolledUser, "required", "password-only", internalApi.GetStacks, nil, nil, http.StatusForbidden},
+		{"required and enrolling", otherUser, "required", "password-only", internalApi.CreateMySecondFactor, nil, nil, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := SecondFactorFilter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}), repo)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx := request.WithUser(r.Context(), &user.DefaultInfo{
+				UserId:         tt.userId,
+				OrganizationId: tt.organizationId,
+				Impersonator:   tt.impersonator,
+			})
+			ctx = request.WithEndpoint(ctx, tt.endpoint)
+			if tt.sessionId != "" {
+				ctx = request.WithSession(ctx, tt.sessionId)
+			}
+			if tt.apiToken != nil {
+				ctx = request.WithApiToken(ctx, tt.apiToken)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r.WithContext(ctx))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	gcache "github.com/patrickmn/go-cache"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// 토큰 introspection 캐시와 같은 주기로만 캐시하여, 권한이나 정책의 변경과 세션 종료가 늦어도 이 시간 안에 반영되도록 한다.
+const lookupCacheTTL = 30 * time.Second
+
+// NewRepository returns the repository for the auth and audit middleware
+// which caches the lookups made on every request for a short time.
+// Sensitive endpoints always read the database and refresh the cache.
+func NewRepository(repo repository.Repository) repository.Repository {
+	c := gcache.New(lookupCacheTTL, 10*time.Minute)
+
+	repo.Auth = &authRepository{IAuthRepository: repo.Auth, cache: c}
+	repo.User = &userRepository{IUserRepository: repo.User, cache: c}
+	repo.SecurityPolicy = &securityPolicyRepository{ISecurityPolicyRepository: repo.SecurityPolicy, cache: c}
+	repo.SecondFactor = &secondFactorRepository{ISecondFactorRepository: repo.SecondFactor, cache: c}
+	repo.Permission = &permissionRepository{IPermissionRepository: repo.Permission, cache: c}
+	return repo
+}
+
+type entry[T any] struct {
+	value T
+	err   error
+}
+
+func lookup[T any](ctx context.Context, c *gcache.Cache, key string, load func() (T, error)) (T, error) {
+	if !isSensitive(ctx) {
+		if cached, ok := c.Get(key); ok {
+			e := cached.(entry[T])
+			return e.value, e.err
+		}
+	}
+
+	value, err := load()
+	// 조회에 실패한 결과는 캐시하지 않지만 레코드가 없다는 결과는 캐시한다.
+	if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		c.Set(key, entry[T]{value: value, err: err}, gcache.DefaultExpiration)
+	}
+	return value, err
+}
+
+func isSensitive(ctx context.Context) bool {
+	endpoint, ok := request.EndpointFrom(ctx)
+	return ok && internalApi.ApiMap[endpoint].Sensitive
+}
+
+type authRepository struct {
+	repository.IAuthRepository
+	cache *gcache.Cache
+}
+
+func (r *authRepository) IsSessionRevoked(ctx context.Context, sessionId string) (bool, error) {
+	return lookup(ctx, r.cache, "session-revoked:"+sessionId, func() (bool, error) {
+		return r.IAuthRepository.IsSessionRevoked(ctx, sessionId)
+	})
+}
+
+type userRepository struct {
+	repository.IUserRepository
+	cache *gcache.Cache
+}
+
+func (r *userRepository) GetByUuid(ctx context.Context, userId uuid.UUID) (model.User, error) {
+	return lookup(ctx, r.cache, "user:"+userId.String(), func() (model.User, error) {
+		return r.IUserRepository.GetByUuid(ctx, userId)
+	})
+}
+
+type securityPolicyRepository struct {
+	repository.ISecurityPolicyRepository
+	cache *gcache.Cache
+}
+
+func (r *securityPolicyRepository) Get(ctx context.Context, organizationId string) (model.SecurityPolicy, error) {
+	return lookup(ctx, r.cache, "security-policy:"+organizationId, func() (model.SecurityPolicy, error) {
+		return r.ISecurityPolicyRepository.Get(ctx, organizationId)
+	})
+}
+
+type secondFactorRepository struct {
+	repository.ISecondFactorRepository
+	cache *gcache.Cache
+}
+
+func (r *secondFactorRepository) Get(ctx context.Context, userId uuid.UUID) (model.SecondFactor, error) {
+	return lookup(ctx, r.cache, "second-factor:"+userId.String(), func() (model.SecondFactor, error) {
+		return r.ISecondFactorRepository.Get(ctx, userId)
+	})
+}
+
+func (r *secondFactorRepository) IsSessionVerified(ctx context.Context, userId uuid.UUID, sessionId string) (bool, error) {
+	// 인증을 마치면 바로 통과해야 하므로 인증된 세션만 캐시한다.
+	key := "second-factor-session:" + userId.String() + ":" + sessionId
+	if verified, ok := r.cache.Get(key); ok && !isSensitive(ctx) {
+		return verified.(bool), nil
+	}
+	verified, err := r.ISecondFactorRepository.IsSessionVerified(ctx, userId, sessionId)
+	if err == nil && verified {
+		r.cache.Set(key, true, gcache.DefaultExpiration)
+	}
+	return verified, err
+}
+
+type permissionRepository struct {
+	repository.IPermissionRepository
+	cache *gcache.Cache
+}
+
+func (r *permissionRepository) ListAllowedEndpoints(ctx context.Context, roleIds []string) ([]*model.Endpoint, error) {
+	sorted := append([]string{}, roleIds...)
+	sort.Strings(sorted)
+	return lookup(ctx, r.cache, "allowed-endpoints:"+strings.Join(sorted, ","), func() ([]*model.Endpoint, error) {
+		return r.IPermissionRepository.ListAllowedEndpoints(ctx, roleIds)
+	})
+}
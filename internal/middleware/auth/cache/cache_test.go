@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"gorm.io/gorm"
+)
+
+type fakeUserRepository struct {
+	repository.IUserRepository
+	err   error
+	calls int
+}
+
+func (r *fakeUserRepository) GetByUuid(ctx context.Context, userId uuid.UUID) (model.User, error) {
+	r.calls++
+	return model.User{ID: userId}, r.err
+}
+
+type fakeSecondFactorRepository struct {
+	repository.ISecondFactorRepository
+	verified bool
+	calls    int
+}
+
+func (r *fakeSecondFactorRepository) IsSessionVerified(ctx context.Context, userId uuid.UUID, sessionId string) (bool, error) {
+	r.calls++
+	return r.verified, nil
+}
+
+func TestLookupCache(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name      string
+		endpoint  internalApi.Endpoint
+		err       error
+		wantCalls int
+	}{
+		{name: "cached", endpoint: internalApi.GetUser, wantCalls: 1},
+		{name: "not found is cached", endpoint: internalApi.GetUser, err: gorm.ErrRecordNotFound, wantCalls: 1},
+		{name: "error is not cached", endpoint: internalApi.GetUser, err: fmt.Errorf("connection refused"), wantCalls: 3},
+		{name: "sensitive endpoint reads the database", endpoint: internalApi.DeleteUser, wantCalls: 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeUserRepository{err: tc.err}
+			repo := NewRepository(repository.Repository{User: fake})
+			ctx := request.WithEndpoint(context.Background(), tc.endpoint)
+
+			for i := 0; i < 3; i++ {
+				if _, err := repo.User.GetByUuid(ctx, userId); err != tc.err {
+					t.Fatalf("expected error %v, got %v", tc.err, err)
+				}
+			}
+			if fake.calls != tc.wantCalls {
+				t.Errorf("expected %d calls, got %d", tc.wantCalls, fake.calls)
+			}
+		})
+	}
+}
+
+func TestIsSessionVerifiedCache(t *testing.T) {
+	fake := &fakeSecondFactorRepository{}
+	repo := NewRepository(repository.Repository{SecondFactor: fake})
+	userId := uuid.New()
+
+	// 인증 전 결과는 캐시하지 않으므로 인증을 마치면 바로 통과한다.
+	if verified, _ := repo.SecondFactor.IsSessionVerified(context.Background(), userId, "sid"); verified {
+		t.Fatal("expected the session is not verified")
+	}
+	fake.verified = true
+	if verified, _ := repo.SecondFactor.IsSessionVerified(context.Background(), userId, "sid"); !verified {
+		t.Fatal("expected the session is verified")
+	}
+	if verified, _ := repo.SecondFactor.IsSessionVerified(context.Background(), userId, "sid"); !verified {
+		t.Fatal("expected the session is verified")
+	}
+	if fake.calls != 2 {
+		t.Errorf("expected 2 calls, got %d", fake.calls)
+	}
+}
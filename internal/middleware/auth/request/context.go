@@ -5,6 +5,7 @@ import (
 
 	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
 )
 
 type key int
@@ -15,6 +16,7 @@ const (
 	sessionKey
 	endpointKey
 	auditKey
+	apiTokenKey
 )
 
 func WithValue(parent context.Context, key, val interface{}) context.Context {
@@ -65,3 +67,14 @@ func AuditFrom(ctx context.Context) (string, bool) {
 	audit, ok := ctx.Value(auditKey).(string)
 	return audit, ok
 }
+
+// WithApiToken records the api token which authenticated the request.
+func WithApiToken(parent context.Context, apiToken *model.ApiToken) context.Context {
+	return WithValue(parent, apiTokenKey, apiToken)
+}
+
+// ApiTokenFrom returns false if the request is authenticated by keycloak token.
+func ApiTokenFrom(ctx context.Context) (*model.ApiToken, bool) {
+	apiToken, ok := ctx.Value(apiTokenKey).(*model.ApiToken)
+	return apiToken, ok
+}
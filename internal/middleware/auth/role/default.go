@@ -46,8 +46,24 @@ var defaultPermissionOfAdmin = defaultPermission{
 		internalApi.DeleteUser,
 		internalApi.UpdateUser,
 		internalApi.ResetPassword,
+		internalApi.UnlockUser,
+		internalApi.ResetUserSecondFactor,
+		internalApi.GetUserSessions,
+		internalApi.RevokeUserSession,
+		internalApi.RevokeUserSessions,
 		internalApi.CheckId,
 		internalApi.CheckEmail,
+		internalApi.ImportUsers,
+		internalApi.GetUserImports,
+		internalApi.GetUserImport,
+		internalApi.ExportUsers,
+
+		// Invitation
+		internalApi.CreateInvitation,
+		internalApi.GetInvitations,
+		internalApi.GetInvitation,
+		internalApi.ResendInvitation,
+		internalApi.RevokeInvitation,
 
 		// MyProfile
 		internalApi.GetMyProfile,
@@ -55,6 +71,18 @@ var defaultPermissionOfAdmin = defaultPermission{
 		internalApi.UpdateMyPassword,
 		internalApi.RenewPasswordExpiredDate,
 		internalApi.DeleteMyProfile,
+		internalApi.GetMyNotificationPreference,
+		internalApi.UpdateMyNotificationPreference,
+		internalApi.GetMyApiTokens,
+		internalApi.CreateMyApiToken,
+		internalApi.RevokeMyApiToken,
+		internalApi.GetMySecondFactor,
+		internalApi.CreateMySecondFactor,
+		internalApi.VerifyMySecondFactor,
+		internalApi.RegenerateMyRecoveryCodes,
+		internalApi.DeleteMySecondFactor,
+		internalApi.GetMySessions,
+		internalApi.RevokeMySession,
 
 		// Organization
 		internalApi.Admin_CreateOrganization,
@@ -62,6 +90,8 @@ var defaultPermissionOfAdmin = defaultPermission{
 		internalApi.GetOrganizations,
 		internalApi.GetOrganization,
 		internalApi.UpdateOrganization,
+		internalApi.GetSecurityPolicy,
+		internalApi.UpdateSecurityPolicy,
 
 		// Cluster
 		internalApi.UpdatePrimaryCluster,
@@ -146,6 +176,36 @@ var defaultPermissionOfAdmin = defaultPermission{
 		internalApi.SetFavoriteProjectNamespace,
 		internalApi.UnSetFavoriteProject,
 		internalApi.UnSetFavoriteProjectNamespace,
+
+		// ServiceAccount
+		internalApi.CreateServiceAccount,
+		internalApi.GetServiceAccounts,
+		internalApi.GetServiceAccount,
+		internalApi.UpdateServiceAccount,
+		internalApi.DeleteServiceAccount,
+		internalApi.GetServiceAccountTokens,
+		internalApi.CreateServiceAccountToken,
+		internalApi.RevokeServiceAccountToken,
+
+		// IdentityProvider
+		internalApi.CreateIdentityProvider,
+		internalApi.GetIdentityProviders,
+		internalApi.GetIdentityProvider,
+		internalApi.UpdateIdentityProvider,
+		internalApi.DeleteIdentityProvider,
+
+		// Scim
+		internalApi.ScimGetServiceProviderConfig,
+		internalApi.ScimGetUsers,
+		internalApi.ScimGetUser,
+		internalApi.ScimCreateUser,
+		internalApi.ScimReplaceUser,
+		internalApi.ScimPatchUser,
+		internalApi.ScimDeleteUser,
+		internalApi.ScimGetGroups,
+		internalApi.ScimGetGroup,
+		internalApi.ScimReplaceGroup,
+		internalApi.ScimPatchGroup,
 	},
 }
 
@@ -170,10 +230,23 @@ var defaultPermissionOfUser = defaultPermission{
 		internalApi.UpdateMyPassword,
 		internalApi.RenewPasswordExpiredDate,
 		internalApi.DeleteMyProfile,
+		internalApi.GetMyNotificationPreference,
+		internalApi.UpdateMyNotificationPreference,
+		internalApi.GetMyApiTokens,
+		internalApi.CreateMyApiToken,
+		internalApi.RevokeMyApiToken,
+		internalApi.GetMySecondFactor,
+		internalApi.CreateMySecondFactor,
+		internalApi.VerifyMySecondFactor,
+		internalApi.RegenerateMyRecoveryCodes,
+		internalApi.DeleteMySecondFactor,
+		internalApi.GetMySessions,
+		internalApi.RevokeMySession,
 
 		// Organization
 		internalApi.GetOrganizations,
 		internalApi.GetOrganization,
+		internalApi.GetSecurityPolicy,
 
 		// Cluster
 		internalApi.GetClusters,
@@ -226,6 +299,7 @@ var defaultPermissionOfUser = defaultPermission{
 		internalApi.DeleteSystemNotification,
 		internalApi.UpdateSystemNotification,
 		internalApi.CreateSystemNotificationAction,
+		internalApi.CreateNotificationStreamTicket,
 
 		// Stack
 		internalApi.GetStacks,
@@ -266,17 +340,39 @@ var defaultLeaderPermission = defaultPermission{
 		internalApi.UpdateProject,
 		internalApi.DeleteProject,
 		internalApi.AddProjectMember,
+		internalApi.GetProjectMember,
 		internalApi.GetProjectMembers,
 		internalApi.RemoveProjectMember,
 		internalApi.UpdateProjectMemberRole,
 		internalApi.CreateProjectNamespace,
 		internalApi.GetProjectNamespaces,
 		internalApi.GetProjectNamespace,
+		internalApi.UpdateProjectNamespace,
 		internalApi.DeleteProjectNamespace,
+		internalApi.GetProjectNamespaceK8sResources,
+		internalApi.GetProjectNamespaceKubeconfig,
+		internalApi.GetProjectKubeconfig,
 		internalApi.SetFavoriteProject,
 		internalApi.SetFavoriteProjectNamespace,
 		internalApi.UnSetFavoriteProject,
 		internalApi.UnSetFavoriteProjectNamespace,
+
+		// AppServeApp
+		internalApi.CreateAppServeApp,
+		internalApi.GetAppServeApps,
+		internalApi.GetNumOfAppsOnStack,
+		internalApi.GetAppServeApp,
+		internalApi.GetAppServeAppTasksByAppId,
+		internalApi.GetAppServeAppTaskDetail,
+		internalApi.GetAppServeAppLatestTask,
+		internalApi.GetAppServeAppLog,
+		internalApi.IsAppServeAppExist,
+		internalApi.IsAppServeAppNameExist,
+		internalApi.DeleteAppServeApp,
+		internalApi.UpdateAppServeApp,
+		internalApi.UpdateAppServeAppStatus,
+		internalApi.UpdateAppServeAppEndpoint,
+		internalApi.RollbackAppServeApp,
 	},
 }
 
@@ -286,13 +382,34 @@ var defaultMemberPermission = defaultPermission{
 		// Project
 		internalApi.GetProjects,
 		internalApi.GetProject,
+		internalApi.GetProjectMember,
 		internalApi.GetProjectMembers,
 		internalApi.GetProjectNamespaces,
 		internalApi.GetProjectNamespace,
+		internalApi.GetProjectNamespaceK8sResources,
+		internalApi.GetProjectNamespaceKubeconfig,
+		internalApi.GetProjectKubeconfig,
 		internalApi.SetFavoriteProject,
 		internalApi.SetFavoriteProjectNamespace,
 		internalApi.UnSetFavoriteProject,
 		internalApi.UnSetFavoriteProjectNamespace,
+
+		// AppServeApp
+		internalApi.CreateAppServeApp,
+		internalApi.GetAppServeApps,
+		internalApi.GetNumOfAppsOnStack,
+		internalApi.GetAppServeApp,
+		internalApi.GetAppServeAppTasksByAppId,
+		internalApi.GetAppServeAppTaskDetail,
+		internalApi.GetAppServeAppLatestTask,
+		internalApi.GetAppServeAppLog,
+		internalApi.IsAppServeAppExist,
+		internalApi.IsAppServeAppNameExist,
+		internalApi.DeleteAppServeApp,
+		internalApi.UpdateAppServeApp,
+		internalApi.UpdateAppServeAppStatus,
+		internalApi.UpdateAppServeAppEndpoint,
+		internalApi.RollbackAppServeApp,
 	},
 }
 
@@ -302,12 +419,25 @@ var defaultViewerPermission = defaultPermission{
 		// Project
 		internalApi.GetProjects,
 		internalApi.GetProject,
+		internalApi.GetProjectMember,
 		internalApi.GetProjectMembers,
 		internalApi.GetProjectNamespaces,
 		internalApi.GetProjectNamespace,
+		internalApi.GetProjectNamespaceK8sResources,
 		internalApi.SetFavoriteProject,
 		internalApi.SetFavoriteProjectNamespace,
 		internalApi.UnSetFavoriteProject,
 		internalApi.UnSetFavoriteProjectNamespace,
+
+		// AppServeApp
+		internalApi.GetAppServeApps,
+		internalApi.GetNumOfAppsOnStack,
+		internalApi.GetAppServeApp,
+		internalApi.GetAppServeAppTasksByAppId,
+		internalApi.GetAppServeAppTaskDetail,
+		internalApi.GetAppServeAppLatestTask,
+		internalApi.GetAppServeAppLog,
+		internalApi.IsAppServeAppExist,
+		internalApi.IsAppServeAppNameExist,
 	},
 }
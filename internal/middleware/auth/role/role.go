@@ -42,8 +42,12 @@ func StrToRole(role string) Role {
 		return Admin
 	case "user":
 		return User
-	case "leader":
+	case "leader", "project-leader":
 		return leader
+	case "member", "project-member":
+		return member
+	case "viewer", "project-viewer":
+		return viewer
 	default:
 		return ""
 	}
@@ -11,6 +11,15 @@ type Info interface {
 	GetOrganizationId() string
 	GetRoleOrganizationMapping() map[string]string
 	GetRoleProjectMapping() map[string]string
+	GetImpersonator() *Impersonator
+}
+
+// Impersonator is the master admin acting as the user with the impersonation token.
+type Impersonator struct {
+	ImpersonationId uuid.UUID
+	UserId          uuid.UUID
+	AccountId       string
+	OrganizationId  string
 }
 
 // DefaultInfo provides a simple user information exchange object
@@ -22,6 +31,7 @@ type DefaultInfo struct {
 	ProjectIds              []string
 	RoleOrganizationMapping map[string]string
 	RoleProjectMapping      map[string]string
+	Impersonator            *Impersonator
 }
 
 func (i *DefaultInfo) GetUserId() uuid.UUID {
@@ -48,6 +58,10 @@ func (i *DefaultInfo) GetRoleOrganizationMapping() map[string]string {
 	return i.RoleOrganizationMapping
 }
 
+func (i *DefaultInfo) GetImpersonator() *Impersonator {
+	return i.Impersonator
+}
+
 // well-known user and group names
 const (
 	TksAdminRole  = "tks_admin"
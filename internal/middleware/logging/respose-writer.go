@@ -3,6 +3,7 @@ package logging
 import (
 	"bytes"
 	"net/http"
+	"strings"
 )
 
 type loggingResponseWriter struct {
@@ -22,10 +23,19 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 }
 
 func (lrw *loggingResponseWriter) Write(buf []byte) (int, error) {
-	lrw.body.Write(buf)
+	// stream 응답은 연결이 끊길 때까지 계속되므로 기록하지 않는다.
+	if !strings.HasPrefix(lrw.Header().Get("Content-Type"), "text/event-stream") {
+		lrw.body.Write(buf)
+	}
 	return lrw.ResponseWriter.Write(buf)
 }
 
+func (lrw *loggingResponseWriter) Flush() {
+	if flusher, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 func (lrw *loggingResponseWriter) GetBody() *bytes.Buffer {
 	return &lrw.body
 }
@@ -35,8 +35,9 @@ func (m *Middleware) Handle(endpoint internalApi.Endpoint, handle http.Handler)
 	preHandler := m.authorizer.WithAuthorization(handle)
 	// TODO: this is a temporary solution. check if this is the right place to put audit middleware
 	preHandler = m.audit.WithAudit(endpoint, preHandler)
-	preHandler = m.requestRecoder.WithRequestRecoder(endpoint, preHandler)
 	preHandler = m.authenticator.WithAuthentication(preHandler)
+	// authenticator 가 endpoint 에 따라 토큰 검증 방식을 정하므로 endpoint 를 먼저 기록한다.
+	preHandler = m.requestRecoder.WithRequestRecoder(endpoint, preHandler)
 
 	// post-handler
 	// append post-handler below
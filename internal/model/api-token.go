@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScimEndpointGroup is the endpoint group of the SCIM provisioning endpoints.
+const ScimEndpointGroup = "Scim"
+
+// ApiToken is a long-lived token of a service account or a user.
+// Only the hash of the token is stored.
+type ApiToken struct {
+	gorm.Model
+
+	ID               uuid.UUID    `gorm:"primarykey;type:uuid"`
+	OrganizationId   string       `gorm:"index"`
+	Organization     Organization `gorm:"foreignKey:OrganizationId"`
+	Name             string
+	TokenPrefix      string
+	TokenHash        string          `gorm:"uniqueIndex"`
+	ServiceAccountId *uuid.UUID      `gorm:"type:uuid;index"`
+	ServiceAccount   *ServiceAccount `gorm:"foreignKey:ServiceAccountId"`
+	UserId           *uuid.UUID      `gorm:"type:uuid;index"`
+	User             *User           `gorm:"foreignKey:UserId"`
+	EndpointGroups   []string        `gorm:"serializer:json"`
+	ExpiredAt        *time.Time
+	LastUsedAt       *time.Time
+	RevokedAt        *time.Time
+	CreatorId        *uuid.UUID `gorm:"type:uuid"`
+	Creator          *User      `gorm:"foreignKey:CreatorId"`
+}
+
+func (m *ApiToken) IsServiceAccountToken() bool {
+	return m.ServiceAccountId != nil
+}
+
+func (m *ApiToken) IsUsable(at time.Time) bool {
+	if m.RevokedAt != nil {
+		return false
+	}
+	return m.ExpiredAt == nil || at.Before(*m.ExpiredAt)
+}
+
+// AllowsGroup reports whether the token can call the endpoints of the group. The empty groups allow every endpoint.
+func (m *ApiToken) AllowsGroup(group string) bool {
+	if len(m.EndpointGroups) == 0 {
+		return true
+	}
+	for _, g := range m.EndpointGroups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// IsProvisioningToken reports whether the token is a service account token allowed only for the SCIM provisioning endpoints.
+func (m *ApiToken) IsProvisioningToken() bool {
+	return m.IsServiceAccountToken() && len(m.EndpointGroups) == 1 && m.EndpointGroups[0] == ScimEndpointGroup
+}
@@ -1,23 +1,153 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const (
+	AuditResultSuccess = "SUCCESS"
+	AuditResultFailure = "FAILURE"
+)
+
 // Models
+// Audit is chained per organization by the hash of the previous audit. Audits created before the chaining have no sequence.
 type Audit struct {
 	gorm.Model
 
 	ID               uuid.UUID `gorm:"primarykey"`
-	OrganizationId   string
+	OrganizationId   string    `gorm:"uniqueIndex:idx_audit_chain,where:sequence > 0"`
+	Sequence         int64     `gorm:"uniqueIndex:idx_audit_chain,where:sequence > 0"`
+	PrevHash         string
+	Hash             string
 	OrganizationName string
 	Group            string
 	Message          string
+	MessageEn        string
 	Description      string
 	ClientIP         string
 	UserId           *uuid.UUID `gorm:"type:uuid"`
 	UserAccountId    string
 	UserName         string
 	UserRoles        string
+	// 관리자 대리 접속으로 호출한 경우 실제 호출한 관리자
+	ImpersonatorId             *uuid.UUID `gorm:"type:uuid"`
+	ImpersonatorAccountId      string
+	ImpersonatorOrganizationId string
+
+	Endpoint     string
+	Method       string
+	Path         string
+	ResourceType string `gorm:"index"`
+	ResourceId   string `gorm:"index"`
+	StatusCode   int
+	Result       string
+	RequestId    string
+	// 민감한 값은 가린 채로 변경 전후의 리소스를 필드 단위로 비교한 결과
+	Changes []AuditChange `gorm:"serializer:json"`
+}
+
+// ComputeHash returns the hash of the content and the previous hash. CreatedAt is hashed in microseconds as stored in the database.
+func (m Audit) ComputeHash() string {
+	content, _ := json.Marshal(struct {
+		ID                         uuid.UUID
+		OrganizationId             string
+		OrganizationName           string
+		Sequence                   int64
+		PrevHash                   string
+		CreatedAt                  int64
+		Group                      string
+		Message                    string
+		MessageEn                  string
+		Description                string
+		ClientIP                   string
+		UserId                     *uuid.UUID
+		UserAccountId              string
+		UserName                   string
+		UserRoles                  string
+		ImpersonatorId             *uuid.UUID
+		ImpersonatorAccountId      string
+		ImpersonatorOrganizationId string
+		Endpoint                   string
+		Method                     string
+		Path                       string
+		ResourceType               string
+		ResourceId                 string
+		StatusCode                 int
+		Result                     string
+		RequestId                  string
+		Changes                    []AuditChange
+	}{
+		m.ID, m.OrganizationId, m.OrganizationName, m.Sequence, m.PrevHash, m.CreatedAt.UnixMicro(), m.Group, m.Message, m.MessageEn, m.Description, m.ClientIP,
+		m.UserId, m.UserAccountId, m.UserName, m.UserRoles, m.ImpersonatorId, m.ImpersonatorAccountId, m.ImpersonatorOrganizationId,
+		m.Endpoint, m.Method, m.Path, m.ResourceType, m.ResourceId, m.StatusCode, m.Result, m.RequestId, m.Changes,
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditCheckpoint is the signed hash of the last audit of the organization at the time.
+// A chain rewritten without the signing secret does not match the checkpoints.
+// The retention checkpoint is the last audit archived and purged, where the chain of the remaining audits starts.
+type AuditCheckpoint struct {
+	ID             uuid.UUID `gorm:"primarykey;type:uuid"`
+	OrganizationId string    `gorm:"index"`
+	Sequence       int64
+	Hash           string
+	Retention      bool
+	Signature      string
+	CreatedAt      time.Time
+}
+
+// SigningContent returns the content signed by the checkpoint.
+func (m AuditCheckpoint) SigningContent() []byte {
+	fields := []interface{}{m.ID, m.OrganizationId, m.Sequence, m.Hash, m.CreatedAt.UnixMicro()}
+	// 주기적인 체크포인트의 서명은 그대로 두고, 보존 기간 정리의 체크포인트만 구분해서 서명한다.
+	if m.Retention {
+		fields = append(fields, "retention")
+	}
+	content, _ := json.Marshal(fields)
+	return content
+}
+
+// AuditChainVerification is the result of walking the audit chain of the organization.
+type AuditChainVerification struct {
+	OrganizationId  string
+	Verified        bool
+	CheckedCount    int64
+	FirstSequence   int64
+	LastSequence    int64
+	LastHash        string
+	CheckpointCount int64
+	BrokenAuditId   *uuid.UUID
+	BrokenSequence  int64
+	BrokenReason    string
+	VerifiedAt      time.Time
+	// 보존 기간이 지나 정리된 마지막 감사 로그의 sequence
+	ArchivedSequence int64
+}
+
+// AuditForwardCursor is the position of the audits sent to the SIEM. NextAttemptAt is the lease of the replica sending,
+// or the backoff after the failure.
+// The position is the last sequence sent per organization. The chain of an organization is committed in the order of the sequence,
+// so an audit committed late is never skipped.
+type AuditForwardCursor struct {
+	Name          string           `gorm:"primarykey"`
+	Sequences     map[string]int64 `gorm:"serializer:json"`
+	NextAttemptAt time.Time
+	Attempts      int
+	LastError     string
+	UpdatedAt     time.Time
+}
+
+// AuditChange is the change of a field of the resource. Nested fields are joined with dot.
+type AuditChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
 }
@@ -6,6 +6,11 @@ import (
 	"time"
 )
 
+const (
+	LoginFailureKindAccount  = "ACCOUNT"
+	LoginFailureKindClientIp = "CLIENT_IP"
+)
+
 // Models
 type ExpiredTokenTime struct {
 	gorm.Model
@@ -15,9 +20,44 @@ type ExpiredTokenTime struct {
 	ExpiredTime    time.Time
 }
 
+// RevokedSession rejects the access tokens of a signed out session until they expire.
+type RevokedSession struct {
+	SessionId      string    `gorm:"primarykey"`
+	OrganizationId string    `gorm:"not null"`
+	ExpiredAt      time.Time `gorm:"index"`
+	CreatedAt      time.Time
+}
+
 type CacheEmailCode struct {
 	gorm.Model
 
-	UserId uuid.UUID `gorm:"not null"`
-	Code   string    `gorm:"type:varchar(6);not null"`
+	UserId      uuid.UUID `gorm:"not null"`
+	Code        string    `gorm:"type:varchar(6);not null"`
+	FailedCount int       `gorm:"not null;default:0"`
+}
+
+// LoginFailure counts the consecutive authentication failures of an account or a client ip.
+type LoginFailure struct {
+	gorm.Model
+
+	Kind           string `gorm:"index:idx_login_failure_subject,unique;not null"`
+	OrganizationId string `gorm:"index:idx_login_failure_subject,unique;not null"`
+	Subject        string `gorm:"index:idx_login_failure_subject,unique;not null"`
+	FailedCount    int
+	LastFailedAt   time.Time
+	LockedUntil    *time.Time
+}
+
+func (m LoginFailure) IsLocked(now time.Time) bool {
+	return m.LockedUntil != nil && now.Before(*m.LockedUntil)
+}
+
+// Session is an active keycloak session of a user.
+type Session struct {
+	ID             string
+	UserId         string
+	IpAddress      string
+	Clients        []string
+	StartedAt      time.Time
+	LastAccessedAt time.Time
 }
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EscalationPolicy notifies the tiers in order while a critical systemNotification of the rule is not taken.
+// After the last tier, the tiers are repeated RepeatCount more times.
+type EscalationPolicy struct {
+	gorm.Model
+
+	ID             uuid.UUID `gorm:"primarykey"`
+	OrganizationId string
+	Organization   Organization `gorm:"foreignKey:OrganizationId"`
+	Name           string
+	Description    string
+	RepeatCount    int
+	Tiers          []EscalationTier `gorm:"foreignKey:EscalationPolicyId"`
+	CreatorId      *uuid.UUID       `gorm:"type:uuid"`
+	Creator        *User            `gorm:"foreignKey:CreatorId"`
+	UpdatorId      *uuid.UUID       `gorm:"type:uuid"`
+	Updator        *User            `gorm:"foreignKey:UpdatorId"`
+}
+
+// EscalationTier is notified DelayMinutes after the previous tier (or the systemNotification itself for the first tier).
+type EscalationTier struct {
+	gorm.Model
+
+	ID                     uuid.UUID `gorm:"primarykey"`
+	EscalationPolicyId     uuid.UUID `gorm:"index"`
+	Level                  int
+	DelayMinutes           int
+	TargetUsers            []User                `gorm:"many2many:escalation_tier_users;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT"`
+	TargetUserIds          []string              `gorm:"-:all"`
+	OnCallScheduleId       *uuid.UUID            `gorm:"type:uuid"`
+	OnCallSchedule         *OnCallSchedule       `gorm:"foreignKey:OnCallScheduleId"`
+	NotificationChannels   []NotificationChannel `gorm:"many2many:escalation_tier_notification_channels;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT"`
+	NotificationChannelIds []string              `gorm:"-:all"`
+}
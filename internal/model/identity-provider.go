@@ -0,0 +1,139 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type IdentityProviderType string
+
+const (
+	IdentityProviderTypeLdap IdentityProviderType = "ldap"
+	IdentityProviderTypeOidc IdentityProviderType = "oidc"
+	IdentityProviderTypeSaml IdentityProviderType = "saml"
+)
+
+type IdentityProviderRoleMappingType string
+
+const (
+	IdentityProviderRoleMappingTypeGroup     IdentityProviderRoleMappingType = "group"
+	IdentityProviderRoleMappingTypeAttribute IdentityProviderRoleMappingType = "attribute"
+)
+
+// 외부 디렉터리의 그룹은 keycloak 사용자의 이 속성으로 가져온다.
+const IdentityProviderGroupsAttribute = "tks_idp_groups"
+
+// IdentityProviderConfigGroupsAttribute is the config key of the group claim (OIDC), attribute (SAML) or member attribute (LDAP) of the directory.
+const IdentityProviderConfigGroupsAttribute = "groupsAttribute"
+
+// IdentityProvider is the corporate directory federated to the realm of the organization.
+// LDAP is registered as the user federation of the realm, and OIDC and SAML as the identity provider (broker).
+// Secrets such as the client secret or the bind credential are kept only in keycloak.
+type IdentityProvider struct {
+	gorm.Model
+
+	ID             uuid.UUID    `gorm:"primarykey;type:uuid"`
+	OrganizationId string       `gorm:"index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationId"`
+	Alias          string
+	Type           IdentityProviderType
+	DisplayName    string
+	Enabled        bool
+	// LDAP 은 user federation component 의 id, OIDC 와 SAML 은 identity provider 의 internal id
+	KeycloakId    string
+	Config        map[string]string             `gorm:"serializer:json"`
+	DefaultRoleId *string                       `gorm:"type:text"`
+	DefaultRole   *Role                         `gorm:"foreignKey:DefaultRoleId"`
+	RoleMappings  []IdentityProviderRoleMapping `gorm:"foreignKey:IdentityProviderId"`
+	CreatorId     *uuid.UUID                    `gorm:"type:uuid"`
+	Creator       *User                         `gorm:"foreignKey:CreatorId"`
+}
+
+func (m *IdentityProvider) BeforeDelete(db *gorm.DB) (err error) {
+	return db.Where("identity_provider_id = ?", m.ID).Delete(&IdentityProviderRoleMapping{}).Error
+}
+
+// GroupsAttribute returns the name of the attribute that has the groups of the user in the directory.
+func (m IdentityProvider) GroupsAttribute() string {
+	if name := m.Config[IdentityProviderConfigGroupsAttribute]; name != "" {
+		return name
+	}
+	if m.Type == IdentityProviderTypeLdap {
+		return "memberOf"
+	}
+	return "groups"
+}
+
+// AttributeNames returns the attributes of the directory used by the role mappings.
+func (m IdentityProvider) AttributeNames() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, mapping := range m.RoleMappings {
+		if mapping.Type != IdentityProviderRoleMappingTypeAttribute {
+			continue
+		}
+		if _, ok := seen[mapping.AttributeName]; ok {
+			continue
+		}
+		seen[mapping.AttributeName] = struct{}{}
+		names = append(names, mapping.AttributeName)
+	}
+	return names
+}
+
+// IdentityProviderRoleMapping maps the group or the attribute value of the federated user to the role.
+type IdentityProviderRoleMapping struct {
+	ID                 uuid.UUID `gorm:"primarykey;type:uuid"`
+	IdentityProviderId uuid.UUID `gorm:"type:uuid;index"`
+	Type               IdentityProviderRoleMappingType
+	AttributeName      string
+	Value              string
+	RoleId             string
+	Role               Role `gorm:"foreignKey:RoleId"`
+}
+
+// Matches reports whether the keycloak attributes of the user has the value of the mapping.
+// The group from LDAP is the DN, so its CN is also compared.
+func (m IdentityProviderRoleMapping) Matches(attributes map[string][]string) bool {
+	name := m.AttributeName
+	if m.Type == IdentityProviderRoleMappingTypeGroup {
+		name = IdentityProviderGroupsAttribute
+	}
+	for _, value := range attributes[name] {
+		if strings.EqualFold(value, m.Value) {
+			return true
+		}
+		if m.Type == IdentityProviderRoleMappingTypeGroup && strings.EqualFold(groupCommonName(value), m.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func groupCommonName(dn string) string {
+	rdn, _, _ := strings.Cut(dn, ",")
+	key, value, ok := strings.Cut(rdn, "=")
+	if !ok || !strings.EqualFold(strings.TrimSpace(key), "cn") {
+		return dn
+	}
+	return strings.TrimSpace(value)
+}
+
+// ResolveRoles returns the roles of the federated user. The default role is used if no mapping matches.
+func (m IdentityProvider) ResolveRoles(attributes map[string][]string) []Role {
+	var roles []Role
+	seen := make(map[string]struct{})
+	for _, mapping := range m.RoleMappings {
+		if _, ok := seen[mapping.RoleId]; ok || !mapping.Matches(attributes) {
+			continue
+		}
+		seen[mapping.RoleId] = struct{}{}
+		roles = append(roles, mapping.Role)
+	}
+	if len(roles) == 0 && m.DefaultRole != nil {
+		roles = append(roles, *m.DefaultRole)
+	}
+	return roles
+}
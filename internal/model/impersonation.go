@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ImpersonationStatus string
+
+const (
+	ImpersonationStatusActive  ImpersonationStatus = "ACTIVE"
+	ImpersonationStatusStopped ImpersonationStatus = "STOPPED"
+	ImpersonationStatusExpired ImpersonationStatus = "EXPIRED"
+)
+
+// Impersonation is the support session of the master admin acting as the user of an organization.
+// The token is not stored. It is rejected once the impersonation is stopped or expired.
+type Impersonation struct {
+	gorm.Model
+
+	ID             uuid.UUID `gorm:"primarykey;type:uuid"`
+	OrganizationId string    `gorm:"index"`
+	UserId         uuid.UUID `gorm:"type:uuid"`
+	User           *User     `gorm:"foreignKey:UserId"`
+	ImpersonatorId uuid.UUID `gorm:"type:uuid;index"`
+	Impersonator   *User     `gorm:"foreignKey:ImpersonatorId"`
+	Reason         string
+	ExpiredAt      time.Time
+	StoppedAt      *time.Time
+	StopperId      *uuid.UUID `gorm:"type:uuid"`
+}
+
+// CurrentStatus returns the status of the impersonation at the time.
+func (m Impersonation) CurrentStatus(at time.Time) ImpersonationStatus {
+	if m.StoppedAt != nil {
+		return ImpersonationStatusStopped
+	}
+	if !at.Before(m.ExpiredAt) {
+		return ImpersonationStatusExpired
+	}
+	return ImpersonationStatusActive
+}
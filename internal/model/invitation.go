@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "PENDING"
+	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
+	InvitationStatusRevoked  InvitationStatus = "REVOKED"
+	// 만료는 저장하지 않고 ExpiredAt 으로 판단한다.
+	InvitationStatusExpired InvitationStatus = "EXPIRED"
+)
+
+// Invitation invites a user of the email to the organization with the roles and the project memberships assigned in advance.
+// The invitation of the user already provisioned, e.g. by SCIM, has UserId before the acceptance and only sets the password.
+// Only the hash of the token in the invitation link is stored.
+type Invitation struct {
+	gorm.Model
+
+	ID             uuid.UUID    `gorm:"primarykey;type:uuid"`
+	OrganizationId string       `gorm:"index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationId"`
+	Email          string
+	Name           string
+	Department     string
+	Roles          []Role              `gorm:"many2many:invitation_roles"`
+	Projects       []InvitationProject `gorm:"foreignKey:InvitationId"`
+	TokenHash      string              `gorm:"uniqueIndex"`
+	Status         InvitationStatus
+	ExpiredAt      time.Time
+	SentCount      int
+	LastSentAt     time.Time
+	AcceptedAt     *time.Time
+	UserId         *uuid.UUID `gorm:"type:uuid"`
+	User           *User      `gorm:"foreignKey:UserId"`
+	CreatorId      *uuid.UUID `gorm:"type:uuid"`
+	Creator        *User      `gorm:"foreignKey:CreatorId"`
+}
+
+// CurrentStatus returns the status of the invitation at the time. The pending invitation past ExpiredAt is expired.
+func (m Invitation) CurrentStatus(at time.Time) InvitationStatus {
+	if m.Status == InvitationStatusPending && !at.Before(m.ExpiredAt) {
+		return InvitationStatusExpired
+	}
+	return m.Status
+}
+
+// InvitationProject is the project membership given to the invitee on acceptance.
+type InvitationProject struct {
+	ID            uuid.UUID    `gorm:"primarykey;type:uuid"`
+	InvitationId  uuid.UUID    `gorm:"type:uuid;index"`
+	ProjectId     string       `gorm:"not null"`
+	Project       *Project     `gorm:"foreignKey:ProjectId"`
+	ProjectRoleId string       `gorm:"not null"`
+	ProjectRole   *ProjectRole `gorm:"foreignKey:ProjectRoleId"`
+}
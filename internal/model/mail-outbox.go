@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"gorm.io/gorm"
+)
+
+// 비밀번호, 인증 코드, 초대 링크처럼 계정 정보를 담은 메일의 종류
+var credentialMailCategories = []string{
+	domain.MAIL_CATEGORY_VERIFY_IDENTITY,
+	domain.MAIL_CATEGORY_TEMPORARY_PASSWORD,
+	domain.MAIL_CATEGORY_GENERATING_ORGANIZATION,
+	domain.MAIL_CATEGORY_INVITATION,
+}
+
+// MailOutbox is an outbound mail to a single recipient. It is sent by the background worker and retried until it is dead.
+// The body of the credential mail is cleared once it is sent or dead, and the mail can not be resent.
+type MailOutbox struct {
+	gorm.Model
+
+	ID             uuid.UUID `gorm:"primarykey"`
+	OrganizationId string    `gorm:"index"`
+	Category       string
+	From           string
+	To             string
+	Subject        string
+	Body           string                  `json:"-"`
+	Status         domain.MailOutboxStatus `gorm:"index"`
+	Attempts       int
+	NextAttemptAt  time.Time `gorm:"index"`
+	LastError      string
+	SentAt         *time.Time
+}
+
+// HasCredential reports whether the mail carries a credential such as a password, a code or an invitation link.
+func (m MailOutbox) HasCredential() bool {
+	return helper.Contains(credentialMailCategories, m.Category)
+}
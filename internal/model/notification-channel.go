@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"gorm.io/gorm"
+)
+
+// NotificationChannel is an external messenger (slack, mattermost, teams or generic webhook) of an organization.
+// Template is used only by the generic webhook to render the JSON body.
+type NotificationChannel struct {
+	gorm.Model
+
+	ID             uuid.UUID `gorm:"primarykey"`
+	OrganizationId string
+	Organization   Organization `gorm:"foreignKey:OrganizationId"`
+	Name           string
+	Description    string
+	Type           string
+	Url            string
+	Template       string
+	Enabled        bool
+	CreatorId      *uuid.UUID `gorm:"type:uuid"`
+	Creator        *User      `gorm:"foreignKey:CreatorId"`
+	UpdatorId      *uuid.UUID `gorm:"type:uuid"`
+	Updator        *User      `gorm:"foreignKey:UpdatorId"`
+}
+
+// NotificationDelivery records every delivery attempt of a systemNotification to a channel (or email).
+// The delivery to a channel is queued as PENDING with the message, and sent by the background worker.
+type NotificationDelivery struct {
+	gorm.Model
+
+	ID                       uuid.UUID `gorm:"primarykey"`
+	OrganizationId           string    `gorm:"index"`
+	SystemNotificationId     uuid.UUID `gorm:"index"`
+	SystemNotificationRuleId *uuid.UUID
+	NotificationChannelId    *uuid.UUID
+	EscalationLevel          int
+	ChannelType              string
+	Target                   string
+	Status                   domain.NotificationDeliveryStatus
+	StatusCode               int
+	ErrorMessage             string
+	// 발송할 메시지(JSON). 발송을 마치면 지운다.
+	Message       string `json:"-"`
+	Attempts      int
+	NextAttemptAt *time.Time `gorm:"index"`
+}
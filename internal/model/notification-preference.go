@@ -0,0 +1,107 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"gorm.io/gorm"
+)
+
+// NotificationPreference is the notification setting of a user. A user without the preference receives every notification.
+type NotificationPreference struct {
+	UserId              uuid.UUID `gorm:"primarykey;type:uuid"`
+	Channels            []string  `gorm:"serializer:json"`
+	MinimumSeverity     string
+	QuietHoursStart     string
+	QuietHoursEnd       string
+	Timezone            string
+	UnsubscribedRules   []SystemNotificationRule `gorm:"many2many:notification_preference_unsubscribed_rules;joinForeignKey:UserId;joinReferences:SystemNotificationRuleId"`
+	UnsubscribedRuleIds []string                 `gorm:"-:all"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func NewNotificationPreference(userId uuid.UUID) NotificationPreference {
+	return NotificationPreference{
+		UserId:            userId,
+		Channels:          []string{domain.NOTIFICATION_PREFERENCE_CHANNEL_EMAIL},
+		UnsubscribedRules: []SystemNotificationRule{},
+	}
+}
+
+func (m *NotificationPreference) BeforeDelete(db *gorm.DB) (err error) {
+	return db.Table("notification_preference_unsubscribed_rules").Where("user_id = ?", m.UserId).Delete(nil).Error
+}
+
+// Accepts reports whether the notification of the rule and severity can be sent to the user through the channel at the time.
+// critical 알림은 quiet hours 에도 발송한다.
+func (m *NotificationPreference) Accepts(channel string, ruleId uuid.UUID, severity string, at time.Time) bool {
+	enabled := false
+	for _, c := range m.Channels {
+		if c == channel {
+			enabled = true
+			break
+		}
+	}
+	if !enabled {
+		return false
+	}
+
+	for _, rule := range m.UnsubscribedRules {
+		if rule.ID == ruleId {
+			return false
+		}
+	}
+
+	if m.MinimumSeverity != "" && severityRank(severity) < severityRank(m.MinimumSeverity) {
+		return false
+	}
+
+	if !strings.EqualFold(severity, "critical") && m.inQuietHours(at) {
+		return false
+	}
+	return true
+}
+
+func (m *NotificationPreference) inQuietHours(at time.Time) bool {
+	if m.QuietHoursStart == "" || m.QuietHoursEnd == "" {
+		return false
+	}
+	start, err := time.Parse("15:04", m.QuietHoursStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", m.QuietHoursEnd)
+	if err != nil {
+		return false
+	}
+
+	if m.Timezone != "" {
+		if loc, err := time.LoadLocation(m.Timezone); err == nil {
+			at = at.In(loc)
+		}
+	}
+	now := at.Hour()*60 + at.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	// 22:00 ~ 07:00 과 같이 자정을 넘기는 경우
+	if from > to {
+		return now >= from || now < to
+	}
+	return now >= from && now < to
+}
+
+func severityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 3
+	case "warning":
+		return 2
+	case "info":
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OnCallSchedule hands over the on-call duty to the next member every RotationDays from StartAt.
+type OnCallSchedule struct {
+	gorm.Model
+
+	ID             uuid.UUID `gorm:"primarykey"`
+	OrganizationId string
+	Organization   Organization `gorm:"foreignKey:OrganizationId"`
+	Name           string
+	Description    string
+	StartAt        time.Time
+	RotationDays   int                    `gorm:"default:7"`
+	Members        []OnCallScheduleMember `gorm:"foreignKey:OnCallScheduleId"`
+	MemberIds      []string               `gorm:"-:all"`
+	CreatorId      *uuid.UUID             `gorm:"type:uuid"`
+	Creator        *User                  `gorm:"foreignKey:CreatorId"`
+	UpdatorId      *uuid.UUID             `gorm:"type:uuid"`
+	Updator        *User                  `gorm:"foreignKey:UpdatorId"`
+}
+
+type OnCallScheduleMember struct {
+	OnCallScheduleId uuid.UUID `gorm:"primarykey"`
+	Sequence         int       `gorm:"primarykey;autoIncrement:false"`
+	UserId           uuid.UUID `gorm:"type:uuid"`
+	User             User      `gorm:"foreignKey:UserId"`
+}
+
+// OnCallAt returns the member on duty at the time. Members must be sorted by Sequence.
+func (m *OnCallSchedule) OnCallAt(at time.Time) (User, bool) {
+	if len(m.Members) == 0 || m.RotationDays <= 0 || at.Before(m.StartAt) {
+		return User{}, false
+	}
+	rotation := time.Duration(m.RotationDays) * 24 * time.Hour
+	index := int(at.Sub(m.StartAt)/rotation) % len(m.Members)
+	return m.Members[index].User, true
+}
@@ -160,6 +160,15 @@ func newDashboard() *Permission {
 							api.GetChartDashboard,
 							api.GetStacksDashboard,
 							api.GetResourcesDashboard,
+							api.GetDashboard,
+							api.GetPolicyStatusDashboard,
+							api.GetPolicyUpdateDashboard,
+							api.GetPolicyEnforcementDashboard,
+							api.GetPolicyViolationDashboard,
+							api.GetPolicyViolationLogDashboard,
+							api.GetPolicyStatisticsDashboard,
+							api.GetWorkloadDashboard,
+							api.GetPolicyViolationTop5Dashboard,
 						),
 					},
 					{
@@ -167,6 +176,10 @@ func newDashboard() *Permission {
 						Name:      "수정",
 						Key:       OperationUpdate,
 						IsAllowed: helper.BoolP(false),
+						Endpoints: endpointObjects(
+							api.CreateDashboard,
+							api.UpdateDashboard,
+						),
 					},
 				},
 			},
@@ -209,6 +222,9 @@ func newStack() *Permission {
 							api.GetBootstrapKubeconfig,
 							api.GetNodes,
 
+							// StackTemplate
+							api.GetOrganizationCloudServices,
+
 							// AppGroup
 							api.GetAppgroups,
 							api.GetAppgroup,
@@ -222,6 +238,7 @@ func newStack() *Permission {
 						IsAllowed: helper.BoolP(false),
 						Endpoints: endpointObjects(
 							api.CreateStack,
+							api.ImportStack,
 							api.InstallStack,
 							api.CreateAppgroup,
 
@@ -243,6 +260,9 @@ func newStack() *Permission {
 						IsAllowed: helper.BoolP(false),
 						Endpoints: endpointObjects(
 							api.UpdateStack,
+
+							// Cluster
+							api.ResumeCluster,
 						),
 					},
 					{
@@ -325,6 +345,7 @@ func newPolicy() *Permission {
 							api.Admin_GetPolicyTemplateVersion,
 							api.Admin_ExistsPolicyTemplateName,
 							api.Admin_ExistsPolicyTemplateKind,
+							api.Admin_ExtractParameters,
 
 							// StackPolicyStatus
 							api.ListStackPolicyStatus,
@@ -335,6 +356,10 @@ func newPolicy() *Permission {
 							api.ListPolicy,
 							api.GetPolicy,
 							api.ExistsPolicyName,
+							api.ExistsPolicyResourceName,
+							api.GetPolicyEdit,
+							api.GetPolicyStatistics,
+							api.StackPolicyStatistics,
 
 							// OrganizationPolicyTemplate
 							api.ListPolicyTemplate,
@@ -345,6 +370,7 @@ func newPolicy() *Permission {
 							api.GetPolicyTemplateVersion,
 							api.ExistsPolicyTemplateKind,
 							api.ExistsPolicyTemplateName,
+							api.ExtractParameters,
 
 							// PolicyTemplateExample
 							api.ListPolicyTemplateExample,
@@ -364,6 +390,7 @@ func newPolicy() *Permission {
 							// Policy
 							api.SetMandatoryPolicies,
 							api.CreatePolicy,
+							api.AddPoliciesForStack,
 
 							// OrganizationPolicyTemplate
 							api.CreatePolicyTemplate,
@@ -405,6 +432,7 @@ func newPolicy() *Permission {
 
 							// Policy
 							api.DeletePolicy,
+							api.DeletePoliciesForStack,
 
 							// OrganizationPolicyTemplate
 							api.DeletePolicyTemplate,
@@ -441,6 +469,7 @@ func newNotification() *Permission {
 						Endpoints: endpointObjects(
 							api.GetSystemNotification,
 							api.GetSystemNotifications,
+							api.CreateNotificationStreamTicket,
 						),
 					},
 					{
@@ -473,6 +502,10 @@ func newNotification() *Permission {
 						Key:       OperationRead,
 						IsAllowed: helper.BoolP(false),
 						Children:  []*Permission{},
+						Endpoints: endpointObjects(
+							api.GetPolicyNotifications,
+							api.GetPolicyNotification,
+						),
 					},
 					{
 						ID:        uuid.New(),
@@ -629,6 +662,7 @@ func newProject() *Permission {
 							api.GetProjectNamespaces,
 							api.GetProjectNamespace,
 							api.GetProjectNamespaceK8sResources,
+							api.GetProjectNamespaceKubeconfig,
 						),
 					},
 					{
@@ -679,6 +713,7 @@ func newProject() *Permission {
 							api.IsAppServeAppNameExist,
 							api.GetAppServeAppTaskDetail,
 							api.GetAppServeAppTasksByAppId,
+							api.GetAppServeAppLog,
 						),
 					},
 					{
@@ -833,6 +868,12 @@ func newConfiguration() *Permission {
 						Endpoints: endpointObjects(
 							api.ListUser,
 							api.GetUser,
+							api.GetUserSessions,
+							api.GetUserImports,
+							api.GetUserImport,
+							api.ExportUsers,
+							api.GetInvitations,
+							api.GetInvitation,
 							api.CheckId,
 							api.CheckEmail,
 						),
@@ -846,6 +887,9 @@ func newConfiguration() *Permission {
 							api.CreateUser,
 							api.CheckId,
 							api.CheckEmail,
+							api.ImportUsers,
+							api.CreateInvitation,
+							api.ResendInvitation,
 						),
 					},
 					{
@@ -855,7 +899,12 @@ func newConfiguration() *Permission {
 						IsAllowed: helper.BoolP(false),
 						Endpoints: endpointObjects(
 							api.UpdateUser,
+							api.UpdateUsers,
 							api.ResetPassword,
+							api.UnlockUser,
+							api.ResetUserSecondFactor,
+							api.RevokeUserSession,
+							api.RevokeUserSessions,
 						),
 					},
 					{
@@ -865,6 +914,7 @@ func newConfiguration() *Permission {
 						IsAllowed: helper.BoolP(false),
 						Endpoints: endpointObjects(
 							api.DeleteUser,
+							api.RevokeInvitation,
 						),
 					},
 				},
@@ -884,6 +934,8 @@ func newConfiguration() *Permission {
 							api.GetTksRole,
 							api.GetPermissionsByRoleId,
 							api.GetPermissionTemplates,
+							api.GetUsersInRoleId,
+							api.IsRoleNameExisted,
 						),
 					},
 					{
@@ -903,6 +955,8 @@ func newConfiguration() *Permission {
 						Endpoints: endpointObjects(
 							api.UpdateTksRole,
 							api.UpdatePermissionsByRoleId,
+							api.AppendUsersToRole,
+							api.RemoveUsersFromRole,
 						),
 					},
 					{
@@ -929,6 +983,19 @@ func newConfiguration() *Permission {
 						Endpoints: endpointObjects(
 							api.GetSystemNotificationRules,
 							api.GetSystemNotificationRule,
+							api.CheckSystemNotificationRuleName,
+							api.GetOrganizationSystemNotificationTemplates,
+							api.GetOrganizationSystemNotificationTemplate,
+							api.GetSystemNotificationCredentials,
+							api.GetNotificationChannels,
+							api.GetNotificationChannel,
+							api.GetNotificationDeliveries,
+							api.GetEscalationPolicies,
+							api.GetEscalationPolicy,
+							api.GetOnCallSchedules,
+							api.GetOnCallSchedule,
+							api.GetSystemNotificationSilences,
+							api.GetSystemNotificationSilence,
 						),
 					},
 					{
@@ -938,6 +1005,12 @@ func newConfiguration() *Permission {
 						IsAllowed: helper.BoolP(false),
 						Endpoints: endpointObjects(
 							api.CreateSystemNotificationRule,
+							api.MakeDefaultSystemNotificationRules,
+							api.CreateSystemNotificationCredential,
+							api.CreateNotificationChannel,
+							api.CreateEscalationPolicy,
+							api.CreateOnCallSchedule,
+							api.CreateSystemNotificationSilence,
 						),
 					},
 					{
@@ -947,6 +1020,12 @@ func newConfiguration() *Permission {
 						IsAllowed: helper.BoolP(false),
 						Endpoints: endpointObjects(
 							api.UpdateSystemNotificationRule,
+							api.RotateSystemNotificationCredential,
+							api.UpdateNotificationChannel,
+							api.TestNotificationChannel,
+							api.UpdateEscalationPolicy,
+							api.UpdateOnCallSchedule,
+							api.UpdateSystemNotificationSilence,
 						),
 					},
 					{
@@ -956,6 +1035,11 @@ func newConfiguration() *Permission {
 						IsAllowed: helper.BoolP(false),
 						Endpoints: endpointObjects(
 							api.DeleteSystemNotificationRule,
+							api.DeleteSystemNotificationCredential,
+							api.DeleteNotificationChannel,
+							api.DeleteEscalationPolicy,
+							api.DeleteOnCallSchedule,
+							api.DeleteSystemNotificationSilence,
 						),
 					},
 				},
@@ -974,6 +1058,9 @@ func newCommon() *Permission {
 		Endpoints: endpointObjects(
 			// Auth
 			api.Login,
+			api.LoginSecondFactor,
+			api.LoginWithIdentityProvider,
+			api.GetLoginIdentityProviders,
 			api.Logout,
 			api.RefreshToken,
 			api.FindId,
@@ -992,12 +1079,30 @@ func newCommon() *Permission {
 			api.UnSetFavoriteProject,
 			api.UnSetFavoriteProjectNamespace,
 
+			// User
+			api.GetPermissionsByAccountId,
+
 			// MyProfile
 			api.GetMyProfile,
 			api.UpdateMyProfile,
 			api.UpdateMyPassword,
 			api.RenewPasswordExpiredDate,
 			api.DeleteMyProfile,
+			api.GetMyNotificationPreference,
+			api.UpdateMyNotificationPreference,
+			api.GetMyApiTokens,
+			api.CreateMyApiToken,
+			api.RevokeMyApiToken,
+			api.GetMySecondFactor,
+			api.CreateMySecondFactor,
+			api.VerifyMySecondFactor,
+			api.RegenerateMyRecoveryCodes,
+			api.DeleteMySecondFactor,
+			api.GetMySessions,
+			api.RevokeMySession,
+
+			// Organization
+			api.GetSecurityPolicy,
 
 			// StackTemplate
 			api.GetOrganizationStackTemplates,
@@ -1026,6 +1131,11 @@ func newAdmin() *Permission {
 			api.GetOrganizations,
 			api.UpdatePrimaryCluster,
 			api.CheckOrganizationName,
+			api.UpdateSecurityPolicy,
+			api.AddOrganizationStackTemplates,
+			api.RemoveOrganizationStackTemplates,
+			api.AddOrganizationSystemNotificationTemplates,
+			api.RemoveOrganizationSystemNotificationTemplates,
 
 			// User
 			api.ResetPassword,
@@ -1041,6 +1151,11 @@ func newAdmin() *Permission {
 			api.Admin_DeleteStackTemplate,
 			api.Admin_UpdateStackTemplateOrganizations,
 			api.Admin_CheckStackTemplateName,
+			api.Admin_GetStackTemplateTemplateIds,
+
+			// PolicyTemplate
+			api.Admin_AddPermittedPolicyTemplatesForOrganization,
+			api.Admin_DeletePermittedPolicyTemplatesForOrganization,
 
 			// Admin
 			api.Admin_GetUser,
@@ -1050,17 +1165,59 @@ func newAdmin() *Permission {
 			api.Admin_DeleteUser,
 			api.Admin_GetSystemNotificationTemplate,
 			api.Admin_CreateSystemNotificationTemplate,
+			api.Admin_DeleteSystemNotificationTemplate,
+			api.Admin_CheckSystemNotificationTemplateName,
 			api.Admin_ListUser,
 			api.Admin_GetTksRole,
 			api.Admin_GetProjects,
 			api.Admin_UpdateSystemNotificationTemplate,
 			api.Admin_ListTksRoles,
 			api.Admin_GetSystemNotificationTemplates,
+			api.Admin_GetMailOutboxes,
+			api.Admin_ResendMailOutbox,
+			api.Admin_CreateImpersonation,
+			api.Admin_GetImpersonations,
+			api.Admin_StopImpersonation,
+			api.Admin_GetRetentionPolicies,
+			api.Admin_UpdateRetentionPolicies,
+			api.Admin_GetRetentionStatus,
+
+			// ServiceAccount
+			api.CreateServiceAccount,
+			api.GetServiceAccounts,
+			api.GetServiceAccount,
+			api.UpdateServiceAccount,
+			api.DeleteServiceAccount,
+			api.GetServiceAccountTokens,
+			api.CreateServiceAccountToken,
+			api.RevokeServiceAccountToken,
+
+			// IdentityProvider
+			api.CreateIdentityProvider,
+			api.GetIdentityProviders,
+			api.GetIdentityProvider,
+			api.UpdateIdentityProvider,
+			api.DeleteIdentityProvider,
+
+			// Scim
+			api.ScimGetServiceProviderConfig,
+			api.ScimGetUsers,
+			api.ScimGetUser,
+			api.ScimCreateUser,
+			api.ScimReplaceUser,
+			api.ScimPatchUser,
+			api.ScimDeleteUser,
+			api.ScimGetGroups,
+			api.ScimGetGroup,
+			api.ScimReplaceGroup,
+			api.ScimPatchGroup,
 
 			// Audit
 			api.GetAudits,
 			api.GetAudit,
 			api.DeleteAudit,
+			api.VerifyAudits,
+			api.ExportAudits,
 
 			api.CreateSystemNotification,
 			api.DeleteSystemNotification,
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RetentionDataTypeAudit              = "AUDIT"
+	RetentionDataTypeSystemNotification = "SYSTEM_NOTIFICATION"
+	RetentionDataTypeAppServeAppTask    = "APP_SERVE_APP_TASK"
+	RetentionDataTypeCacheEmailCode     = "CACHE_EMAIL_CODE"
+
+	RetentionRunSuccess = "SUCCESS"
+	// 실행 시간이 다 되어 남은 데이터는 다음 실행에서 정리한다.
+	RetentionRunPartial = "PARTIAL"
+	RetentionRunFailure = "FAILURE"
+)
+
+var RetentionDataTypes = []string{
+	RetentionDataTypeAudit,
+	RetentionDataTypeSystemNotification,
+	RetentionDataTypeAppServeAppTask,
+	RetentionDataTypeCacheEmailCode,
+}
+
+// 감사 로그는 조직이 보존 기간을 정하기 전까지 정리하지 않는다.
+var defaultRetentionDays = map[string]int{
+	RetentionDataTypeAudit:              0,
+	RetentionDataTypeSystemNotification: 365,
+	RetentionDataTypeAppServeAppTask:    180,
+	RetentionDataTypeCacheEmailCode:     1,
+}
+
+// RetentionPolicy is the retention period of a data type in an organization. RetentionDays 0 means forever.
+// The expired rows are archived before the purge if Archive is set. An organization without the policy follows NewRetentionPolicy.
+type RetentionPolicy struct {
+	OrganizationId string `gorm:"primarykey"`
+	DataType       string `gorm:"primarykey"`
+	RetentionDays  int
+	Archive        bool
+	UpdatorId      *uuid.UUID `gorm:"type:uuid"`
+	Updator        User       `gorm:"foreignKey:UpdatorId"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewRetentionPolicy(organizationId string, dataType string) RetentionPolicy {
+	return RetentionPolicy{
+		OrganizationId: organizationId,
+		DataType:       dataType,
+		RetentionDays:  defaultRetentionDays[dataType],
+	}
+}
+
+// Cutoff returns the time before which the rows are expired.
+func (m RetentionPolicy) Cutoff(now time.Time) time.Time {
+	return now.Add(-time.Duration(m.RetentionDays) * 24 * time.Hour)
+}
+
+// RetentionJob is the schedule of the retention job. NextRunAt is also the lease of the replica running the job.
+type RetentionJob struct {
+	Name           string `gorm:"primarykey"`
+	NextRunAt      time.Time
+	LastStartedAt  *time.Time
+	LastFinishedAt *time.Time
+	UpdatedAt      time.Time
+}
+
+// RetentionRun is the result of the retention of a data type in an organization by a run of the job.
+type RetentionRun struct {
+	ID             uuid.UUID `gorm:"primarykey;type:uuid"`
+	JobStartedAt   time.Time `gorm:"index"`
+	OrganizationId string    `gorm:"index"`
+	DataType       string
+	RetentionDays  int
+	Cutoff         time.Time
+	ArchivedCount  int64
+	PurgedCount    int64
+	ArchiveKeys    []string `gorm:"serializer:json"`
+	Status         string
+	Error          string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+}
+
+// RetentionStatus is the schedule of the retention job and the results of its last run.
+type RetentionStatus struct {
+	Enabled        bool
+	ArchiveEnabled bool
+	Running        bool
+	NextRunAt      *time.Time
+	LastStartedAt  *time.Time
+	LastFinishedAt *time.Time
+	Runs           []RetentionRun
+}
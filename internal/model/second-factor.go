@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecondFactor is the TOTP of a user. It is used on login after the enrollment is verified.
+type SecondFactor struct {
+	UserId             uuid.UUID `gorm:"primarykey;type:uuid"`
+	EncryptedSecret    string
+	Enabled            bool
+	EnabledAt          *time.Time
+	LastUsedStep       int64
+	RecoveryCodeHashes []string `gorm:"serializer:json"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// SecondFactorChallenge is the login waiting for the second factor. It is consumed once when the code is verified.
+type SecondFactorChallenge struct {
+	TokenHash string    `gorm:"primarykey"`
+	UserId    uuid.UUID `gorm:"type:uuid;not null"`
+	ExpiredAt time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+// SecondFactorSession is the keycloak session which passed the second factor.
+// The tokens of the other sessions are rejected while the second factor of the user is enabled.
+type SecondFactorSession struct {
+	SessionId string    `gorm:"primarykey"`
+	UserId    uuid.UUID `gorm:"type:uuid;index;not null"`
+	ExpiredAt time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal"
+	"github.com/openinfradev/tks-api/internal/helper"
+)
+
+const (
+	defaultPasswordMinLength     = 8
+	defaultSessionTimeoutMinutes = 24 * 60
+)
+
+// SecurityPolicy is the password and session policy of an organization. An organization without the policy follows NewSecurityPolicy.
+type SecurityPolicy struct {
+	OrganizationId            string `gorm:"primarykey"`
+	PasswordExpiryDays        int
+	PasswordMinLength         int
+	PasswordRequireUppercase  bool
+	PasswordRequireLowercase  bool
+	PasswordRequireDigit      bool
+	PasswordRequireSpecial    bool
+	PasswordHistoryDepth      int
+	SessionIdleTimeoutMinutes int
+	SessionMaxLifespanMinutes int
+	RequireSecondFactor       bool
+	UpdatorId                 *uuid.UUID `gorm:"type:uuid"`
+	Updator                   User       `gorm:"foreignKey:UpdatorId"`
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
+}
+
+func NewSecurityPolicy(organizationId string) SecurityPolicy {
+	out := SecurityPolicy{
+		OrganizationId:            organizationId,
+		PasswordExpiryDays:        int(internal.PasswordExpiredDuration / (24 * time.Hour)),
+		PasswordMinLength:         defaultPasswordMinLength,
+		SessionIdleTimeoutMinutes: defaultSessionTimeoutMinutes,
+		SessionMaxLifespanMinutes: defaultSessionTimeoutMinutes,
+	}
+	return out
+}
+
+// IsPasswordExpired reports whether the password changed at passwordUpdatedAt is expired. PasswordExpiryDays 0 means never.
+func (m SecurityPolicy) IsPasswordExpired(passwordUpdatedAt time.Time) bool {
+	if m.PasswordExpiryDays <= 0 {
+		return false
+	}
+	return helper.IsDurationExpired(passwordUpdatedAt, time.Duration(m.PasswordExpiryDays)*24*time.Hour)
+}
+
+// IsUserPasswordExpired reports whether the password of the user is expired.
+// The password of the federated user is managed by the directory, and the master admin account never expires.
+func (m SecurityPolicy) IsUserPasswordExpired(user User) bool {
+	// TKS control plane 동작을 위해, master 조직의 admin 계정은 비밀번호가 만료되지 않는다.
+	if user.OrganizationId == "master" && user.AccountId == "admin" {
+		return false
+	}
+	return user.IdentityProviderId == nil && m.IsPasswordExpired(user.PasswordUpdatedAt)
+}
+
+// ValidatePassword checks the length and the character classes of the password. The password history is checked by keycloak.
+func (m SecurityPolicy) ValidatePassword(password string) error {
+	var violations []string
+	if len([]rune(password)) < m.PasswordMinLength {
+		violations = append(violations, fmt.Sprintf("at least %d characters", m.PasswordMinLength))
+	}
+
+	var upper, lower, digit, special bool
+	for _, c := range password {
+		switch {
+		case unicode.IsUpper(c):
+			upper = true
+		case unicode.IsLower(c):
+			lower = true
+		case unicode.IsDigit(c):
+			digit = true
+		default:
+			special = true
+		}
+	}
+	if m.PasswordRequireUppercase && !upper {
+		violations = append(violations, "an uppercase letter")
+	}
+	if m.PasswordRequireLowercase && !lower {
+		violations = append(violations, "a lowercase letter")
+	}
+	if m.PasswordRequireDigit && !digit {
+		violations = append(violations, "a digit")
+	}
+	if m.PasswordRequireSpecial && !special {
+		violations = append(violations, "a special character")
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("password requires %s", strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// GenerateTemporaryPassword makes a random password which satisfies the policy.
+func (m SecurityPolicy) GenerateTemporaryPassword() string {
+	length := m.PasswordMinLength
+	if length < defaultPasswordMinLength {
+		length = defaultPasswordMinLength
+	}
+	return helper.GenerateRandomPassword(length)
+}
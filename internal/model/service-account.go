@@ -0,0 +1,34 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceAccount is the principal of the automations in the organization.
+// It has roles like a user, and authenticates only with its api tokens.
+type ServiceAccount struct {
+	gorm.Model
+
+	ID             uuid.UUID    `gorm:"primarykey;type:uuid"`
+	OrganizationId string       `gorm:"index"`
+	Organization   Organization `gorm:"foreignKey:OrganizationId"`
+	Name           string
+	Description    string
+	Roles          []Role     `gorm:"many2many:service_account_roles;"`
+	CreatorId      *uuid.UUID `gorm:"type:uuid"`
+	Creator        *User      `gorm:"foreignKey:CreatorId"`
+}
+
+func (m *ServiceAccount) BeforeDelete(db *gorm.DB) (err error) {
+	err = db.Table("service_account_roles").Unscoped().Where("service_account_id = ?", m.ID).Delete(nil).Error
+	if err != nil {
+		return err
+	}
+	return db.Where("service_account_id = ?", m.ID).Delete(&ApiToken{}).Error
+}
+
+// AccountId is shown as the account of the requests made by the service account.
+func (m *ServiceAccount) AccountId() string {
+	return "serviceaccount:" + m.Name
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StreamTicket authorizes a connection of the notification stream once, so the access token is not put in the url.
+// Only the hash of the ticket is stored.
+type StreamTicket struct {
+	TicketHash     string    `gorm:"primarykey"`
+	OrganizationId string    `gorm:"not null"`
+	UserId         uuid.UUID `gorm:"type:uuid"`
+	ExpiredAt      time.Time `gorm:"index"`
+	CreatedAt      time.Time
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SystemNotificationCredential is a webhook credential issued to alertmanager of an organization (or a cluster of it).
+// The secret is kept to verify HMAC signatures. The previous secret stays valid until PreviousSecretExpiredAt for rotation.
+type SystemNotificationCredential struct {
+	gorm.Model
+
+	ID                      uuid.UUID `gorm:"primarykey"`
+	OrganizationId          string
+	Organization            Organization `gorm:"foreignKey:OrganizationId"`
+	ClusterId               string
+	Name                    string
+	Description             string
+	Secret                  string `json:"-"`
+	PreviousSecret          string `json:"-"`
+	PreviousSecretExpiredAt *time.Time
+	RotatedAt               *time.Time
+	LastUsedAt              *time.Time
+	CreatorId               *uuid.UUID `gorm:"type:uuid"`
+	Creator                 *User      `gorm:"foreignKey:CreatorId"`
+}
+
+// SystemNotificationCredentialNonce is a nonce used by a webhook request. It is kept until ExpiredAt to reject the replayed request on every replica.
+type SystemNotificationCredentialNonce struct {
+	CredentialId uuid.UUID `gorm:"primarykey;type:uuid"`
+	Nonce        string    `gorm:"primarykey"`
+	ExpiredAt    time.Time `gorm:"index"`
+}
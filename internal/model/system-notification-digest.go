@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SystemNotificationDigestItem holds a low-severity systemNotification until the digest mail of the rule is sent.
+type SystemNotificationDigestItem struct {
+	gorm.Model
+
+	ID                       uuid.UUID `gorm:"primarykey"`
+	OrganizationId           string
+	SystemNotificationRuleId uuid.UUID `gorm:"index"`
+	SystemNotificationId     uuid.UUID
+	SystemNotification       SystemNotification `gorm:"foreignKey:SystemNotificationId"`
+	DigestMode               string             `gorm:"index"`
+	SentAt                   *time.Time         `gorm:"index"`
+}
@@ -16,6 +16,7 @@ type SystemNotificationCondition struct {
 	Parameter                datatypes.JSON
 	Parameters               []domain.SystemNotificationParameter `gorm:"-:all"`
 	EnableEmail              bool
+	EmailDigestMode          string
 	EnablePortal             bool
 }
 
@@ -34,6 +35,10 @@ type SystemNotificationRule struct {
 	SystemNotificationCondition  SystemNotificationCondition `gorm:"foreignKey:SystemNotificationRuleId"`
 	TargetUsers                  []User                      `gorm:"many2many:system_notification_rule_users;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT"`
 	TargetUserIds                []string                    `gorm:"-:all"`
+	NotificationChannels         []NotificationChannel       `gorm:"many2many:system_notification_rule_notification_channels;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT"`
+	NotificationChannelIds       []string                    `gorm:"-:all"`
+	EscalationPolicyId           *uuid.UUID                  `gorm:"type:uuid"`
+	EscalationPolicy             *EscalationPolicy           `gorm:"foreignKey:EscalationPolicyId"`
 	MessageTitle                 string
 	MessageContent               string
 	MessageActionProposal        string
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"gorm.io/gorm"
+)
+
+// SystemNotificationSilence mutes the systemNotifications matched during the period.
+// The empty matchers match everything, so a silence without matchers mutes the whole organization.
+type SystemNotificationSilence struct {
+	gorm.Model
+
+	ID                       uuid.UUID    `gorm:"primarykey"`
+	OrganizationId           string       `gorm:"index"`
+	Organization             Organization `gorm:"foreignKey:OrganizationId"`
+	ClusterId                string
+	Node                     string
+	SystemNotificationRuleId *uuid.UUID              `gorm:"type:uuid"`
+	SystemNotificationRule   *SystemNotificationRule `gorm:"foreignKey:SystemNotificationRuleId"`
+	Severity                 string
+	StartsAt                 time.Time
+	EndsAt                   time.Time `gorm:"index"`
+	Comment                  string
+	CreatorId                *uuid.UUID `gorm:"type:uuid"`
+	Creator                  *User      `gorm:"foreignKey:CreatorId"`
+	UpdatorId                *uuid.UUID `gorm:"type:uuid"`
+	Updator                  *User      `gorm:"foreignKey:UpdatorId"`
+}
+
+func (m *SystemNotificationSilence) Matches(systemNotification SystemNotification) bool {
+	if m.ClusterId != "" && m.ClusterId != systemNotification.ClusterId.String() {
+		return false
+	}
+	if m.Node != "" && m.Node != systemNotification.Node {
+		return false
+	}
+	if m.SystemNotificationRuleId != nil &&
+		(systemNotification.SystemNotificationRuleId == nil || *m.SystemNotificationRuleId != *systemNotification.SystemNotificationRuleId) {
+		return false
+	}
+	if m.Severity != "" && !strings.EqualFold(m.Severity, systemNotification.Severity) {
+		return false
+	}
+	return true
+}
+
+// Mutes reports whether the silence is active at the time and matches the systemNotification.
+func (m *SystemNotificationSilence) Mutes(systemNotification SystemNotification, at time.Time) bool {
+	return m.StatusAt(at) == domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_ACTIVE && m.Matches(systemNotification)
+}
+
+func (m *SystemNotificationSilence) StatusAt(at time.Time) string {
+	if at.Before(m.StartsAt) {
+		return domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_PENDING
+	}
+	if at.Before(m.EndsAt) {
+		return domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_ACTIVE
+	}
+	return domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_EXPIRED
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/pkg/domain"
+)
+
+func TestSystemNotificationSilenceMutes(t *testing.T) {
+	now := time.Now()
+	ruleId := uuid.New()
+	otherRuleId := uuid.New()
+
+	systemNotification := SystemNotification{
+		ClusterId:                domain.ClusterId("c1"),
+		Node:                     "node-1",
+		SystemNotificationRuleId: &ruleId,
+		Severity:                 "critical",
+	}
+
+	tests := []struct {
+		name    string
+		silence SystemNotificationSilence
+		want    bool
+	}{
+		{"empty matchers mute everything", SystemNotificationSilence{}, true},
+		{"same cluster", SystemNotificationSilence{ClusterId: "c1"}, true},
+		{"other cluster", SystemNotificationSilence{ClusterId: "c2"}, false},
+		{"other node", SystemNotificationSilence{Node: "node-2"}, false},
+		{"same rule", SystemNotificationSilence{SystemNotificationRuleId: &ruleId}, true},
+		{"other rule", SystemNotificationSilence{SystemNotificationRuleId: &otherRuleId}, false},
+		{"severity is case insensitive", SystemNotificationSilence{Severity: "CRITICAL"}, true},
+		{"other severity", SystemNotificationSilence{Severity: "warning"}, false},
+		{"all matchers", SystemNotificationSilence{ClusterId: "c1", Node: "node-1", SystemNotificationRuleId: &ruleId, Severity: "critical"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.silence.StartsAt = now.Add(-time.Hour)
+			tt.silence.EndsAt = now.Add(time.Hour)
+			if got := tt.silence.Mutes(systemNotification, now); got != tt.want {
+				t.Errorf("Mutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSystemNotificationSilenceMutesOnlyWhileActive(t *testing.T) {
+	now := time.Now()
+	silence := SystemNotificationSilence{
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+	}
+
+	tests := []struct {
+		name   string
+		at     time.Time
+		status string
+		want   bool
+	}{
+		{"pending", now.Add(-2 * time.Hour), domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_PENDING, false},
+		{"active", now, domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_ACTIVE, true},
+		{"ends at is exclusive", now.Add(time.Hour), domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_EXPIRED, false},
+		{"expired", now.Add(2 * time.Hour), domain.SYSTEM_NOTIFICATION_SILENCE_STATUS_EXPIRED, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := silence.StatusAt(tt.at); got != tt.status {
+				t.Errorf("StatusAt() = %s, want %s", got, tt.status)
+			}
+			if got := silence.Mutes(SystemNotification{}, tt.at); got != tt.want {
+				t.Errorf("Mutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,8 +15,8 @@ type SystemNotification struct {
 
 	ID                        uuid.UUID `gorm:"primarykey"`
 	Name                      string
-	NotificationType          string `gorm:"default:SYSTEM_NOTIFICATION"`
-	OrganizationId            string
+	NotificationType          string       `gorm:"default:SYSTEM_NOTIFICATION"`
+	OrganizationId            string       `gorm:"index"`
 	Organization              Organization `gorm:"foreignKey:OrganizationId"`
 	ClusterId                 domain.ClusterId
 	Cluster                   Cluster `gorm:"foreignKey:ClusterId"`
@@ -40,6 +40,13 @@ type SystemNotification struct {
 	Readers                   []User                                `gorm:"many2many:system_notification_users;constraint:OnUpdate:RESTRICT,OnDelete:RESTRICT"`
 	SystemNotificationRuleId  *uuid.UUID
 	PolicyName                string
+	Fingerprint               string `gorm:"index"`
+	FireCount                 int    `gorm:"default:1"`
+	LastFiredAt               *time.Time
+	EscalationLevel           int
+	NextEscalationAt          *time.Time `gorm:"index"`
+	SilenceId                 *uuid.UUID `gorm:"type:uuid;index"`
+	Silenced                  bool       `gorm:"-:all"`
 }
 
 type SystemNotificationAction struct {
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserImportStatus string
+
+const (
+	UserImportStatusPending   UserImportStatus = "PENDING"
+	UserImportStatusRunning   UserImportStatus = "RUNNING"
+	UserImportStatusCompleted UserImportStatus = "COMPLETED"
+	// 검증에 실패한 행이 하나라도 있으면 사용자를 생성하지 않는다.
+	UserImportStatusRejected UserImportStatus = "REJECTED"
+)
+
+type UserImportRowStatus string
+
+const (
+	UserImportRowStatusPending UserImportRowStatus = "PENDING"
+	UserImportRowStatusInvalid UserImportRowStatus = "INVALID"
+	UserImportRowStatusCreated UserImportRowStatus = "CREATED"
+	UserImportRowStatusFailed  UserImportRowStatus = "FAILED"
+)
+
+// UserImportJob creates the users of the rows in the background. It is claimed by the worker with a lease like the mail outbox.
+type UserImportJob struct {
+	gorm.Model
+
+	ID             uuid.UUID        `gorm:"primarykey;type:uuid"`
+	OrganizationId string           `gorm:"index"`
+	Status         UserImportStatus `gorm:"index"`
+	Rows           []UserImportRow  `gorm:"foreignKey:JobId"`
+	TotalCount     int
+	CreatedCount   int
+	FailedCount    int
+	NextAttemptAt  time.Time `gorm:"index"`
+	StartedAt      *time.Time
+	CompletedAt    *time.Time
+	CreatorId      *uuid.UUID `gorm:"type:uuid"`
+	Creator        *User      `gorm:"foreignKey:CreatorId"`
+}
+
+// UserImportRow is a user to create. Roles are the names of the tks roles.
+type UserImportRow struct {
+	ID          uuid.UUID `gorm:"primarykey;type:uuid"`
+	JobId       uuid.UUID `gorm:"type:uuid;index"`
+	Line        int
+	AccountId   string
+	Name        string
+	Email       string
+	Department  string
+	Description string
+	Roles       []string `gorm:"serializer:json"`
+	Status      UserImportRowStatus
+	Message     string
+	UserId      *uuid.UUID `gorm:"type:uuid"`
+}
+
+// UserExport is the user with the project memberships to export.
+type UserExport struct {
+	User     User
+	Projects []UserExportProject
+}
+
+type UserExportProject struct {
+	ProjectId       string
+	ProjectName     string
+	ProjectRoleName string
+}
@@ -11,12 +11,14 @@ import (
 type User struct {
 	gorm.Model
 
-	ID                uuid.UUID `gorm:"primarykey;type:uuid" json:"id"`
-	AccountId         string    `json:"accountId"`
-	Password          string    `gorm:"-:all" json:"password"`
-	Name              string    `json:"name"`
-	Token             string    `json:"token"`
-	Roles             []Role    `gorm:"many2many:user_roles;" json:"roles"`
+	ID        uuid.UUID `gorm:"primarykey;type:uuid" json:"id"`
+	AccountId string    `json:"accountId"`
+	Password  string    `gorm:"-:all" json:"password"`
+	Name      string    `json:"name"`
+	Token     string    `json:"token"`
+	// 역할이 변경되어 토큰을 다시 발급할 때 사용한다.
+	RefreshToken      string `gorm:"-:all" json:"-"`
+	Roles             []Role `gorm:"many2many:user_roles;" json:"roles"`
 	OrganizationId    string
 	Organization      Organization `gorm:"foreignKey:OrganizationId;references:ID" json:"organization"`
 	Creator           string       `json:"creator"`
@@ -24,10 +26,22 @@ type User struct {
 	UpdatedAt         time.Time    `json:"updatedAt"`
 	PasswordUpdatedAt time.Time    `json:"passwordUpdatedAt"`
 	PasswordExpired   bool         `gorm:"-:all" json:"passwordExpired"`
+	// 외부 디렉터리에서 로그인하여 생성된 사용자. 비밀번호는 외부 디렉터리에서 관리한다.
+	IdentityProviderId *uuid.UUID `gorm:"type:uuid" json:"identityProviderId"`
+	// SCIM 으로 프로비저닝된 사용자의 외부 시스템(HR 등) 식별자
+	ExternalId string `gorm:"not null;default:''" json:"externalId"`
+	// 비활성화된 사용자는 로그인할 수 없다.
+	Disabled bool `gorm:"not null;default:false" json:"disabled"`
+
+	// 2단계 인증이 필요한 로그인은 Token 대신 SecondFactorToken 을 발급한다.
+	SecondFactorToken              string `gorm:"-:all" json:"secondFactorToken"`
+	SecondFactorEnrollmentRequired bool   `gorm:"-:all" json:"secondFactorEnrollmentRequired"`
 
 	Email       string `json:"email"`
 	Department  string `json:"department"`
 	Description string `json:"description"`
+
+	NotificationPreference *NotificationPreference `gorm:"foreignKey:UserId" json:"notificationPreference,omitempty"`
 }
 
 func (u *User) BeforeDelete(db *gorm.DB) (err error) {
@@ -35,5 +49,13 @@ func (u *User) BeforeDelete(db *gorm.DB) (err error) {
 	if err != nil {
 		return err
 	}
+	err = db.Delete(&NotificationPreference{UserId: u.ID}).Error
+	if err != nil {
+		return err
+	}
+	err = db.Delete(&SecondFactor{UserId: u.ID}).Error
+	if err != nil {
+		return err
+	}
 	return nil
 }
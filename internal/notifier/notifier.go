@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const httpTimeout = 10 * time.Second
+
+// 알림 채널의 주소는 조직 관리자가 입력하므로, 내부망으로의 요청을 막기 위해 연결하는 시점의 IP 를 검사한다.
+var httpClient = &http.Client{
+	Timeout: httpTimeout,
+	Transport: &http.Transport{
+		Proxy:               nil,
+		DialContext:         (&net.Dialer{Timeout: httpTimeout, Control: checkDialAddress}).DialContext,
+		TLSHandshakeTimeout: httpTimeout,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 3 {
+			return fmt.Errorf("too many redirects")
+		}
+		return checkScheme(req.URL)
+	},
+}
+
+type Notifier interface {
+	// Notify delivers the message and returns the http status code of the receiver.
+	Notify(ctx context.Context, message *MessageInfo) (statusCode int, err error)
+}
+
+type MessageInfo struct {
+	OrganizationId string    `json:"organizationId"`
+	ClusterId      string    `json:"clusterId"`
+	Name           string    `json:"name"`
+	Severity       string    `json:"severity"`
+	Node           string    `json:"node"`
+	Title          string    `json:"title"`
+	Content        string    `json:"content"`
+	ActionProposal string    `json:"actionProposal"`
+	GrafanaUrl     string    `json:"grafanaUrl"`
+	Status         string    `json:"status"`
+	FiredAt        time.Time `json:"firedAt"`
+}
+
+// New returns the notifier for the channel type. template is used only by WEBHOOK.
+func New(channelType string, url string, template string) (Notifier, error) {
+	switch channelType {
+	case domain.NOTIFICATION_CHANNEL_TYPE_SLACK, domain.NOTIFICATION_CHANNEL_TYPE_MATTERMOST:
+		return &SlackNotifier{Url: url}, nil
+	case domain.NOTIFICATION_CHANNEL_TYPE_TEAMS:
+		return &TeamsNotifier{Url: url}, nil
+	case domain.NOTIFICATION_CHANNEL_TYPE_WEBHOOK:
+		return NewWebhookNotifier(url, template)
+	}
+	return nil, fmt.Errorf("not supported channel type %s", channelType)
+}
+
+func postJSON(ctx context.Context, url string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	// 응답 본문은 내부 정보를 노출할 수 있으므로 결과에 남기지 않는다.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	log.Debugf(ctx, "notification delivered to %s", url)
+	return res.StatusCode, nil
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ValidateUrl checks that the url is https and its host resolves only to public addresses.
+func ValidateUrl(ctx context.Context, rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return err
+	}
+	if err = checkScheme(u); err != nil {
+		return err
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("host is empty")
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err = checkAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "https" {
+		return fmt.Errorf("only https is allowed")
+	}
+	return nil
+}
+
+func checkDialAddress(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	return checkAddr(addrPort.Addr())
+}
+
+// checkAddr rejects the loopback, link-local, private and other non-public addresses.
+func checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("address %s is not allowed", addr)
+	}
+	return nil
+}
+
+// carrier-grade NAT (RFC 6598)
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
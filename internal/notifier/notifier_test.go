@@ -0,0 +1,37 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateUrl(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"public address", "https://8.8.8.8/hooks", false},
+		{"http is not allowed", "http://8.8.8.8/hooks", true},
+		{"empty host", "https:///hooks", true},
+		{"loopback", "https://127.0.0.1/hooks", true},
+		{"loopback ipv6", "https://[::1]/hooks", true},
+		{"link-local metadata", "https://169.254.169.254/latest/meta-data", true},
+		{"private 10/8", "https://10.0.0.1/hooks", true},
+		{"private 172.16/12", "https://172.16.0.1/hooks", true},
+		{"private 192.168/16", "https://192.168.0.1/hooks", true},
+		{"shared address space", "https://100.64.0.1/hooks", true},
+		{"unspecified", "https://0.0.0.0/hooks", true},
+		{"ipv4 mapped loopback", "https://[::ffff:127.0.0.1]/hooks", true},
+		{"unique local ipv6", "https://[fd00::1]/hooks", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUrl(context.Background(), tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUrl(%s) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
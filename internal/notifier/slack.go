@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SlackNotifier sends the message to slack (or mattermost) incoming webhook.
+type SlackNotifier struct {
+	Url string
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, message *MessageInfo) (int, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*[%s] %s*\n", strings.ToUpper(message.Severity), message.Title)
+	if message.Content != "" {
+		fmt.Fprintf(&sb, "%s\n", message.Content)
+	}
+	if message.ActionProposal != "" {
+		fmt.Fprintf(&sb, "> %s\n", message.ActionProposal)
+	}
+	fmt.Fprintf(&sb, "cluster: `%s`", message.ClusterId)
+	if message.Node != "" {
+		fmt.Fprintf(&sb, ", node: `%s`", message.Node)
+	}
+	if message.GrafanaUrl != "" {
+		fmt.Fprintf(&sb, "\n<%s|Grafana>", message.GrafanaUrl)
+	}
+
+	body, err := marshal(map[string]string{"text": sb.String()})
+	if err != nil {
+		return 0, err
+	}
+	return postJSON(ctx, n.Url, body)
+}
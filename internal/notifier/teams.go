@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"strings"
+)
+
+// TeamsNotifier sends the message to MS Teams incoming webhook as a MessageCard.
+type TeamsNotifier struct {
+	Url string
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (n *TeamsNotifier) Notify(ctx context.Context, message *MessageInfo) (int, error) {
+	facts := []teamsFact{
+		{Name: "Severity", Value: message.Severity},
+		{Name: "Cluster", Value: message.ClusterId},
+	}
+	if message.Node != "" {
+		facts = append(facts, teamsFact{Name: "Node", Value: message.Node})
+	}
+	if message.ActionProposal != "" {
+		facts = append(facts, teamsFact{Name: "Action", Value: message.ActionProposal})
+	}
+
+	card := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "http://schema.org/extensions",
+		"summary":    message.Title,
+		"title":      message.Title,
+		"text":       message.Content,
+		"themeColor": teamsThemeColor(message.Severity),
+		"sections":   []map[string]interface{}{{"facts": facts}},
+	}
+	if message.GrafanaUrl != "" {
+		card["potentialAction"] = []map[string]interface{}{{
+			"@type":   "OpenUri",
+			"name":    "Grafana",
+			"targets": []map[string]string{{"os": "default", "uri": message.GrafanaUrl}},
+		}}
+	}
+
+	body, err := marshal(card)
+	if err != nil {
+		return 0, err
+	}
+	return postJSON(ctx, n.Url, body)
+}
+
+func teamsThemeColor(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return "D70000"
+	case "warning":
+		return "FFA500"
+	}
+	return "0076D7"
+}
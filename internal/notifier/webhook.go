@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+// WebhookNotifier posts the message to generic http endpoint.
+// If the template is empty, the message itself is sent as JSON.
+// In the template, use {{ json .Title }} to write the escaped JSON string.
+type WebhookNotifier struct {
+	Url      string
+	template *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func NewWebhookNotifier(url string, tmpl string) (*WebhookNotifier, error) {
+	n := &WebhookNotifier{Url: url}
+	if tmpl == "" {
+		return n, nil
+	}
+
+	parsed, err := template.New("webhook").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	n.template = parsed
+	return n, nil
+}
+
+// ValidateTemplate checks whether the template renders valid JSON with a sample message.
+func ValidateTemplate(tmpl string) error {
+	n, err := NewWebhookNotifier("", tmpl)
+	if err != nil {
+		return err
+	}
+	_, err = n.render(&MessageInfo{})
+	return err
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, message *MessageInfo) (int, error) {
+	body, err := n.render(message)
+	if err != nil {
+		return 0, err
+	}
+	return postJSON(ctx, n.Url, body)
+}
+
+func (n *WebhookNotifier) render(message *MessageInfo) ([]byte, error) {
+	if n.template == nil {
+		return marshal(message)
+	}
+
+	var buf bytes.Buffer
+	if err := n.template.Execute(&buf, message); err != nil {
+		return nil, err
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("rendered template is not valid json")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IApiTokenRepository interface {
+	Get(ctx context.Context, apiTokenId uuid.UUID) (model.ApiToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (model.ApiToken, error)
+	FetchByServiceAccountId(ctx context.Context, serviceAccountId uuid.UUID, pg *pagination.Pagination) ([]model.ApiToken, error)
+	FetchByUserId(ctx context.Context, userId uuid.UUID, pg *pagination.Pagination) ([]model.ApiToken, error)
+	Create(ctx context.Context, dto model.ApiToken) (apiTokenId uuid.UUID, err error)
+	Revoke(ctx context.Context, apiTokenId uuid.UUID, at time.Time) (err error)
+	RevokeByUserId(ctx context.Context, userId uuid.UUID, at time.Time) (err error)
+	UpdateLastUsedAt(ctx context.Context, apiTokenId uuid.UUID, at time.Time) (err error)
+}
+
+type ApiTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewApiTokenRepository(db *gorm.DB) IApiTokenRepository {
+	return &ApiTokenRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *ApiTokenRepository) Get(ctx context.Context, apiTokenId uuid.UUID) (out model.ApiToken, err error) {
+	res := r.db.WithContext(ctx).Preload(clause.Associations).First(&out, "id = ?", apiTokenId)
+	if res.Error != nil {
+		return model.ApiToken{}, res.Error
+	}
+	return
+}
+
+func (r *ApiTokenRepository) GetByHash(ctx context.Context, tokenHash string) (out model.ApiToken, err error) {
+	res := r.db.WithContext(ctx).
+		Preload("ServiceAccount").Preload("ServiceAccount.Roles").Preload("ServiceAccount.Organization").
+		Preload("User").Preload("User.Roles").
+		First(&out, "token_hash = ?", tokenHash)
+	if res.Error != nil {
+		return model.ApiToken{}, res.Error
+	}
+	return
+}
+
+func (r *ApiTokenRepository) FetchByServiceAccountId(ctx context.Context, serviceAccountId uuid.UUID, pg *pagination.Pagination) (out []model.ApiToken, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Preload("Creator").Model(&model.ApiToken{}).
+		Where("service_account_id = ?", serviceAccountId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *ApiTokenRepository) FetchByUserId(ctx context.Context, userId uuid.UUID, pg *pagination.Pagination) (out []model.ApiToken, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Preload("Creator").Model(&model.ApiToken{}).
+		Where("user_id = ?", userId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *ApiTokenRepository) Create(ctx context.Context, dto model.ApiToken) (apiTokenId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+func (r *ApiTokenRepository) Revoke(ctx context.Context, apiTokenId uuid.UUID, at time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.ApiToken{}).
+		Where("id = ? AND revoked_at IS NULL", apiTokenId).
+		Update("revoked_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *ApiTokenRepository) RevokeByUserId(ctx context.Context, userId uuid.UUID, at time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.ApiToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *ApiTokenRepository) UpdateLastUsedAt(ctx context.Context, apiTokenId uuid.UUID, at time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.ApiToken{}).
+		Where("id = ?", apiTokenId).
+		UpdateColumn("last_used_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
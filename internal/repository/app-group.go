@@ -18,6 +18,7 @@ import (
 // Interfaces
 type IAppGroupRepository interface {
 	Fetch(ctx context.Context, clusterId domain.ClusterId, pg *pagination.Pagination) (res []model.AppGroup, err error)
+	FetchByClusterIds(ctx context.Context, clusterIds []domain.ClusterId) (res []model.AppGroup, err error)
 	Get(ctx context.Context, id domain.AppGroupId) (model.AppGroup, error)
 	Create(ctx context.Context, dto model.AppGroup) (id domain.AppGroupId, err error)
 	Update(ctx context.Context, dto model.AppGroup) (err error)
@@ -53,6 +54,16 @@ func (r *AppGroupRepository) Fetch(ctx context.Context, clusterId domain.Cluster
 	return out, nil
 }
 
+func (r *AppGroupRepository) FetchByClusterIds(ctx context.Context, clusterIds []domain.ClusterId) (out []model.AppGroup, err error) {
+	res := r.db.WithContext(ctx).Model(&model.AppGroup{}).
+		Where("cluster_id IN ?", clusterIds).
+		Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return out, nil
+}
+
 func (r *AppGroupRepository) Get(ctx context.Context, id domain.AppGroupId) (out model.AppGroup, err error) {
 	res := r.db.WithContext(ctx).First(&out, "id = ?", id)
 	if res.RowsAffected == 0 || res.Error != nil {
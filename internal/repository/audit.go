@@ -2,10 +2,11 @@ package repository
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/pagination"
@@ -16,7 +17,19 @@ type IAuditRepository interface {
 	Get(ctx context.Context, auditId uuid.UUID) (model.Audit, error)
 	Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.Audit, error)
 	Create(ctx context.Context, dto model.Audit) (auditId uuid.UUID, err error)
-	Delete(ctx context.Context, auditId uuid.UUID) (err error)
+	FetchChain(ctx context.Context, organizationId string, afterSequence int64, limit int) ([]model.Audit, error)
+	GetLast(ctx context.Context, organizationId string) (model.Audit, error)
+	GetBySequence(ctx context.Context, organizationId string, sequence int64) (model.Audit, error)
+	GetChainOrganizationIds(ctx context.Context) ([]string, error)
+	CreateCheckpoint(ctx context.Context, dto model.AuditCheckpoint) (err error)
+	FetchCheckpoints(ctx context.Context, organizationId string) ([]model.AuditCheckpoint, error)
+	GetLastCheckpoint(ctx context.Context, organizationId string) (model.AuditCheckpoint, error)
+	FetchRange(ctx context.Context, pg *pagination.Pagination, startDate time.Time, endDate time.Time) ([]model.Audit, error)
+	GetLastSequences(ctx context.Context) (map[string]int64, error)
+	ClaimForwardCursor(ctx context.Context, name string, lease time.Duration) (cursor model.AuditForwardCursor, claimed bool, err error)
+	UpdateForwardCursor(ctx context.Context, dto model.AuditForwardCursor) (err error)
+	FetchExpired(ctx context.Context, organizationId string, cutoff time.Time, maxSequence int64, limit int) ([]model.Audit, error)
+	Purge(ctx context.Context, auditIds []uuid.UUID) (int64, error)
 }
 
 type AuditRepository struct {
@@ -33,7 +46,7 @@ func NewAuditRepository(db *gorm.DB) IAuditRepository {
 func (r *AuditRepository) Get(ctx context.Context, auditId uuid.UUID) (out model.Audit, err error) {
 	res := r.db.WithContext(ctx).First(&out, "id = ?", auditId)
 	if res.Error != nil {
-		return
+		return out, res.Error
 	}
 	return
 }
@@ -52,15 +65,211 @@ func (r *AuditRepository) Fetch(ctx context.Context, pg *pagination.Pagination)
 	return
 }
 
+// Create appends the audit to the chain of the organization. The audits of an organization are serialized by the advisory lock.
 func (r *AuditRepository) Create(ctx context.Context, dto model.Audit) (auditId uuid.UUID, err error) {
 	dto.ID = uuid.New()
+	dto.CreatedAt = time.Now().Truncate(time.Microsecond)
+	dto.UpdatedAt = dto.CreatedAt
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "audit:"+dto.OrganizationId).Error; err != nil {
+			return err
+		}
+
+		var last model.Audit
+		res := tx.Unscoped().Where("organization_id = ? AND sequence > 0", dto.OrganizationId).
+			Order("sequence DESC").Limit(1).Find(&last)
+		if res.Error != nil {
+			return res.Error
+		}
+		dto.Sequence = last.Sequence + 1
+		dto.PrevHash = last.Hash
+		dto.Hash = dto.ComputeHash()
+
+		return tx.Create(&dto).Error
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return dto.ID, nil
+}
+
+// FetchChain returns the chained audits after the sequence in order, including the soft deleted ones.
+func (r *AuditRepository) FetchChain(ctx context.Context, organizationId string, afterSequence int64, limit int) (out []model.Audit, err error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("organization_id = ? AND sequence > ?", organizationId, afterSequence).
+		Order("sequence ASC").Limit(limit).Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *AuditRepository) GetLast(ctx context.Context, organizationId string) (out model.Audit, err error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("organization_id = ? AND sequence > 0", organizationId).
+		Order("sequence DESC").First(&out)
+	if res.Error != nil {
+		return model.Audit{}, res.Error
+	}
+	return
+}
+
+func (r *AuditRepository) GetBySequence(ctx context.Context, organizationId string, sequence int64) (out model.Audit, err error) {
+	res := r.db.WithContext(ctx).Unscoped().First(&out, "organization_id = ? AND sequence = ?", organizationId, sequence)
+	if res.Error != nil {
+		return model.Audit{}, res.Error
+	}
+	return
+}
+
+func (r *AuditRepository) GetChainOrganizationIds(ctx context.Context) (out []string, err error) {
+	res := r.db.WithContext(ctx).Unscoped().Model(&model.Audit{}).
+		Where("sequence > 0").Distinct().Pluck("organization_id", &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *AuditRepository) CreateCheckpoint(ctx context.Context, dto model.AuditCheckpoint) (err error) {
 	res := r.db.WithContext(ctx).Create(&dto)
 	if res.Error != nil {
-		return uuid.Nil, res.Error
+		return res.Error
 	}
-	return dto.ID, nil
+	return nil
+}
+
+func (r *AuditRepository) FetchCheckpoints(ctx context.Context, organizationId string) (out []model.AuditCheckpoint, err error) {
+	res := r.db.WithContext(ctx).Where("organization_id = ?", organizationId).Order("sequence ASC").Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *AuditRepository) GetLastCheckpoint(ctx context.Context, organizationId string) (out model.AuditCheckpoint, err error) {
+	res := r.db.WithContext(ctx).Where("organization_id = ?", organizationId).Order("sequence DESC").First(&out)
+	if res.Error != nil {
+		return model.AuditCheckpoint{}, res.Error
+	}
+	return
 }
 
-func (r *AuditRepository) Delete(ctx context.Context, auditId uuid.UUID) (err error) {
-	return fmt.Errorf("to be implemented")
+// FetchRange returns the audits created in [startDate, endDate) with the filters of the pagination.
+func (r *AuditRepository) FetchRange(ctx context.Context, pg *pagination.Pagination, startDate time.Time, endDate time.Time) (out []model.Audit, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	db := r.db.WithContext(ctx).Model(&model.Audit{}).
+		Where("created_at >= ? AND created_at < ?", startDate, endDate)
+
+	_, res := pg.Fetch(db, &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+// GetLastSequences returns the last sequence of the chain of each organization.
+func (r *AuditRepository) GetLastSequences(ctx context.Context) (map[string]int64, error) {
+	return getLastAuditSequences(r.db.WithContext(ctx))
+}
+
+func getLastAuditSequences(db *gorm.DB) (map[string]int64, error) {
+	var rows []struct {
+		OrganizationId string
+		Sequence       int64
+	}
+	res := db.Unscoped().Model(&model.Audit{}).
+		Select("organization_id, MAX(sequence) AS sequence").
+		Where("sequence > 0").Group("organization_id").Scan(&rows)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	out := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		out[row.OrganizationId] = row.Sequence
+	}
+	return out, nil
+}
+
+// ClaimForwardCursor takes the cursor for the lease. The cursor starts from the last audits, so the audits before the forwarding is enabled are not sent.
+func (r *AuditRepository) ClaimForwardCursor(ctx context.Context, name string, lease time.Duration) (out model.AuditForwardCursor, claimed bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var count int64
+		if err := tx.Model(&model.AuditForwardCursor{}).Where("name = ?", name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			sequences, err := getLastAuditSequences(tx)
+			if err != nil {
+				return err
+			}
+			initial := model.AuditForwardCursor{Name: name, Sequences: sequences, NextAttemptAt: now}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&initial).Error; err != nil {
+				return err
+			}
+		}
+
+		var cursors []model.AuditForwardCursor
+		leasedUntil, err := claimLease(tx, &cursors, func(cursor model.AuditForwardCursor) interface{} { return cursor.Name },
+			leaseClaim{KeyColumn: "name", DueColumn: "next_attempt_at", Lease: lease, Limit: 1},
+			"name = ?", name)
+		if err != nil || len(cursors) == 0 {
+			return err
+		}
+
+		out = cursors[0]
+		out.NextAttemptAt = leasedUntil
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return model.AuditForwardCursor{}, false, err
+	}
+	return
+}
+
+func (r *AuditRepository) UpdateForwardCursor(ctx context.Context, dto model.AuditForwardCursor) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.AuditForwardCursor{}).
+		Where("name = ?", dto.Name).
+		Select("Sequences", "NextAttemptAt", "Attempts", "LastError").
+		Updates(&dto)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+// FetchExpired returns the audits created before the cutoff in the order of the chain, including the soft deleted ones.
+// The chain is cut at the last sequence created before the cutoff and not after maxSequence, the signed checkpoint,
+// so that no hole is left in the chain. The last audit of the organization is always kept to continue the chain.
+func (r *AuditRepository) FetchExpired(ctx context.Context, organizationId string, cutoff time.Time, maxSequence int64, limit int) (out []model.Audit, err error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("organization_id = ?", organizationId).
+		Where("(sequence = 0 AND created_at < ?) OR (sequence > 0 AND sequence <= ? "+
+			"AND sequence <= (SELECT COALESCE(MAX(sequence), 0) FROM audits WHERE organization_id = ? AND sequence > 0 AND created_at < ?) "+
+			"AND sequence < (SELECT COALESCE(MAX(sequence), 0) FROM audits WHERE organization_id = ? AND sequence > 0))",
+			cutoff, maxSequence, organizationId, cutoff, organizationId).
+		Order("sequence ASC, created_at ASC").Limit(limit).Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+// Purge deletes the audits permanently. It is only for the retention, the audits are never deleted otherwise.
+func (r *AuditRepository) Purge(ctx context.Context, auditIds []uuid.UUID) (int64, error) {
+	if len(auditIds) == 0 {
+		return 0, nil
+	}
+	res := r.db.WithContext(ctx).Unscoped().Where("id IN ?", auditIds).Delete(&model.Audit{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
 }
@@ -14,8 +14,15 @@ type IAuthRepository interface {
 	GetEmailCode(ctx context.Context, userId uuid.UUID) (model.CacheEmailCode, error)
 	UpdateEmailCode(ctx context.Context, userId uuid.UUID, code string) error
 	DeleteEmailCode(ctx context.Context, userId uuid.UUID) error
+	IncreaseEmailCodeFailure(ctx context.Context, userId uuid.UUID) (int, error)
 	GetExpiredTimeOnToken(ctx context.Context, organizationId string, userId string) (*model.ExpiredTokenTime, error)
 	UpdateExpiredTimeOnToken(ctx context.Context, organizationId string, userId string) error
+	RevokeSession(ctx context.Context, organizationId string, sessionId string, expiredAt time.Time) error
+	IsSessionRevoked(ctx context.Context, sessionId string) (bool, error)
+	GetLoginFailure(ctx context.Context, kind string, organizationId string, subject string) (model.LoginFailure, error)
+	IncreaseLoginFailure(ctx context.Context, kind string, organizationId string, subject string, window time.Duration) (model.LoginFailure, error)
+	LockLoginFailure(ctx context.Context, kind string, organizationId string, subject string, lockedUntil time.Time) error
+	DeleteLoginFailure(ctx context.Context, kind string, organizationId string, subject string) error
 }
 
 type AuthRepository struct {
@@ -45,13 +52,26 @@ func (r *AuthRepository) GetEmailCode(ctx context.Context, userId uuid.UUID) (mo
 }
 
 func (r *AuthRepository) UpdateEmailCode(ctx context.Context, userId uuid.UUID, code string) error {
-	return r.db.WithContext(ctx).Model(&model.CacheEmailCode{}).Where("user_id = ?", userId).Update("code", code).Error
+	return r.db.WithContext(ctx).Model(&model.CacheEmailCode{}).Where("user_id = ?", userId).Updates(map[string]interface{}{"code": code, "failed_count": 0}).Error
 }
 
 func (r *AuthRepository) DeleteEmailCode(ctx context.Context, userId uuid.UUID) error {
 	return r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userId).Delete(&model.CacheEmailCode{}).Error
 }
 
+func (r *AuthRepository) IncreaseEmailCodeFailure(ctx context.Context, userId uuid.UUID) (int, error) {
+	res := r.db.WithContext(ctx).Model(&model.CacheEmailCode{}).Where("user_id = ?", userId).
+		Update("failed_count", gorm.Expr("failed_count + 1"))
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	cacheEmailCode, err := r.GetEmailCode(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+	return cacheEmailCode.FailedCount, nil
+}
+
 func (r *AuthRepository) GetExpiredTimeOnToken(ctx context.Context, organizationId string, userId string) (*model.ExpiredTokenTime, error) {
 	var expiredTokenTime model.ExpiredTokenTime
 	if err := r.db.WithContext(ctx).Where("organization_id = ? AND subject_id = ?", organizationId, userId).First(&expiredTokenTime).Error; err != nil {
@@ -70,3 +90,74 @@ func (r *AuthRepository) UpdateExpiredTimeOnToken(ctx context.Context, organizat
 		OrganizationId: organizationId,
 	}).Error
 }
+
+// RevokeSession keeps the session until expiredAt, and deletes the revoked sessions already expired.
+func (r *AuthRepository) RevokeSession(ctx context.Context, organizationId string, sessionId string, expiredAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expired_at < ?", time.Now()).Delete(&model.RevokedSession{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expired_at"}),
+		}).Create(&model.RevokedSession{
+			SessionId:      sessionId,
+			OrganizationId: organizationId,
+			ExpiredAt:      expiredAt,
+		}).Error
+	})
+}
+
+func (r *AuthRepository) IsSessionRevoked(ctx context.Context, sessionId string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.RevokedSession{}).
+		Where("session_id = ? AND expired_at > ?", sessionId, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *AuthRepository) GetLoginFailure(ctx context.Context, kind string, organizationId string, subject string) (out model.LoginFailure, err error) {
+	res := r.db.WithContext(ctx).First(&out, "kind = ? AND organization_id = ? AND subject = ?", kind, organizationId, subject)
+	if res.Error != nil {
+		return out, res.Error
+	}
+	return out, nil
+}
+
+// IncreaseLoginFailure counts a failure. The count starts again from 1 when the last failure is older than window.
+func (r *AuthRepository) IncreaseLoginFailure(ctx context.Context, kind string, organizationId string, subject string, window time.Duration) (model.LoginFailure, error) {
+	now := time.Now()
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "kind"}, {Name: "organization_id"}, {Name: "subject"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failed_count":   gorm.Expr("CASE WHEN login_failures.last_failed_at < ? THEN 1 ELSE login_failures.failed_count + 1 END", now.Add(-window)),
+			"last_failed_at": now,
+			"updated_at":     now,
+		}),
+	}).Create(&model.LoginFailure{
+		Kind:           kind,
+		OrganizationId: organizationId,
+		Subject:        subject,
+		FailedCount:    1,
+		LastFailedAt:   now,
+	}).Error
+	if err != nil {
+		return model.LoginFailure{}, err
+	}
+	return r.GetLoginFailure(ctx, kind, organizationId, subject)
+}
+
+func (r *AuthRepository) LockLoginFailure(ctx context.Context, kind string, organizationId string, subject string, lockedUntil time.Time) error {
+	return r.db.WithContext(ctx).Model(&model.LoginFailure{}).
+		Where("kind = ? AND organization_id = ? AND subject = ?", kind, organizationId, subject).
+		Update("locked_until", lockedUntil).Error
+}
+
+func (r *AuthRepository) DeleteLoginFailure(ctx context.Context, kind string, organizationId string, subject string) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("kind = ? AND organization_id = ? AND subject = ?", kind, organizationId, subject).
+		Delete(&model.LoginFailure{}).Error
+}
@@ -21,6 +21,7 @@ type IClusterRepository interface {
 	Fetch(ctx context.Context, pg *pagination.Pagination) (res []model.Cluster, err error)
 	FetchByCloudAccountId(ctx context.Context, cloudAccountId uuid.UUID, pg *pagination.Pagination) (res []model.Cluster, err error)
 	FetchByOrganizationId(ctx context.Context, organizationId string, userId uuid.UUID, pg *pagination.Pagination) (res []model.Cluster, err error)
+	FetchByOrganizationIds(ctx context.Context, organizationIds []string) (res []model.Cluster, err error)
 	Get(ctx context.Context, id domain.ClusterId) (model.Cluster, error)
 	GetByName(ctx context.Context, organizationId string, name string) (model.Cluster, error)
 	Create(ctx context.Context, dto model.Cluster) (clusterId domain.ClusterId, err error)
@@ -85,6 +86,17 @@ func (r *ClusterRepository) FetchByOrganizationId(ctx context.Context, organizat
 	return
 }
 
+// FetchByOrganizationIds returns the clusters of the organizations without the associations.
+func (r *ClusterRepository) FetchByOrganizationIds(ctx context.Context, organizationIds []string) (out []model.Cluster, err error) {
+	res := r.db.WithContext(ctx).Model(&model.Cluster{}).
+		Where("organization_id IN ?", organizationIds).
+		Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
 func (r *ClusterRepository) FetchByCloudAccountId(ctx context.Context, cloudAccountId uuid.UUID, pg *pagination.Pagination) (out []model.Cluster, err error) {
 	if pg == nil {
 		pg = pagination.NewPagination(nil)
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IEscalationPolicyRepository interface {
+	Get(ctx context.Context, escalationPolicyId uuid.UUID) (model.EscalationPolicy, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.EscalationPolicy, error)
+	Create(ctx context.Context, dto model.EscalationPolicy) (escalationPolicyId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.EscalationPolicy) (err error)
+	Delete(ctx context.Context, escalationPolicyId uuid.UUID) (err error)
+}
+
+type EscalationPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewEscalationPolicyRepository(db *gorm.DB) IEscalationPolicyRepository {
+	return &EscalationPolicyRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *EscalationPolicyRepository) Get(ctx context.Context, escalationPolicyId uuid.UUID) (out model.EscalationPolicy, err error) {
+	res := r.preload(r.db.WithContext(ctx)).First(&out, "id = ?", escalationPolicyId)
+	if res.Error != nil {
+		return model.EscalationPolicy{}, res.Error
+	}
+	return
+}
+
+func (r *EscalationPolicyRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.EscalationPolicy, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.preload(r.db.WithContext(ctx)).Model(&model.EscalationPolicy{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *EscalationPolicyRepository) Create(ctx context.Context, dto model.EscalationPolicy) (escalationPolicyId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	for i := range dto.Tiers {
+		dto.Tiers[i].ID = uuid.New()
+		dto.Tiers[i].Level = i
+	}
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+// Update replaces the tiers of the policy with dto.Tiers.
+func (r *EscalationPolicyRepository) Update(ctx context.Context, dto model.EscalationPolicy) (err error) {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.EscalationPolicy{}).
+			Where("id = ?", dto.ID).
+			Updates(map[string]interface{}{
+				"Name":        dto.Name,
+				"Description": dto.Description,
+				"RepeatCount": dto.RepeatCount,
+				"UpdatorId":   dto.UpdatorId,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if err := deleteEscalationTiers(tx, dto.ID); err != nil {
+			return err
+		}
+
+		for i := range dto.Tiers {
+			dto.Tiers[i].ID = uuid.New()
+			dto.Tiers[i].EscalationPolicyId = dto.ID
+			dto.Tiers[i].Level = i
+		}
+		if len(dto.Tiers) > 0 {
+			if res = tx.Create(&dto.Tiers); res.Error != nil {
+				return res.Error
+			}
+		}
+		return nil
+	})
+}
+
+func (r *EscalationPolicyRepository) Delete(ctx context.Context, escalationPolicyId uuid.UUID) (err error) {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 알림 설정과의 연결을 먼저 해제한다.
+		res := tx.Model(&model.SystemNotificationRule{}).
+			Where("escalation_policy_id = ?", escalationPolicyId).
+			Update("escalation_policy_id", nil)
+		if res.Error != nil {
+			return res.Error
+		}
+		if err := deleteEscalationTiers(tx, escalationPolicyId); err != nil {
+			return err
+		}
+		res = tx.Delete(&model.EscalationPolicy{}, "id = ?", escalationPolicyId)
+		if res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+}
+
+func (r *EscalationPolicyRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("level ASC")
+		}).
+		Preload("Tiers.TargetUsers").
+		Preload("Tiers.NotificationChannels").
+		Preload("Tiers.OnCallSchedule").
+		Preload("Tiers.OnCallSchedule.Members", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		Preload("Tiers.OnCallSchedule.Members.User").
+		Preload("Creator").
+		Preload("Updator")
+}
+
+func deleteEscalationTiers(tx *gorm.DB, escalationPolicyId uuid.UUID) error {
+	subQuery := tx.Model(&model.EscalationTier{}).Select("id").Where("escalation_policy_id = ?", escalationPolicyId)
+	res := tx.Exec("DELETE FROM escalation_tier_users WHERE escalation_tier_id IN (?)", subQuery)
+	if res.Error != nil {
+		return res.Error
+	}
+	res = tx.Exec("DELETE FROM escalation_tier_notification_channels WHERE escalation_tier_id IN (?)", subQuery)
+	if res.Error != nil {
+		return res.Error
+	}
+	res = tx.Unscoped().Delete(&model.EscalationTier{}, "escalation_policy_id = ?", escalationPolicyId)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
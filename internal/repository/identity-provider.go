@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IIdentityProviderRepository interface {
+	Get(ctx context.Context, identityProviderId uuid.UUID) (model.IdentityProvider, error)
+	GetByAlias(ctx context.Context, organizationId string, alias string) (model.IdentityProvider, error)
+	GetByKeycloakId(ctx context.Context, organizationId string, keycloakId string) (model.IdentityProvider, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.IdentityProvider, error)
+	FetchEnabled(ctx context.Context, organizationId string) ([]model.IdentityProvider, error)
+	Create(ctx context.Context, dto model.IdentityProvider) (identityProviderId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.IdentityProvider) (err error)
+	Delete(ctx context.Context, dto model.IdentityProvider) (err error)
+}
+
+type IdentityProviderRepository struct {
+	db *gorm.DB
+}
+
+func NewIdentityProviderRepository(db *gorm.DB) IIdentityProviderRepository {
+	return &IdentityProviderRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *IdentityProviderRepository) Get(ctx context.Context, identityProviderId uuid.UUID) (out model.IdentityProvider, err error) {
+	res := r.preload(ctx).First(&out, "id = ?", identityProviderId)
+	if res.Error != nil {
+		return model.IdentityProvider{}, res.Error
+	}
+	return
+}
+
+func (r *IdentityProviderRepository) GetByAlias(ctx context.Context, organizationId string, alias string) (out model.IdentityProvider, err error) {
+	res := r.preload(ctx).First(&out, "organization_id = ? AND alias = ?", organizationId, alias)
+	if res.Error != nil {
+		return model.IdentityProvider{}, res.Error
+	}
+	return
+}
+
+func (r *IdentityProviderRepository) GetByKeycloakId(ctx context.Context, organizationId string, keycloakId string) (out model.IdentityProvider, err error) {
+	res := r.preload(ctx).First(&out, "organization_id = ? AND keycloak_id = ?", organizationId, keycloakId)
+	if res.Error != nil {
+		return model.IdentityProvider{}, res.Error
+	}
+	return
+}
+
+func (r *IdentityProviderRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.IdentityProvider, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.preload(ctx).Model(&model.IdentityProvider{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *IdentityProviderRepository) FetchEnabled(ctx context.Context, organizationId string) (out []model.IdentityProvider, err error) {
+	res := r.preload(ctx).Where("organization_id = ? AND enabled = ?", organizationId, true).Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *IdentityProviderRepository) Create(ctx context.Context, dto model.IdentityProvider) (identityProviderId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	for i := range dto.RoleMappings {
+		dto.RoleMappings[i].ID = uuid.New()
+	}
+	res := r.db.WithContext(ctx).Omit("RoleMappings.Role").Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+func (r *IdentityProviderRepository) Update(ctx context.Context, dto model.IdentityProvider) (err error) {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.IdentityProvider{}).
+			Where("id = ?", dto.ID).
+			Updates(map[string]interface{}{
+				"DisplayName":   dto.DisplayName,
+				"Enabled":       dto.Enabled,
+				"Config":        dto.Config,
+				"DefaultRoleId": dto.DefaultRoleId,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if err := tx.Where("identity_provider_id = ?", dto.ID).Delete(&model.IdentityProviderRoleMapping{}).Error; err != nil {
+			return err
+		}
+		for i := range dto.RoleMappings {
+			dto.RoleMappings[i].ID = uuid.New()
+			dto.RoleMappings[i].IdentityProviderId = dto.ID
+		}
+		if len(dto.RoleMappings) == 0 {
+			return nil
+		}
+		return tx.Omit("Role").Create(&dto.RoleMappings).Error
+	})
+}
+
+func (r *IdentityProviderRepository) Delete(ctx context.Context, dto model.IdentityProvider) (err error) {
+	res := r.db.WithContext(ctx).Delete(&dto)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *IdentityProviderRepository) preload(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("DefaultRole").Preload("RoleMappings.Role").Preload("Creator")
+}
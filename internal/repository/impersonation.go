@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IImpersonationRepository interface {
+	Get(ctx context.Context, impersonationId uuid.UUID) (model.Impersonation, error)
+	Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.Impersonation, error)
+	Create(ctx context.Context, dto model.Impersonation) (impersonationId uuid.UUID, err error)
+	Stop(ctx context.Context, impersonationId uuid.UUID, stopperId uuid.UUID, at time.Time) (err error)
+}
+
+type ImpersonationRepository struct {
+	db *gorm.DB
+}
+
+func NewImpersonationRepository(db *gorm.DB) IImpersonationRepository {
+	return &ImpersonationRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *ImpersonationRepository) Get(ctx context.Context, impersonationId uuid.UUID) (out model.Impersonation, err error) {
+	res := r.preload(ctx).First(&out, "id = ?", impersonationId)
+	if res.Error != nil {
+		return model.Impersonation{}, res.Error
+	}
+	return
+}
+
+func (r *ImpersonationRepository) Fetch(ctx context.Context, pg *pagination.Pagination) (out []model.Impersonation, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.preload(ctx).Model(&model.Impersonation{}), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *ImpersonationRepository) Create(ctx context.Context, dto model.Impersonation) (impersonationId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Omit("User", "Impersonator").Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+// Stop marks the impersonation stopped. It fails if the impersonation was already stopped.
+func (r *ImpersonationRepository) Stop(ctx context.Context, impersonationId uuid.UUID, stopperId uuid.UUID, at time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.Impersonation{}).
+		Where("id = ? AND stopped_at IS NULL", impersonationId).
+		Updates(map[string]interface{}{
+			"StoppedAt": at,
+			"StopperId": stopperId,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("impersonation %s is already stopped", impersonationId)
+	}
+	return nil
+}
+
+func (r *ImpersonationRepository) preload(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("User").Preload("User.Organization").Preload("User.Roles").Preload("Impersonator")
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IInvitationRepository interface {
+	Get(ctx context.Context, invitationId uuid.UUID) (model.Invitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (model.Invitation, error)
+	FetchPendingByEmail(ctx context.Context, organizationId string, email string) ([]model.Invitation, error)
+	GetUnsentByUserId(ctx context.Context, userId uuid.UUID) (model.Invitation, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.Invitation, error)
+	Create(ctx context.Context, dto model.Invitation) (invitationId uuid.UUID, err error)
+	UpdateToken(ctx context.Context, invitationId uuid.UUID, tokenHash string, expiredAt time.Time, sentAt time.Time) (err error)
+	UpdateStatus(ctx context.Context, invitationId uuid.UUID, status model.InvitationStatus) (err error)
+	UpdateUserId(ctx context.Context, invitationId uuid.UUID, userId uuid.UUID) (err error)
+	Accept(ctx context.Context, invitationId uuid.UUID, at time.Time) (err error)
+}
+
+type InvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) IInvitationRepository {
+	return &InvitationRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *InvitationRepository) Get(ctx context.Context, invitationId uuid.UUID) (out model.Invitation, err error) {
+	res := r.preload(ctx).First(&out, "id = ?", invitationId)
+	if res.Error != nil {
+		return model.Invitation{}, res.Error
+	}
+	return
+}
+
+func (r *InvitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (out model.Invitation, err error) {
+	res := r.preload(ctx).Preload("Organization").Preload("User").First(&out, "token_hash = ?", tokenHash)
+	if res.Error != nil {
+		return model.Invitation{}, res.Error
+	}
+	return
+}
+
+func (r *InvitationRepository) FetchPendingByEmail(ctx context.Context, organizationId string, email string) (out []model.Invitation, err error) {
+	res := r.db.WithContext(ctx).
+		Where("organization_id = ? AND email = ? AND status = ?", organizationId, email, model.InvitationStatusPending).
+		Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+// GetUnsentByUserId returns the pending invitation of the provisioned user which is not mailed yet.
+func (r *InvitationRepository) GetUnsentByUserId(ctx context.Context, userId uuid.UUID) (out model.Invitation, err error) {
+	res := r.db.WithContext(ctx).
+		First(&out, "user_id = ? AND status = ? AND sent_count = 0", userId, model.InvitationStatusPending)
+	if res.Error != nil {
+		return model.Invitation{}, res.Error
+	}
+	return
+}
+
+func (r *InvitationRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.Invitation, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.preload(ctx).Model(&model.Invitation{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, dto model.Invitation) (invitationId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	for i := range dto.Projects {
+		dto.Projects[i].ID = uuid.New()
+	}
+	res := r.db.WithContext(ctx).Omit("Roles.*", "Projects.Project", "Projects.ProjectRole", "User").Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+func (r *InvitationRepository) UpdateToken(ctx context.Context, invitationId uuid.UUID, tokenHash string, expiredAt time.Time, sentAt time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.Invitation{}).
+		Where("id = ?", invitationId).
+		Updates(map[string]interface{}{
+			"TokenHash":  tokenHash,
+			"ExpiredAt":  expiredAt,
+			"LastSentAt": sentAt,
+			"SentCount":  gorm.Expr("sent_count + 1"),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+// UpdateStatus changes the status of the invitation other than the acceptance.
+func (r *InvitationRepository) UpdateStatus(ctx context.Context, invitationId uuid.UUID, status model.InvitationStatus) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.Invitation{}).
+		Where("id = ?", invitationId).
+		Updates(map[string]interface{}{
+			"Status":     status,
+			"AcceptedAt": nil,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *InvitationRepository) UpdateUserId(ctx context.Context, invitationId uuid.UUID, userId uuid.UUID) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.Invitation{}).
+		Where("id = ?", invitationId).
+		Update("user_id", userId)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+// Accept marks the pending invitation accepted. It fails if the invitation was accepted or revoked concurrently.
+func (r *InvitationRepository) Accept(ctx context.Context, invitationId uuid.UUID, at time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.Invitation{}).
+		Where("id = ? AND status = ?", invitationId, model.InvitationStatusPending).
+		Updates(map[string]interface{}{
+			"Status":     model.InvitationStatusAccepted,
+			"AcceptedAt": at,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("invitation %s is not pending", invitationId)
+	}
+	return nil
+}
+
+func (r *InvitationRepository) preload(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Roles").Preload("Projects.Project").Preload("Projects.ProjectRole").Preload("Creator")
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// leaseClaim describes the rows a background worker takes for a while.
+type leaseClaim struct {
+	// 가져간 행을 갱신할 때 쓰는 열. 비어 있으면 id 이다.
+	KeyColumn string
+	// 처리할 시각이 지난 행을 고르고, 가져간 행은 lease 만큼 미루는 열
+	DueColumn string
+	Lease     time.Duration
+	Limit     int
+}
+
+// claimLease locks the due rows which match the query, skipping the rows locked by the other replicas,
+// and postpones them by the lease. The rows are claimed again after the lease if the worker dies while handling them.
+// It must be called in a transaction, and returns the time until which the rows are leased.
+func claimLease[T any](tx *gorm.DB, out *[]T, key func(T) interface{}, c leaseClaim, query interface{}, args ...interface{}) (time.Time, error) {
+	now := time.Now()
+	leasedUntil := now.Add(c.Lease)
+
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where(query, args...).
+		Where(c.DueColumn+" <= ?", now).
+		Order(c.DueColumn + " ASC").
+		Limit(c.Limit).
+		Find(out)
+	if res.Error != nil {
+		return leasedUntil, res.Error
+	}
+	if len(*out) == 0 {
+		return leasedUntil, nil
+	}
+
+	keyColumn := c.KeyColumn
+	if keyColumn == "" {
+		keyColumn = "id"
+	}
+	keys := make([]interface{}, len(*out))
+	for i, row := range *out {
+		keys[i] = key(row)
+	}
+	return leasedUntil, tx.Model(new(T)).Where(keyColumn+" IN ?", keys).Update(c.DueColumn, leasedUntil).Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/pkg/domain"
+)
+
+// Interfaces
+type IMailOutboxRepository interface {
+	Get(ctx context.Context, mailOutboxId uuid.UUID) (model.MailOutbox, error)
+	Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.MailOutbox, error)
+	Create(ctx context.Context, dto model.MailOutbox) (mailOutboxId uuid.UUID, err error)
+	ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]model.MailOutbox, error)
+	UpdateResult(ctx context.Context, dto model.MailOutbox) (err error)
+}
+
+type MailOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewMailOutboxRepository(db *gorm.DB) IMailOutboxRepository {
+	return &MailOutboxRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *MailOutboxRepository) Get(ctx context.Context, mailOutboxId uuid.UUID) (out model.MailOutbox, err error) {
+	res := r.db.WithContext(ctx).First(&out, "id = ?", mailOutboxId)
+	if res.Error != nil {
+		return model.MailOutbox{}, res.Error
+	}
+	return
+}
+
+func (r *MailOutboxRepository) Fetch(ctx context.Context, pg *pagination.Pagination) (out []model.MailOutbox, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Model(&model.MailOutbox{}), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *MailOutboxRepository) Create(ctx context.Context, dto model.MailOutbox) (mailOutboxId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	dto.Status = domain.MailOutboxStatus_PENDING
+	if dto.NextAttemptAt.IsZero() {
+		dto.NextAttemptAt = time.Now()
+	}
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+// ClaimDue returns pending mails to send and postpones them by lease,
+// so that the other replicas skip them and they are retried if this worker dies while sending.
+func (r *MailOutboxRepository) ClaimDue(ctx context.Context, lease time.Duration, limit int) (out []model.MailOutbox, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := claimLease(tx, &out, func(m model.MailOutbox) interface{} { return m.ID },
+			leaseClaim{DueColumn: "next_attempt_at", Lease: lease, Limit: limit},
+			"status = ?", domain.MailOutboxStatus_PENDING)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r *MailOutboxRepository) UpdateResult(ctx context.Context, dto model.MailOutbox) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.MailOutbox{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"Status":        dto.Status,
+			"Attempts":      dto.Attempts,
+			"NextAttemptAt": dto.NextAttemptAt,
+			"LastError":     dto.LastError,
+			"SentAt":        dto.SentAt,
+			"Body":          dto.Body,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type INotificationChannelRepository interface {
+	Get(ctx context.Context, notificationChannelId uuid.UUID) (model.NotificationChannel, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.NotificationChannel, error)
+	Create(ctx context.Context, dto model.NotificationChannel) (notificationChannelId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.NotificationChannel) (err error)
+	Delete(ctx context.Context, notificationChannelId uuid.UUID) (err error)
+}
+
+type NotificationChannelRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationChannelRepository(db *gorm.DB) INotificationChannelRepository {
+	return &NotificationChannelRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *NotificationChannelRepository) Get(ctx context.Context, notificationChannelId uuid.UUID) (out model.NotificationChannel, err error) {
+	res := r.db.WithContext(ctx).Preload(clause.Associations).First(&out, "id = ?", notificationChannelId)
+	if res.Error != nil {
+		return model.NotificationChannel{}, res.Error
+	}
+	return
+}
+
+func (r *NotificationChannelRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.NotificationChannel, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Preload(clause.Associations).Model(&model.NotificationChannel{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *NotificationChannelRepository) Create(ctx context.Context, dto model.NotificationChannel) (notificationChannelId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+func (r *NotificationChannelRepository) Update(ctx context.Context, dto model.NotificationChannel) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.NotificationChannel{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"Name":        dto.Name,
+			"Description": dto.Description,
+			"Url":         dto.Url,
+			"Template":    dto.Template,
+			"Enabled":     dto.Enabled,
+			"UpdatorId":   dto.UpdatorId,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *NotificationChannelRepository) Delete(ctx context.Context, notificationChannelId uuid.UUID) (err error) {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 알림 설정 및 escalation tier 와의 연결을 먼저 해제한다.
+		res := tx.Exec("DELETE FROM system_notification_rule_notification_channels WHERE notification_channel_id = ?", notificationChannelId)
+		if res.Error != nil {
+			return res.Error
+		}
+		res = tx.Exec("DELETE FROM escalation_tier_notification_channels WHERE notification_channel_id = ?", notificationChannelId)
+		if res.Error != nil {
+			return res.Error
+		}
+		res = tx.Delete(&model.NotificationChannel{}, "id = ?", notificationChannelId)
+		if res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+}
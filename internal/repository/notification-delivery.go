@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/pkg/domain"
+)
+
+// Interfaces
+type INotificationDeliveryRepository interface {
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.NotificationDelivery, error)
+	Create(ctx context.Context, dto model.NotificationDelivery) (notificationDeliveryId uuid.UUID, err error)
+	ClaimDue(ctx context.Context, lease time.Duration, limit int) ([]model.NotificationDelivery, error)
+	UpdateResult(ctx context.Context, dto model.NotificationDelivery) (err error)
+}
+
+type NotificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationDeliveryRepository(db *gorm.DB) INotificationDeliveryRepository {
+	return &NotificationDeliveryRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *NotificationDeliveryRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.NotificationDelivery, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Model(&model.NotificationDelivery{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *NotificationDeliveryRepository) Create(ctx context.Context, dto model.NotificationDelivery) (notificationDeliveryId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+// ClaimDue returns pending deliveries to send and postpones them by lease,
+// so that the other replicas skip them and they are retried if this worker dies while sending.
+func (r *NotificationDeliveryRepository) ClaimDue(ctx context.Context, lease time.Duration, limit int) (out []model.NotificationDelivery, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := claimLease(tx, &out, func(d model.NotificationDelivery) interface{} { return d.ID },
+			leaseClaim{DueColumn: "next_attempt_at", Lease: lease, Limit: limit},
+			"status = ?", domain.NotificationDeliveryStatus_PENDING)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r *NotificationDeliveryRepository) UpdateResult(ctx context.Context, dto model.NotificationDelivery) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.NotificationDelivery{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"Status":        dto.Status,
+			"StatusCode":    dto.StatusCode,
+			"ErrorMessage":  dto.ErrorMessage,
+			"Message":       dto.Message,
+			"Attempts":      dto.Attempts,
+			"NextAttemptAt": dto.NextAttemptAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+// Interfaces
+type INotificationPreferenceRepository interface {
+	Get(ctx context.Context, userId uuid.UUID) (model.NotificationPreference, error)
+	FetchByUserIds(ctx context.Context, userIds []uuid.UUID) ([]model.NotificationPreference, error)
+	Save(ctx context.Context, dto model.NotificationPreference) error
+}
+
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) INotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *NotificationPreferenceRepository) Get(ctx context.Context, userId uuid.UUID) (out model.NotificationPreference, err error) {
+	res := r.db.WithContext(ctx).Preload("UnsubscribedRules").First(&out, "user_id = ?", userId)
+	if res.Error != nil {
+		return out, res.Error
+	}
+	return
+}
+
+func (r *NotificationPreferenceRepository) FetchByUserIds(ctx context.Context, userIds []uuid.UUID) (out []model.NotificationPreference, err error) {
+	if len(userIds) == 0 {
+		return out, nil
+	}
+	res := r.db.WithContext(ctx).Preload("UnsubscribedRules").Find(&out, "user_id IN ?", userIds)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, dto model.NotificationPreference) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("UnsubscribedRules").Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"channels", "minimum_severity", "quiet_hours_start", "quiet_hours_end", "timezone", "updated_at"}),
+		}).Create(&dto).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&dto).Association("UnsubscribedRules").Unscoped().Replace(dto.UnsubscribedRules)
+	})
+}
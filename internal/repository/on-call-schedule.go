@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IOnCallScheduleRepository interface {
+	Get(ctx context.Context, onCallScheduleId uuid.UUID) (model.OnCallSchedule, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.OnCallSchedule, error)
+	Create(ctx context.Context, dto model.OnCallSchedule) (onCallScheduleId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.OnCallSchedule) (err error)
+	Delete(ctx context.Context, onCallScheduleId uuid.UUID) (err error)
+}
+
+type OnCallScheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewOnCallScheduleRepository(db *gorm.DB) IOnCallScheduleRepository {
+	return &OnCallScheduleRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *OnCallScheduleRepository) Get(ctx context.Context, onCallScheduleId uuid.UUID) (out model.OnCallSchedule, err error) {
+	res := r.preload(r.db.WithContext(ctx)).First(&out, "id = ?", onCallScheduleId)
+	if res.Error != nil {
+		return model.OnCallSchedule{}, res.Error
+	}
+	return
+}
+
+func (r *OnCallScheduleRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.OnCallSchedule, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.preload(r.db.WithContext(ctx)).Model(&model.OnCallSchedule{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *OnCallScheduleRepository) Create(ctx context.Context, dto model.OnCallSchedule) (onCallScheduleId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	for i := range dto.Members {
+		dto.Members[i].Sequence = i
+	}
+	res := r.db.WithContext(ctx).Omit("Members.User").Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+// Update replaces the members of the schedule with dto.Members.
+func (r *OnCallScheduleRepository) Update(ctx context.Context, dto model.OnCallSchedule) (err error) {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.OnCallSchedule{}).
+			Where("id = ?", dto.ID).
+			Updates(map[string]interface{}{
+				"Name":         dto.Name,
+				"Description":  dto.Description,
+				"StartAt":      dto.StartAt,
+				"RotationDays": dto.RotationDays,
+				"UpdatorId":    dto.UpdatorId,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+
+		res = tx.Delete(&model.OnCallScheduleMember{}, "on_call_schedule_id = ?", dto.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		for i := range dto.Members {
+			dto.Members[i].OnCallScheduleId = dto.ID
+			dto.Members[i].Sequence = i
+		}
+		if len(dto.Members) > 0 {
+			if res = tx.Omit("User").Create(&dto.Members); res.Error != nil {
+				return res.Error
+			}
+		}
+		return nil
+	})
+}
+
+func (r *OnCallScheduleRepository) Delete(ctx context.Context, onCallScheduleId uuid.UUID) (err error) {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// escalation tier 와의 연결을 먼저 해제한다.
+		res := tx.Model(&model.EscalationTier{}).
+			Where("on_call_schedule_id = ?", onCallScheduleId).
+			Update("on_call_schedule_id", nil)
+		if res.Error != nil {
+			return res.Error
+		}
+		res = tx.Delete(&model.OnCallScheduleMember{}, "on_call_schedule_id = ?", onCallScheduleId)
+		if res.Error != nil {
+			return res.Error
+		}
+		res = tx.Delete(&model.OnCallSchedule{}, "id = ?", onCallScheduleId)
+		if res.Error != nil {
+			return res.Error
+		}
+		return nil
+	})
+}
+
+func (r *OnCallScheduleRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Members", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		Preload("Members.User").
+		Preload("Creator").
+		Preload("Updator")
+}
@@ -14,6 +14,7 @@ type IPermissionRepository interface {
 	Get(ctx context.Context, id uuid.UUID) (*model.Permission, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, permission *model.Permission) error
+	ListAllowedEndpoints(ctx context.Context, roleIds []string) ([]*model.Endpoint, error)
 }
 
 type PermissionRepository struct {
@@ -82,3 +83,22 @@ func (r PermissionRepository) Update(ctx context.Context, p *model.Permission) e
 	// update on is_allowed
 	return r.db.WithContext(ctx).Model(&model.Permission{}).Where("id = ?", p.ID).Updates(map[string]interface{}{"is_allowed": p.IsAllowed}).Error
 }
+
+// ListAllowedEndpoints returns the endpoints linked to allowed permissions of the given roles.
+func (r PermissionRepository) ListAllowedEndpoints(ctx context.Context, roleIds []string) ([]*model.Endpoint, error) {
+	var endpoints []*model.Endpoint
+	if len(roleIds) == 0 {
+		return endpoints, nil
+	}
+
+	err := r.db.WithContext(ctx).Model(&model.Endpoint{}).Distinct("endpoints.name", "endpoints.group").
+		Joins("JOIN permission_endpoints ON permission_endpoints.endpoint_name = endpoints.name").
+		Joins("JOIN permissions ON permissions.id = permission_endpoints.permission_id").
+		Where("permissions.role_id IN ? AND permissions.is_allowed = ? AND permissions.deleted_at IS NULL", roleIds, true).
+		Find(&endpoints).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoints, nil
+}
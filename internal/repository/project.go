@@ -22,6 +22,7 @@ type IProjectRepository interface {
 	GetProjectById(ctx context.Context, organizationId string, projectId string) (*model.Project, error)
 	GetProjectByIdAndLeader(ctx context.Context, organizationId string, projectId string) (*model.Project, error)
 	GetProjectByName(ctx context.Context, organizationId string, projectName string) (*model.Project, error)
+	GetProjectsWithMembers(ctx context.Context, organizationId string) ([]model.Project, error)
 	UpdateProject(ctx context.Context, p *model.Project) error
 	GetAllProjectRoles(ctx context.Context) ([]model.ProjectRole, error)
 	GetProjectRoleByName(ctx context.Context, name string) (*model.ProjectRole, error)
@@ -324,6 +325,22 @@ func (r *ProjectRepository) GetProjectByIdAndLeader(ctx context.Context, organiz
 	return p, nil
 }
 
+// GetProjectsWithMembers returns every project of the organization with the members and their roles.
+func (r *ProjectRepository) GetProjectsWithMembers(ctx context.Context, organizationId string) (ps []model.Project, err error) {
+	res := r.db.WithContext(ctx).
+		Preload("ProjectMembers").
+		Preload("ProjectMembers.ProjectRole").
+		Where("organization_id = ?", organizationId).
+		Order("name ASC").
+		Find(&ps)
+	if res.Error != nil {
+		log.Error(ctx, res.Error)
+		return nil, res.Error
+	}
+
+	return ps, nil
+}
+
 func (r *ProjectRepository) GetProjectByName(ctx context.Context, organizationId string, projectName string) (p *model.Project, err error) {
 	res := r.db.WithContext(ctx).Limit(1).
 		Where("organization_id = ? and name = ?", organizationId, projectName).
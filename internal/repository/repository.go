@@ -7,23 +7,42 @@ import (
 type FilterFunc func(user *gorm.DB) *gorm.DB
 
 type Repository struct {
-	Auth                       IAuthRepository
-	User                       IUserRepository
-	Cluster                    IClusterRepository
-	Organization               IOrganizationRepository
-	AppGroup                   IAppGroupRepository
-	AppServeApp                IAppServeAppRepository
-	CloudAccount               ICloudAccountRepository
-	StackTemplate              IStackTemplateRepository
-	Role                       IRoleRepository
-	Permission                 IPermissionRepository
-	Endpoint                   IEndpointRepository
-	Project                    IProjectRepository
-	Audit                      IAuditRepository
-	PolicyTemplate             IPolicyTemplateRepository
-	Policy                     IPolicyRepository
-	SystemNotification         ISystemNotificationRepository
-	SystemNotificationTemplate ISystemNotificationTemplateRepository
-	SystemNotificationRule     ISystemNotificationRuleRepository
-	Dashboard                  IDashboardRepository
+	Auth                         IAuthRepository
+	User                         IUserRepository
+	Cluster                      IClusterRepository
+	Organization                 IOrganizationRepository
+	AppGroup                     IAppGroupRepository
+	AppServeApp                  IAppServeAppRepository
+	CloudAccount                 ICloudAccountRepository
+	StackTemplate                IStackTemplateRepository
+	Role                         IRoleRepository
+	Permission                   IPermissionRepository
+	Endpoint                     IEndpointRepository
+	Project                      IProjectRepository
+	Audit                        IAuditRepository
+	PolicyTemplate               IPolicyTemplateRepository
+	Policy                       IPolicyRepository
+	SystemNotification           ISystemNotificationRepository
+	SystemNotificationTemplate   ISystemNotificationTemplateRepository
+	SystemNotificationRule       ISystemNotificationRuleRepository
+	SystemNotificationCredential ISystemNotificationCredentialRepository
+	NotificationChannel          INotificationChannelRepository
+	NotificationDelivery         INotificationDeliveryRepository
+	MailOutbox                   IMailOutboxRepository
+	EscalationPolicy             IEscalationPolicyRepository
+	OnCallSchedule               IOnCallScheduleRepository
+	SystemNotificationSilence    ISystemNotificationSilenceRepository
+	SystemNotificationDigest     ISystemNotificationDigestRepository
+	NotificationPreference       INotificationPreferenceRepository
+	ServiceAccount               IServiceAccountRepository
+	ApiToken                     IApiTokenRepository
+	SecurityPolicy               ISecurityPolicyRepository
+	Retention                    IRetentionRepository
+	SecondFactor                 ISecondFactorRepository
+	IdentityProvider             IIdentityProviderRepository
+	Invitation                   IInvitationRepository
+	UserImport                   IUserImportRepository
+	Impersonation                IImpersonationRepository
+	Dashboard                    IDashboardRepository
+	StreamTicket                 IStreamTicketRepository
 }
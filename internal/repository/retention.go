@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/pkg/domain"
+)
+
+// Interfaces
+type IRetentionRepository interface {
+	FetchPolicies(ctx context.Context, organizationId string) ([]model.RetentionPolicy, error)
+	SavePolicies(ctx context.Context, dtos []model.RetentionPolicy) error
+	FetchOrganizationIds(ctx context.Context) ([]string, error)
+	GetJob(ctx context.Context, name string) (model.RetentionJob, error)
+	ClaimJob(ctx context.Context, name string, lease time.Duration) (job model.RetentionJob, claimed bool, err error)
+	UpdateJob(ctx context.Context, dto model.RetentionJob) error
+	CreateRun(ctx context.Context, dto model.RetentionRun) error
+	FetchRuns(ctx context.Context, jobStartedAt time.Time, organizationId string) ([]model.RetentionRun, error)
+	DeleteRuns(ctx context.Context, before time.Time) error
+	FetchExpiredSystemNotifications(ctx context.Context, organizationId string, cutoff time.Time, limit int) ([]model.SystemNotification, error)
+	PurgeSystemNotifications(ctx context.Context, systemNotificationIds []uuid.UUID) (int64, error)
+	FetchExpiredAppServeAppTasks(ctx context.Context, organizationId string, cutoff time.Time, limit int) ([]model.AppServeAppTask, error)
+	PurgeAppServeAppTasks(ctx context.Context, taskIds []string) (int64, error)
+	PurgeExpiredEmailCodes(ctx context.Context, organizationId string, cutoff time.Time, limit int) (int64, error)
+}
+
+type RetentionRepository struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepository(db *gorm.DB) IRetentionRepository {
+	return &RetentionRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *RetentionRepository) FetchPolicies(ctx context.Context, organizationId string) (out []model.RetentionPolicy, err error) {
+	res := r.db.WithContext(ctx).Preload("Updator").Where("organization_id = ?", organizationId).Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *RetentionRepository) SavePolicies(ctx context.Context, dtos []model.RetentionPolicy) error {
+	if len(dtos) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Omit("Updator").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "data_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"retention_days", "archive", "updator_id", "updated_at"}),
+	}).Create(&dtos).Error
+}
+
+// FetchOrganizationIds returns the ids of all organizations, including the deleted ones which still have the data.
+func (r *RetentionRepository) FetchOrganizationIds(ctx context.Context) (out []string, err error) {
+	res := r.db.WithContext(ctx).Unscoped().Model(&model.Organization{}).Order("id").Pluck("id", &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *RetentionRepository) GetJob(ctx context.Context, name string) (out model.RetentionJob, err error) {
+	res := r.db.WithContext(ctx).First(&out, "name = ?", name)
+	if res.Error != nil {
+		return model.RetentionJob{}, res.Error
+	}
+	return
+}
+
+// ClaimJob takes the job for the lease if it is due. The job is due at once when it is created.
+func (r *RetentionRepository) ClaimJob(ctx context.Context, name string, lease time.Duration) (out model.RetentionJob, claimed bool, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		initial := model.RetentionJob{Name: name, NextRunAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&initial).Error; err != nil {
+			return err
+		}
+
+		var jobs []model.RetentionJob
+		leasedUntil, err := claimLease(tx, &jobs, func(job model.RetentionJob) interface{} { return job.Name },
+			leaseClaim{KeyColumn: "name", DueColumn: "next_run_at", Lease: lease, Limit: 1},
+			"name = ?", name)
+		if err != nil || len(jobs) == 0 {
+			return err
+		}
+
+		out = jobs[0]
+		out.NextRunAt = leasedUntil
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return model.RetentionJob{}, false, err
+	}
+	return
+}
+
+func (r *RetentionRepository) UpdateJob(ctx context.Context, dto model.RetentionJob) error {
+	res := r.db.WithContext(ctx).Model(&model.RetentionJob{}).
+		Where("name = ?", dto.Name).
+		Updates(map[string]interface{}{
+			"NextRunAt":      dto.NextRunAt,
+			"LastStartedAt":  dto.LastStartedAt,
+			"LastFinishedAt": dto.LastFinishedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *RetentionRepository) CreateRun(ctx context.Context, dto model.RetentionRun) error {
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+// FetchRuns returns the results of the job run started at jobStartedAt. All organizations are included if organizationId is empty.
+func (r *RetentionRepository) FetchRuns(ctx context.Context, jobStartedAt time.Time, organizationId string) (out []model.RetentionRun, err error) {
+	db := r.db.WithContext(ctx).Where("job_started_at = ?", jobStartedAt)
+	if organizationId != "" {
+		db = db.Where("organization_id = ?", organizationId)
+	}
+	res := db.Order("organization_id ASC, data_type ASC").Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *RetentionRepository) DeleteRuns(ctx context.Context, before time.Time) error {
+	res := r.db.WithContext(ctx).Where("job_started_at < ?", before).Delete(&model.RetentionRun{})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+// FetchExpiredSystemNotifications returns the closed or soft deleted systemNotifications not updated since the cutoff with the actions.
+// An open systemNotification is kept however old it is, since it may be still firing or waiting for a taker.
+func (r *RetentionRepository) FetchExpiredSystemNotifications(ctx context.Context, organizationId string, cutoff time.Time, limit int) (out []model.SystemNotification, err error) {
+	res := r.db.WithContext(ctx).Unscoped().
+		Preload("SystemNotificationActions", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Order("created_at ASC")
+		}).
+		Where("organization_id = ? AND updated_at < ?", organizationId, cutoff).
+		Where("status = ? OR deleted_at IS NOT NULL", domain.SystemNotificationActionStatus_CLOSED).
+		Order("updated_at ASC").Limit(limit).Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+// PurgeSystemNotifications deletes the systemNotifications permanently with the rows referring to them.
+func (r *RetentionRepository) PurgeSystemNotifications(ctx context.Context, systemNotificationIds []uuid.UUID) (purged int64, err error) {
+	if len(systemNotificationIds) == 0 {
+		return 0, nil
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("system_notification_id IN ?", systemNotificationIds).Delete(&model.SystemNotificationAction{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("system_notification_id IN ?", systemNotificationIds).Delete(&model.SystemNotificationDigestItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("system_notification_id IN ?", systemNotificationIds).Delete(&model.NotificationDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM system_notification_users WHERE system_notification_id IN ?", systemNotificationIds).Error; err != nil {
+			return err
+		}
+		res := tx.Unscoped().Where("id IN ?", systemNotificationIds).Delete(&model.SystemNotification{})
+		if res.Error != nil {
+			return res.Error
+		}
+		purged = res.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return
+}
+
+// FetchExpiredAppServeAppTasks returns the tasks created before the cutoff. The last task of each app is kept as the current state of the app.
+func (r *RetentionRepository) FetchExpiredAppServeAppTasks(ctx context.Context, organizationId string, cutoff time.Time, limit int) (out []model.AppServeAppTask, err error) {
+	res := r.db.WithContext(ctx).
+		Where("app_serve_app_id IN (SELECT id FROM app_serve_apps WHERE organization_id = ?)", organizationId).
+		Where("created_at < ?", cutoff).
+		Where("id NOT IN (SELECT DISTINCT ON (app_serve_app_id) id FROM app_serve_app_tasks ORDER BY app_serve_app_id, created_at DESC)").
+		Order("created_at ASC").Limit(limit).Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *RetentionRepository) PurgeAppServeAppTasks(ctx context.Context, taskIds []string) (int64, error) {
+	if len(taskIds) == 0 {
+		return 0, nil
+	}
+	res := r.db.WithContext(ctx).Where("id IN ?", taskIds).Delete(&model.AppServeAppTask{})
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// PurgeExpiredEmailCodes deletes the email codes of the users in the organization issued before the cutoff.
+// The codes are secrets and expire in minutes, so they are never archived.
+func (r *RetentionRepository) PurgeExpiredEmailCodes(ctx context.Context, organizationId string, cutoff time.Time, limit int) (int64, error) {
+	res := r.db.WithContext(ctx).Exec("DELETE FROM cache_email_codes WHERE id IN (SELECT id FROM cache_email_codes "+
+		"WHERE user_id IN (SELECT id FROM users WHERE organization_id = ?) AND updated_at < ? ORDER BY id LIMIT ?)",
+		organizationId, cutoff, limit)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+// Interfaces
+type ISecondFactorRepository interface {
+	Get(ctx context.Context, userId uuid.UUID) (model.SecondFactor, error)
+	Save(ctx context.Context, dto model.SecondFactor) error
+	Enable(ctx context.Context, userId uuid.UUID, enabledAt time.Time, recoveryCodeHashes []string) error
+	UseStep(ctx context.Context, userId uuid.UUID, step int64) error
+	UpdateRecoveryCodeHashes(ctx context.Context, userId uuid.UUID, hashes []string) error
+	UseRecoveryCode(ctx context.Context, userId uuid.UUID, hashes []string, remains []string) error
+	UpdateEncryptedSecret(ctx context.Context, userId uuid.UUID, encryptedSecret string) error
+	Delete(ctx context.Context, userId uuid.UUID) error
+
+	CreateChallenge(ctx context.Context, dto model.SecondFactorChallenge) error
+	ConsumeChallenge(ctx context.Context, tokenHash string) error
+	CreateSession(ctx context.Context, dto model.SecondFactorSession) error
+	IsSessionVerified(ctx context.Context, userId uuid.UUID, sessionId string) (bool, error)
+}
+
+type SecondFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewSecondFactorRepository(db *gorm.DB) ISecondFactorRepository {
+	return &SecondFactorRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *SecondFactorRepository) Get(ctx context.Context, userId uuid.UUID) (out model.SecondFactor, err error) {
+	res := r.db.WithContext(ctx).First(&out, "user_id = ?", userId)
+	if res.Error != nil {
+		return out, res.Error
+	}
+	return
+}
+
+func (r *SecondFactorRepository) Save(ctx context.Context, dto model.SecondFactor) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"encrypted_secret", "enabled", "enabled_at", "last_used_step", "recovery_code_hashes", "updated_at"}),
+	}).Create(&dto).Error
+}
+
+func (r *SecondFactorRepository) Enable(ctx context.Context, userId uuid.UUID, enabledAt time.Time, recoveryCodeHashes []string) error {
+	return r.db.WithContext(ctx).Model(&model.SecondFactor{UserId: userId}).
+		Select("enabled", "enabled_at", "recovery_code_hashes").
+		Updates(model.SecondFactor{Enabled: true, EnabledAt: &enabledAt, RecoveryCodeHashes: recoveryCodeHashes}).Error
+}
+
+// UseStep records the time step of the TOTP code. The step used before is rejected to prevent replay.
+func (r *SecondFactorRepository) UseStep(ctx context.Context, userId uuid.UUID, step int64) error {
+	res := r.db.WithContext(ctx).Model(&model.SecondFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userId, step).
+		Update("last_used_step", step)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("step %d is already used", step)
+	}
+	return nil
+}
+
+func (r *SecondFactorRepository) UpdateRecoveryCodeHashes(ctx context.Context, userId uuid.UUID, hashes []string) error {
+	return r.db.WithContext(ctx).Model(&model.SecondFactor{UserId: userId}).
+		Select("recovery_code_hashes").
+		Updates(model.SecondFactor{RecoveryCodeHashes: hashes}).Error
+}
+
+// UseRecoveryCode replaces the recovery codes with remains only if they are not changed since hashes are read.
+// The same recovery code used concurrently is accepted only once.
+func (r *SecondFactorRepository) UseRecoveryCode(ctx context.Context, userId uuid.UUID, hashes []string, remains []string) error {
+	current, err := json.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+	res := r.db.WithContext(ctx).Model(&model.SecondFactor{}).
+		Where("user_id = ? AND recovery_code_hashes = ?", userId, string(current)).
+		Select("recovery_code_hashes").
+		Updates(model.SecondFactor{RecoveryCodeHashes: remains})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("recovery codes of user %s are already changed", userId)
+	}
+	return nil
+}
+
+func (r *SecondFactorRepository) UpdateEncryptedSecret(ctx context.Context, userId uuid.UUID, encryptedSecret string) error {
+	return r.db.WithContext(ctx).Model(&model.SecondFactor{UserId: userId}).
+		Update("encrypted_secret", encryptedSecret).Error
+}
+
+func (r *SecondFactorRepository) Delete(ctx context.Context, userId uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.SecondFactorSession{}, "user_id = ?", userId).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.SecondFactor{}, "user_id = ?", userId).Error
+	})
+}
+
+func (r *SecondFactorRepository) CreateChallenge(ctx context.Context, dto model.SecondFactorChallenge) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.SecondFactorChallenge{}, "expired_at < ?", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(&dto).Error
+	})
+}
+
+// ConsumeChallenge deletes the challenge. It fails if the challenge is already consumed or expired.
+func (r *SecondFactorRepository) ConsumeChallenge(ctx context.Context, tokenHash string) error {
+	res := r.db.WithContext(ctx).
+		Where("token_hash = ? AND expired_at > ?", tokenHash, time.Now()).
+		Delete(&model.SecondFactorChallenge{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("second factor challenge is already used or expired")
+	}
+	return nil
+}
+
+func (r *SecondFactorRepository) CreateSession(ctx context.Context, dto model.SecondFactorSession) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.SecondFactorSession{}, "expired_at < ?", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id", "expired_at"}),
+		}).Create(&dto).Error
+	})
+}
+
+func (r *SecondFactorRepository) IsSessionVerified(ctx context.Context, userId uuid.UUID, sessionId string) (bool, error) {
+	var count int64
+	res := r.db.WithContext(ctx).Model(&model.SecondFactorSession{}).
+		Where("session_id = ? AND user_id = ? AND expired_at > ?", sessionId, userId, time.Now()).
+		Count(&count)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+// Interfaces
+type ISecurityPolicyRepository interface {
+	Get(ctx context.Context, organizationId string) (model.SecurityPolicy, error)
+	Save(ctx context.Context, dto model.SecurityPolicy) error
+}
+
+type SecurityPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewSecurityPolicyRepository(db *gorm.DB) ISecurityPolicyRepository {
+	return &SecurityPolicyRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *SecurityPolicyRepository) Get(ctx context.Context, organizationId string) (out model.SecurityPolicy, err error) {
+	res := r.db.WithContext(ctx).Preload("Updator").First(&out, "organization_id = ?", organizationId)
+	if res.Error != nil {
+		return out, res.Error
+	}
+	return
+}
+
+func (r *SecurityPolicyRepository) Save(ctx context.Context, dto model.SecurityPolicy) error {
+	return r.db.WithContext(ctx).Omit("Updator").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "organization_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"password_expiry_days", "password_min_length",
+			"password_require_uppercase", "password_require_lowercase", "password_require_digit", "password_require_special",
+			"password_history_depth", "session_idle_timeout_minutes", "session_max_lifespan_minutes", "require_second_factor", "updator_id", "updated_at"}),
+	}).Create(&dto).Error
+}
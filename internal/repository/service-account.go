@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IServiceAccountRepository interface {
+	Get(ctx context.Context, serviceAccountId uuid.UUID) (model.ServiceAccount, error)
+	GetByName(ctx context.Context, organizationId string, name string) (model.ServiceAccount, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.ServiceAccount, error)
+	Create(ctx context.Context, dto model.ServiceAccount) (serviceAccountId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.ServiceAccount) (err error)
+	Delete(ctx context.Context, dto model.ServiceAccount) (err error)
+}
+
+type ServiceAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceAccountRepository(db *gorm.DB) IServiceAccountRepository {
+	return &ServiceAccountRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *ServiceAccountRepository) Get(ctx context.Context, serviceAccountId uuid.UUID) (out model.ServiceAccount, err error) {
+	res := r.db.WithContext(ctx).Preload(clause.Associations).First(&out, "id = ?", serviceAccountId)
+	if res.Error != nil {
+		return model.ServiceAccount{}, res.Error
+	}
+	return
+}
+
+func (r *ServiceAccountRepository) GetByName(ctx context.Context, organizationId string, name string) (out model.ServiceAccount, err error) {
+	res := r.db.WithContext(ctx).First(&out, "organization_id = ? AND name = ?", organizationId, name)
+	if res.Error != nil {
+		return model.ServiceAccount{}, res.Error
+	}
+	return
+}
+
+func (r *ServiceAccountRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.ServiceAccount, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Preload(clause.Associations).Model(&model.ServiceAccount{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *ServiceAccountRepository) Create(ctx context.Context, dto model.ServiceAccount) (serviceAccountId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+func (r *ServiceAccountRepository) Update(ctx context.Context, dto model.ServiceAccount) (err error) {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.ServiceAccount{}).
+			Where("id = ?", dto.ID).
+			Updates(map[string]interface{}{
+				"Description": dto.Description,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		return tx.Model(&dto).Association("Roles").Replace(dto.Roles)
+	})
+}
+
+func (r *ServiceAccountRepository) Delete(ctx context.Context, dto model.ServiceAccount) (err error) {
+	res := r.db.WithContext(ctx).Delete(&dto)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+// Interfaces
+type IStreamTicketRepository interface {
+	Create(ctx context.Context, dto model.StreamTicket) error
+	Consume(ctx context.Context, ticketHash string) (model.StreamTicket, error)
+}
+
+type StreamTicketRepository struct {
+	db *gorm.DB
+}
+
+func NewStreamTicketRepository(db *gorm.DB) IStreamTicketRepository {
+	return &StreamTicketRepository{
+		db: db,
+	}
+}
+
+// Logics
+// Create stores the ticket, and deletes the tickets already expired.
+func (r *StreamTicketRepository) Create(ctx context.Context, dto model.StreamTicket) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expired_at < ?", time.Now()).Delete(&model.StreamTicket{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&dto).Error
+	})
+}
+
+// Consume deletes the ticket and returns it. The ticket can be consumed only once before it expires.
+func (r *StreamTicketRepository) Consume(ctx context.Context, ticketHash string) (out model.StreamTicket, err error) {
+	var tickets []model.StreamTicket
+	res := r.db.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("ticket_hash = ? AND expired_at > ?", ticketHash, time.Now()).
+		Delete(&tickets)
+	if res.Error != nil {
+		return out, res.Error
+	}
+	if len(tickets) == 0 {
+		return out, fmt.Errorf("stream ticket is not found or expired")
+	}
+	return tickets[0], nil
+}
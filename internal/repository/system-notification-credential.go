@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type ISystemNotificationCredentialRepository interface {
+	Get(ctx context.Context, systemNotificationCredentialId uuid.UUID) (model.SystemNotificationCredential, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotificationCredential, error)
+	Create(ctx context.Context, dto model.SystemNotificationCredential) (systemNotificationCredentialId uuid.UUID, err error)
+	UpdateSecret(ctx context.Context, dto model.SystemNotificationCredential) (err error)
+	UpdateLastUsedAt(ctx context.Context, systemNotificationCredentialId uuid.UUID, lastUsedAt time.Time) (err error)
+	Delete(ctx context.Context, systemNotificationCredentialId uuid.UUID) (err error)
+	UseNonce(ctx context.Context, systemNotificationCredentialId uuid.UUID, nonce string, expiredAt time.Time) (used bool, err error)
+}
+
+type SystemNotificationCredentialRepository struct {
+	db *gorm.DB
+}
+
+func NewSystemNotificationCredentialRepository(db *gorm.DB) ISystemNotificationCredentialRepository {
+	return &SystemNotificationCredentialRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *SystemNotificationCredentialRepository) Get(ctx context.Context, systemNotificationCredentialId uuid.UUID) (out model.SystemNotificationCredential, err error) {
+	res := r.db.WithContext(ctx).Preload(clause.Associations).First(&out, "id = ?", systemNotificationCredentialId)
+	if res.Error != nil {
+		return model.SystemNotificationCredential{}, res.Error
+	}
+	return
+}
+
+func (r *SystemNotificationCredentialRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.SystemNotificationCredential, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Preload(clause.Associations).Model(&model.SystemNotificationCredential{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *SystemNotificationCredentialRepository) Create(ctx context.Context, dto model.SystemNotificationCredential) (systemNotificationCredentialId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+func (r *SystemNotificationCredentialRepository) UpdateSecret(ctx context.Context, dto model.SystemNotificationCredential) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.SystemNotificationCredential{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"Secret":                  dto.Secret,
+			"PreviousSecret":          dto.PreviousSecret,
+			"PreviousSecretExpiredAt": dto.PreviousSecretExpiredAt,
+			"RotatedAt":               dto.RotatedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *SystemNotificationCredentialRepository) UpdateLastUsedAt(ctx context.Context, systemNotificationCredentialId uuid.UUID, lastUsedAt time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.SystemNotificationCredential{}).
+		Where("id = ?", systemNotificationCredentialId).
+		Update("last_used_at", lastUsedAt)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *SystemNotificationCredentialRepository) Delete(ctx context.Context, systemNotificationCredentialId uuid.UUID) (err error) {
+	res := r.db.WithContext(ctx).Delete(&model.SystemNotificationCredential{}, "id = ?", systemNotificationCredentialId)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+// UseNonce stores the nonce of the credential, and reports whether the nonce is already used. The expired nonces of the credential are removed first.
+func (r *SystemNotificationCredentialRepository) UseNonce(ctx context.Context, systemNotificationCredentialId uuid.UUID, nonce string, expiredAt time.Time) (used bool, err error) {
+	res := r.db.WithContext(ctx).
+		Where("credential_id = ? AND expired_at < ?", systemNotificationCredentialId, time.Now()).
+		Delete(&model.SystemNotificationCredentialNonce{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	res = r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model.SystemNotificationCredentialNonce{
+		CredentialId: systemNotificationCredentialId,
+		Nonce:        nonce,
+		ExpiredAt:    expiredAt,
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 0, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+// Interfaces
+type ISystemNotificationDigestRepository interface {
+	Create(ctx context.Context, dto model.SystemNotificationDigestItem) (systemNotificationDigestItemId uuid.UUID, err error)
+	ClaimDue(ctx context.Context, digestMode string, before time.Time, limit int) ([]model.SystemNotificationDigestItem, error)
+}
+
+type SystemNotificationDigestRepository struct {
+	db *gorm.DB
+}
+
+func NewSystemNotificationDigestRepository(db *gorm.DB) ISystemNotificationDigestRepository {
+	return &SystemNotificationDigestRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *SystemNotificationDigestRepository) Create(ctx context.Context, dto model.SystemNotificationDigestItem) (systemNotificationDigestItemId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+// ClaimDue marks the unsent items created before the time as sent and returns them,
+// so that each item is summarized only once among the replicas. The mail itself is retried by the outbox.
+func (r *SystemNotificationDigestRepository) ClaimDue(ctx context.Context, digestMode string, before time.Time, limit int) (out []model.SystemNotificationDigestItem, err error) {
+	ids := make([]uuid.UUID, 0)
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.SystemNotificationDigestItem{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("digest_mode = ? AND sent_at IS NULL AND created_at < ?", digestMode, before).
+			Order("created_at ASC").
+			Limit(limit).
+			Pluck("id", &ids)
+		if res.Error != nil {
+			return res.Error
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&model.SystemNotificationDigestItem{}).Where("id IN ?", ids).Update("sent_at", time.Now()).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	res := r.db.WithContext(ctx).
+		Preload("SystemNotification").
+		Preload("SystemNotification.Cluster").
+		Where("id IN ?", ids).
+		Order("created_at ASC").
+		Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
@@ -123,6 +123,8 @@ func (r *SystemNotificationRuleRepository) Update(ctx context.Context, dto model
 	m.MessageContent = dto.MessageContent
 	m.MessageActionProposal = dto.MessageActionProposal
 	m.UpdatorId = dto.UpdatorId
+	m.EscalationPolicyId = dto.EscalationPolicyId
+	m.EscalationPolicy = nil
 
 	res = r.db.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: true}).Save(&m)
 	if res.Error != nil {
@@ -134,6 +136,11 @@ func (r *SystemNotificationRuleRepository) Update(ctx context.Context, dto model
 		return err
 	}
 
+	err = r.db.WithContext(ctx).Model(&m).Association("NotificationChannels").Replace(dto.NotificationChannels)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
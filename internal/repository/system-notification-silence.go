@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type ISystemNotificationSilenceRepository interface {
+	Get(ctx context.Context, systemNotificationSilenceId uuid.UUID) (model.SystemNotificationSilence, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotificationSilence, error)
+	FetchActive(ctx context.Context, organizationId string, at time.Time) ([]model.SystemNotificationSilence, error)
+	Create(ctx context.Context, dto model.SystemNotificationSilence) (systemNotificationSilenceId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.SystemNotificationSilence) (err error)
+	Delete(ctx context.Context, systemNotificationSilenceId uuid.UUID) (err error)
+}
+
+type SystemNotificationSilenceRepository struct {
+	db *gorm.DB
+}
+
+func NewSystemNotificationSilenceRepository(db *gorm.DB) ISystemNotificationSilenceRepository {
+	return &SystemNotificationSilenceRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *SystemNotificationSilenceRepository) Get(ctx context.Context, systemNotificationSilenceId uuid.UUID) (out model.SystemNotificationSilence, err error) {
+	res := r.db.WithContext(ctx).Preload(clause.Associations).First(&out, "id = ?", systemNotificationSilenceId)
+	if res.Error != nil {
+		return model.SystemNotificationSilence{}, res.Error
+	}
+	return
+}
+
+func (r *SystemNotificationSilenceRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.SystemNotificationSilence, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Preload(clause.Associations).Model(&model.SystemNotificationSilence{}).
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *SystemNotificationSilenceRepository) FetchActive(ctx context.Context, organizationId string, at time.Time) (out []model.SystemNotificationSilence, err error) {
+	res := r.db.WithContext(ctx).
+		Where("organization_id = ? AND starts_at <= ? AND ends_at > ?", organizationId, at, at).
+		Order("created_at ASC").
+		Find(&out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *SystemNotificationSilenceRepository) Create(ctx context.Context, dto model.SystemNotificationSilence) (systemNotificationSilenceId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	res := r.db.WithContext(ctx).Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+func (r *SystemNotificationSilenceRepository) Update(ctx context.Context, dto model.SystemNotificationSilence) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.SystemNotificationSilence{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"ClusterId":                dto.ClusterId,
+			"Node":                     dto.Node,
+			"SystemNotificationRuleId": dto.SystemNotificationRuleId,
+			"Severity":                 dto.Severity,
+			"StartsAt":                 dto.StartsAt,
+			"EndsAt":                   dto.EndsAt,
+			"Comment":                  dto.Comment,
+			"UpdatorId":                dto.UpdatorId,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *SystemNotificationSilenceRepository) Delete(ctx context.Context, systemNotificationSilenceId uuid.UUID) (err error) {
+	res := r.db.WithContext(ctx).Delete(&model.SystemNotificationSilence{}, "id = ?", systemNotificationSilenceId)
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
@@ -23,7 +23,11 @@ type ISystemNotificationRepository interface {
 	FetchSystemNotifications(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotification, error)
 	FetchPolicyNotifications(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotification, error)
 	FetchPodRestart(ctx context.Context, organizationId string, start time.Time, end time.Time) ([]model.SystemNotification, error)
+	GetOpenedByFingerprint(ctx context.Context, organizationId string, fingerprint string) (model.SystemNotification, error)
 	Create(ctx context.Context, dto model.SystemNotification) (systemNotificationId uuid.UUID, err error)
+	UpdateFired(ctx context.Context, dto model.SystemNotification) (err error)
+	ClaimEscalationDue(ctx context.Context, lease time.Duration, limit int) ([]model.SystemNotification, error)
+	UpdateEscalation(ctx context.Context, systemNotificationId uuid.UUID, level int, nextEscalationAt *time.Time) (err error)
 	Update(ctx context.Context, dto model.SystemNotification) (err error)
 	Delete(ctx context.Context, dto model.SystemNotification) (err error)
 	CreateSystemNotificationAction(ctx context.Context, dto model.SystemNotificationAction) (systemNotificationActionId uuid.UUID, err error)
@@ -80,6 +84,14 @@ func (r *SystemNotificationRepository) FetchSystemNotifications(ctx context.Cont
 		Where("system_notification_rule_users.user_id is null OR system_notification_rule_users.user_id = ?", userInfo.GetUserId()).
 		Where("system_notifications.organization_id = ? AND system_notifications.notification_type = 'SYSTEM_NOTIFICATION'", organizationId)
 
+	// 무음 처리된 알림은 기록만 하고, silenced 필터로 요청한 경우에만 보여준다.
+	silencedFilter := pg.GetFilter("silenced")
+	if silencedFilter != nil && silencedFilter.Values[0] == "true" {
+		db = db.Where("system_notifications.silence_id is not null")
+	} else {
+		db = db.Where("system_notifications.silence_id is null")
+	}
+
 	readFilter := pg.GetFilter("read")
 	if readFilter != nil {
 		if readFilter.Values[0] == "true" {
@@ -142,10 +154,20 @@ func (r *SystemNotificationRepository) FetchPodRestart(ctx context.Context, orga
 	return
 }
 
+func (r *SystemNotificationRepository) GetOpenedByFingerprint(ctx context.Context, organizationId string, fingerprint string) (out model.SystemNotification, err error) {
+	res := r.db.WithContext(ctx).Order("created_at DESC").
+		First(&out, "organization_id = ? AND fingerprint = ? AND status <> ?", organizationId, fingerprint, domain.SystemNotificationActionStatus_CLOSED)
+	if res.Error != nil {
+		return model.SystemNotification{}, res.Error
+	}
+	return
+}
+
 func (r *SystemNotificationRepository) Create(ctx context.Context, dto model.SystemNotification) (systemNotificationId uuid.UUID, err error) {
 
 	dto.ID = uuid.New()
 	dto.Status = domain.SystemNotificationActionStatus_CREATED
+	dto.FireCount = 1
 	res := r.db.WithContext(ctx).Create(&dto)
 	if res.Error != nil {
 		return uuid.Nil, res.Error
@@ -165,6 +187,50 @@ func (r *SystemNotificationRepository) Update(ctx context.Context, dto model.Sys
 	return nil
 }
 
+// UpdateFired records that the alert of an opened systemNotification fired again.
+func (r *SystemNotificationRepository) UpdateFired(ctx context.Context, dto model.SystemNotification) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.SystemNotification{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"FireCount":   gorm.Expr("fire_count + 1"),
+			"LastFiredAt": dto.LastFiredAt,
+			"RawData":     dto.RawData,
+			"SilenceId":   dto.SilenceId,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+// ClaimEscalationDue returns the systemNotifications not taken until the escalation time and postpones them by lease,
+// so that the other replicas skip them.
+func (r *SystemNotificationRepository) ClaimEscalationDue(ctx context.Context, lease time.Duration, limit int) (out []model.SystemNotification, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := claimLease(tx, &out, func(m model.SystemNotification) interface{} { return m.ID },
+			leaseClaim{DueColumn: "next_escalation_at", Lease: lease, Limit: limit},
+			"status = ?", domain.SystemNotificationActionStatus_CREATED)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r *SystemNotificationRepository) UpdateEscalation(ctx context.Context, systemNotificationId uuid.UUID, level int, nextEscalationAt *time.Time) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.SystemNotification{}).
+		Where("id = ?", systemNotificationId).
+		Updates(map[string]interface{}{
+			"EscalationLevel":  level,
+			"NextEscalationAt": nextEscalationAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
 func (r *SystemNotificationRepository) Delete(ctx context.Context, dto model.SystemNotification) (err error) {
 	res := r.db.WithContext(ctx).Delete(&model.SystemNotification{}, "id = ?", dto.ID)
 	if res.Error != nil {
@@ -185,9 +251,13 @@ func (r *SystemNotificationRepository) CreateSystemNotificationAction(ctx contex
 	if res.Error != nil {
 		return uuid.Nil, res.Error
 	}
+	// 처리가 시작되면 에스컬레이션을 멈춘다.
 	res = r.db.WithContext(ctx).Model(&model.SystemNotification{}).
 		Where("id = ?", dto.SystemNotificationId).
-		Update("status", dto.Status)
+		Updates(map[string]interface{}{
+			"Status":           dto.Status,
+			"NextEscalationAt": nil,
+		})
 	if res.Error != nil {
 		return uuid.Nil, res.Error
 	}
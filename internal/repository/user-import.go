@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+)
+
+// Interfaces
+type IUserImportRepository interface {
+	Get(ctx context.Context, organizationId string, jobId uuid.UUID) (model.UserImportJob, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.UserImportJob, error)
+	Create(ctx context.Context, dto model.UserImportJob) (jobId uuid.UUID, err error)
+	ClaimDue(ctx context.Context, lease time.Duration) ([]model.UserImportJob, error)
+	UpdateRow(ctx context.Context, dto model.UserImportRow) (err error)
+	UpdateResult(ctx context.Context, dto model.UserImportJob) (err error)
+}
+
+type UserImportRepository struct {
+	db *gorm.DB
+}
+
+func NewUserImportRepository(db *gorm.DB) IUserImportRepository {
+	return &UserImportRepository{
+		db: db,
+	}
+}
+
+// Logics
+func (r *UserImportRepository) Get(ctx context.Context, organizationId string, jobId uuid.UUID) (out model.UserImportJob, err error) {
+	res := r.db.WithContext(ctx).
+		Preload("Rows", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line ASC")
+		}).
+		Preload("Creator").
+		First(&out, "organization_id = ? AND id = ?", organizationId, jobId)
+	if res.Error != nil {
+		return model.UserImportJob{}, res.Error
+	}
+	return
+}
+
+func (r *UserImportRepository) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) (out []model.UserImportJob, err error) {
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+
+	_, res := pg.Fetch(r.db.WithContext(ctx).Model(&model.UserImportJob{}).Preload("Creator").
+		Where("organization_id = ?", organizationId), &out)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return
+}
+
+func (r *UserImportRepository) Create(ctx context.Context, dto model.UserImportJob) (jobId uuid.UUID, err error) {
+	dto.ID = uuid.New()
+	for i := range dto.Rows {
+		dto.Rows[i].ID = uuid.New()
+	}
+	if dto.NextAttemptAt.IsZero() {
+		dto.NextAttemptAt = time.Now()
+	}
+	res := r.db.WithContext(ctx).Omit("Creator").Create(&dto)
+	if res.Error != nil {
+		return uuid.Nil, res.Error
+	}
+	return dto.ID, nil
+}
+
+// ClaimDue returns a job to run and postpones it by lease, so that the other replicas skip it.
+// The running job of the dead worker is claimed again after the lease and resumes from the pending rows.
+func (r *UserImportRepository) ClaimDue(ctx context.Context, lease time.Duration) (out []model.UserImportJob, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		leasedUntil, err := claimLease(tx, &out, func(job model.UserImportJob) interface{} { return job.ID },
+			leaseClaim{DueColumn: "next_attempt_at", Lease: lease, Limit: 1},
+			"status IN ?", []model.UserImportStatus{model.UserImportStatusPending, model.UserImportStatusRunning})
+		if err != nil || len(out) == 0 {
+			return err
+		}
+
+		job := &out[0]
+		now := time.Now()
+		if job.StartedAt == nil {
+			job.StartedAt = &now
+		}
+		job.Status = model.UserImportStatusRunning
+		job.NextAttemptAt = leasedUntil
+		if err := tx.Model(&model.UserImportJob{}).Where("id = ?", job.ID).
+			Updates(map[string]interface{}{
+				"Status":    job.Status,
+				"StartedAt": job.StartedAt,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Order("line ASC").Find(&job.Rows, "job_id = ?", job.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r *UserImportRepository) UpdateRow(ctx context.Context, dto model.UserImportRow) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.UserImportRow{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"Status":  dto.Status,
+			"Message": dto.Message,
+			"UserId":  dto.UserId,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
+
+func (r *UserImportRepository) UpdateResult(ctx context.Context, dto model.UserImportJob) (err error) {
+	res := r.db.WithContext(ctx).Model(&model.UserImportJob{}).
+		Where("id = ?", dto.ID).
+		Updates(map[string]interface{}{
+			"Status":        dto.Status,
+			"CreatedCount":  dto.CreatedCount,
+			"FailedCount":   dto.FailedCount,
+			"NextAttemptAt": dto.NextAttemptAt,
+			"CompletedAt":   dto.CompletedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	return nil
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/scim"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
 	"github.com/openinfradev/tks-api/pkg/log"
 	"gorm.io/gorm"
@@ -16,12 +19,16 @@ import (
 // Interface
 type IUserRepository interface {
 	Create(ctx context.Context, user *model.User) (*model.User, error)
+	CreateInTx(ctx context.Context, user *model.User, fn func() error) (*model.User, error)
 	List(ctx context.Context, filters ...FilterFunc) (out *[]model.User, err error)
+	ListPage(ctx context.Context, offset int, limit int, filters ...FilterFunc) (out []model.User, total int64, err error)
 	ListWithPagination(ctx context.Context, pg *pagination.Pagination, organizationId string) (out *[]model.User, err error)
 	Get(ctx context.Context, accountId string, organizationId string) (model.User, error)
 	GetByUuid(ctx context.Context, userId uuid.UUID) (model.User, error)
 	Update(ctx context.Context, user *model.User) (*model.User, error)
 	UpdatePasswordAt(ctx context.Context, userId uuid.UUID, organizationId string, isTemporary bool) error
+	UpdateDisabled(ctx context.Context, userId uuid.UUID, disabled bool) error
+	UpdateRolesInTx(ctx context.Context, userId uuid.UUID, roles []model.Role, fn func() error) error
 	DeleteWithUuid(ctx context.Context, uuid uuid.UUID) error
 	Flush(ctx context.Context, organizationId string) error
 
@@ -31,6 +38,7 @@ type IUserRepository interface {
 	OrganizationFilter(organization string) FilterFunc
 	EmailFilter(email string) FilterFunc
 	NameFilter(name string) FilterFunc
+	ScimFilter(filter scim.Filter) FilterFunc
 }
 
 type UserRepository struct {
@@ -81,6 +89,27 @@ func (r *UserRepository) Create(ctx context.Context, user *model.User) (*model.U
 	return &resp, nil
 }
 
+// CreateInTx creates the user and calls fn in the same transaction. The user is not created if fn fails.
+func (r *UserRepository) CreateInTx(ctx context.Context, user *model.User, fn func() error) (*model.User, error) {
+	user.PasswordUpdatedAt = time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return fn()
+	})
+	if err != nil {
+		log.Error(ctx, err)
+		return nil, err
+	}
+	resp, err := r.getUserByAccountId(ctx, user.AccountId, user.Organization.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 func (r *UserRepository) List(ctx context.Context, filters ...FilterFunc) (*[]model.User, error) {
 	var users []model.User
 	var res *gorm.DB
@@ -114,6 +143,29 @@ func (r *UserRepository) List(ctx context.Context, filters ...FilterFunc) (*[]mo
 	return &out, nil
 }
 
+// ListPage returns the users from offset in the order of creation, and the total count of the users matched.
+func (r *UserRepository) ListPage(ctx context.Context, offset int, limit int, filters ...FilterFunc) (out []model.User, total int64, err error) {
+	db := r.db.WithContext(ctx).Model(&model.User{})
+	for _, f := range filters {
+		db = f(db)
+	}
+
+	if err = db.Count(&total).Error; err != nil {
+		log.Errorf(ctx, "error is :%s(%T)", err.Error(), err)
+		return nil, 0, err
+	}
+	if limit <= 0 || int64(offset) >= total {
+		return []model.User{}, total, nil
+	}
+
+	res := db.Preload("Organization").Preload("Roles").Order("users.created_at").Offset(offset).Limit(limit).Find(&out)
+	if res.Error != nil {
+		log.Errorf(ctx, "error is :%s(%T)", res.Error.Error(), res.Error)
+		return nil, 0, res.Error
+	}
+	return out, total, nil
+}
+
 func (r *UserRepository) ListWithPagination(ctx context.Context, pg *pagination.Pagination, organizationId string) (*[]model.User, error) {
 	var users []model.User
 
@@ -194,11 +246,12 @@ func (r *UserRepository) ListUsersByRole(ctx context.Context, organizationId str
 
 func (r *UserRepository) Update(ctx context.Context, user *model.User) (*model.User, error) {
 	res := r.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", user.ID).
-		Select("Name", "Email", "Department", "Description").Updates(model.User{
+		Select("Name", "Email", "Department", "Description", "ExternalId").Updates(model.User{
 		Name:        user.Name,
 		Email:       user.Email,
 		Department:  user.Department,
 		Description: user.Description,
+		ExternalId:  user.ExternalId,
 	})
 
 	if res.Error != nil {
@@ -241,6 +294,31 @@ func (r *UserRepository) UpdatePasswordAt(ctx context.Context, userId uuid.UUID,
 	return nil
 }
 
+func (r *UserRepository) UpdateDisabled(ctx context.Context, userId uuid.UUID, disabled bool) error {
+	res := r.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userId).
+		Update("disabled", disabled)
+	if res.Error != nil {
+		log.Errorf(ctx, "error is :%s(%T)", res.Error.Error(), res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return httpErrors.NewNotFoundError(httpErrors.NotFound, "", "")
+	}
+
+	return nil
+}
+
+// UpdateRolesInTx replaces the roles of the user and calls fn in the same transaction. The roles are not changed if fn fails.
+func (r *UserRepository) UpdateRolesInTx(ctx context.Context, userId uuid.UUID, roles []model.Role, fn func() error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.User{ID: userId}).Association("Roles").Replace(roles); err != nil {
+			log.Errorf(ctx, "error is :%s(%T)", err.Error(), err)
+			return err
+		}
+		return fn()
+	})
+}
+
 func (r *UserRepository) DeleteWithUuid(ctx context.Context, uuid uuid.UUID) error {
 	var user model.User
 	if err := r.db.WithContext(ctx).Model(&model.User{}).Preload("Organization").Preload("Roles").Find(&user, "id = ?", uuid).Error; err != nil {
@@ -318,3 +396,88 @@ func (r *UserRepository) NameFilter(name string) FilterFunc {
 		return user.Where("name = ?", name)
 	}
 }
+
+// SCIM 속성별 사용자 컬럼. 값은 SCIM 응답과 같은 형식의 문자열로 비교한다.
+var scimUserColumns = map[string]string{
+	"id":             "CAST(users.id AS TEXT)",
+	"username":       "users.account_id",
+	"externalid":     "users.external_id",
+	"displayname":    "users.name",
+	"name.formatted": "users.name",
+	"emails":         "users.email",
+	"emails.value":   "users.email",
+	"department":     "users.department",
+	"active":         "CASE WHEN users.disabled THEN 'false' ELSE 'true' END",
+}
+
+// 다중 값인 SCIM 속성은 사용자의 역할 중 하나라도 만족하면 일치한다.
+var scimUserRoleColumns = map[string]string{
+	"groups":         "user_roles.role_id",
+	"groups.value":   "user_roles.role_id",
+	"groups.display": "roles.name",
+}
+
+var scimLikeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ScimFilter matches the users in the database as scim.Filter matches the attributes. The comparison is case-insensitive.
+func (r *UserRepository) ScimFilter(filter scim.Filter) FilterFunc {
+	return func(user *gorm.DB) *gorm.DB {
+		ors := make([]string, 0, len(filter))
+		args := []interface{}{}
+		for _, conditions := range filter {
+			ands := make([]string, 0, len(conditions))
+			for _, cond := range conditions {
+				query, condArgs := scimUserCondition(cond)
+				ands = append(ands, query)
+				args = append(args, condArgs...)
+			}
+			ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+		}
+		return user.Where("("+strings.Join(ors, " OR ")+")", args...)
+	}
+}
+
+func scimUserCondition(cond scim.Condition) (string, []interface{}) {
+	if column, ok := scimUserColumns[cond.Attribute]; ok {
+		return scimCompare("COALESCE("+column+", '')", cond)
+	}
+
+	column, ok := scimUserRoleColumns[cond.Attribute]
+	if !ok {
+		// 저장하지 않는 속성은 값이 없는 것으로 본다.
+		if cond.Operator == scim.OperatorNotEqual {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	}
+	exists := "EXISTS (SELECT 1 FROM user_roles JOIN roles ON roles.id = user_roles.role_id WHERE user_roles.user_id = users.id AND %s)"
+	switch cond.Operator {
+	case scim.OperatorPresent:
+		return fmt.Sprintf(exists, column+" <> ''"), nil
+	case scim.OperatorNotEqual:
+		query, args := scimCompare(column, scim.Condition{Attribute: cond.Attribute, Operator: scim.OperatorEqual, Value: cond.Value})
+		return "NOT " + fmt.Sprintf(exists, query), args
+	default:
+		query, args := scimCompare(column, cond)
+		return fmt.Sprintf(exists, query), args
+	}
+}
+
+func scimCompare(column string, cond scim.Condition) (string, []interface{}) {
+	switch cond.Operator {
+	case scim.OperatorPresent:
+		return column + " <> ''", nil
+	case scim.OperatorEqual:
+		return "LOWER(" + column + ") = LOWER(?)", []interface{}{cond.Value}
+	case scim.OperatorNotEqual:
+		return "LOWER(" + column + ") <> LOWER(?)", []interface{}{cond.Value}
+	case scim.OperatorContains:
+		return column + " ILIKE ?", []interface{}{"%" + scimLikeEscaper.Replace(cond.Value) + "%"}
+	case scim.OperatorStartsWith:
+		return column + " ILIKE ?", []interface{}{scimLikeEscaper.Replace(cond.Value) + "%"}
+	case scim.OperatorEndsWith:
+		return column + " ILIKE ?", []interface{}{"%" + scimLikeEscaper.Replace(cond.Value)}
+	default:
+		return "FALSE", nil
+	}
+}
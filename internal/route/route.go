@@ -1,6 +1,7 @@
 package route
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -16,9 +17,12 @@ import (
 	"github.com/openinfradev/tks-api/internal/keycloak"
 	internalMiddleware "github.com/openinfradev/tks-api/internal/middleware"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/authenticator"
+	authApiToken "github.com/openinfradev/tks-api/internal/middleware/auth/authenticator/apitoken"
 	authCustom "github.com/openinfradev/tks-api/internal/middleware/auth/authenticator/custom"
+	authImpersonation "github.com/openinfradev/tks-api/internal/middleware/auth/authenticator/impersonation"
 	authKeycloak "github.com/openinfradev/tks-api/internal/middleware/auth/authenticator/keycloak"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/authorizer"
+	authCache "github.com/openinfradev/tks-api/internal/middleware/auth/cache"
 	"github.com/openinfradev/tks-api/internal/repository"
 	"github.com/openinfradev/tks-api/internal/usecase"
 	argowf "github.com/openinfradev/tks-api/pkg/argo-client"
@@ -36,60 +40,121 @@ var (
 	SYSTEM_API_PREFIX  = internal.SYSTEM_API_PREFIX
 )
 
-func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloak, asset http.Handler) http.Handler {
+func SetupRouter(ctx context.Context, db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloak, asset http.Handler) http.Handler {
 	r := mux.NewRouter()
 
 	cache := gcache.New(5*time.Minute, 10*time.Minute)
 
 	repoFactory := repository.Repository{
-		Auth:                       repository.NewAuthRepository(db),
-		User:                       repository.NewUserRepository(db),
-		Cluster:                    repository.NewClusterRepository(db),
-		Organization:               repository.NewOrganizationRepository(db),
-		AppGroup:                   repository.NewAppGroupRepository(db),
-		AppServeApp:                repository.NewAppServeAppRepository(db),
-		CloudAccount:               repository.NewCloudAccountRepository(db),
-		StackTemplate:              repository.NewStackTemplateRepository(db),
-		SystemNotification:         repository.NewSystemNotificationRepository(db),
-		SystemNotificationTemplate: repository.NewSystemNotificationTemplateRepository(db),
-		SystemNotificationRule:     repository.NewSystemNotificationRuleRepository(db),
-		Role:                       repository.NewRoleRepository(db),
-		Project:                    repository.NewProjectRepository(db),
-		Permission:                 repository.NewPermissionRepository(db),
-		Endpoint:                   repository.NewEndpointRepository(db),
-		Audit:                      repository.NewAuditRepository(db),
-		PolicyTemplate:             repository.NewPolicyTemplateRepository(db),
-		Policy:                     repository.NewPolicyRepository(db),
-		Dashboard:                  repository.NewDashboardRepository(db),
+		Auth:                         repository.NewAuthRepository(db),
+		User:                         repository.NewUserRepository(db),
+		Cluster:                      repository.NewClusterRepository(db),
+		Organization:                 repository.NewOrganizationRepository(db),
+		AppGroup:                     repository.NewAppGroupRepository(db),
+		AppServeApp:                  repository.NewAppServeAppRepository(db),
+		CloudAccount:                 repository.NewCloudAccountRepository(db),
+		StackTemplate:                repository.NewStackTemplateRepository(db),
+		SystemNotification:           repository.NewSystemNotificationRepository(db),
+		SystemNotificationTemplate:   repository.NewSystemNotificationTemplateRepository(db),
+		SystemNotificationRule:       repository.NewSystemNotificationRuleRepository(db),
+		SystemNotificationCredential: repository.NewSystemNotificationCredentialRepository(db),
+		NotificationChannel:          repository.NewNotificationChannelRepository(db),
+		NotificationDelivery:         repository.NewNotificationDeliveryRepository(db),
+		MailOutbox:                   repository.NewMailOutboxRepository(db),
+		EscalationPolicy:             repository.NewEscalationPolicyRepository(db),
+		OnCallSchedule:               repository.NewOnCallScheduleRepository(db),
+		SystemNotificationSilence:    repository.NewSystemNotificationSilenceRepository(db),
+		SystemNotificationDigest:     repository.NewSystemNotificationDigestRepository(db),
+		NotificationPreference:       repository.NewNotificationPreferenceRepository(db),
+		ServiceAccount:               repository.NewServiceAccountRepository(db),
+		ApiToken:                     repository.NewApiTokenRepository(db),
+		SecurityPolicy:               repository.NewSecurityPolicyRepository(db),
+		Retention:                    repository.NewRetentionRepository(db),
+		SecondFactor:                 repository.NewSecondFactorRepository(db),
+		IdentityProvider:             repository.NewIdentityProviderRepository(db),
+		Invitation:                   repository.NewInvitationRepository(db),
+		UserImport:                   repository.NewUserImportRepository(db),
+		Impersonation:                repository.NewImpersonationRepository(db),
+		Role:                         repository.NewRoleRepository(db),
+		Project:                      repository.NewProjectRepository(db),
+		Permission:                   repository.NewPermissionRepository(db),
+		Endpoint:                     repository.NewEndpointRepository(db),
+		Audit:                        repository.NewAuditRepository(db),
+		PolicyTemplate:               repository.NewPolicyTemplateRepository(db),
+		Policy:                       repository.NewPolicyRepository(db),
+		Dashboard:                    repository.NewDashboardRepository(db),
+		StreamTicket:                 repository.NewStreamTicketRepository(db),
 	}
 
+	// 다른 usecase 가 함께 쓰는 usecase 는 한 번만 만든다.
+	userUsecase := usecase.NewUserUsecase(repoFactory, kc)
+	projectUsecase := usecase.NewProjectUsecase(repoFactory, kc, argoClient)
+	permissionUsecase := usecase.NewPermissionUsecase(repoFactory, kc)
+	dashboardUsecase := usecase.NewDashboardUsecase(repoFactory, cache)
+	invitationUsecase := usecase.NewInvitationUsecase(repoFactory, userUsecase, projectUsecase)
+
 	usecaseFactory := usecase.Usecase{
-		Auth:                       usecase.NewAuthUsecase(repoFactory, kc),
-		User:                       usecase.NewUserUsecase(repoFactory, kc),
-		Cluster:                    usecase.NewClusterUsecase(repoFactory, argoClient, cache, kc),
-		Organization:               usecase.NewOrganizationUsecase(repoFactory, argoClient, kc),
-		AppGroup:                   usecase.NewAppGroupUsecase(repoFactory, argoClient),
-		AppServeApp:                usecase.NewAppServeAppUsecase(repoFactory, argoClient),
-		CloudAccount:               usecase.NewCloudAccountUsecase(repoFactory, argoClient),
-		StackTemplate:              usecase.NewStackTemplateUsecase(repoFactory),
-		Dashboard:                  usecase.NewDashboardUsecase(repoFactory, cache),
-		SystemNotification:         usecase.NewSystemNotificationUsecase(repoFactory),
-		SystemNotificationTemplate: usecase.NewSystemNotificationTemplateUsecase(repoFactory),
-		SystemNotificationRule:     usecase.NewSystemNotificationRuleUsecase(repoFactory),
-		Stack:                      usecase.NewStackUsecase(repoFactory, argoClient, usecase.NewDashboardUsecase(repoFactory, cache), kc),
-		Project:                    usecase.NewProjectUsecase(repoFactory, kc, argoClient),
-		Audit:                      usecase.NewAuditUsecase(repoFactory),
-		Role:                       usecase.NewRoleUsecase(repoFactory, kc),
-		Permission:                 usecase.NewPermissionUsecase(repoFactory, kc),
-		PolicyTemplate:             usecase.NewPolicyTemplateUsecase(repoFactory),
-		Policy:                     usecase.NewPolicyUsecase(repoFactory),
+		Auth:                         usecase.NewAuthUsecase(repoFactory, kc),
+		User:                         userUsecase,
+		Cluster:                      usecase.NewClusterUsecase(repoFactory, argoClient, cache, kc),
+		Organization:                 usecase.NewOrganizationUsecase(repoFactory, argoClient, kc),
+		AppGroup:                     usecase.NewAppGroupUsecase(repoFactory, argoClient),
+		AppServeApp:                  usecase.NewAppServeAppUsecase(repoFactory, argoClient),
+		CloudAccount:                 usecase.NewCloudAccountUsecase(repoFactory, argoClient),
+		StackTemplate:                usecase.NewStackTemplateUsecase(repoFactory),
+		Dashboard:                    dashboardUsecase,
+		SystemNotification:           usecase.NewSystemNotificationUsecase(repoFactory),
+		SystemNotificationTemplate:   usecase.NewSystemNotificationTemplateUsecase(repoFactory),
+		SystemNotificationRule:       usecase.NewSystemNotificationRuleUsecase(repoFactory),
+		SystemNotificationCredential: usecase.NewSystemNotificationCredentialUsecase(repoFactory),
+		NotificationChannel:          usecase.NewNotificationChannelUsecase(repoFactory),
+		MailOutbox:                   usecase.NewMailOutboxUsecase(repoFactory),
+		EscalationPolicy:             usecase.NewEscalationPolicyUsecase(repoFactory),
+		OnCallSchedule:               usecase.NewOnCallScheduleUsecase(repoFactory),
+		SystemNotificationSilence:    usecase.NewSystemNotificationSilenceUsecase(repoFactory),
+		SystemNotificationDigest:     usecase.NewSystemNotificationDigestUsecase(repoFactory),
+		NotificationPreference:       usecase.NewNotificationPreferenceUsecase(repoFactory),
+		ServiceAccount:               usecase.NewServiceAccountUsecase(repoFactory),
+		ApiToken:                     usecase.NewApiTokenUsecase(repoFactory),
+		SecurityPolicy:               usecase.NewSecurityPolicyUsecase(repoFactory, kc),
+		Retention:                    usecase.NewRetentionUsecase(repoFactory),
+		SecondFactor:                 usecase.NewSecondFactorUsecase(repoFactory),
+		IdentityProvider:             usecase.NewIdentityProviderUsecase(repoFactory, kc),
+		Scim:                         usecase.NewScimUsecase(repoFactory, userUsecase, invitationUsecase),
+		Session:                      usecase.NewSessionUsecase(repoFactory, kc),
+		Invitation:                   invitationUsecase,
+		UserImport:                   usecase.NewUserImportUsecase(repoFactory, userUsecase, permissionUsecase, invitationUsecase),
+		Impersonation:                usecase.NewImpersonationUsecase(repoFactory),
+		Stream:                       usecase.NewStreamUsecase(repoFactory),
+		Stack:                        usecase.NewStackUsecase(repoFactory, argoClient, dashboardUsecase, kc),
+		Project:                      projectUsecase,
+		Audit:                        usecase.NewAuditUsecase(repoFactory),
+		AuditForward:                 usecase.NewAuditForwardUsecase(repoFactory),
+		Role:                         usecase.NewRoleUsecase(repoFactory, kc),
+		Permission:                   permissionUsecase,
+		PolicyTemplate:               usecase.NewPolicyTemplateUsecase(repoFactory),
+		Policy:                       usecase.NewPolicyUsecase(repoFactory),
 	}
 
+	// outbox 에 쌓인 메일은 백그라운드에서 재시도와 함께 발송한다. 백그라운드 작업은 서버가 종료될 때 함께 멈춘다.
+	usecase.StartWorkers(ctx,
+		usecaseFactory.MailOutbox,
+		usecaseFactory.NotificationChannel,
+		usecaseFactory.EscalationPolicy,
+		usecaseFactory.SystemNotificationDigest,
+		usecaseFactory.Stream,
+		usecaseFactory.UserImport,
+		usecaseFactory.Audit,
+		usecaseFactory.AuditForward,
+		usecaseFactory.Retention)
+
+	// 매 요청마다 하는 사용자, 정책, 권한 조회는 짧게 캐시한다.
+	authRepo := authCache.NewRepository(repoFactory)
 	customMiddleware := internalMiddleware.NewMiddleware(
-		authenticator.NewAuthenticator(authKeycloak.NewKeycloakAuthenticator(kc), repoFactory, authCustom.NewCustomAuthenticator(repoFactory)),
-		authorizer.NewDefaultAuthorization(repoFactory),
+		authenticator.NewAuthenticator(authKeycloak.NewKeycloakAuthenticator(kc), authRepo, authCustom.NewCustomAuthenticator(authRepo), authApiToken.NewApiTokenAuthenticator(authRepo), authImpersonation.NewImpersonationAuthenticator(authRepo)),
+		authorizer.NewDefaultAuthorization(authRepo),
 		requestRecoder.NewDefaultRequestRecoder(),
-		audit.NewDefaultAudit(repoFactory))
+		audit.NewDefaultAudit(authRepo))
 
 	r.Use(logging.LoggingMiddleware)
 
@@ -98,12 +163,19 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 
 	authHandler := delivery.NewAuthHandler(usecaseFactory)
 	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/login", authHandler.Login).Methods(http.MethodPost)
+	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/login/second-factor", authHandler.LoginSecondFactor).Methods(http.MethodPost)
+	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/login/identity-provider", authHandler.LoginWithIdentityProvider).Methods(http.MethodPost)
+	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/identity-providers", authHandler.GetLoginIdentityProviders).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/auth/logout", customMiddleware.Handle(internalApi.Logout, http.HandlerFunc(authHandler.Logout))).Methods(http.MethodPost)
 	r.Handle(API_PREFIX+API_VERSION+"/auth/refresh", customMiddleware.Handle(internalApi.RefreshToken, http.HandlerFunc(authHandler.RefreshToken))).Methods(http.MethodPost)
 	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/find-id/verification", authHandler.FindId).Methods(http.MethodPost)
 	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/find-password/verification", authHandler.FindPassword).Methods(http.MethodPost)
 	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/find-id/code", authHandler.VerifyIdentityForLostId).Methods(http.MethodPost)
 	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/find-password/code", authHandler.VerifyIdentityForLostPassword).Methods(http.MethodPost)
+
+	invitationHandler := delivery.NewInvitationHandler(usecaseFactory)
+	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/invitations", invitationHandler.GetInvitationByToken).Methods(http.MethodGet)
+	r.HandleFunc(API_PREFIX+API_VERSION+"/auth/invitations/acceptance", invitationHandler.AcceptInvitation).Methods(http.MethodPost)
 	r.Handle(API_PREFIX+API_VERSION+"/auth/verify-token", customMiddleware.Handle(internalApi.VerifyToken, http.HandlerFunc(authHandler.VerifyToken))).Methods(http.MethodGet)
 	//r.HandleFunc(API_PREFIX+API_VERSION+"/cookie-test", authHandler.CookieTest).Methods(http.MethodPost)
 	//r.HandleFunc(API_PREFIX+API_VERSION+"/auth/callback", authHandler.CookieTestCallback).Methods(http.MethodGet)
@@ -115,15 +187,54 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users", customMiddleware.Handle(internalApi.UpdateUsers, http.HandlerFunc(userHandler.UpdateUsers))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}", customMiddleware.Handle(internalApi.UpdateUser, http.HandlerFunc(userHandler.Update))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}/reset-password", customMiddleware.Handle(internalApi.ResetPassword, http.HandlerFunc(userHandler.ResetPassword))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}/unlock", customMiddleware.Handle(internalApi.UnlockUser, http.HandlerFunc(userHandler.Unlock))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}", customMiddleware.Handle(internalApi.DeleteUser, http.HandlerFunc(userHandler.Delete))).Methods(http.MethodDelete)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/account-id/{accountId}/existence", customMiddleware.Handle(internalApi.CheckId, http.HandlerFunc(userHandler.CheckId))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/email/{email}/existence", customMiddleware.Handle(internalApi.CheckEmail, http.HandlerFunc(userHandler.CheckEmail))).Methods(http.MethodGet)
 
+	userImportHandler := delivery.NewUserImportHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/user-imports", customMiddleware.Handle(internalApi.ImportUsers, http.HandlerFunc(userImportHandler.ImportUsers))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/user-imports", customMiddleware.Handle(internalApi.GetUserImports, http.HandlerFunc(userImportHandler.GetUserImports))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/user-imports/{userImportId}", customMiddleware.Handle(internalApi.GetUserImport, http.HandlerFunc(userImportHandler.GetUserImport))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/user-exports", customMiddleware.Handle(internalApi.ExportUsers, http.HandlerFunc(userImportHandler.ExportUsers))).Methods(http.MethodGet)
+
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile", customMiddleware.Handle(internalApi.GetMyProfile, http.HandlerFunc(userHandler.GetMyProfile))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile", customMiddleware.Handle(internalApi.UpdateMyProfile, http.HandlerFunc(userHandler.UpdateMyProfile))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/password", customMiddleware.Handle(internalApi.UpdateMyPassword, http.HandlerFunc(userHandler.UpdateMyPassword))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/next-password-change", customMiddleware.Handle(internalApi.RenewPasswordExpiredDate, http.HandlerFunc(userHandler.RenewPasswordExpiredDate))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile", customMiddleware.Handle(internalApi.DeleteMyProfile, http.HandlerFunc(userHandler.DeleteMyProfile))).Methods(http.MethodDelete)
+
+	notificationPreferenceHandler := delivery.NewNotificationPreferenceHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/notification-preference", customMiddleware.Handle(internalApi.GetMyNotificationPreference, http.HandlerFunc(notificationPreferenceHandler.GetMyNotificationPreference))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/notification-preference", customMiddleware.Handle(internalApi.UpdateMyNotificationPreference, http.HandlerFunc(notificationPreferenceHandler.UpdateMyNotificationPreference))).Methods(http.MethodPut)
+	r.HandleFunc(API_PREFIX+API_VERSION+"/notification-preferences/unsubscribe", notificationPreferenceHandler.GetUnsubscribe).Methods(http.MethodGet)
+	r.HandleFunc(API_PREFIX+API_VERSION+"/notification-preferences/unsubscribe", notificationPreferenceHandler.Unsubscribe).Methods(http.MethodPost)
+
+	apiTokenHandler := delivery.NewApiTokenHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/api-tokens", customMiddleware.Handle(internalApi.GetMyApiTokens, http.HandlerFunc(apiTokenHandler.GetMyApiTokens))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/api-tokens", customMiddleware.Handle(internalApi.CreateMyApiToken, http.HandlerFunc(apiTokenHandler.CreateMyApiToken))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/api-tokens/{apiTokenId}", customMiddleware.Handle(internalApi.RevokeMyApiToken, http.HandlerFunc(apiTokenHandler.RevokeMyApiToken))).Methods(http.MethodDelete)
+
+	secondFactorHandler := delivery.NewSecondFactorHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/second-factor", customMiddleware.Handle(internalApi.GetMySecondFactor, http.HandlerFunc(secondFactorHandler.GetMySecondFactor))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/second-factor", customMiddleware.Handle(internalApi.CreateMySecondFactor, http.HandlerFunc(secondFactorHandler.CreateMySecondFactor))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/second-factor/verification", customMiddleware.Handle(internalApi.VerifyMySecondFactor, http.HandlerFunc(secondFactorHandler.VerifyMySecondFactor))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/second-factor/recovery-codes", customMiddleware.Handle(internalApi.RegenerateMyRecoveryCodes, http.HandlerFunc(secondFactorHandler.RegenerateMyRecoveryCodes))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/second-factor", customMiddleware.Handle(internalApi.DeleteMySecondFactor, http.HandlerFunc(secondFactorHandler.DeleteMySecondFactor))).Methods(http.MethodDelete)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}/second-factor", customMiddleware.Handle(internalApi.ResetUserSecondFactor, http.HandlerFunc(secondFactorHandler.ResetUserSecondFactor))).Methods(http.MethodDelete)
+
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/invitations", customMiddleware.Handle(internalApi.CreateInvitation, http.HandlerFunc(invitationHandler.CreateInvitation))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/invitations", customMiddleware.Handle(internalApi.GetInvitations, http.HandlerFunc(invitationHandler.GetInvitations))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/invitations/{invitationId}", customMiddleware.Handle(internalApi.GetInvitation, http.HandlerFunc(invitationHandler.GetInvitation))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/invitations/{invitationId}/resend", customMiddleware.Handle(internalApi.ResendInvitation, http.HandlerFunc(invitationHandler.ResendInvitation))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/invitations/{invitationId}", customMiddleware.Handle(internalApi.RevokeInvitation, http.HandlerFunc(invitationHandler.RevokeInvitation))).Methods(http.MethodDelete)
+
+	sessionHandler := delivery.NewSessionHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/sessions", customMiddleware.Handle(internalApi.GetMySessions, http.HandlerFunc(sessionHandler.GetMySessions))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/my-profile/sessions/{sessionId}", customMiddleware.Handle(internalApi.RevokeMySession, http.HandlerFunc(sessionHandler.RevokeMySession))).Methods(http.MethodDelete)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}/sessions", customMiddleware.Handle(internalApi.GetUserSessions, http.HandlerFunc(sessionHandler.GetUserSessions))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}/sessions", customMiddleware.Handle(internalApi.RevokeUserSessions, http.HandlerFunc(sessionHandler.RevokeUserSessions))).Methods(http.MethodDelete)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}/sessions/{sessionId}", customMiddleware.Handle(internalApi.RevokeUserSession, http.HandlerFunc(sessionHandler.RevokeUserSession))).Methods(http.MethodDelete)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/users/{accountId}/permissions", customMiddleware.Handle(internalApi.GetPermissionsByAccountId, http.HandlerFunc(userHandler.GetPermissionsByAccountId))).Methods(http.MethodGet)
 
 	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/organizations/{organizationId}/users", customMiddleware.Handle(internalApi.Admin_CreateUser, http.HandlerFunc(userHandler.Admin_Create))).Methods(http.MethodPost)
@@ -137,6 +248,10 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}", customMiddleware.Handle(internalApi.GetOrganization, http.HandlerFunc(organizationHandler.GetOrganization))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}", customMiddleware.Handle(internalApi.UpdateOrganization, http.HandlerFunc(organizationHandler.UpdateOrganization))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/primary-cluster", customMiddleware.Handle(internalApi.UpdatePrimaryCluster, http.HandlerFunc(organizationHandler.UpdatePrimaryCluster))).Methods(http.MethodPatch)
+
+	securityPolicyHandler := delivery.NewSecurityPolicyHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/security-policy", customMiddleware.Handle(internalApi.GetSecurityPolicy, http.HandlerFunc(securityPolicyHandler.GetSecurityPolicy))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/security-policy", customMiddleware.Handle(internalApi.UpdateSecurityPolicy, http.HandlerFunc(securityPolicyHandler.UpdateSecurityPolicy))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/name/{name}/existence", customMiddleware.Handle(internalApi.CheckOrganizationName, http.HandlerFunc(organizationHandler.CheckOrganizationName))).Methods(http.MethodGet)
 
 	clusterHandler := delivery.NewClusterHandler(usecaseFactory)
@@ -220,6 +335,20 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/dashboards/{dashboardKey}", customMiddleware.Handle(internalApi.GetDashboard, http.HandlerFunc(dashboardHandler.GetDashboard))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/dashboards/{dashboardKey}", customMiddleware.Handle(internalApi.UpdateDashboard, http.HandlerFunc(dashboardHandler.UpdateDashboard))).Methods(http.MethodPut)
 
+	mailOutboxHandler := delivery.NewMailOutboxHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/mail-outboxes", customMiddleware.Handle(internalApi.Admin_GetMailOutboxes, http.HandlerFunc(mailOutboxHandler.GetMailOutboxes))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/mail-outboxes/{mailOutboxId}/resend", customMiddleware.Handle(internalApi.Admin_ResendMailOutbox, http.HandlerFunc(mailOutboxHandler.ResendMailOutbox))).Methods(http.MethodPost)
+
+	impersonationHandler := delivery.NewImpersonationHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/organizations/{organizationId}/users/{accountId}/impersonation", customMiddleware.Handle(internalApi.Admin_CreateImpersonation, http.HandlerFunc(impersonationHandler.CreateImpersonation))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/impersonations", customMiddleware.Handle(internalApi.Admin_GetImpersonations, http.HandlerFunc(impersonationHandler.GetImpersonations))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/impersonations/{impersonationId}", customMiddleware.Handle(internalApi.Admin_StopImpersonation, http.HandlerFunc(impersonationHandler.StopImpersonation))).Methods(http.MethodDelete)
+
+	retentionHandler := delivery.NewRetentionHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/organizations/{organizationId}/retention-policies", customMiddleware.Handle(internalApi.Admin_GetRetentionPolicies, http.HandlerFunc(retentionHandler.GetRetentionPolicies))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/organizations/{organizationId}/retention-policies", customMiddleware.Handle(internalApi.Admin_UpdateRetentionPolicies, http.HandlerFunc(retentionHandler.UpdateRetentionPolicies))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/retention", customMiddleware.Handle(internalApi.Admin_GetRetentionStatus, http.HandlerFunc(retentionHandler.GetRetentionStatus))).Methods(http.MethodGet)
+
 	systemNotificationTemplateHandler := delivery.NewSystemNotificationTemplateHandler(usecaseFactory)
 	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/system-notification-templates", customMiddleware.Handle(internalApi.Admin_CreateSystemNotificationTemplate, http.HandlerFunc(systemNotificationTemplateHandler.CreateSystemNotificationTemplate))).Methods(http.MethodPost)
 	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/system-notification-templates", customMiddleware.Handle(internalApi.Admin_GetSystemNotificationTemplates, http.HandlerFunc(systemNotificationTemplateHandler.GetSystemNotificationTemplates))).Methods(http.MethodGet)
@@ -241,6 +370,42 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-rules/{systemNotificationRuleId}", customMiddleware.Handle(internalApi.UpdateSystemNotificationRule, http.HandlerFunc(systemNotificationRuleHandler.UpdateSystemNotificationRule))).Methods(http.MethodPut)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-rules/{systemNotificationRuleId}", customMiddleware.Handle(internalApi.DeleteSystemNotificationRule, http.HandlerFunc(systemNotificationRuleHandler.DeleteSystemNotificationRule))).Methods(http.MethodDelete)
 
+	systemNotificationCredentialHandler := delivery.NewSystemNotificationCredentialHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-credentials", customMiddleware.Handle(internalApi.CreateSystemNotificationCredential, http.HandlerFunc(systemNotificationCredentialHandler.CreateSystemNotificationCredential))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-credentials", customMiddleware.Handle(internalApi.GetSystemNotificationCredentials, http.HandlerFunc(systemNotificationCredentialHandler.GetSystemNotificationCredentials))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-credentials/{systemNotificationCredentialId}", customMiddleware.Handle(internalApi.DeleteSystemNotificationCredential, http.HandlerFunc(systemNotificationCredentialHandler.DeleteSystemNotificationCredential))).Methods(http.MethodDelete)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-credentials/{systemNotificationCredentialId}/rotate", customMiddleware.Handle(internalApi.RotateSystemNotificationCredential, http.HandlerFunc(systemNotificationCredentialHandler.RotateSystemNotificationCredential))).Methods(http.MethodPut)
+
+	notificationChannelHandler := delivery.NewNotificationChannelHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-channels", customMiddleware.Handle(internalApi.CreateNotificationChannel, http.HandlerFunc(notificationChannelHandler.CreateNotificationChannel))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-channels", customMiddleware.Handle(internalApi.GetNotificationChannels, http.HandlerFunc(notificationChannelHandler.GetNotificationChannels))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-channels/{notificationChannelId}", customMiddleware.Handle(internalApi.GetNotificationChannel, http.HandlerFunc(notificationChannelHandler.GetNotificationChannel))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-channels/{notificationChannelId}", customMiddleware.Handle(internalApi.UpdateNotificationChannel, http.HandlerFunc(notificationChannelHandler.UpdateNotificationChannel))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-channels/{notificationChannelId}", customMiddleware.Handle(internalApi.DeleteNotificationChannel, http.HandlerFunc(notificationChannelHandler.DeleteNotificationChannel))).Methods(http.MethodDelete)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-channels/{notificationChannelId}/test", customMiddleware.Handle(internalApi.TestNotificationChannel, http.HandlerFunc(notificationChannelHandler.TestNotificationChannel))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-deliveries", customMiddleware.Handle(internalApi.GetNotificationDeliveries, http.HandlerFunc(notificationChannelHandler.GetNotificationDeliveries))).Methods(http.MethodGet)
+
+	escalationPolicyHandler := delivery.NewEscalationPolicyHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/escalation-policies", customMiddleware.Handle(internalApi.CreateEscalationPolicy, http.HandlerFunc(escalationPolicyHandler.CreateEscalationPolicy))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/escalation-policies", customMiddleware.Handle(internalApi.GetEscalationPolicies, http.HandlerFunc(escalationPolicyHandler.GetEscalationPolicies))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/escalation-policies/{escalationPolicyId}", customMiddleware.Handle(internalApi.GetEscalationPolicy, http.HandlerFunc(escalationPolicyHandler.GetEscalationPolicy))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/escalation-policies/{escalationPolicyId}", customMiddleware.Handle(internalApi.UpdateEscalationPolicy, http.HandlerFunc(escalationPolicyHandler.UpdateEscalationPolicy))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/escalation-policies/{escalationPolicyId}", customMiddleware.Handle(internalApi.DeleteEscalationPolicy, http.HandlerFunc(escalationPolicyHandler.DeleteEscalationPolicy))).Methods(http.MethodDelete)
+
+	onCallScheduleHandler := delivery.NewOnCallScheduleHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/on-call-schedules", customMiddleware.Handle(internalApi.CreateOnCallSchedule, http.HandlerFunc(onCallScheduleHandler.CreateOnCallSchedule))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/on-call-schedules", customMiddleware.Handle(internalApi.GetOnCallSchedules, http.HandlerFunc(onCallScheduleHandler.GetOnCallSchedules))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/on-call-schedules/{onCallScheduleId}", customMiddleware.Handle(internalApi.GetOnCallSchedule, http.HandlerFunc(onCallScheduleHandler.GetOnCallSchedule))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/on-call-schedules/{onCallScheduleId}", customMiddleware.Handle(internalApi.UpdateOnCallSchedule, http.HandlerFunc(onCallScheduleHandler.UpdateOnCallSchedule))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/on-call-schedules/{onCallScheduleId}", customMiddleware.Handle(internalApi.DeleteOnCallSchedule, http.HandlerFunc(onCallScheduleHandler.DeleteOnCallSchedule))).Methods(http.MethodDelete)
+
+	systemNotificationSilenceHandler := delivery.NewSystemNotificationSilenceHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-silences", customMiddleware.Handle(internalApi.CreateSystemNotificationSilence, http.HandlerFunc(systemNotificationSilenceHandler.CreateSystemNotificationSilence))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-silences", customMiddleware.Handle(internalApi.GetSystemNotificationSilences, http.HandlerFunc(systemNotificationSilenceHandler.GetSystemNotificationSilences))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-silences/{systemNotificationSilenceId}", customMiddleware.Handle(internalApi.GetSystemNotificationSilence, http.HandlerFunc(systemNotificationSilenceHandler.GetSystemNotificationSilence))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-silences/{systemNotificationSilenceId}", customMiddleware.Handle(internalApi.UpdateSystemNotificationSilence, http.HandlerFunc(systemNotificationSilenceHandler.UpdateSystemNotificationSilence))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notification-silences/{systemNotificationSilenceId}", customMiddleware.Handle(internalApi.DeleteSystemNotificationSilence, http.HandlerFunc(systemNotificationSilenceHandler.DeleteSystemNotificationSilence))).Methods(http.MethodDelete)
+
 	systemNotificationHandler := delivery.NewSystemNotificationHandler(usecaseFactory)
 	r.HandleFunc(SYSTEM_API_PREFIX+SYSTEM_API_VERSION+"/system-notifications", systemNotificationHandler.CreateSystemNotification).Methods(http.MethodPost)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notifications", customMiddleware.Handle(internalApi.GetSystemNotifications, http.HandlerFunc(systemNotificationHandler.GetSystemNotifications))).Methods(http.MethodGet)
@@ -250,6 +415,11 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/system-notifications/{systemNotificationId}/actions", customMiddleware.Handle(internalApi.CreateSystemNotificationAction, http.HandlerFunc(systemNotificationHandler.CreateSystemNotificationAction))).Methods(http.MethodPost)
 	r.HandleFunc(API_PREFIX+API_VERSION+"/alerttest", systemNotificationHandler.CreateSystemNotification).Methods(http.MethodPost)
 
+	streamHandler := delivery.NewStreamHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-stream/tickets", customMiddleware.Handle(internalApi.CreateNotificationStreamTicket, http.HandlerFunc(streamHandler.CreateNotificationStreamTicket))).Methods(http.MethodPost)
+	// EventSource 는 Authorization 헤더를 설정할 수 없으므로 일회용 ticket 으로 인가한다.
+	r.HandleFunc(API_PREFIX+API_VERSION+"/organizations/{organizationId}/notification-stream", streamHandler.GetNotificationStream).Methods(http.MethodGet)
+
 	policyNotificationHandler := delivery.NewPolicyNotificationHandler(usecaseFactory)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/policy-notifications", customMiddleware.Handle(internalApi.GetSystemNotifications, http.HandlerFunc(policyNotificationHandler.GetPolicyNotifications))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/policy-notifications/{policyNotificationId}", customMiddleware.Handle(internalApi.GetSystemNotification, http.HandlerFunc(policyNotificationHandler.GetPolicyNotification))).Methods(http.MethodGet)
@@ -298,6 +468,8 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 
 	auditHandler := delivery.NewAuditHandler(usecaseFactory)
 	r.Handle(API_PREFIX+API_VERSION+"/admin/audits", customMiddleware.Handle(internalApi.GetAudits, http.HandlerFunc(auditHandler.GetAudits))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/admin/audits/export", customMiddleware.Handle(internalApi.ExportAudits, http.HandlerFunc(auditHandler.ExportAudits))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/admin/audits/verification", customMiddleware.Handle(internalApi.VerifyAudits, http.HandlerFunc(auditHandler.VerifyAudits))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/admin/audits/{auditId}", customMiddleware.Handle(internalApi.GetAudit, http.HandlerFunc(auditHandler.GetAudit))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+"/admin/audits/{auditId}", customMiddleware.Handle(internalApi.DeleteAudit, http.HandlerFunc(auditHandler.DeleteAudit))).Methods(http.MethodDelete)
 
@@ -322,6 +494,37 @@ func SetupRouter(db *gorm.DB, argoClient argowf.ArgoClient, kc keycloak.IKeycloa
 	permissionHandler := delivery.NewPermissionHandler(usecaseFactory)
 	r.Handle(API_PREFIX+API_VERSION+"/permissions/templates", customMiddleware.Handle(internalApi.GetPermissionTemplates, http.HandlerFunc(permissionHandler.GetPermissionTemplates))).Methods(http.MethodGet)
 
+	serviceAccountHandler := delivery.NewServiceAccountHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts", customMiddleware.Handle(internalApi.CreateServiceAccount, http.HandlerFunc(serviceAccountHandler.CreateServiceAccount))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts", customMiddleware.Handle(internalApi.GetServiceAccounts, http.HandlerFunc(serviceAccountHandler.GetServiceAccounts))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts/{serviceAccountId}", customMiddleware.Handle(internalApi.GetServiceAccount, http.HandlerFunc(serviceAccountHandler.GetServiceAccount))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts/{serviceAccountId}", customMiddleware.Handle(internalApi.UpdateServiceAccount, http.HandlerFunc(serviceAccountHandler.UpdateServiceAccount))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts/{serviceAccountId}", customMiddleware.Handle(internalApi.DeleteServiceAccount, http.HandlerFunc(serviceAccountHandler.DeleteServiceAccount))).Methods(http.MethodDelete)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts/{serviceAccountId}/tokens", customMiddleware.Handle(internalApi.GetServiceAccountTokens, http.HandlerFunc(serviceAccountHandler.GetServiceAccountTokens))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts/{serviceAccountId}/tokens", customMiddleware.Handle(internalApi.CreateServiceAccountToken, http.HandlerFunc(serviceAccountHandler.CreateServiceAccountToken))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/service-accounts/{serviceAccountId}/tokens/{apiTokenId}", customMiddleware.Handle(internalApi.RevokeServiceAccountToken, http.HandlerFunc(serviceAccountHandler.RevokeServiceAccountToken))).Methods(http.MethodDelete)
+
+	identityProviderHandler := delivery.NewIdentityProviderHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/identity-providers", customMiddleware.Handle(internalApi.CreateIdentityProvider, http.HandlerFunc(identityProviderHandler.CreateIdentityProvider))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/identity-providers", customMiddleware.Handle(internalApi.GetIdentityProviders, http.HandlerFunc(identityProviderHandler.GetIdentityProviders))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/identity-providers/{identityProviderId}", customMiddleware.Handle(internalApi.GetIdentityProvider, http.HandlerFunc(identityProviderHandler.GetIdentityProvider))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/identity-providers/{identityProviderId}", customMiddleware.Handle(internalApi.UpdateIdentityProvider, http.HandlerFunc(identityProviderHandler.UpdateIdentityProvider))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/identity-providers/{identityProviderId}", customMiddleware.Handle(internalApi.DeleteIdentityProvider, http.HandlerFunc(identityProviderHandler.DeleteIdentityProvider))).Methods(http.MethodDelete)
+
+	// SCIM 2.0 프로비저닝. 서비스 계정의 프로비저닝 토큰으로만 호출할 수 있다.
+	scimHandler := delivery.NewScimHandler(usecaseFactory)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/ServiceProviderConfig", customMiddleware.Handle(internalApi.ScimGetServiceProviderConfig, http.HandlerFunc(scimHandler.ScimGetServiceProviderConfig))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Users", customMiddleware.Handle(internalApi.ScimGetUsers, http.HandlerFunc(scimHandler.ScimGetUsers))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Users", customMiddleware.Handle(internalApi.ScimCreateUser, http.HandlerFunc(scimHandler.ScimCreateUser))).Methods(http.MethodPost)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Users/{userId}", customMiddleware.Handle(internalApi.ScimGetUser, http.HandlerFunc(scimHandler.ScimGetUser))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Users/{userId}", customMiddleware.Handle(internalApi.ScimReplaceUser, http.HandlerFunc(scimHandler.ScimReplaceUser))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Users/{userId}", customMiddleware.Handle(internalApi.ScimPatchUser, http.HandlerFunc(scimHandler.ScimPatchUser))).Methods(http.MethodPatch)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Users/{userId}", customMiddleware.Handle(internalApi.ScimDeleteUser, http.HandlerFunc(scimHandler.ScimDeleteUser))).Methods(http.MethodDelete)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Groups", customMiddleware.Handle(internalApi.ScimGetGroups, http.HandlerFunc(scimHandler.ScimGetGroups))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Groups/{groupId}", customMiddleware.Handle(internalApi.ScimGetGroup, http.HandlerFunc(scimHandler.ScimGetGroup))).Methods(http.MethodGet)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Groups/{groupId}", customMiddleware.Handle(internalApi.ScimReplaceGroup, http.HandlerFunc(scimHandler.ScimReplaceGroup))).Methods(http.MethodPut)
+	r.Handle(API_PREFIX+API_VERSION+"/organizations/{organizationId}/scim/v2/Groups/{groupId}", customMiddleware.Handle(internalApi.ScimPatchGroup, http.HandlerFunc(scimHandler.ScimPatchGroup))).Methods(http.MethodPatch)
+
 	policyTemplateHandler := delivery.NewPolicyTemplateHandler(usecaseFactory)
 	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/policy-templates", customMiddleware.Handle(internalApi.Admin_ListPolicyTemplate, http.HandlerFunc(policyTemplateHandler.Admin_ListPolicyTemplate))).Methods(http.MethodGet)
 	r.Handle(API_PREFIX+API_VERSION+ADMINAPI_PREFIX+"/policy-templates", customMiddleware.Handle(internalApi.Admin_CreatePolicyTemplate, http.HandlerFunc(policyTemplateHandler.Admin_CreatePolicyTemplate))).Methods(http.MethodPost)
@@ -0,0 +1,257 @@
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SCIM 필터(RFC 7644 3.4.2.2)는 프로비저닝 클라이언트가 사용하는 "속성 연산자 값" 조건을 and / or 로 연결한 형식만 지원한다.
+// 괄호와 not 은 지원하지 않으며, and 는 or 보다 먼저 결합한다.
+
+const (
+	OperatorEqual      = "eq"
+	OperatorNotEqual   = "ne"
+	OperatorContains   = "co"
+	OperatorStartsWith = "sw"
+	OperatorEndsWith   = "ew"
+	OperatorPresent    = "pr"
+)
+
+var operators = map[string]struct{}{
+	OperatorEqual:      {},
+	OperatorNotEqual:   {},
+	OperatorContains:   {},
+	OperatorStartsWith: {},
+	OperatorEndsWith:   {},
+	OperatorPresent:    {},
+}
+
+type Condition struct {
+	// 소문자로 정규화된 속성 경로. 예) username, name.givenname, emails.value
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+// Filter 는 and 로 연결된 조건 묶음들을 or 로 연결한 것이다.
+type Filter [][]Condition
+
+// Attributes 는 리소스의 속성 경로(소문자)별 값이다.
+type Attributes map[string][]string
+
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+
+	out := Filter{[]Condition{}}
+	for i := 0; i < len(tokens); {
+		if i > 0 {
+			switch strings.ToLower(tokens[i]) {
+			case "and":
+			case "or":
+				out = append(out, []Condition{})
+			default:
+				return nil, fmt.Errorf("expected and/or but got %s", tokens[i])
+			}
+			i++
+		}
+		if i+1 >= len(tokens) {
+			return nil, fmt.Errorf("incomplete filter %s", filter)
+		}
+
+		cond := Condition{
+			Attribute: NormalizeAttribute(tokens[i]),
+			Operator:  strings.ToLower(tokens[i+1]),
+		}
+		if _, ok := operators[cond.Operator]; !ok {
+			return nil, fmt.Errorf("unsupported operator %s", tokens[i+1])
+		}
+		i += 2
+		if cond.Operator != OperatorPresent {
+			if i >= len(tokens) {
+				return nil, fmt.Errorf("value of %s is missing", cond.Attribute)
+			}
+			if cond.Value, err = parseValue(tokens[i]); err != nil {
+				return nil, err
+			}
+			i++
+		}
+		out[len(out)-1] = append(out[len(out)-1], cond)
+	}
+	return out, nil
+}
+
+func (f Filter) Matches(attributes Attributes) bool {
+	for _, conditions := range f {
+		matched := true
+		for _, cond := range conditions {
+			if !cond.Matches(attributes) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches 는 대소문자를 구분하지 않고 비교한다. 다중 값 속성은 값 중 하나라도 만족하면 일치한다.
+func (c Condition) Matches(attributes Attributes) bool {
+	values := attributes[c.Attribute]
+	if c.Operator == OperatorPresent {
+		for _, v := range values {
+			if v != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if c.Operator == OperatorNotEqual {
+		for _, v := range values {
+			if strings.EqualFold(v, c.Value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	expected := strings.ToLower(c.Value)
+	for _, v := range values {
+		v = strings.ToLower(v)
+		switch c.Operator {
+		case OperatorEqual:
+			if v == expected {
+				return true
+			}
+		case OperatorContains:
+			if strings.Contains(v, expected) {
+				return true
+			}
+		case OperatorStartsWith:
+			if strings.HasPrefix(v, expected) {
+				return true
+			}
+		case OperatorEndsWith:
+			if strings.HasSuffix(v, expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Path 는 PATCH 요청의 대상 속성이다. 예) members[value eq "id"], name.givenName, emails[type eq "work"].value
+type Path struct {
+	Attribute    string
+	SubAttribute string
+	Filter       Filter
+}
+
+func ParsePath(path string) (out Path, err error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return out, fmt.Errorf("empty path")
+	}
+
+	if start := strings.Index(path, "["); start >= 0 {
+		end := strings.LastIndex(path, "]")
+		if end < start {
+			return out, fmt.Errorf("invalid path %s", path)
+		}
+		if out.Filter, err = ParseFilter(path[start+1 : end]); err != nil {
+			return out, err
+		}
+		out.Attribute = NormalizeAttribute(path[:start])
+		if rest := path[end+1:]; rest != "" {
+			if !strings.HasPrefix(rest, ".") {
+				return out, fmt.Errorf("invalid path %s", path)
+			}
+			out.SubAttribute = strings.ToLower(rest[1:])
+		}
+		return out, nil
+	}
+
+	attribute := NormalizeAttribute(path)
+	if i := strings.Index(attribute, "."); i >= 0 {
+		out.Attribute, out.SubAttribute = attribute[:i], attribute[i+1:]
+	} else {
+		out.Attribute = attribute
+	}
+	return out, nil
+}
+
+// NormalizeAttribute 는 스키마 URN 접두어를 제거하고 소문자로 바꾼다.
+// 예) urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department -> department
+func NormalizeAttribute(attribute string) string {
+	attribute = strings.ToLower(strings.TrimSpace(attribute))
+	if strings.HasPrefix(attribute, "urn:") {
+		if i := strings.LastIndex(attribute, ":"); i >= 0 {
+			attribute = attribute[i+1:]
+		}
+	}
+	return attribute
+}
+
+func tokenize(filter string) (tokens []string, err error) {
+	var current strings.Builder
+	inQuote, escaped := false, false
+	for _, c := range filter {
+		switch {
+		case inQuote:
+			current.WriteRune(c)
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inQuote = false
+			}
+		case c == '"':
+			inQuote = true
+			current.WriteRune(c)
+		case c == ' ' || c == '\t':
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(c)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in filter %s", filter)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseValue 는 JSON 문자열, true/false, null, 숫자를 비교할 문자열로 바꾼다.
+func parseValue(token string) (string, error) {
+	if strings.HasPrefix(token, "\"") {
+		v, err := strconv.Unquote(token)
+		if err != nil {
+			return "", fmt.Errorf("invalid value %s", token)
+		}
+		return v, nil
+	}
+	switch strings.ToLower(token) {
+	case "true", "false":
+		return strings.ToLower(token), nil
+	case "null":
+		return "", nil
+	}
+	if _, err := strconv.ParseFloat(token, 64); err != nil {
+		return "", fmt.Errorf("invalid value %s", token)
+	}
+	return token, nil
+}
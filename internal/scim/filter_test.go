@@ -0,0 +1,77 @@
+package scim_test
+
+import (
+	"testing"
+
+	"github.com/openinfradev/tks-api/internal/scim"
+)
+
+func TestFilterMatches(t *testing.T) {
+	attributes := scim.Attributes{
+		"username":     {"hong"},
+		"externalid":   {"E-1001"},
+		"emails.value": {"hong@example.com"},
+		"active":       {"true"},
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`userName eq "hong"`, true},
+		{`UserName Eq "HONG"`, true},
+		{`userName eq "kim"`, false},
+		{`userName ne "kim"`, true},
+		{`emails.value co "example"`, true},
+		{`emails.value ew ".org"`, false},
+		{`externalId sw "E-"`, true},
+		{`active eq true and userName eq "hong"`, true},
+		{`active eq false and userName eq "hong"`, false},
+		{`userName eq "kim" or externalId eq "E-1001"`, true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "hong"`, true},
+		{`displayName pr`, false},
+		{`userName eq "say \"hi\""`, false},
+	}
+	for _, tt := range tests {
+		filter, err := scim.ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%s) error = %v", tt.filter, err)
+			continue
+		}
+		if got := filter.Matches(attributes); got != tt.want {
+			t.Errorf("ParseFilter(%s).Matches() = %t, want %t", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	for _, filter := range []string{``, `userName`, `userName gt "a"`, `userName eq "a`, `userName eq "a" xor active eq true`, `userName eq hong`} {
+		if _, err := scim.ParseFilter(filter); err == nil {
+			t.Errorf("ParseFilter(%s) accepted the invalid filter", filter)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path         string
+		attribute    string
+		subAttribute string
+		hasFilter    bool
+	}{
+		{"active", "active", "", false},
+		{"name.givenName", "name", "givenname", false},
+		{`members[value eq "2819c223"]`, "members", "", true},
+		{`emails[type eq "work"].value`, "emails", "value", true},
+		{"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department", "department", "", false},
+	}
+	for _, tt := range tests {
+		path, err := scim.ParsePath(tt.path)
+		if err != nil {
+			t.Errorf("ParsePath(%s) error = %v", tt.path, err)
+			continue
+		}
+		if path.Attribute != tt.attribute || path.SubAttribute != tt.subAttribute || (path.Filter != nil) != tt.hasFilter {
+			t.Errorf("ParsePath(%s) = %+v", tt.path, path)
+		}
+	}
+}
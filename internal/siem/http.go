@@ -0,0 +1,66 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+const httpSendTimeout = 30 * time.Second
+
+// HttpSink posts the audits to the http collector in JSON lines.
+type HttpSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func NewHttpSink(url string, token string) *HttpSink {
+	return &HttpSink{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: httpSendTimeout},
+	}
+}
+
+func (s *HttpSink) Send(ctx context.Context, audits []model.Audit) error {
+	var buf bytes.Buffer
+	for _, audit := range audits {
+		line, err := marshal(audit)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *HttpSink) Close() error {
+	return nil
+}
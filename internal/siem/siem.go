@@ -0,0 +1,100 @@
+package siem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/spf13/viper"
+)
+
+// Sink sends the audits to the external SIEM. Send fails as a whole, and the audits are sent again on retry.
+type Sink interface {
+	Send(ctx context.Context, audits []model.Audit) error
+	Close() error
+}
+
+// New returns the sink by audit-forwarder. It returns nil if the forwarding is disabled.
+func New() (Sink, error) {
+	address := viper.GetString("audit-forwarder-address")
+	switch viper.GetString("audit-forwarder") {
+	case "":
+		return nil, nil
+	case "syslog":
+		return NewSyslogSink(address, viper.GetBool("audit-forwarder-tls")), nil
+	case "http":
+		return NewHttpSink(address, viper.GetString("audit-forwarder-token")), nil
+	default:
+		return nil, fmt.Errorf("invalid audit-forwarder %s", viper.GetString("audit-forwarder"))
+	}
+}
+
+// Event is the audit sent to the SIEM.
+type Event struct {
+	ID                         string              `json:"id"`
+	OrganizationId             string              `json:"organizationId"`
+	OrganizationName           string              `json:"organizationName"`
+	Sequence                   int64               `json:"sequence"`
+	Hash                       string              `json:"hash"`
+	Group                      string              `json:"group"`
+	Endpoint                   string              `json:"endpoint"`
+	Method                     string              `json:"method"`
+	Path                       string              `json:"path"`
+	ResourceType               string              `json:"resourceType"`
+	ResourceId                 string              `json:"resourceId"`
+	StatusCode                 int                 `json:"statusCode"`
+	Result                     string              `json:"result"`
+	RequestId                  string              `json:"requestId"`
+	Message                    string              `json:"message"`
+	MessageEn                  string              `json:"messageEn"`
+	Description                string              `json:"description"`
+	ClientIP                   string              `json:"clientIP"`
+	UserId                     string              `json:"userId"`
+	UserAccountId              string              `json:"userAccountId"`
+	UserName                   string              `json:"userName"`
+	UserRoles                  string              `json:"userRoles"`
+	ImpersonatorAccountId      string              `json:"impersonatorAccountId,omitempty"`
+	ImpersonatorOrganizationId string              `json:"impersonatorOrganizationId,omitempty"`
+	Changes                    []model.AuditChange `json:"changes,omitempty"`
+	CreatedAt                  time.Time           `json:"createdAt"`
+}
+
+func NewEvent(audit model.Audit) Event {
+	event := Event{
+		ID:                         audit.ID.String(),
+		OrganizationId:             audit.OrganizationId,
+		OrganizationName:           audit.OrganizationName,
+		Sequence:                   audit.Sequence,
+		Hash:                       audit.Hash,
+		Group:                      audit.Group,
+		Endpoint:                   audit.Endpoint,
+		Method:                     audit.Method,
+		Path:                       audit.Path,
+		ResourceType:               audit.ResourceType,
+		ResourceId:                 audit.ResourceId,
+		StatusCode:                 audit.StatusCode,
+		Result:                     audit.Result,
+		RequestId:                  audit.RequestId,
+		Message:                    audit.Message,
+		MessageEn:                  audit.MessageEn,
+		Description:                audit.Description,
+		ClientIP:                   audit.ClientIP,
+		UserAccountId:              audit.UserAccountId,
+		UserName:                   audit.UserName,
+		UserRoles:                  audit.UserRoles,
+		ImpersonatorAccountId:      audit.ImpersonatorAccountId,
+		ImpersonatorOrganizationId: audit.ImpersonatorOrganizationId,
+		Changes:                    audit.Changes,
+		CreatedAt:                  audit.CreatedAt,
+	}
+	if audit.UserId != nil {
+		event.UserId = audit.UserId.String()
+	}
+	return event
+}
+
+func marshal(audit model.Audit) ([]byte, error) {
+	return json.Marshal(NewEvent(audit))
+}
@@ -0,0 +1,142 @@
+package siem
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+const (
+	syslogDialTimeout  = 10 * time.Second
+	syslogWriteTimeout = 30 * time.Second
+	// RFC 5424 의 log audit facility
+	syslogFacility       = 13
+	syslogSeverityInfo   = 6
+	syslogSeverityNotice = 5
+	syslogAppName        = "tks-api"
+	// structured data 의 식별자. 등록된 enterprise number 가 없으므로 RFC 5612 의 예시 번호를 사용한다.
+	syslogSdId = "audit@32473"
+)
+
+// SyslogSink sends the audits in RFC 5424 over TCP with octet counting framing (RFC 6587), or over TLS (RFC 5425).
+type SyslogSink struct {
+	address  string
+	useTLS   bool
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func NewSyslogSink(address string, useTLS bool) *SyslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &SyslogSink{
+		address:  address,
+		useTLS:   useTLS,
+		hostname: hostname,
+	}
+}
+
+func (s *SyslogSink) Send(ctx context.Context, audits []model.Audit) error {
+	var buf bytes.Buffer
+	for _, audit := range audits {
+		message, err := s.format(audit)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "%d %s", len(message), message)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout)); err != nil {
+		return s.reset(err)
+	}
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		// 끊어진 연결은 다음 재시도에서 다시 연결한다.
+		return s.reset(err)
+	}
+	return nil
+}
+
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reset(nil)
+}
+
+func (s *SyslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogDialTimeout}
+	if !s.useTLS {
+		return dialer.DialContext(ctx, "tcp", s.address)
+	}
+	host, _, err := net.SplitHostPort(s.address)
+	if err != nil {
+		return nil, err
+	}
+	tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+	return tlsDialer.DialContext(ctx, "tcp", s.address)
+}
+
+func (s *SyslogSink) reset(err error) error {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// format writes <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD] MSG. The message is the audit in JSON.
+func (s *SyslogSink) format(audit model.Audit) (string, error) {
+	body, err := marshal(audit)
+	if err != nil {
+		return "", err
+	}
+	severity := syslogSeverityInfo
+	if audit.Result == model.AuditResultFailure {
+		severity = syslogSeverityNotice
+	}
+	msgId := audit.Endpoint
+	if msgId == "" {
+		msgId = "-"
+	}
+
+	sd := fmt.Sprintf("[%s id=\"%s\" organizationId=\"%s\" userAccountId=\"%s\" result=\"%s\" resourceType=\"%s\" resourceId=\"%s\"]",
+		syslogSdId, escapeSdValue(audit.ID.String()), escapeSdValue(audit.OrganizationId), escapeSdValue(audit.UserAccountId),
+		escapeSdValue(audit.Result), escapeSdValue(audit.ResourceType), escapeSdValue(audit.ResourceId))
+
+	return fmt.Sprintf("<%d>1 %s %s %s - %s %s %s",
+		syslogFacility*8+severity, audit.CreatedAt.UTC().Format(time.RFC3339Nano), s.hostname, syslogAppName,
+		truncate(msgId, 32), sd, body), nil
+}
+
+// escapeSdValue escapes '"', '\' and ']' in the param value of the structured data.
+func escapeSdValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+func truncate(value string, length int) string {
+	if len(value) > length {
+		return value[:length]
+	}
+	return value
+}
@@ -0,0 +1,71 @@
+package siem
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/model"
+)
+
+func TestSyslogSinkSend(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		var length int
+		if _, err := fmt.Fscanf(reader, "%d ", &length); err != nil {
+			return
+		}
+		buf := make([]byte, length)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return
+		}
+		received <- string(buf)
+	}()
+
+	sink := NewSyslogSink(listener.Addr().String(), false)
+	defer sink.Close()
+	audit := model.Audit{
+		ID:             uuid.New(),
+		OrganizationId: "org]1",
+		UserAccountId:  `say "hi"`,
+		Endpoint:       "UpdateStack",
+		Result:         model.AuditResultFailure,
+	}
+	audit.CreatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := sink.Send(context.Background(), []model.Audit{audit}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case message := <-received:
+		wantPrefix := fmt.Sprintf("<%d>1 2024-01-02T03:04:05Z ", syslogFacility*8+syslogSeverityNotice)
+		if !strings.HasPrefix(message, wantPrefix) {
+			t.Errorf("message = %s, want prefix %s", message, wantPrefix)
+		}
+		for _, want := range []string{" tks-api - UpdateStack [audit@32473 ", `organizationId="org\]1"`, `userAccountId="say \"hi\""`, `"endpoint":"UpdateStack"`} {
+			if !strings.Contains(message, want) {
+				t.Errorf("message = %s, want %s", message, want)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message is not received")
+	}
+}
@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"gorm.io/gorm"
+)
+
+const postgresChannel = "tks_stream_events"
+
+// postgresBackend shares the events among the replicas by LISTEN/NOTIFY of the database they already use.
+type postgresBackend struct {
+	db *gorm.DB
+}
+
+func NewPostgresBackend(db *gorm.DB) Backend {
+	return &postgresBackend{
+		db: db,
+	}
+}
+
+func (b *postgresBackend) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", postgresChannel, string(payload)).Error
+}
+
+func (b *postgresBackend) Listen(ctx context.Context, handler func(Event)) error {
+	sqlDB, err := b.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unsupported driver connection %T", driverConn)
+		}
+		pgConn := stdlibConn.Conn()
+
+		if _, err := pgConn.Exec(ctx, "LISTEN "+postgresChannel); err != nil {
+			return err
+		}
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+
+			var event Event
+			if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+				log.Errorf(ctx, "invalid stream event payload. err : %v", err)
+				continue
+			}
+			handler(event)
+		}
+	})
+}
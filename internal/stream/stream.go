@@ -0,0 +1,174 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	subscriberBufferSize = 64
+	listenRetryInterval  = 5 * time.Second
+)
+
+// Event is pushed to the connected clients of the organization.
+type Event struct {
+	ID             string      `json:"id"`
+	Type           string      `json:"type"`
+	OrganizationId string      `json:"organizationId"`
+	Data           interface{} `json:"data"`
+	CreatedAt      time.Time   `json:"createdAt"`
+}
+
+// Backend carries the events among the replicas.
+// Without the backend, the events are delivered only to the clients connected to this process.
+type Backend interface {
+	Publish(ctx context.Context, event Event) error
+	Listen(ctx context.Context, handler func(Event)) error
+}
+
+type Broker struct {
+	backend Backend
+
+	mu          sync.RWMutex
+	subscribers map[string]map[chan Event]struct{}
+}
+
+func NewBroker(backend Backend) *Broker {
+	return &Broker{
+		backend:     backend,
+		subscribers: make(map[string]map[chan Event]struct{}),
+	}
+}
+
+var broker = NewBroker(nil)
+
+func Initialize(ctx context.Context, db *gorm.DB) error {
+	switch viper.GetString("stream-backend") {
+	case "", "memory":
+		broker = NewBroker(nil)
+	case "postgres":
+		broker = NewBroker(NewPostgresBackend(db))
+	default:
+		return fmt.Errorf("invalid stream-backend %s", viper.GetString("stream-backend"))
+	}
+
+	go broker.Run(ctx)
+	return nil
+}
+
+// Publish sends the event to the clients of the organization on every replica.
+func Publish(ctx context.Context, event Event) {
+	broker.Publish(ctx, event)
+}
+
+// PublishLocal sends the event only to the clients connected to this process.
+// It is for the events that every replica detects by itself.
+func PublishLocal(event Event) {
+	broker.dispatch(withDefaults(event))
+}
+
+func Subscribe(organizationId string) (<-chan Event, func()) {
+	return broker.Subscribe(organizationId)
+}
+
+// SubscribedOrganizations returns the organizations which have the clients connected to this process.
+func SubscribedOrganizations() []string {
+	return broker.SubscribedOrganizations()
+}
+
+func (b *Broker) Publish(ctx context.Context, event Event) {
+	event = withDefaults(event)
+	if b.backend == nil {
+		b.dispatch(event)
+		return
+	}
+	if err := b.backend.Publish(ctx, event); err != nil {
+		log.Errorf(ctx, "failed to publish stream event [%s]. err : %v", event.Type, err)
+	}
+}
+
+// Subscribe returns the events of the organization until the returned cancel function is called.
+func (b *Broker) Subscribe(organizationId string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBufferSize)
+
+	b.mu.Lock()
+	if b.subscribers[organizationId] == nil {
+		b.subscribers[organizationId] = make(map[chan Event]struct{})
+	}
+	b.subscribers[organizationId][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[organizationId], ch)
+			if len(b.subscribers[organizationId]) == 0 {
+				delete(b.subscribers, organizationId)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (b *Broker) SubscribedOrganizations() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	out := make([]string, 0, len(b.subscribers))
+	for organizationId := range b.subscribers {
+		out = append(out, organizationId)
+	}
+	return out
+}
+
+// Run receives the events of the other replicas until ctx is done.
+func (b *Broker) Run(ctx context.Context) {
+	if b.backend == nil {
+		return
+	}
+
+	for {
+		if err := b.backend.Listen(ctx, b.dispatch); err != nil {
+			log.Errorf(ctx, "failed to listen stream events. err : %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+func withDefaults(event Event) Event {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	return event
+}
+
+func (b *Broker) dispatch(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[event.OrganizationId] {
+		// 느린 client 때문에 다른 client 가 밀리지 않도록 버퍼가 차면 버린다.
+		select {
+		case ch <- event:
+		default:
+			log.Warnf(context.Background(), "stream subscriber is slow. event [%s] is dropped", event.ID)
+		}
+	}
+}
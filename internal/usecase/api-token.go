@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	internalApi "github.com/openinfradev/tks-api/internal/delivery/api"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// 목록에서 토큰을 구분할 수 있도록 앞부분만 저장한다.
+const apiTokenPrefixLength = 12
+
+type IApiTokenUsecase interface {
+	FetchByServiceAccountId(ctx context.Context, serviceAccountId uuid.UUID, pg *pagination.Pagination) ([]model.ApiToken, error)
+	FetchByUserId(ctx context.Context, userId uuid.UUID, pg *pagination.Pagination) ([]model.ApiToken, error)
+	Create(ctx context.Context, dto model.ApiToken) (apiTokenId uuid.UUID, token string, err error)
+	RevokeByServiceAccount(ctx context.Context, serviceAccountId uuid.UUID, apiTokenId uuid.UUID) (model.ApiToken, error)
+	RevokeByUser(ctx context.Context, userId uuid.UUID, apiTokenId uuid.UUID) (model.ApiToken, error)
+}
+
+type ApiTokenUsecase struct {
+	repo repository.IApiTokenRepository
+}
+
+func NewApiTokenUsecase(r repository.Repository) IApiTokenUsecase {
+	return &ApiTokenUsecase{
+		repo: r.ApiToken,
+	}
+}
+
+func (u *ApiTokenUsecase) FetchByServiceAccountId(ctx context.Context, serviceAccountId uuid.UUID, pg *pagination.Pagination) ([]model.ApiToken, error) {
+	return u.repo.FetchByServiceAccountId(ctx, serviceAccountId, pg)
+}
+
+func (u *ApiTokenUsecase) FetchByUserId(ctx context.Context, userId uuid.UUID, pg *pagination.Pagination) ([]model.ApiToken, error) {
+	return u.repo.FetchByUserId(ctx, userId, pg)
+}
+
+// Create issues the token of the service account or the user of dto. The token is returned only here, and only its hash is stored.
+func (u *ApiTokenUsecase) Create(ctx context.Context, dto model.ApiToken) (apiTokenId uuid.UUID, token string, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, "", httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+
+	if dto.ExpiredAt != nil && !dto.ExpiredAt.After(time.Now()) {
+		return uuid.Nil, "", httpErrors.NewBadRequestError(fmt.Errorf("expiredAt %s is past", dto.ExpiredAt), "AT_INVALID_EXPIRED_AT", "")
+	}
+	if err = validateEndpointGroups(dto.EndpointGroups); err != nil {
+		return uuid.Nil, "", err
+	}
+
+	token, err = helper.GenerateApiToken()
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	dto.TokenHash = helper.HashApiToken(token)
+	dto.TokenPrefix = token[:apiTokenPrefixLength]
+
+	apiTokenId, err = u.repo.Create(ctx, dto)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	return apiTokenId, token, nil
+}
+
+func (u *ApiTokenUsecase) RevokeByServiceAccount(ctx context.Context, serviceAccountId uuid.UUID, apiTokenId uuid.UUID) (out model.ApiToken, err error) {
+	out, err = u.get(ctx, apiTokenId)
+	if err != nil {
+		return out, err
+	}
+	if out.ServiceAccountId == nil || *out.ServiceAccountId != serviceAccountId {
+		return out, httpErrors.NewNotFoundError(fmt.Errorf("not found api token"), "AT_NOT_EXISTED_API_TOKEN", "")
+	}
+	return out, u.repo.Revoke(ctx, apiTokenId, time.Now())
+}
+
+func (u *ApiTokenUsecase) RevokeByUser(ctx context.Context, userId uuid.UUID, apiTokenId uuid.UUID) (out model.ApiToken, err error) {
+	out, err = u.get(ctx, apiTokenId)
+	if err != nil {
+		return out, err
+	}
+	if out.UserId == nil || *out.UserId != userId {
+		return out, httpErrors.NewNotFoundError(fmt.Errorf("not found api token"), "AT_NOT_EXISTED_API_TOKEN", "")
+	}
+	return out, u.repo.Revoke(ctx, apiTokenId, time.Now())
+}
+
+func (u *ApiTokenUsecase) get(ctx context.Context, apiTokenId uuid.UUID) (out model.ApiToken, err error) {
+	out, err = u.repo.Get(ctx, apiTokenId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "AT_NOT_EXISTED_API_TOKEN", "")
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+func validateEndpointGroups(groups []string) error {
+	known := make(map[string]struct{})
+	for _, info := range internalApi.ApiMap {
+		known[info.Group] = struct{}{}
+	}
+	for _, group := range groups {
+		if _, ok := known[group]; !ok {
+			return httpErrors.NewBadRequestError(fmt.Errorf("invalid endpoint group %s", group), "AT_INVALID_ENDPOINT_GROUP", "")
+		}
+	}
+	return nil
+}
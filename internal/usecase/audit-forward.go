@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/internal/siem"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const (
+	auditForwardCursorName   = "siem"
+	auditForwardPollInterval = 10 * time.Second
+	auditForwardLease        = time.Minute
+	auditForwardBatchSize    = 500
+	auditForwardMaxBackoff   = 5 * time.Minute
+)
+
+type IAuditForwardUsecase interface {
+	Run(ctx context.Context)
+}
+
+type AuditForwardUsecase struct {
+	repo repository.IAuditRepository
+}
+
+func NewAuditForwardUsecase(r repository.Repository) IAuditForwardUsecase {
+	return &AuditForwardUsecase{
+		repo: r.Audit,
+	}
+}
+
+// Run streams the new audits to the SIEM until ctx is done. The database is the buffer,
+// so the audits created while the SIEM is down are sent after it recovers.
+func (u *AuditForwardUsecase) Run(ctx context.Context) {
+	sink, err := siem.New()
+	if err != nil {
+		log.Errorf(ctx, "failed to create the audit forwarder. %v", err)
+		return
+	}
+	if sink == nil {
+		return
+	}
+	defer sink.Close()
+
+	runWorker(ctx, auditForwardPollInterval, func(ctx context.Context) {
+		u.forward(ctx, sink)
+	})
+}
+
+func (u *AuditForwardUsecase) forward(ctx context.Context, sink siem.Sink) {
+	cursor, claimed, err := u.repo.ClaimForwardCursor(ctx, auditForwardCursorName, auditForwardLease)
+	if err != nil {
+		log.Errorf(ctx, "failed to claim the audit forward cursor. %v", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	lastSequences, err := u.repo.GetLastSequences(ctx)
+	if err != nil {
+		log.Errorf(ctx, "failed to get the last sequences of audits. %v", err)
+		cursor.NextAttemptAt = time.Now()
+		u.update(ctx, cursor)
+		return
+	}
+	if cursor.Sequences == nil {
+		cursor.Sequences = lastSequences
+	}
+
+	// 조직별 체인의 sequence 를 위치로 삼아, 늦게 커밋된 감사 로그도 순서대로 보낸다.
+	organizationIds := make([]string, 0, len(lastSequences))
+	for organizationId := range lastSequences {
+		organizationIds = append(organizationIds, organizationId)
+	}
+	sort.Strings(organizationIds)
+
+	deadline := time.Now().Add(auditForwardLease / 2)
+organizations:
+	for _, organizationId := range organizationIds {
+		for cursor.Sequences[organizationId] < lastSequences[organizationId] {
+			if time.Now().After(deadline) {
+				break organizations
+			}
+
+			audits, err := u.repo.FetchChain(ctx, organizationId, cursor.Sequences[organizationId], auditForwardBatchSize)
+			if err != nil {
+				log.Errorf(ctx, "failed to fetch audits to forward. %v", err)
+				break organizations
+			}
+			if len(audits) == 0 {
+				break
+			}
+
+			if err = sink.Send(ctx, audits); err != nil {
+				cursor.Attempts++
+				cursor.LastError = err.Error()
+				cursor.NextAttemptAt = time.Now().Add(auditForwardBackoff(cursor.Attempts))
+				log.Warnf(ctx, "failed to forward audits. attempts : %d, err : %v", cursor.Attempts, err)
+				u.update(ctx, cursor)
+				return
+			}
+
+			cursor.Sequences[organizationId] = audits[len(audits)-1].Sequence
+			cursor.Attempts, cursor.LastError = 0, ""
+		}
+	}
+
+	cursor.NextAttemptAt = time.Now()
+	u.update(ctx, cursor)
+}
+
+func (u *AuditForwardUsecase) update(ctx context.Context, cursor model.AuditForwardCursor) {
+	if err := u.repo.UpdateForwardCursor(ctx, cursor); err != nil {
+		log.Errorf(ctx, "failed to update the audit forward cursor. %v", err)
+	}
+}
+
+func auditForwardBackoff(attempts int) time.Duration {
+	backoff := auditForwardPollInterval
+	for i := 1; i < attempts && backoff < auditForwardMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > auditForwardMaxBackoff {
+		return auditForwardMaxBackoff
+	}
+	return backoff
+}
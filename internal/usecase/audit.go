@@ -2,19 +2,36 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
 	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/pagination"
 	"github.com/openinfradev/tks-api/internal/repository"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	auditCheckpointInterval = time.Hour
+	auditVerifyBatchSize    = 1000
+	auditExportBatchSize    = 1000
+	auditExportMaxRange     = 366 * 24 * time.Hour
 )
 
 type IAuditUsecase interface {
 	Get(ctx context.Context, auditId uuid.UUID) (model.Audit, error)
 	Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.Audit, error)
 	Create(ctx context.Context, dto model.Audit) (auditId uuid.UUID, err error)
-	Delete(ctx context.Context, dto model.Audit) error
+	Verify(ctx context.Context, organizationId string) (model.AuditChainVerification, error)
+	Export(ctx context.Context, pg *pagination.Pagination, startDate time.Time, endDate time.Time, fn func([]model.Audit) error) error
+	Run(ctx context.Context)
 }
 
 type AuditUsecase struct {
@@ -66,15 +83,34 @@ func (u *AuditUsecase) Create(ctx context.Context, dto model.Audit) (auditId uui
 	return auditId, nil
 }
 
+// Get returns the audit. The audits of the other organizations are not found unless the user is in the master organization.
 func (u *AuditUsecase) Get(ctx context.Context, auditId uuid.UUID) (res model.Audit, err error) {
+	requestUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return model.Audit{}, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid token"), "A_INVALID_TOKEN", "")
+	}
 	res, err = u.repo.Get(ctx, auditId)
 	if err != nil {
 		return model.Audit{}, err
 	}
+	// 다른 조직의 감사 로그가 있는지 알 수 없도록 찾지 못한 것으로 응답한다.
+	if requestUser.GetOrganizationId() != masterOrganizationId && requestUser.GetOrganizationId() != res.OrganizationId {
+		return model.Audit{}, httpErrors.NewNotFoundError(fmt.Errorf("audit %s is not found", auditId), "", "")
+	}
 	return
 }
 
+// Fetch returns the audits. The audits of the other organizations are excluded unless the user is in the master organization.
 func (u *AuditUsecase) Fetch(ctx context.Context, pg *pagination.Pagination) (audits []model.Audit, err error) {
+	requestUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid token"), "A_INVALID_TOKEN", "")
+	}
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+	scopeAuditsToOrganization(pg, requestUser.GetOrganizationId())
+
 	audits, err = u.repo.Fetch(ctx, pg)
 	if err != nil {
 		return nil, err
@@ -82,10 +118,207 @@ func (u *AuditUsecase) Fetch(ctx context.Context, pg *pagination.Pagination) (au
 	return
 }
 
-func (u *AuditUsecase) Delete(ctx context.Context, dto model.Audit) (err error) {
-	err = u.repo.Delete(ctx, dto.ID)
+// Export calls fn with the audits in [startDate, endDate) in the order of creation, batch by batch.
+// The audits of the other organizations are excluded unless the user is in the master organization.
+func (u *AuditUsecase) Export(ctx context.Context, pg *pagination.Pagination, startDate time.Time, endDate time.Time, fn func([]model.Audit) error) error {
+	requestUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("invalid token"), "A_INVALID_TOKEN", "")
+	}
+	if !startDate.Before(endDate) || endDate.Sub(startDate) > auditExportMaxRange {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invalid date range %s ~ %s", startDate, endDate), "AU_INVALID_DATE_RANGE", "")
+	}
+	if pg == nil {
+		pg = pagination.NewPagination(nil)
+	}
+	scopeAuditsToOrganization(pg, requestUser.GetOrganizationId())
+	// 내보내는 동안 추가되는 로그가 페이지를 밀어내지 않도록 오래된 순으로 읽는다.
+	pg.SortColumn, pg.SortOrder = "created_at", "ASC"
+	pg.Limit = auditExportBatchSize
+
+	for page := 1; ; page++ {
+		pg.Page = page
+		pg.MakePaginationRequest()
+		audits, err := u.repo.FetchRange(ctx, pg, startDate, endDate)
+		if err != nil {
+			return httpErrors.NewInternalServerError(err, "", "")
+		}
+		if len(audits) > 0 {
+			if err = fn(audits); err != nil {
+				return err
+			}
+		}
+		if len(audits) < auditExportBatchSize {
+			return nil
+		}
+	}
+}
+
+func scopeAuditsToOrganization(pg *pagination.Pagination, organizationId string) {
+	if organizationId == masterOrganizationId {
+		return
+	}
+	pg.AddFilter(pagination.Filter{
+		Column:   "organization_id",
+		Operator: "$eq",
+		Values:   []string{organizationId},
+	})
+}
+
+// Verify walks the audit chain of the organization and reports the first broken link.
+func (u *AuditUsecase) Verify(ctx context.Context, organizationId string) (out model.AuditChainVerification, err error) {
+	requestUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return out, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid token"), "A_INVALID_TOKEN", "")
+	}
+	if organizationId == "" {
+		organizationId = requestUser.GetOrganizationId()
+	}
+	if requestUser.GetOrganizationId() != masterOrganizationId && requestUser.GetOrganizationId() != organizationId {
+		return out, httpErrors.NewForbiddenError(fmt.Errorf("can not verify audits of other organization"), "AU_NOT_ALLOWED_ORGANIZATION", "")
+	}
+
+	out = model.AuditChainVerification{
+		OrganizationId: organizationId,
+		Verified:       true,
+		VerifiedAt:     time.Now(),
+	}
+	broken := func(audit *model.Audit, sequence int64, reason string) {
+		out.Verified = false
+		out.BrokenSequence = sequence
+		out.BrokenReason = reason
+		if audit != nil {
+			id := audit.ID
+			out.BrokenAuditId = &id
+		}
+	}
+
+	checkpoints, err := u.repo.FetchCheckpoints(ctx, organizationId)
+	if err != nil {
+		return out, httpErrors.NewInternalServerError(err, "", "")
+	}
+	checkpointHashes := make(map[int64]string, len(checkpoints))
+	retentionHashes := make(map[int64]string)
+	for _, checkpoint := range checkpoints {
+		if !helper.VerifySignatureWithSecret(checkpoint.SigningContent(), checkpoint.Signature) {
+			broken(nil, checkpoint.Sequence, "체크포인트의 서명이 일치하지 않습니다.")
+			return out, nil
+		}
+		if checkpoint.Retention {
+			retentionHashes[checkpoint.Sequence] = checkpoint.Hash
+		} else {
+			checkpointHashes[checkpoint.Sequence] = checkpoint.Hash
+		}
+	}
+
+	var prev model.Audit
+	// 보존 기간이 지나 정리된 감사 로그는 정리할 때 남긴 체크포인트에서부터 이어서 검증한다.
+	first, err := u.repo.FetchChain(ctx, organizationId, 0, 1)
+	if err != nil {
+		return out, httpErrors.NewInternalServerError(err, "", "")
+	}
+	if len(first) > 0 && first[0].Sequence > 1 {
+		if hash, ok := retentionHashes[first[0].Sequence-1]; ok {
+			prev = model.Audit{Sequence: first[0].Sequence - 1, Hash: hash}
+			out.ArchivedSequence = prev.Sequence
+			out.CheckpointCount++
+		}
+	}
+	for {
+		audits, err := u.repo.FetchChain(ctx, organizationId, prev.Sequence, auditVerifyBatchSize)
+		if err != nil {
+			return out, httpErrors.NewInternalServerError(err, "", "")
+		}
+		for i := range audits {
+			audit := &audits[i]
+			out.CheckedCount++
+
+			switch {
+			case audit.Sequence != prev.Sequence+1:
+				broken(audit, prev.Sequence+1, fmt.Sprintf("%d 번 감사 로그가 없습니다.", prev.Sequence+1))
+			case audit.PrevHash != prev.Hash:
+				broken(audit, audit.Sequence, "이전 감사 로그의 해시와 일치하지 않습니다.")
+			case audit.Hash != audit.ComputeHash():
+				broken(audit, audit.Sequence, "감사 로그의 내용이 해시와 일치하지 않습니다.")
+			}
+			if hash, ok := checkpointHashes[audit.Sequence]; ok && out.Verified {
+				if hash != audit.Hash {
+					broken(audit, audit.Sequence, "체크포인트의 해시와 일치하지 않습니다.")
+				} else {
+					out.CheckpointCount++
+				}
+			}
+			if !out.Verified {
+				return out, nil
+			}
+
+			if out.FirstSequence == 0 {
+				out.FirstSequence = audit.Sequence
+			}
+			out.LastSequence = audit.Sequence
+			out.LastHash = audit.Hash
+			prev = *audit
+		}
+		if len(audits) < auditVerifyBatchSize {
+			break
+		}
+	}
+
+	// 마지막 체크포인트 이후의 감사 로그가 잘려나간 경우
+	if len(checkpoints) > 0 && checkpoints[len(checkpoints)-1].Sequence > out.LastSequence {
+		broken(nil, out.LastSequence+1, fmt.Sprintf("%d 번 감사 로그가 없습니다.", out.LastSequence+1))
+	}
+	return out, nil
+}
+
+// Run signs the last audit of each organization periodically. It does nothing without audit-signing-secret.
+func (u *AuditUsecase) Run(ctx context.Context) {
+	// 서명 키 없이 만든 체크포인트는 위변조를 증명하지 못하므로 만들지 않는다.
+	if viper.GetString("audit-signing-secret") == "" {
+		log.Warn(ctx, "audit-signing-secret is not configured. the checkpoints of the audit log are not created")
+		return
+	}
+
+	// 새 감사 로그가 없으면 체크포인트를 만들지 않으므로 시작할 때 바로 만들어도 된다.
+	runWorker(ctx, auditCheckpointInterval, u.checkpoint)
+}
+
+func (u *AuditUsecase) checkpoint(ctx context.Context) {
+	organizationIds, err := u.repo.GetChainOrganizationIds(ctx)
 	if err != nil {
-		return httpErrors.NewNotFoundError(err, "", "")
+		log.Errorf(ctx, "failed to get organizations of audits. %v", err)
+		return
+	}
+
+	for _, organizationId := range organizationIds {
+		last, err := u.repo.GetLast(ctx, organizationId)
+		if err != nil {
+			log.Error(ctx, err)
+			continue
+		}
+		lastCheckpoint, err := u.repo.GetLastCheckpoint(ctx, organizationId)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(ctx, err)
+			continue
+		}
+		if err == nil && lastCheckpoint.Sequence >= last.Sequence {
+			continue
+		}
+
+		checkpoint := model.AuditCheckpoint{
+			ID:             uuid.New(),
+			OrganizationId: organizationId,
+			Sequence:       last.Sequence,
+			Hash:           last.Hash,
+			CreatedAt:      time.Now().Truncate(time.Microsecond),
+		}
+		checkpoint.Signature, err = helper.SignWithSecret(checkpoint.SigningContent())
+		if err != nil {
+			log.Errorf(ctx, "failed to sign checkpoint of audits of organization %s. %v", organizationId, err)
+			return
+		}
+		if err = u.repo.CreateCheckpoint(ctx, checkpoint); err != nil {
+			log.Errorf(ctx, "failed to create checkpoint of audits of organization %s. %v", organizationId, err)
+		}
 	}
-	return nil
 }
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+)
+
+type fakeAuditRepository struct {
+	repository.IAuditRepository
+	audits []model.Audit
+}
+
+func (r *fakeAuditRepository) Get(ctx context.Context, auditId uuid.UUID) (model.Audit, error) {
+	for _, audit := range r.audits {
+		if audit.ID == auditId {
+			return audit, nil
+		}
+	}
+	return model.Audit{}, nil
+}
+
+// Fetch applies only the organization_id filters of the pagination.
+func (r *fakeAuditRepository) Fetch(ctx context.Context, pg *pagination.Pagination) (out []model.Audit, err error) {
+	for _, audit := range r.audits {
+		matched := true
+		for _, filter := range pg.Filters {
+			if filter.Column == "organization_id" && filter.Values[0] != audit.OrganizationId {
+				matched = false
+			}
+		}
+		if matched {
+			out = append(out, audit)
+		}
+	}
+	return out, nil
+}
+
+func TestAuditUsecaseScopesOrganization(t *testing.T) {
+	org1Audit := model.Audit{ID: uuid.New(), OrganizationId: "org1"}
+	org2Audit := model.Audit{ID: uuid.New(), OrganizationId: "org2"}
+	u := &AuditUsecase{repo: &fakeAuditRepository{audits: []model.Audit{org1Audit, org2Audit}}}
+
+	tests := []struct {
+		name           string
+		organizationId string
+		wantFetched    int
+		wantGetOrg2    bool
+	}{
+		{"admin of organization", "org1", 1, false},
+		{"admin of other organization", "org3", 0, false},
+		{"master admin", masterOrganizationId, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := request.WithUser(context.Background(), &user.DefaultInfo{
+				UserId:                  uuid.New(),
+				OrganizationId:          tt.organizationId,
+				RoleOrganizationMapping: map[string]string{tt.organizationId: user.AdminRole},
+			})
+
+			audits, err := u.Fetch(ctx, nil)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if len(audits) != tt.wantFetched {
+				t.Errorf("Fetch() returned %d audits, want %d", len(audits), tt.wantFetched)
+			}
+			for _, audit := range audits {
+				if tt.organizationId != masterOrganizationId && audit.OrganizationId != tt.organizationId {
+					t.Errorf("Fetch() returned the audit of organization %s", audit.OrganizationId)
+				}
+			}
+
+			_, err = u.Get(ctx, org2Audit.ID)
+			if (err == nil) != tt.wantGetOrg2 {
+				t.Errorf("Get() of other organization error = %v, want allowed %v", err, tt.wantGetOrg2)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,6 +19,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/Nerzal/gocloak/v13"
+	"github.com/google/uuid"
 	"github.com/openinfradev/tks-api/internal"
 	"github.com/openinfradev/tks-api/internal/helper"
 	"github.com/openinfradev/tks-api/internal/keycloak"
@@ -26,13 +28,17 @@ import (
 	"github.com/openinfradev/tks-api/internal/repository"
 	"github.com/openinfradev/tks-api/pkg/domain"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
 )
 
 type IAuthUsecase interface {
-	Login(ctx context.Context, accountId string, password string, organizationId string) (model.User, error)
+	Login(ctx context.Context, accountId string, password string, organizationId string, clientIp string) (model.User, error)
+	LoginSecondFactor(ctx context.Context, secondFactorToken string, code string, clientIp string) (model.User, error)
+	LoginWithIdentityProvider(ctx context.Context, organizationId string, code string, redirectUri string, clientIp string) (model.User, error)
 	Logout(ctx context.Context, sessionId string, organizationId string) error
-	FindId(ctx context.Context, code string, email string, userName string, organizationId string) (string, error)
-	FindPassword(ctx context.Context, code string, accountId string, email string, userName string, organizationId string) error
+	FindId(ctx context.Context, code string, email string, userName string, organizationId string, clientIp string) (string, error)
+	FindPassword(ctx context.Context, code string, accountId string, email string, userName string, organizationId string, clientIp string) error
 	VerifyIdentity(ctx context.Context, accountId string, email string, userName string, organizationId string) error
 	SingleSignIn(ctx context.Context, organizationId, accountId, password string) ([]*http.Cookie, error)
 	SingleSignOut(ctx context.Context, organizationId string) (string, []*http.Cookie, error)
@@ -41,7 +47,6 @@ type IAuthUsecase interface {
 }
 
 const (
-	passwordLength                  = 8
 	KEYCLOAK_IDENTITY_COOKIE        = "KEYCLOAK_IDENTITY"
 	KEYCLOAK_IDENTITY_LEGACY_COOKIE = "KEYCLOAK_IDENTITY_LEGACY"
 )
@@ -53,6 +58,12 @@ type AuthUsecase struct {
 	clusterRepository      repository.IClusterRepository
 	appgroupRepository     repository.IAppGroupRepository
 	organizationRepository repository.IOrganizationRepository
+	mailOutboxRepository   repository.IMailOutboxRepository
+	roleRepository         repository.IRoleRepository
+	systemNotificationRepo repository.ISystemNotificationRepository
+	securityPolicyRepo     repository.ISecurityPolicyRepository
+	secondFactorRepo       repository.ISecondFactorRepository
+	identityProviderRepo   repository.IIdentityProviderRepository
 }
 
 func NewAuthUsecase(r repository.Repository, kc keycloak.IKeycloak) IAuthUsecase {
@@ -63,14 +74,45 @@ func NewAuthUsecase(r repository.Repository, kc keycloak.IKeycloak) IAuthUsecase
 		clusterRepository:      r.Cluster,
 		appgroupRepository:     r.AppGroup,
 		organizationRepository: r.Organization,
+		mailOutboxRepository:   r.MailOutbox,
+		roleRepository:         r.Role,
+		systemNotificationRepo: r.SystemNotification,
+		securityPolicyRepo:     r.SecurityPolicy,
+		secondFactorRepo:       r.SecondFactor,
+		identityProviderRepo:   r.IdentityProvider,
 	}
 }
 
-func (u *AuthUsecase) Login(ctx context.Context, accountId string, password string, organizationId string) (model.User, error) {
+// secondFactorChallenge 는 비밀번호 확인을 마친 로그인이 2단계 인증을 기다리는 동안 클라이언트에 암호화되어 전달된다.
+type secondFactorChallenge struct {
+	UserId         uuid.UUID `json:"userId"`
+	OrganizationId string    `json:"organizationId"`
+	AccountId      string    `json:"accountId"`
+	Token          string    `json:"token"`
+	ExpiredAt      time.Time `json:"expiredAt"`
+}
+
+func (u *AuthUsecase) Login(ctx context.Context, accountId string, password string, organizationId string, clientIp string) (model.User, error) {
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindClientIp, "", clientIp); err != nil {
+		return model.User{}, err
+	}
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindAccount, organizationId, accountId); err != nil {
+		return model.User{}, err
+	}
+
 	// Authentication with DB
+	// 외부 디렉터리(LDAP)의 사용자는 처음 로그인할 때 생성한다.
 	user, err := u.userRepository.Get(ctx, accountId, organizationId)
+	provisioning := false
 	if err != nil {
-		return model.User{}, httpErrors.NewBadRequestError(err, "A_INVALID_ID", "")
+		if !u.hasLdapFederation(ctx, organizationId) {
+			// 없는 계정과 조직으로 실패 기록이 쌓이지 않도록 IP 만 센다.
+			u.recordLoginFailure(ctx, "", "", clientIp)
+			return model.User{}, httpErrors.NewBadRequestError(err, "A_INVALID_ID", "")
+		}
+		provisioning = true
+	} else if user.Disabled {
+		return model.User{}, httpErrors.NewForbiddenError(fmt.Errorf("account %s is disabled", accountId), "A_DISABLED_ACCOUNT", "")
 	}
 
 	var accountToken *model.User
@@ -79,22 +121,236 @@ func (u *AuthUsecase) Login(ctx context.Context, accountId string, password stri
 		apiErr, ok := err.(*gocloak.APIError)
 		if ok {
 			if apiErr.Code == 401 {
+				// 아직 생성되지 않은 LDAP 사용자는 계정이 있는지 알 수 없으므로 IP 만 센다. 계정의 잠금은 keycloak 이 한다.
+				if provisioning {
+					u.recordLoginFailure(ctx, "", "", clientIp)
+					return model.User{}, httpErrors.NewBadRequestError(fmt.Errorf("Mismatch password"), "A_INVALID_ID", "")
+				}
+				u.recordLoginFailure(ctx, organizationId, accountId, clientIp)
 				return model.User{}, httpErrors.NewBadRequestError(fmt.Errorf("Mismatch password"), "A_INVALID_PASSWORD", "")
 			}
 		}
 		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
 	}
 
+	user, accountToken, err = u.syncFederatedLogin(ctx, organizationId, accountId, provisioning, user, accountToken)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	return u.completeLogin(ctx, user, accountToken.Token)
+}
+
+// LoginWithIdentityProvider logs in with the authorization code issued by keycloak after the login through the identity provider (OIDC, SAML).
+func (u *AuthUsecase) LoginWithIdentityProvider(ctx context.Context, organizationId string, code string, redirectUri string, clientIp string) (model.User, error) {
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindClientIp, "", clientIp); err != nil {
+		return model.User{}, err
+	}
+
+	accountToken, err := u.kc.LoginWithAuthorizationCode(ctx, organizationId, code, redirectUri)
+	if err != nil {
+		u.recordLoginFailure(ctx, "", "", clientIp)
+		return model.User{}, httpErrors.NewBadRequestError(err, "A_FAILED_IDENTITY_PROVIDER_LOGIN", "")
+	}
+	claims, err := u.kc.ParseAccessToken(ctx, accountToken.Token, organizationId)
+	if err != nil {
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	accountId, ok := claims["preferred_username"].(string)
+	if !ok || accountId == "" {
+		return model.User{}, httpErrors.NewInternalServerError(fmt.Errorf("preferred_username is not found in token"), "", "")
+	}
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindAccount, organizationId, accountId); err != nil {
+		return model.User{}, err
+	}
+
+	user, err := u.userRepository.Get(ctx, accountId, organizationId)
+	provisioning := err != nil
+	user, accountToken, err = u.syncFederatedLogin(ctx, organizationId, accountId, provisioning, user, accountToken)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	return u.completeLogin(ctx, user, accountToken.Token)
+}
+
+// syncFederatedLogin creates the federated user who logs in for the first time, or updates the roles of the user.
+// The token is reissued if the roles are changed because it has the roles at the login.
+func (u *AuthUsecase) syncFederatedLogin(ctx context.Context, organizationId string, accountId string, provisioning bool,
+	user model.User, accountToken *model.User) (model.User, *model.User, error) {
+	var err error
+	changed := provisioning
+	if provisioning {
+		user, err = provisionFederatedUser(ctx, u.kc, u.identityProviderRepo, u.userRepository, organizationId, accountId)
+	} else {
+		user, changed, err = syncFederatedUser(ctx, u.kc, u.identityProviderRepo, u.userRepository, user)
+	}
+	if err != nil {
+		return model.User{}, nil, err
+	}
+	if !changed {
+		return user, accountToken, nil
+	}
+
+	accountToken, err = u.kc.RefreshToken(ctx, organizationId, accountToken.RefreshToken)
+	if err != nil {
+		return model.User{}, nil, httpErrors.NewInternalServerError(err, "", "")
+	}
+	return user, accountToken, nil
+}
+
+// completeLogin issues the token to the authenticated user, or the second factor token if the user has the second factor.
+func (u *AuthUsecase) completeLogin(ctx context.Context, user model.User, token string) (model.User, error) {
+	organizationId := user.Organization.ID
+	secondFactor, err := u.secondFactorRepo.Get(ctx, user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err == nil && secondFactor.Enabled {
+		// 로그인 실패 횟수는 2단계 인증까지 마친 후에 초기화한다.
+		user.SecondFactorToken, err = u.issueSecondFactorToken(ctx, user, token)
+		if err != nil {
+			return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+		}
+		return user, nil
+	}
+
+	if err = u.authRepository.DeleteLoginFailure(ctx, model.LoginFailureKindAccount, organizationId, user.AccountId); err != nil {
+		log.Error(ctx, err)
+	}
+
 	// Insert token
-	user.Token = accountToken.Token
+	user.Token = token
 
-	if !(organizationId == "master" && accountId == "admin") {
-		user.PasswordExpired = helper.IsDurationExpired(user.PasswordUpdatedAt, internal.PasswordExpiredDuration)
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepo, organizationId)
+	if err != nil {
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
 	}
+	user.PasswordExpired = securityPolicy.IsUserPasswordExpired(user)
+	user.SecondFactorEnrollmentRequired = securityPolicy.RequireSecondFactor
 
 	return user, nil
 }
 
+func (u *AuthUsecase) hasLdapFederation(ctx context.Context, organizationId string) bool {
+	providers, err := u.identityProviderRepo.FetchEnabled(ctx, organizationId)
+	if err != nil {
+		log.Error(ctx, err)
+		return false
+	}
+	for _, provider := range providers {
+		if provider.Type == model.IdentityProviderTypeLdap {
+			return true
+		}
+	}
+	return false
+}
+
+// LoginSecondFactor completes the login with the TOTP code or a recovery code. On failure, the returned user has only the account of the challenge.
+func (u *AuthUsecase) LoginSecondFactor(ctx context.Context, secondFactorToken string, code string, clientIp string) (model.User, error) {
+	challenge, err := u.parseSecondFactorToken(secondFactorToken)
+	if err != nil {
+		return model.User{}, err
+	}
+	failed := model.User{AccountId: challenge.AccountId, Organization: model.Organization{ID: challenge.OrganizationId}}
+
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindClientIp, "", clientIp); err != nil {
+		return failed, err
+	}
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindAccount, challenge.OrganizationId, challenge.AccountId); err != nil {
+		return failed, err
+	}
+
+	secondFactor, err := u.secondFactorRepo.Get(ctx, challenge.UserId)
+	if err != nil || !secondFactor.Enabled {
+		return failed, httpErrors.NewBadRequestError(fmt.Errorf("second factor is not enabled"), "A_INVALID_SECOND_FACTOR_CODE", "")
+	}
+	if err = verifySecondFactor(ctx, u.secondFactorRepo, secondFactor, code, true); err != nil {
+		u.recordLoginFailure(ctx, challenge.OrganizationId, challenge.AccountId, clientIp)
+		return failed, err
+	}
+	// 2단계 인증용 토큰은 한 번만 사용할 수 있다.
+	if err = u.secondFactorRepo.ConsumeChallenge(ctx, helper.HashInvitationToken(secondFactorToken)); err != nil {
+		return failed, httpErrors.NewBadRequestError(err, "A_EXPIRED_SECOND_FACTOR_TOKEN", "")
+	}
+
+	// 토큰은 암호화된 2단계 인증용 토큰에 담겨 있었으므로 서명을 다시 확인하지 않는다.
+	parsedToken, err := helper.StringToTokenWithoutVerification(challenge.Token)
+	if err != nil {
+		return failed, httpErrors.NewInternalServerError(err, "", "")
+	}
+	claims, err := helper.RetrieveClaims(parsedToken)
+	if err != nil {
+		return failed, httpErrors.NewInternalServerError(err, "", "")
+	}
+	sessionId, ok := claims["sid"].(string)
+	if !ok {
+		return failed, httpErrors.NewInternalServerError(fmt.Errorf("session id is not found in token"), "", "")
+	}
+	if err = createSecondFactorSession(ctx, u.secondFactorRepo, u.securityPolicyRepo, challenge.UserId, challenge.OrganizationId, sessionId); err != nil {
+		return failed, err
+	}
+
+	if err = u.authRepository.DeleteLoginFailure(ctx, model.LoginFailureKindAccount, challenge.OrganizationId, challenge.AccountId); err != nil {
+		log.Error(ctx, err)
+	}
+
+	user, err := u.userRepository.Get(ctx, challenge.AccountId, challenge.OrganizationId)
+	if err != nil {
+		return failed, httpErrors.NewBadRequestError(err, "A_INVALID_ID", "")
+	}
+	user.Token = challenge.Token
+
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepo, challenge.OrganizationId)
+	if err != nil {
+		return failed, httpErrors.NewInternalServerError(err, "", "")
+	}
+	user.PasswordExpired = securityPolicy.IsUserPasswordExpired(user)
+
+	return user, nil
+}
+
+func (u *AuthUsecase) issueSecondFactorToken(ctx context.Context, user model.User, token string) (string, error) {
+	expiredAt := time.Now().Add(internal.SecondFactorExpireTime)
+	data, err := json.Marshal(secondFactorChallenge{
+		UserId:         user.ID,
+		OrganizationId: user.Organization.ID,
+		AccountId:      user.AccountId,
+		Token:          token,
+		ExpiredAt:      expiredAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	secondFactorToken, err := helper.EncryptWithSecret(data)
+	if err != nil {
+		return "", err
+	}
+	err = u.secondFactorRepo.CreateChallenge(ctx, model.SecondFactorChallenge{
+		TokenHash: helper.HashInvitationToken(secondFactorToken),
+		UserId:    user.ID,
+		ExpiredAt: expiredAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return secondFactorToken, nil
+}
+
+func (u *AuthUsecase) parseSecondFactorToken(secondFactorToken string) (out secondFactorChallenge, err error) {
+	data, _, err := helper.DecryptWithSecret(secondFactorToken)
+	if err != nil {
+		return out, httpErrors.NewBadRequestError(err, "A_EXPIRED_SECOND_FACTOR_TOKEN", "")
+	}
+	if err = json.Unmarshal(data, &out); err != nil {
+		return out, httpErrors.NewBadRequestError(err, "A_EXPIRED_SECOND_FACTOR_TOKEN", "")
+	}
+	if time.Now().After(out.ExpiredAt) {
+		return out, httpErrors.NewBadRequestError(fmt.Errorf("expired second factor token"), "A_EXPIRED_SECOND_FACTOR_TOKEN", "")
+	}
+	return out, nil
+}
+
 func (u *AuthUsecase) Logout(ctx context.Context, sessionId string, organizationName string) error {
 	// [TODO] refresh token 을 추가하고, session timeout 을 줄이는 방향으로 고려할 것
 	err := u.kc.Logout(ctx, sessionId, organizationName)
@@ -104,10 +360,15 @@ func (u *AuthUsecase) Logout(ctx context.Context, sessionId string, organization
 	return nil
 }
 
-func (u *AuthUsecase) FindId(ctx context.Context, code string, email string, userName string, organizationId string) (string, error) {
+func (u *AuthUsecase) FindId(ctx context.Context, code string, email string, userName string, organizationId string, clientIp string) (string, error) {
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindClientIp, "", clientIp); err != nil {
+		return "", err
+	}
+
 	users, err := u.userRepository.List(ctx, u.userRepository.OrganizationFilter(organizationId),
 		u.userRepository.NameFilter(userName), u.userRepository.EmailFilter(email))
 	if err != nil && users == nil {
+		u.recordLoginFailure(ctx, "", "", clientIp)
 		return "", httpErrors.NewBadRequestError(err, "A_INVALID_ID", "")
 	}
 	if err != nil {
@@ -121,7 +382,8 @@ func (u *AuthUsecase) FindId(ctx context.Context, code string, email string, use
 		return "", httpErrors.NewBadRequestError(fmt.Errorf("expired code"), "A_EXPIRED_CODE", "")
 	}
 	if emailCode.Code != code {
-		return "", httpErrors.NewBadRequestError(fmt.Errorf("invalid code"), "A_INVALID_CODE", "")
+		u.recordLoginFailure(ctx, organizationId, "", clientIp)
+		return "", u.increaseEmailCodeFailure(ctx, (*users)[0].ID)
 	}
 	if err := u.authRepository.DeleteEmailCode(ctx, (*users)[0].ID); err != nil {
 		return "", httpErrors.NewInternalServerError(err, "", "")
@@ -130,11 +392,16 @@ func (u *AuthUsecase) FindId(ctx context.Context, code string, email string, use
 	return (*users)[0].AccountId, nil
 }
 
-func (u *AuthUsecase) FindPassword(ctx context.Context, code string, accountId string, email string, userName string, organizationId string) error {
+func (u *AuthUsecase) FindPassword(ctx context.Context, code string, accountId string, email string, userName string, organizationId string, clientIp string) error {
+	if err := u.checkLoginFailure(ctx, model.LoginFailureKindClientIp, "", clientIp); err != nil {
+		return err
+	}
+
 	users, err := u.userRepository.List(ctx, u.userRepository.OrganizationFilter(organizationId),
 		u.userRepository.AccountIdFilter(accountId), u.userRepository.NameFilter(userName),
 		u.userRepository.EmailFilter(email))
 	if err != nil && users == nil {
+		u.recordLoginFailure(ctx, "", "", clientIp)
 		return httpErrors.NewBadRequestError(err, "A_INVALID_ID", "")
 	}
 	if err != nil {
@@ -149,9 +416,14 @@ func (u *AuthUsecase) FindPassword(ctx context.Context, code string, accountId s
 		return httpErrors.NewBadRequestError(fmt.Errorf("expired code"), "A_EXPIRED_CODE", "")
 	}
 	if emailCode.Code != code {
-		return httpErrors.NewBadRequestError(fmt.Errorf("invalid code"), "A_INVALID_CODE", "")
+		u.recordLoginFailure(ctx, organizationId, "", clientIp)
+		return u.increaseEmailCodeFailure(ctx, user.ID)
+	}
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepo, organizationId)
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
 	}
-	randomPassword := helper.GenerateRandomString(passwordLength)
+	randomPassword := securityPolicy.GenerateTemporaryPassword()
 
 	originUser, err := u.kc.GetUser(ctx, organizationId, accountId)
 	if err != nil {
@@ -178,9 +450,7 @@ func (u *AuthUsecase) FindPassword(ctx context.Context, code string, accountId s
 		return httpErrors.NewInternalServerError(err, "", "")
 	}
 
-	mailer := mail.New(message)
-
-	if err := mailer.SendMail(ctx); err != nil {
+	if err := enqueueMail(ctx, u.mailOutboxRepository, organizationId, domain.MAIL_CATEGORY_TEMPORARY_PASSWORD, message); err != nil {
 		return httpErrors.NewInternalServerError(err, "", "")
 	}
 
@@ -231,10 +501,8 @@ func (u *AuthUsecase) VerifyIdentity(ctx context.Context, accountId string, emai
 		return httpErrors.NewInternalServerError(err, "", "")
 	}
 
-	mailer := mail.New(message)
-
-	if err := mailer.SendMail(ctx); err != nil {
-		log.Errorf(ctx, "mailer.SendMail error. %v", err)
+	if err := enqueueMail(ctx, u.mailOutboxRepository, organizationId, domain.MAIL_CATEGORY_VERIFY_IDENTITY, message); err != nil {
+		log.Errorf(ctx, "enqueueMail error. %v", err)
 		return httpErrors.NewInternalServerError(err, "", "")
 	}
 
@@ -337,6 +605,173 @@ func (u *AuthUsecase) isExpiredEmailCode(code model.CacheEmailCode) bool {
 	return !helper.IsDurationExpired(code.UpdatedAt, internal.EmailCodeExpireTime)
 }
 
+// increaseEmailCodeFailure counts the mismatch of the email code, and discards the code when it reaches internal.EmailCodeMaxFailureCount.
+func (u *AuthUsecase) increaseEmailCodeFailure(ctx context.Context, userId uuid.UUID) error {
+	failedCount, err := u.authRepository.IncreaseEmailCodeFailure(ctx, userId)
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if failedCount < internal.EmailCodeMaxFailureCount {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invalid code"), "A_INVALID_CODE", "")
+	}
+	if err = u.authRepository.DeleteEmailCode(ctx, userId); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return httpErrors.NewBadRequestError(fmt.Errorf("exceeded code attempts"), "A_EXCEEDED_CODE_ATTEMPTS", "")
+}
+
+// checkLoginFailure rejects the attempt while the subject is locked, or before the delay after the last failure of the account passes.
+func (u *AuthUsecase) checkLoginFailure(ctx context.Context, kind string, organizationId string, subject string) error {
+	if subject == "" {
+		return nil
+	}
+	failure, err := u.authRepository.GetLoginFailure(ctx, kind, organizationId, subject)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return loginFailureError(kind, subject, failure, time.Now())
+}
+
+// loginFailureError returns the error if the account or the client ip is locked, or the account must wait for the next attempt.
+func loginFailureError(kind string, subject string, failure model.LoginFailure, now time.Time) error {
+	if failure.IsLocked(now) {
+		if kind == model.LoginFailureKindClientIp {
+			return httpErrors.NewForbiddenError(fmt.Errorf("client ip %s is locked until %s", subject, failure.LockedUntil), "A_LOCKED_CLIENT_IP", "")
+		}
+		return httpErrors.NewForbiddenError(fmt.Errorf("account %s is locked until %s", subject, failure.LockedUntil), "A_LOCKED_ACCOUNT", "")
+	}
+	// 여러 사용자가 같은 IP 를 공유할 수 있으므로 지연은 계정에만 적용한다.
+	if kind == model.LoginFailureKindAccount && now.Before(failure.LastFailedAt.Add(loginDelay(failure.FailedCount))) {
+		return httpErrors.NewTooManyRequestsError(fmt.Errorf("too many attempts on account %s", subject), "A_TOO_MANY_ATTEMPTS", "")
+	}
+	return nil
+}
+
+// recordLoginFailure counts the failure on the account and the client ip. The client ip is counted over all organizations.
+// The organization is notified of the lockout, and nobody is notified when it is empty.
+func (u *AuthUsecase) recordLoginFailure(ctx context.Context, organizationId string, accountId string, clientIp string) {
+	if accountId != "" {
+		u.increaseLoginFailure(ctx, organizationId, model.LoginFailureKindAccount, organizationId, accountId,
+			internal.AccountLockThreshold, internal.AccountLockDuration)
+	}
+	if clientIp != "" {
+		u.increaseLoginFailure(ctx, organizationId, model.LoginFailureKindClientIp, "", clientIp,
+			internal.ClientIpLockThreshold, internal.ClientIpLockDuration)
+	}
+}
+
+func (u *AuthUsecase) increaseLoginFailure(ctx context.Context, notifiedOrganizationId string, kind string, organizationId string, subject string, threshold int, lockDuration time.Duration) {
+	failure, err := u.authRepository.IncreaseLoginFailure(ctx, kind, organizationId, subject, internal.LoginFailureWindow)
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	if failure.FailedCount < threshold {
+		return
+	}
+
+	lockedUntil := time.Now().Add(lockDuration)
+	if err = u.authRepository.LockLoginFailure(ctx, kind, organizationId, subject, lockedUntil); err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	log.Warnf(ctx, "Locked %s [%s] of organization [%s] until %s after %d failures", kind, subject, organizationId, lockedUntil, failure.FailedCount)
+
+	var title, content string
+	if kind == model.LoginFailureKindClientIp {
+		title = fmt.Sprintf("IP [%s]의 접속이 차단되었습니다.", subject)
+		content = fmt.Sprintf("IP [%s]에서 인증에 %d회 실패하여 %s까지 접속이 차단되었습니다.", subject, failure.FailedCount, lockedUntil.Format(time.RFC3339))
+	} else {
+		title = fmt.Sprintf("계정 [%s]이 잠겼습니다.", subject)
+		content = fmt.Sprintf("계정 [%s]의 로그인에 %d회 실패하여 %s까지 잠겼습니다. 본인의 시도가 아니라면 계정을 확인하세요.", subject, failure.FailedCount, lockedUntil.Format(time.RFC3339))
+	}
+	u.notifyLockout(ctx, notifiedOrganizationId, subject, title, content)
+}
+
+// notifyLockout makes a system notification of the lockout and mails it to the admins of the organization.
+func (u *AuthUsecase) notifyLockout(ctx context.Context, organizationId string, subject string, title string, content string) {
+	if organizationId == "" {
+		return
+	}
+	organization, err := u.organizationRepository.Get(ctx, organizationId)
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+
+	// 시스템 알림은 클러스터에 속하므로 primary cluster 가 없는 조직은 메일로만 알린다.
+	if organization.PrimaryClusterId != "" {
+		now := time.Now()
+		dto := model.SystemNotification{
+			OrganizationId:        organizationId,
+			Name:                  "login-lockout",
+			NotificationType:      "SYSTEM_NOTIFICATION",
+			Severity:              "warning",
+			ClusterId:             domain.ClusterId(organization.PrimaryClusterId),
+			Node:                  subject,
+			MessageTitle:          title,
+			MessageContent:        content,
+			MessageActionProposal: "잠금 해제가 필요하면 사용자 관리에서 잠금을 해제하세요.",
+			LastFiredAt:           &now,
+		}
+		dto.ID, err = u.systemNotificationRepo.Create(ctx, dto)
+		if err != nil {
+			log.Error(ctx, err)
+		} else {
+			dto.Status = domain.SystemNotificationActionStatus_CREATED
+			dto.FireCount = 1
+			publishSystemNotification(ctx, domain.STREAM_EVENT_SYSTEM_NOTIFICATION_CREATED, dto)
+		}
+	}
+
+	adminRole, err := u.roleRepository.GetTksRoleByRoleName(ctx, organizationId, "admin")
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	admins, err := u.userRepository.ListUsersByRole(ctx, organizationId, adminRole.ID, nil)
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	to := make([]string, 0, len(*admins))
+	for _, admin := range *admins {
+		if admin.Email != "" {
+			to = append(to, admin.Email)
+		}
+	}
+	if len(to) == 0 {
+		return
+	}
+
+	message, err := mail.MakeSystemNotificationMessage(ctx, organizationId, title, content, "", to)
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	if err = enqueueMail(ctx, u.mailOutboxRepository, organizationId, domain.MAIL_CATEGORY_SYSTEM_NOTIFICATION, message); err != nil {
+		log.Error(ctx, err)
+	}
+}
+
+// loginDelay doubles from a second for each failure over internal.LoginDelayThreshold, up to internal.LoginMaxDelay.
+func loginDelay(failedCount int) time.Duration {
+	if failedCount < internal.LoginDelayThreshold {
+		return 0
+	}
+	delay := internal.LoginMaxDelay
+	if n := failedCount - internal.LoginDelayThreshold; n < 16 {
+		delay = time.Second << n
+	}
+	if delay > internal.LoginMaxDelay {
+		delay = internal.LoginMaxDelay
+	}
+	return delay
+}
+
 func extractFormAction(htmlContent string) (string, error) {
 	doc, err := html.Parse(strings.NewReader(htmlContent))
 	if err != nil {
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"gorm.io/gorm"
+)
+
+func TestLoginFailureError(t *testing.T) {
+	now := time.Now()
+	lockedUntil := now.Add(internal.AccountLockDuration)
+	unlockedAt := now.Add(-time.Second)
+
+	tests := []struct {
+		name     string
+		kind     string
+		failure  model.LoginFailure
+		wantCode string
+	}{
+		{"no failure", model.LoginFailureKindAccount, model.LoginFailure{}, ""},
+		{"below delay threshold", model.LoginFailureKindAccount, model.LoginFailure{FailedCount: internal.LoginDelayThreshold - 1, LastFailedAt: now}, ""},
+		{"account in delay", model.LoginFailureKindAccount, model.LoginFailure{FailedCount: internal.LoginDelayThreshold, LastFailedAt: now}, "A_TOO_MANY_ATTEMPTS"},
+		{"account after delay", model.LoginFailureKindAccount, model.LoginFailure{FailedCount: internal.LoginDelayThreshold, LastFailedAt: now.Add(-internal.LoginMaxDelay)}, ""},
+		{"client ip is not delayed", model.LoginFailureKindClientIp, model.LoginFailure{FailedCount: internal.LoginDelayThreshold, LastFailedAt: now}, ""},
+		{"locked account", model.LoginFailureKindAccount, model.LoginFailure{FailedCount: internal.AccountLockThreshold, LastFailedAt: now.Add(-internal.LoginMaxDelay), LockedUntil: &lockedUntil}, "A_LOCKED_ACCOUNT"},
+		{"locked client ip", model.LoginFailureKindClientIp, model.LoginFailure{FailedCount: internal.ClientIpLockThreshold, LastFailedAt: now, LockedUntil: &lockedUntil}, "A_LOCKED_CLIENT_IP"},
+		{"lock expired", model.LoginFailureKindAccount, model.LoginFailure{FailedCount: internal.AccountLockThreshold, LastFailedAt: now.Add(-internal.AccountLockDuration), LockedUntil: &unlockedAt}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loginFailureError(tt.kind, "subject", tt.failure, now)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			restErr, ok := err.(httpErrors.IRestError)
+			if !ok || restErr.Code() != tt.wantCode {
+				t.Errorf("error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+type fakeLoginFailureRepository struct {
+	repository.IAuthRepository
+	increased []model.LoginFailure
+}
+
+func (r *fakeLoginFailureRepository) GetLoginFailure(ctx context.Context, kind string, organizationId string, subject string) (model.LoginFailure, error) {
+	return model.LoginFailure{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeLoginFailureRepository) IncreaseLoginFailure(ctx context.Context, kind string, organizationId string, subject string, window time.Duration) (model.LoginFailure, error) {
+	failure := model.LoginFailure{Kind: kind, OrganizationId: organizationId, Subject: subject, FailedCount: 1}
+	r.increased = append(r.increased, failure)
+	return failure, nil
+}
+
+type fakeLoginUserRepository struct {
+	repository.IUserRepository
+}
+
+func (r *fakeLoginUserRepository) Get(ctx context.Context, accountId string, organizationId string) (model.User, error) {
+	return model.User{}, httpErrors.NewNotFoundError(gorm.ErrRecordNotFound, "", "")
+}
+
+type fakeLoginIdentityProviderRepository struct {
+	repository.IIdentityProviderRepository
+}
+
+func (r *fakeLoginIdentityProviderRepository) FetchEnabled(ctx context.Context, organizationId string) ([]model.IdentityProvider, error) {
+	return nil, nil
+}
+
+func TestLoginUnknownAccountCountsClientIp(t *testing.T) {
+	authRepo := &fakeLoginFailureRepository{}
+	u := &AuthUsecase{
+		authRepository:       authRepo,
+		userRepository:       &fakeLoginUserRepository{},
+		identityProviderRepo: &fakeLoginIdentityProviderRepository{},
+	}
+
+	_, err := u.Login(context.Background(), "nobody", "password", "no-such-organization", "10.0.0.1")
+	if restErr, ok := err.(httpErrors.IRestError); !ok || restErr.Code() != "A_INVALID_ID" {
+		t.Fatalf("error = %v, want code A_INVALID_ID", err)
+	}
+
+	// 없는 계정과 조직으로는 기록하지 않고 IP 만 센다.
+	if len(authRepo.increased) != 1 {
+		t.Fatalf("expected 1 failure, got %+v", authRepo.increased)
+	}
+	failure := authRepo.increased[0]
+	if failure.Kind != model.LoginFailureKindClientIp || failure.OrganizationId != "" || failure.Subject != "10.0.0.1" {
+		t.Errorf("unexpected failure %+v", failure)
+	}
+}
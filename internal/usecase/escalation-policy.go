@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/mail"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/notifier"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	escalationPollInterval = 30 * time.Second
+	escalationLease        = 5 * time.Minute
+	escalationBatchSize    = 20
+)
+
+type IEscalationPolicyUsecase interface {
+	Get(ctx context.Context, organizationId string, escalationPolicyId uuid.UUID) (model.EscalationPolicy, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.EscalationPolicy, error)
+	Create(ctx context.Context, dto model.EscalationPolicy) (escalationPolicyId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.EscalationPolicy) error
+	Delete(ctx context.Context, organizationId string, escalationPolicyId uuid.UUID) error
+	Run(ctx context.Context)
+}
+
+type EscalationPolicyUsecase struct {
+	repo                       repository.IEscalationPolicyRepository
+	onCallScheduleRepo         repository.IOnCallScheduleRepository
+	userRepo                   repository.IUserRepository
+	notificationChannelRepo    repository.INotificationChannelRepository
+	notificationDeliveryRepo   repository.INotificationDeliveryRepository
+	systemNotificationRepo     repository.ISystemNotificationRepository
+	systemNotificationRuleRepo repository.ISystemNotificationRuleRepository
+	mailOutboxRepo             repository.IMailOutboxRepository
+	notificationPreferenceRepo repository.INotificationPreferenceRepository
+}
+
+func NewEscalationPolicyUsecase(r repository.Repository) IEscalationPolicyUsecase {
+	return &EscalationPolicyUsecase{
+		repo:                       r.EscalationPolicy,
+		onCallScheduleRepo:         r.OnCallSchedule,
+		userRepo:                   r.User,
+		notificationChannelRepo:    r.NotificationChannel,
+		notificationDeliveryRepo:   r.NotificationDelivery,
+		systemNotificationRepo:     r.SystemNotification,
+		systemNotificationRuleRepo: r.SystemNotificationRule,
+		mailOutboxRepo:             r.MailOutbox,
+		notificationPreferenceRepo: r.NotificationPreference,
+	}
+}
+
+func (u *EscalationPolicyUsecase) Get(ctx context.Context, organizationId string, escalationPolicyId uuid.UUID) (out model.EscalationPolicy, err error) {
+	out, err = u.repo.Get(ctx, escalationPolicyId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "EP_NOT_EXISTED_ESCALATION_POLICY", "")
+		}
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return model.EscalationPolicy{}, httpErrors.NewNotFoundError(fmt.Errorf("not found escalation policy"), "EP_NOT_EXISTED_ESCALATION_POLICY", "")
+	}
+	return
+}
+
+func (u *EscalationPolicyUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.EscalationPolicy, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *EscalationPolicyUsecase) Create(ctx context.Context, dto model.EscalationPolicy) (escalationPolicyId uuid.UUID, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+	dto.UpdatorId = &userId
+
+	if err = u.makeTiers(ctx, &dto); err != nil {
+		return uuid.Nil, err
+	}
+
+	return u.repo.Create(ctx, dto)
+}
+
+func (u *EscalationPolicyUsecase) Update(ctx context.Context, dto model.EscalationPolicy) error {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.UpdatorId = &userId
+
+	if _, err := u.Get(ctx, dto.OrganizationId, dto.ID); err != nil {
+		return err
+	}
+
+	if err := u.makeTiers(ctx, &dto); err != nil {
+		return err
+	}
+
+	return u.repo.Update(ctx, dto)
+}
+
+func (u *EscalationPolicyUsecase) Delete(ctx context.Context, organizationId string, escalationPolicyId uuid.UUID) error {
+	if _, err := u.Get(ctx, organizationId, escalationPolicyId); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, escalationPolicyId)
+}
+
+// makeTiers resolves the targets of the tiers, which must belong to the organization.
+func (u *EscalationPolicyUsecase) makeTiers(ctx context.Context, dto *model.EscalationPolicy) (err error) {
+	for i := range dto.Tiers {
+		tier := &dto.Tiers[i]
+
+		tier.TargetUsers, err = getOrganizationUsers(ctx, u.userRepo, dto.OrganizationId, tier.TargetUserIds)
+		if err != nil {
+			return err
+		}
+
+		tier.NotificationChannels = make([]model.NotificationChannel, 0)
+		for _, strId := range tier.NotificationChannelIds {
+			notificationChannelId, err := uuid.Parse(strId)
+			if err != nil {
+				return httpErrors.NewBadRequestError(err, "C_INVALID_NOTIFICATION_CHANNEL_ID", "")
+			}
+			channel, err := u.notificationChannelRepo.Get(ctx, notificationChannelId)
+			if err != nil || channel.OrganizationId != dto.OrganizationId {
+				return httpErrors.NewBadRequestError(fmt.Errorf("invalid notificationChannelId %s", strId), "C_INVALID_NOTIFICATION_CHANNEL_ID", "")
+			}
+			tier.NotificationChannels = append(tier.NotificationChannels, channel)
+		}
+
+		if tier.OnCallScheduleId != nil {
+			schedule, err := u.onCallScheduleRepo.Get(ctx, *tier.OnCallScheduleId)
+			if err != nil || schedule.OrganizationId != dto.OrganizationId {
+				return httpErrors.NewBadRequestError(fmt.Errorf("invalid onCallScheduleId %s", tier.OnCallScheduleId), "C_INVALID_ON_CALL_SCHEDULE_ID", "")
+			}
+		}
+
+		if len(tier.TargetUsers) == 0 && len(tier.NotificationChannels) == 0 && tier.OnCallScheduleId == nil {
+			return httpErrors.NewBadRequestError(fmt.Errorf("tier %d has no target", i), "EP_EMPTY_TIER_TARGET", "")
+		}
+	}
+	return nil
+}
+
+// Run escalates the systemNotifications not taken in time until ctx is done.
+func (u *EscalationPolicyUsecase) Run(ctx context.Context) {
+	runWorker(ctx, escalationPollInterval, u.escalateDue)
+}
+
+func (u *EscalationPolicyUsecase) escalateDue(ctx context.Context) {
+	systemNotifications, err := u.systemNotificationRepo.ClaimEscalationDue(ctx, escalationLease, escalationBatchSize)
+	if err != nil {
+		log.Errorf(ctx, "failed to claim systemNotifications to escalate. %v", err)
+		return
+	}
+
+	for _, systemNotification := range systemNotifications {
+		level, next := u.escalate(ctx, systemNotification)
+		if err := u.systemNotificationRepo.UpdateEscalation(ctx, systemNotification.ID, level, next); err != nil {
+			log.Errorf(ctx, "failed to update escalation of systemNotification [%s]. %v", systemNotification.ID, err)
+		}
+	}
+}
+
+// escalate notifies the current tier and returns the next level and its time.
+// The policy is read from the rule at every step, so that changes of the rule take effect immediately.
+func (u *EscalationPolicyUsecase) escalate(ctx context.Context, systemNotification model.SystemNotification) (level int, next *time.Time) {
+	level = systemNotification.EscalationLevel
+	if systemNotification.SystemNotificationRuleId == nil {
+		return level, nil
+	}
+	rule, err := u.systemNotificationRuleRepo.Get(ctx, *systemNotification.SystemNotificationRuleId)
+	if err != nil || rule.EscalationPolicyId == nil {
+		return level, nil
+	}
+	policy, err := u.repo.Get(ctx, *rule.EscalationPolicyId)
+	if err != nil || len(policy.Tiers) == 0 {
+		log.Errorf(ctx, "failed to get escalation policy of rule [%s]. %v", rule.ID, err)
+		return level, nil
+	}
+
+	total := len(policy.Tiers) * (policy.RepeatCount + 1)
+	if level >= total {
+		return level, nil
+	}
+
+	tier := policy.Tiers[level%len(policy.Tiers)]
+	u.notifyTier(ctx, rule, systemNotification, tier, level+1)
+
+	level++
+	if level >= total {
+		return level, nil
+	}
+	nextAt := time.Now().Add(time.Duration(policy.Tiers[level%len(policy.Tiers)].DelayMinutes) * time.Minute)
+	return level, &nextAt
+}
+
+func (u *EscalationPolicyUsecase) notifyTier(ctx context.Context, rule model.SystemNotificationRule, systemNotification model.SystemNotification, tier model.EscalationTier, level int) {
+	title := fmt.Sprintf("[에스컬레이션 %d단계] %s", level, systemNotification.MessageTitle)
+	deliveries := make([]model.NotificationDelivery, 0)
+
+	users := tier.TargetUsers
+	if tier.OnCallSchedule != nil {
+		if user, ok := tier.OnCallSchedule.OnCallAt(time.Now()); ok {
+			users = append(users, user)
+		}
+	}
+	users = filterNotificationRecipients(ctx, u.notificationPreferenceRepo, users, rule.ID, systemNotification.Severity)
+	if len(users) > 0 {
+		delivery := model.NotificationDelivery{
+			ChannelType: domain.NOTIFICATION_CHANNEL_TYPE_EMAIL,
+			Target:      joinUserEmails(users),
+			Status:      domain.NotificationDeliveryStatus_SUCCESS,
+		}
+		err := enqueueSystemNotificationMails(ctx, u.mailOutboxRepo, systemNotification.OrganizationId, domain.MAIL_CATEGORY_SYSTEM_NOTIFICATION, rule.ID, users,
+			func(user model.User, unsubscribeUrl string) (*mail.MessageInfo, error) {
+				return mail.MakeSystemNotificationMessage(ctx, systemNotification.OrganizationId, title, systemNotification.MessageContent, unsubscribeUrl, []string{user.Email})
+			})
+		if err != nil {
+			delivery.Status = domain.NotificationDeliveryStatus_FAILED
+			delivery.ErrorMessage = err.Error()
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	message := &notifier.MessageInfo{
+		OrganizationId: systemNotification.OrganizationId,
+		ClusterId:      systemNotification.ClusterId.String(),
+		Name:           systemNotification.Name,
+		Severity:       systemNotification.Severity,
+		Node:           systemNotification.Node,
+		Title:          title,
+		Content:        systemNotification.MessageContent,
+		ActionProposal: systemNotification.MessageActionProposal,
+		GrafanaUrl:     systemNotification.GrafanaUrl,
+		Status:         "firing",
+		FiredAt:        systemNotification.CreatedAt,
+	}
+	for _, channel := range tier.NotificationChannels {
+		if !channel.Enabled {
+			continue
+		}
+		deliveries = append(deliveries, queueToChannel(channel, message))
+	}
+
+	for _, delivery := range deliveries {
+		delivery.OrganizationId = systemNotification.OrganizationId
+		delivery.SystemNotificationId = systemNotification.ID
+		delivery.SystemNotificationRuleId = &rule.ID
+		delivery.EscalationLevel = level
+		if delivery.Status == domain.NotificationDeliveryStatus_FAILED {
+			log.Errorf(ctx, "Failed to escalate systemNotification [%s] to %s [%s]. err : %s", systemNotification.ID, delivery.ChannelType, delivery.Target, delivery.ErrorMessage)
+		}
+		if _, err := u.notificationDeliveryRepo.Create(ctx, delivery); err != nil {
+			log.Error(ctx, "Failed to create notificationDelivery ", err)
+		}
+	}
+}
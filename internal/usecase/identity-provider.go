@@ -0,0 +1,390 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/keycloak"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// 유형별 필수 설정과 선택 설정
+var identityProviderConfigKeys = map[model.IdentityProviderType]struct {
+	required []string
+	optional []string
+}{
+	model.IdentityProviderTypeOidc: {
+		required: []string{"authorizationUrl", "tokenUrl", "clientId"},
+		optional: []string{"userInfoUrl", "jwksUrl", "issuer", "logoutUrl", "defaultScope"},
+	},
+	model.IdentityProviderTypeSaml: {
+		required: []string{"singleSignOnServiceUrl", "idpEntityId"},
+		optional: []string{"singleLogoutServiceUrl", "nameIDPolicyFormat", "signingCertificate"},
+	},
+	model.IdentityProviderTypeLdap: {
+		required: []string{"connectionUrl", "usersDn", "bindDn"},
+		optional: []string{"vendor", "usernameLDAPAttribute", "rdnLDAPAttribute", "uuidLDAPAttribute", "userObjectClasses", "customUserSearchFilter"},
+	},
+}
+
+type IIdentityProviderUsecase interface {
+	Get(ctx context.Context, organizationId string, identityProviderId uuid.UUID) (model.IdentityProvider, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.IdentityProvider, error)
+	FetchForLogin(ctx context.Context, organizationId string) ([]model.IdentityProvider, error)
+	Create(ctx context.Context, dto model.IdentityProvider, secret string) (identityProviderId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.IdentityProvider, secret string) error
+	Delete(ctx context.Context, organizationId string, identityProviderId uuid.UUID) (model.IdentityProvider, error)
+}
+
+type IdentityProviderUsecase struct {
+	repo     repository.IIdentityProviderRepository
+	roleRepo repository.IRoleRepository
+	kc       keycloak.IKeycloak
+}
+
+func NewIdentityProviderUsecase(r repository.Repository, kc keycloak.IKeycloak) IIdentityProviderUsecase {
+	return &IdentityProviderUsecase{
+		repo:     r.IdentityProvider,
+		roleRepo: r.Role,
+		kc:       kc,
+	}
+}
+
+func (u *IdentityProviderUsecase) Get(ctx context.Context, organizationId string, identityProviderId uuid.UUID) (out model.IdentityProvider, err error) {
+	out, err = u.repo.Get(ctx, identityProviderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "IDP_NOT_EXISTED_IDENTITY_PROVIDER", "")
+		}
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return out, httpErrors.NewNotFoundError(fmt.Errorf("not found identity provider"), "IDP_NOT_EXISTED_IDENTITY_PROVIDER", "")
+	}
+	return out, nil
+}
+
+func (u *IdentityProviderUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.IdentityProvider, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+// FetchForLogin returns the enabled identity providers shown on the login page. LDAP uses the password login, so it is excluded.
+func (u *IdentityProviderUsecase) FetchForLogin(ctx context.Context, organizationId string) (out []model.IdentityProvider, err error) {
+	providers, err := u.repo.FetchEnabled(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range providers {
+		if provider.Type != model.IdentityProviderTypeLdap {
+			out = append(out, provider)
+		}
+	}
+	return out, nil
+}
+
+func (u *IdentityProviderUsecase) Create(ctx context.Context, dto model.IdentityProvider, secret string) (identityProviderId uuid.UUID, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+
+	if _, err = u.repo.GetByAlias(ctx, dto.OrganizationId, dto.Alias); err == nil {
+		return uuid.Nil, httpErrors.NewConflictError(fmt.Errorf("duplicate identity provider alias %s", dto.Alias), "IDP_CREATE_ALREADY_EXISTED_ALIAS", "")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, err
+	}
+	if dto.Type != model.IdentityProviderTypeSaml && secret == "" {
+		return uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("secret is required for %s", dto.Type), "IDP_INVALID_CONFIG", "")
+	}
+	if err = u.validate(ctx, &dto); err != nil {
+		return uuid.Nil, err
+	}
+
+	dto.KeycloakId, err = u.kc.CreateIdentityProvider(ctx, dto.OrganizationId, dto, secret)
+	if err != nil {
+		log.Error(ctx, err)
+		return uuid.Nil, httpErrors.NewInternalServerError(err, "IDP_FAILED_TO_APPLY_REALM", "")
+	}
+
+	identityProviderId, err = u.repo.Create(ctx, dto)
+	if err != nil {
+		if err := u.kc.DeleteIdentityProvider(ctx, dto.OrganizationId, dto); err != nil {
+			log.Error(ctx, err)
+		}
+		return uuid.Nil, err
+	}
+	return identityProviderId, nil
+}
+
+func (u *IdentityProviderUsecase) Update(ctx context.Context, dto model.IdentityProvider, secret string) error {
+	current, err := u.Get(ctx, dto.OrganizationId, dto.ID)
+	if err != nil {
+		return err
+	}
+	dto.Alias = current.Alias
+	dto.Type = current.Type
+	dto.KeycloakId = current.KeycloakId
+	if err = u.validate(ctx, &dto); err != nil {
+		return err
+	}
+
+	if err = u.kc.UpdateIdentityProvider(ctx, dto.OrganizationId, dto, secret); err != nil {
+		log.Error(ctx, err)
+		return httpErrors.NewInternalServerError(err, "IDP_FAILED_TO_APPLY_REALM", "")
+	}
+	return u.repo.Update(ctx, dto)
+}
+
+// Delete removes the identity provider. The users created from it remain, but can not login until they get the password.
+func (u *IdentityProviderUsecase) Delete(ctx context.Context, organizationId string, identityProviderId uuid.UUID) (out model.IdentityProvider, err error) {
+	out, err = u.Get(ctx, organizationId, identityProviderId)
+	if err != nil {
+		return out, err
+	}
+	if err = u.kc.DeleteIdentityProvider(ctx, organizationId, out); err != nil {
+		log.Error(ctx, err)
+		return out, httpErrors.NewInternalServerError(err, "IDP_FAILED_TO_APPLY_REALM", "")
+	}
+	if err = u.repo.Delete(ctx, out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// validate checks the config of the type, and loads the roles of the mappings.
+func (u *IdentityProviderUsecase) validate(ctx context.Context, dto *model.IdentityProvider) error {
+	keys, ok := identityProviderConfigKeys[dto.Type]
+	if !ok {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invalid identity provider type %s", dto.Type), "IDP_INVALID_CONFIG", "")
+	}
+	for _, key := range keys.required {
+		if strings.TrimSpace(dto.Config[key]) == "" {
+			return httpErrors.NewBadRequestError(fmt.Errorf("config %s is required", key), "IDP_INVALID_CONFIG", "")
+		}
+	}
+	allowed := append([]string{model.IdentityProviderConfigGroupsAttribute}, append(keys.required, keys.optional...)...)
+	for key := range dto.Config {
+		if !helper.Contains(allowed, key) {
+			return httpErrors.NewBadRequestError(fmt.Errorf("unknown config %s", key), "IDP_INVALID_CONFIG", "")
+		}
+	}
+	if vendor, ok := dto.Config["vendor"]; ok && vendor != "ad" && vendor != "other" {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invalid vendor %s", vendor), "IDP_INVALID_CONFIG", "")
+	}
+
+	if dto.DefaultRoleId != nil {
+		role, err := u.roleRepo.GetTksRole(ctx, dto.OrganizationId, *dto.DefaultRoleId)
+		if err != nil {
+			return httpErrors.NewBadRequestError(err, "IDP_INVALID_ROLE", "")
+		}
+		dto.DefaultRole = role
+	}
+	for i, mapping := range dto.RoleMappings {
+		role, err := u.roleRepo.GetTksRole(ctx, dto.OrganizationId, mapping.RoleId)
+		if err != nil {
+			return httpErrors.NewBadRequestError(err, "IDP_INVALID_ROLE", "")
+		}
+		dto.RoleMappings[i].Role = *role
+	}
+	return nil
+}
+
+// provisionFederatedUser creates the user who logs in from the identity provider for the first time.
+// The roles are resolved from the role mappings of the identity provider.
+func provisionFederatedUser(ctx context.Context, kc keycloak.IKeycloak, identityProviderRepo repository.IIdentityProviderRepository,
+	userRepo repository.IUserRepository, organizationId string, accountId string) (model.User, error) {
+	kcUser, err := kc.GetUser(ctx, organizationId, accountId)
+	if err != nil {
+		return model.User{}, httpErrors.NewBadRequestError(err, "A_INVALID_ID", "")
+	}
+
+	provider, err := findIdentityProviderOfUser(ctx, kc, identityProviderRepo, organizationId, kcUser)
+	if err != nil {
+		return model.User{}, err
+	}
+	roles, err := resolveFederatedRoles(provider, kcUser)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	userId, err := uuid.Parse(gocloak.PString(kcUser.ID))
+	if err != nil {
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	name := strings.TrimSpace(gocloak.PString(kcUser.FirstName) + " " + gocloak.PString(kcUser.LastName))
+	if name == "" {
+		name = accountId
+	}
+	// 그룹 가입에 실패하면 사용자를 생성하지 않는다. 동시에 처음 로그인한 요청은 사용자 생성에서 실패한다.
+	user, err := userRepo.CreateInTx(ctx, &model.User{
+		ID:                 userId,
+		AccountId:          accountId,
+		Name:               name,
+		Email:              gocloak.PString(kcUser.Email),
+		OrganizationId:     organizationId,
+		Organization:       model.Organization{ID: organizationId},
+		Roles:              roles,
+		IdentityProviderId: &provider.ID,
+	}, func() error {
+		return updateFederatedGroups(ctx, kc, organizationId, userId.String(), roles, nil)
+	})
+	if err != nil {
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	log.Infof(ctx, "user [%s] is provisioned from identity provider [%s]", accountId, provider.Alias)
+	return *user, nil
+}
+
+// syncFederatedUser resolves the roles of the federated user again on every login, so the changes of the groups
+// and the attributes in the identity provider are applied. It returns true if the roles are changed.
+func syncFederatedUser(ctx context.Context, kc keycloak.IKeycloak, identityProviderRepo repository.IIdentityProviderRepository,
+	userRepo repository.IUserRepository, user model.User) (model.User, bool, error) {
+	if user.IdentityProviderId == nil {
+		return user, false, nil
+	}
+	organizationId := user.Organization.ID
+
+	kcUser, err := kc.GetUser(ctx, organizationId, user.AccountId)
+	if err != nil {
+		return model.User{}, false, httpErrors.NewInternalServerError(err, "", "")
+	}
+	provider, err := identityProviderRepo.Get(ctx, *user.IdentityProviderId)
+	if err != nil {
+		return model.User{}, false, httpErrors.NewInternalServerError(err, "", "")
+	}
+	roles, err := resolveFederatedRoles(provider, kcUser)
+	if err != nil {
+		return model.User{}, false, err
+	}
+
+	var assigning, unassigning []model.Role
+	for _, role := range roles {
+		if !containsRole(user.Roles, role.ID) {
+			assigning = append(assigning, role)
+		}
+	}
+	for _, role := range user.Roles {
+		if !containsRole(roles, role.ID) {
+			unassigning = append(unassigning, role)
+		}
+	}
+	if len(assigning) == 0 && len(unassigning) == 0 {
+		return user, false, nil
+	}
+
+	err = userRepo.UpdateRolesInTx(ctx, user.ID, roles, func() error {
+		return updateFederatedGroups(ctx, kc, organizationId, user.ID.String(), assigning, unassigning)
+	})
+	if err != nil {
+		return model.User{}, false, httpErrors.NewInternalServerError(err, "", "")
+	}
+	log.Infof(ctx, "roles of user [%s] are updated from identity provider [%s]", user.AccountId, provider.Alias)
+	user.Roles = roles
+	return user, true, nil
+}
+
+func resolveFederatedRoles(provider model.IdentityProvider, kcUser *gocloak.User) ([]model.Role, error) {
+	if !provider.Enabled {
+		return nil, httpErrors.NewForbiddenError(fmt.Errorf("identity provider %s is disabled", provider.Alias), "IDP_DISABLED_IDENTITY_PROVIDER", "")
+	}
+
+	var attributes map[string][]string
+	if kcUser.Attributes != nil {
+		attributes = *kcUser.Attributes
+	}
+	roles := provider.ResolveRoles(attributes)
+	if len(roles) == 0 {
+		return nil, httpErrors.NewForbiddenError(fmt.Errorf("no role is mapped to user %s", gocloak.PString(kcUser.Username)), "IDP_NO_MAPPED_ROLE", "")
+	}
+	return roles, nil
+}
+
+func containsRole(roles []model.Role, roleId string) bool {
+	for _, role := range roles {
+		if role.ID == roleId {
+			return true
+		}
+	}
+	return false
+}
+
+// updateFederatedGroups joins the groups of the assigning roles and leaves the groups of the unassigning roles in keycloak.
+// The groups already changed are restored if any of them fails.
+func updateFederatedGroups(ctx context.Context, kc keycloak.IKeycloak, organizationId string, userId string, assigning []model.Role, unassigning []model.Role) (err error) {
+	var joined, left []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, groupName := range joined {
+			if err := kc.LeaveGroup(ctx, organizationId, userId, groupName); err != nil {
+				log.Errorf(ctx, "failed to restore group %s of user %s: %v", groupName, userId, err)
+			}
+		}
+		for _, groupName := range left {
+			if err := kc.JoinGroup(ctx, organizationId, userId, groupName); err != nil {
+				log.Errorf(ctx, "failed to restore group %s of user %s: %v", groupName, userId, err)
+			}
+		}
+	}()
+
+	for _, role := range assigning {
+		groupName := fmt.Sprintf("%s@%s", role.Name, organizationId)
+		if err = kc.JoinGroup(ctx, organizationId, userId, groupName); err != nil {
+			log.Errorf(ctx, "join group in keycloak failed: %v", err)
+			return err
+		}
+		joined = append(joined, groupName)
+	}
+	for _, role := range unassigning {
+		groupName := fmt.Sprintf("%s@%s", role.Name, organizationId)
+		if err = kc.LeaveGroup(ctx, organizationId, userId, groupName); err != nil {
+			log.Errorf(ctx, "leave group in keycloak failed: %v", err)
+			return err
+		}
+		left = append(left, groupName)
+	}
+	return nil
+}
+
+func findIdentityProviderOfUser(ctx context.Context, kc keycloak.IKeycloak, identityProviderRepo repository.IIdentityProviderRepository,
+	organizationId string, kcUser *gocloak.User) (model.IdentityProvider, error) {
+	if kcUser.FederationLink != nil {
+		provider, err := identityProviderRepo.GetByKeycloakId(ctx, organizationId, *kcUser.FederationLink)
+		if err == nil {
+			return provider, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.IdentityProvider{}, err
+		}
+	}
+
+	aliases, err := kc.GetFederatedIdentityAliases(ctx, organizationId, gocloak.PString(kcUser.ID))
+	if err != nil {
+		return model.IdentityProvider{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	for _, alias := range aliases {
+		provider, err := identityProviderRepo.GetByAlias(ctx, organizationId, alias)
+		if err == nil {
+			return provider, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.IdentityProvider{}, err
+		}
+	}
+	return model.IdentityProvider{}, httpErrors.NewBadRequestError(fmt.Errorf("user is not federated"), "A_INVALID_ID", "")
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/mail"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/user"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	masterOrganizationId    = "master"
+	impersonationExpiration = 30 * time.Minute
+)
+
+type IImpersonationUsecase interface {
+	Start(ctx context.Context, organizationId string, accountId string, reason string) (impersonation model.Impersonation, token string, err error)
+	Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.Impersonation, error)
+	Stop(ctx context.Context, impersonationId uuid.UUID) (model.Impersonation, error)
+}
+
+type ImpersonationUsecase struct {
+	repo                 repository.IImpersonationRepository
+	userRepository       repository.IUserRepository
+	roleRepository       repository.IRoleRepository
+	mailOutboxRepository repository.IMailOutboxRepository
+}
+
+func NewImpersonationUsecase(r repository.Repository) IImpersonationUsecase {
+	return &ImpersonationUsecase{
+		repo:                 r.Impersonation,
+		userRepository:       r.User,
+		roleRepository:       r.Role,
+		mailOutboxRepository: r.MailOutbox,
+	}
+}
+
+// Start issues the short-lived token acting as the user. Only the admin of the master organization can start it
+// with the own keycloak token, and the admins of the organization are notified.
+func (u *ImpersonationUsecase) Start(ctx context.Context, organizationId string, accountId string, reason string) (model.Impersonation, string, error) {
+	requestUser, err := u.checkImpersonator(ctx)
+	if err != nil {
+		return model.Impersonation{}, "", err
+	}
+	if organizationId == masterOrganizationId {
+		return model.Impersonation{}, "", httpErrors.NewForbiddenError(fmt.Errorf("user of master organization can not be impersonated"), "IMP_MASTER_USER", "")
+	}
+	// 대리 세션을 만든 뒤 토큰을 발급하지 못하는 일이 없도록 먼저 확인한다.
+	if viper.GetString("token-secret") == "" {
+		return model.Impersonation{}, "", httpErrors.NewBadRequestError(fmt.Errorf("token-secret is not configured"), "IMP_NOT_CONFIGURED", "")
+	}
+
+	target, err := u.userRepository.Get(ctx, accountId, organizationId)
+	if err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return model.Impersonation{}, "", httpErrors.NewNotFoundError(fmt.Errorf("user not found"), "U_NO_USER", "")
+		}
+		return model.Impersonation{}, "", httpErrors.NewInternalServerError(err, "", "")
+	}
+	if target.Disabled {
+		return model.Impersonation{}, "", httpErrors.NewBadRequestError(fmt.Errorf("user %s is disabled", accountId), "IMP_DISABLED_USER", "")
+	}
+
+	impersonationId, err := u.repo.Create(ctx, model.Impersonation{
+		OrganizationId: organizationId,
+		UserId:         target.ID,
+		ImpersonatorId: requestUser.GetUserId(),
+		Reason:         reason,
+		ExpiredAt:      time.Now().Add(impersonationExpiration),
+	})
+	if err != nil {
+		return model.Impersonation{}, "", httpErrors.NewInternalServerError(err, "", "")
+	}
+	impersonation, err := u.repo.Get(ctx, impersonationId)
+	if err != nil {
+		return model.Impersonation{}, "", httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	token, err := helper.CreateImpersonationToken(impersonationId.String(), target.ID.String(), target.AccountId, organizationId,
+		requestUser.GetUserId().String(), requestUser.GetAccountId(), requestUser.GetOrganizationId(), impersonation.ExpiredAt)
+	if err != nil {
+		return model.Impersonation{}, "", httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	u.notify(ctx, impersonation, "사용자 대리 시작 알림",
+		fmt.Sprintf("마스터 조직의 관리자 [%s]가 사용자 [%s]로 대리 접속을 시작하였습니다. 사유 : %s. 대리 접속은 %s 에 만료됩니다.",
+			requestUser.GetAccountId(), target.AccountId, reason, impersonation.ExpiredAt.Format(time.RFC3339)))
+	return impersonation, token, nil
+}
+
+func (u *ImpersonationUsecase) Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.Impersonation, error) {
+	return u.repo.Fetch(ctx, pg)
+}
+
+// Stop rejects the token of the impersonation from now on.
+func (u *ImpersonationUsecase) Stop(ctx context.Context, impersonationId uuid.UUID) (model.Impersonation, error) {
+	requestUser, err := u.checkImpersonator(ctx)
+	if err != nil {
+		return model.Impersonation{}, err
+	}
+
+	impersonation, err := u.repo.Get(ctx, impersonationId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Impersonation{}, httpErrors.NewNotFoundError(err, "IMP_NOT_EXISTED_IMPERSONATION", "")
+		}
+		return model.Impersonation{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	now := time.Now()
+	if impersonation.CurrentStatus(now) != model.ImpersonationStatusActive {
+		return model.Impersonation{}, httpErrors.NewBadRequestError(fmt.Errorf("impersonation is not active"), "IMP_NOT_ACTIVE", "")
+	}
+
+	if err = u.repo.Stop(ctx, impersonationId, requestUser.GetUserId(), now); err != nil {
+		return model.Impersonation{}, httpErrors.NewBadRequestError(err, "IMP_NOT_ACTIVE", "")
+	}
+	impersonation.StoppedAt = &now
+
+	accountId := ""
+	if impersonation.User != nil {
+		accountId = impersonation.User.AccountId
+	}
+	u.notify(ctx, impersonation, "사용자 대리 종료 알림",
+		fmt.Sprintf("마스터 조직의 관리자 [%s]가 사용자 [%s]의 대리 접속을 종료하였습니다.", requestUser.GetAccountId(), accountId))
+	return impersonation, nil
+}
+
+// checkImpersonator returns the request user if the user is the admin of the master organization signed in by oneself.
+func (u *ImpersonationUsecase) checkImpersonator(ctx context.Context) (user.Info, error) {
+	requestUser, ok := request.UserFrom(ctx)
+	if !ok {
+		return nil, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid token"), "A_INVALID_TOKEN", "")
+	}
+	// 대리 접속 토큰이나 API 토큰으로 다시 대리 접속을 시작할 수 없다.
+	if _, byApiToken := request.ApiTokenFrom(ctx); byApiToken || requestUser.GetImpersonator() != nil {
+		return nil, httpErrors.NewForbiddenError(fmt.Errorf("impersonation requires the token of the admin"), "IMP_NOT_ALLOWED", "")
+	}
+	if requestUser.GetOrganizationId() != masterOrganizationId ||
+		requestUser.GetRoleOrganizationMapping()[masterOrganizationId] != user.AdminRole {
+		return nil, httpErrors.NewForbiddenError(fmt.Errorf("only admin of master organization can impersonate"), "IMP_NOT_ALLOWED", "")
+	}
+	return requestUser, nil
+}
+
+// notify mails the admins of the organization of the impersonated user.
+func (u *ImpersonationUsecase) notify(ctx context.Context, impersonation model.Impersonation, title string, content string) {
+	organizationId := impersonation.OrganizationId
+	adminRole, err := u.roleRepository.GetTksRoleByRoleName(ctx, organizationId, user.AdminRole)
+	if err != nil || adminRole == nil {
+		log.Errorf(ctx, "failed to get admin role of organization %s. %v", organizationId, err)
+		return
+	}
+	admins, err := u.userRepository.ListUsersByRole(ctx, organizationId, adminRole.ID, nil)
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	to := make([]string, 0, len(*admins))
+	for _, admin := range *admins {
+		if admin.Email != "" {
+			to = append(to, admin.Email)
+		}
+	}
+	if len(to) == 0 {
+		return
+	}
+
+	message, err := mail.MakeSystemNotificationMessage(ctx, organizationId, title, content, "", to)
+	if err != nil {
+		log.Error(ctx, err)
+		return
+	}
+	if err = enqueueMail(ctx, u.mailOutboxRepository, organizationId, domain.MAIL_CATEGORY_IMPERSONATION, message); err != nil {
+		log.Error(ctx, err)
+	}
+}
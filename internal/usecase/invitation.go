@@ -0,0 +1,418 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/keycloak"
+	"github.com/openinfradev/tks-api/internal/mail"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const invitationExpiration = 7 * 24 * time.Hour
+
+type IInvitationUsecase interface {
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.Invitation, error)
+	Get(ctx context.Context, organizationId string, invitationId uuid.UUID) (model.Invitation, error)
+	Create(ctx context.Context, dto model.Invitation) (invitationId uuid.UUID, err error)
+	Resend(ctx context.Context, organizationId string, invitationId uuid.UUID) error
+	Revoke(ctx context.Context, organizationId string, invitationId uuid.UUID) error
+	GetByToken(ctx context.Context, token string) (model.Invitation, error)
+	Accept(ctx context.Context, token string, dto model.User) (model.User, error)
+	InviteUser(ctx context.Context, user model.User, send bool) error
+	SendUserInvitation(ctx context.Context, user model.User) error
+}
+
+type InvitationUsecase struct {
+	repo                     repository.IInvitationRepository
+	userRepository           repository.IUserRepository
+	roleRepository           repository.IRoleRepository
+	organizationRepository   repository.IOrganizationRepository
+	securityPolicyRepository repository.ISecurityPolicyRepository
+	mailOutboxRepository     repository.IMailOutboxRepository
+	userUsecase              IUserUsecase
+	projectUsecase           IProjectUsecase
+}
+
+func NewInvitationUsecase(r repository.Repository, userUsecase IUserUsecase, projectUsecase IProjectUsecase) IInvitationUsecase {
+	return &InvitationUsecase{
+		repo:                     r.Invitation,
+		userRepository:           r.User,
+		roleRepository:           r.Role,
+		organizationRepository:   r.Organization,
+		securityPolicyRepository: r.SecurityPolicy,
+		mailOutboxRepository:     r.MailOutbox,
+		userUsecase:              userUsecase,
+		projectUsecase:           projectUsecase,
+	}
+}
+
+func (u *InvitationUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.Invitation, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *InvitationUsecase) Get(ctx context.Context, organizationId string, invitationId uuid.UUID) (out model.Invitation, err error) {
+	out, err = u.repo.Get(ctx, invitationId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "INV_NOT_EXISTED_INVITATION", "")
+		}
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return model.Invitation{}, httpErrors.NewNotFoundError(fmt.Errorf("invitation %s is not in the organization", invitationId), "INV_NOT_EXISTED_INVITATION", "")
+	}
+	return out, nil
+}
+
+// Create stores the invitation and mails the link to the email. The token of the link is not stored.
+func (u *InvitationUsecase) Create(ctx context.Context, dto model.Invitation) (invitationId uuid.UUID, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	creatorId := user.GetUserId()
+	dto.CreatorId = &creatorId
+	dto.Email = strings.ToLower(strings.TrimSpace(dto.Email))
+
+	if _, err = u.userRepository.List(ctx, u.userRepository.OrganizationFilter(dto.OrganizationId), u.userRepository.EmailFilter(dto.Email)); err == nil {
+		return uuid.Nil, httpErrors.NewConflictError(fmt.Errorf("user of email %s already exists", dto.Email), "INV_ALREADY_EXISTED_USER", "")
+	} else if _, status := httpErrors.ErrorResponse(err); status != http.StatusNotFound {
+		return uuid.Nil, err
+	}
+
+	now := time.Now()
+	pendings, err := u.repo.FetchPendingByEmail(ctx, dto.OrganizationId, dto.Email)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, pending := range pendings {
+		if pending.CurrentStatus(now) == model.InvitationStatusPending {
+			return uuid.Nil, httpErrors.NewConflictError(fmt.Errorf("email %s is already invited", dto.Email), "INV_ALREADY_INVITED", "")
+		}
+	}
+
+	if err = u.validateRoles(ctx, dto.OrganizationId, dto.Roles); err != nil {
+		return uuid.Nil, err
+	}
+	if err = u.validateProjects(ctx, dto.OrganizationId, dto.Projects); err != nil {
+		return uuid.Nil, err
+	}
+
+	token, err := helper.GenerateInvitationToken()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	dto.TokenHash = helper.HashInvitationToken(token)
+	dto.Status = model.InvitationStatusPending
+	dto.ExpiredAt = now.Add(invitationExpiration)
+	dto.SentCount = 1
+	dto.LastSentAt = now
+
+	invitationId, err = u.repo.Create(ctx, dto)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if err = u.sendMail(ctx, dto, token); err != nil {
+		return uuid.Nil, httpErrors.NewInternalServerError(err, "", "")
+	}
+	return invitationId, nil
+}
+
+// Resend issues a new link of the pending invitation. The link sent before is no longer valid.
+func (u *InvitationUsecase) Resend(ctx context.Context, organizationId string, invitationId uuid.UUID) error {
+	invitation, err := u.Get(ctx, organizationId, invitationId)
+	if err != nil {
+		return err
+	}
+	if invitation.Status != model.InvitationStatusPending {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invitation %s is %s", invitationId, invitation.Status), "INV_NOT_PENDING_INVITATION", "")
+	}
+
+	token, err := helper.GenerateInvitationToken()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	invitation.ExpiredAt = now.Add(invitationExpiration)
+	if err = u.repo.UpdateToken(ctx, invitationId, helper.HashInvitationToken(token), invitation.ExpiredAt, now); err != nil {
+		return err
+	}
+
+	if err = u.sendMail(ctx, invitation, token); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+func (u *InvitationUsecase) Revoke(ctx context.Context, organizationId string, invitationId uuid.UUID) error {
+	invitation, err := u.Get(ctx, organizationId, invitationId)
+	if err != nil {
+		return err
+	}
+	if invitation.Status != model.InvitationStatusPending {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invitation %s is %s", invitationId, invitation.Status), "INV_NOT_PENDING_INVITATION", "")
+	}
+	return u.repo.UpdateStatus(ctx, invitationId, model.InvitationStatusRevoked)
+}
+
+// GetByToken returns the pending invitation of the link. It requires no authentication but the token.
+func (u *InvitationUsecase) GetByToken(ctx context.Context, token string) (out model.Invitation, err error) {
+	if token == "" {
+		return out, httpErrors.NewBadRequestError(fmt.Errorf("empty invitation token"), "INV_INVALID_INVITATION", "")
+	}
+	out, err = u.repo.GetByTokenHash(ctx, helper.HashInvitationToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewBadRequestError(err, "INV_INVALID_INVITATION", "")
+		}
+		return out, err
+	}
+
+	switch out.CurrentStatus(time.Now()) {
+	case model.InvitationStatusPending:
+		return out, nil
+	case model.InvitationStatusExpired:
+		return model.Invitation{}, httpErrors.NewBadRequestError(fmt.Errorf("invitation %s is expired", out.ID), "INV_EXPIRED_INVITATION", "")
+	default:
+		return model.Invitation{}, httpErrors.NewBadRequestError(fmt.Errorf("invitation %s is %s", out.ID, out.Status), "INV_INVALID_INVITATION", "")
+	}
+}
+
+// InviteUser invites the provisioned user who has no password to set the password. If send is false,
+// the invitation is mailed later by SendUserInvitation, e.g. when the user is activated.
+func (u *InvitationUsecase) InviteUser(ctx context.Context, user model.User, send bool) error {
+	token, err := helper.GenerateInvitationToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	userId := user.ID
+	dto := model.Invitation{
+		OrganizationId: user.Organization.ID,
+		Email:          strings.ToLower(strings.TrimSpace(user.Email)),
+		Name:           user.Name,
+		Department:     user.Department,
+		TokenHash:      helper.HashInvitationToken(token),
+		Status:         model.InvitationStatusPending,
+		ExpiredAt:      now.Add(invitationExpiration),
+		UserId:         &userId,
+	}
+	if creator, ok := request.UserFrom(ctx); ok {
+		creatorId := creator.GetUserId()
+		dto.CreatorId = &creatorId
+	}
+	if send {
+		dto.SentCount = 1
+		dto.LastSentAt = now
+	}
+
+	if _, err = u.repo.Create(ctx, dto); err != nil {
+		return err
+	}
+	if !send {
+		return nil
+	}
+	return u.sendMail(ctx, dto, token)
+}
+
+// SendUserInvitation mails the invitation of the provisioned user created by InviteUser without sending.
+// It does nothing if there is no such invitation.
+func (u *InvitationUsecase) SendUserInvitation(ctx context.Context, user model.User) error {
+	invitation, err := u.repo.GetUnsentByUserId(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return u.Resend(ctx, invitation.OrganizationId, invitation.ID)
+}
+
+// Accept creates the user of the invitation with the account id and the password chosen by the invitee,
+// and gives the roles and the project memberships of the invitation.
+// For the provisioned user, it only sets the password and the account id is not changed.
+func (u *InvitationUsecase) Accept(ctx context.Context, token string, dto model.User) (model.User, error) {
+	invitation, err := u.GetByToken(ctx, token)
+	if err != nil {
+		return model.User{}, err
+	}
+	if invitation.UserId != nil {
+		return u.acceptProvisioned(ctx, invitation, dto.Password)
+	}
+	if dto.AccountId == "" {
+		return model.User{}, httpErrors.NewBadRequestError(fmt.Errorf("accountId is required"), "C_INVALID_ACCOUNT_ID", "")
+	}
+	organizationId := invitation.OrganizationId
+
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepository, organizationId)
+	if err != nil {
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = securityPolicy.ValidatePassword(dto.Password); err != nil {
+		return model.User{}, httpErrors.NewBadRequestError(err, "A_PASSWORD_POLICY", "")
+	}
+	if _, err = u.userRepository.Get(ctx, dto.AccountId, organizationId); err == nil {
+		return model.User{}, httpErrors.NewConflictError(fmt.Errorf("duplicate accountId %s", dto.AccountId), "INV_ALREADY_EXISTED_ACCOUNT_ID", "")
+	} else if _, status := httpErrors.ErrorResponse(err); status != http.StatusNotFound {
+		return model.User{}, err
+	}
+
+	// 같은 초대로 동시에 가입하지 않도록 먼저 수락 상태로 바꾸고, 사용자 생성에 실패하면 되돌린다.
+	now := time.Now()
+	if err = u.repo.Accept(ctx, invitation.ID, now); err != nil {
+		return model.User{}, httpErrors.NewBadRequestError(err, "INV_INVALID_INVITATION", "")
+	}
+
+	if dto.Name == "" {
+		dto.Name = invitation.Name
+	}
+	if dto.Department == "" {
+		dto.Department = invitation.Department
+	}
+	dto.Email = invitation.Email
+	dto.Organization = model.Organization{ID: organizationId}
+	dto.Roles = invitation.Roles
+
+	user, err := u.userUsecase.Create(ctx, &dto)
+	if err != nil {
+		if err := u.repo.UpdateStatus(ctx, invitation.ID, model.InvitationStatusPending); err != nil {
+			log.Errorf(ctx, "failed to reopen invitation %s: %v", invitation.ID, err)
+		}
+		return model.User{}, err
+	}
+	if err = u.repo.UpdateUserId(ctx, invitation.ID, user.ID); err != nil {
+		log.Errorf(ctx, "failed to update user of invitation %s: %v", invitation.ID, err)
+	}
+
+	// 가입은 완료되었으므로 프로젝트 멤버 추가에 실패해도 기록만 하고 관리자가 다시 추가한다.
+	for _, project := range invitation.Projects {
+		if err := u.addProjectMember(ctx, organizationId, project, user.ID, now); err != nil {
+			log.Errorf(ctx, "failed to add invited user %s to project %s: %v", user.AccountId, project.ProjectId, err)
+		}
+	}
+
+	return *user, nil
+}
+
+func (u *InvitationUsecase) acceptProvisioned(ctx context.Context, invitation model.Invitation, password string) (model.User, error) {
+	organizationId := invitation.OrganizationId
+	user, err := u.userRepository.GetByUuid(ctx, *invitation.UserId)
+	if err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return model.User{}, httpErrors.NewBadRequestError(err, "INV_INVALID_INVITATION", "")
+		}
+		return model.User{}, err
+	}
+
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepository, organizationId)
+	if err != nil {
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = securityPolicy.ValidatePassword(password); err != nil {
+		return model.User{}, httpErrors.NewBadRequestError(err, "A_PASSWORD_POLICY", "")
+	}
+
+	// 초대 링크는 한 번만 사용할 수 있도록 먼저 수락 상태로 바꾸고, 비밀번호 설정에 실패하면 되돌린다.
+	if err = u.repo.Accept(ctx, invitation.ID, time.Now()); err != nil {
+		return model.User{}, httpErrors.NewBadRequestError(err, "INV_INVALID_INVITATION", "")
+	}
+	if err = u.userUsecase.SetPasswordByAccountId(ctx, user.AccountId, organizationId, password); err != nil {
+		if err := u.repo.UpdateStatus(ctx, invitation.ID, model.InvitationStatusPending); err != nil {
+			log.Errorf(ctx, "failed to reopen invitation %s: %v", invitation.ID, err)
+		}
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+func (u *InvitationUsecase) addProjectMember(ctx context.Context, organizationId string, project model.InvitationProject, userId uuid.UUID, now time.Time) error {
+	pns, err := u.projectUsecase.GetProjectNamespaces(ctx, organizationId, project.ProjectId, nil)
+	if err != nil {
+		return err
+	}
+	stackIds := make(map[string]struct{})
+	for _, pn := range pns {
+		stackIds[pn.StackId] = struct{}{}
+	}
+
+	pmId, err := u.projectUsecase.AddProjectMember(ctx, organizationId, &model.ProjectMember{
+		ProjectId:     project.ProjectId,
+		ProjectUserId: userId,
+		ProjectRoleId: project.ProjectRoleId,
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = u.projectUsecase.AssignKeycloakClientRoleToMember(ctx, organizationId, project.ProjectId, keycloak.DefaultClientID, pmId); err != nil {
+		return err
+	}
+	for stackId := range stackIds {
+		if err = u.projectUsecase.AssignKeycloakClientRoleToMember(ctx, organizationId, project.ProjectId, stackId+"-k8s-api", pmId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *InvitationUsecase) validateRoles(ctx context.Context, organizationId string, roles []model.Role) error {
+	for _, role := range roles {
+		if _, err := u.roleRepository.GetTksRole(ctx, organizationId, role.ID); err != nil {
+			return httpErrors.NewBadRequestError(err, "INV_INVALID_ROLE", "")
+		}
+	}
+	return nil
+}
+
+func (u *InvitationUsecase) validateProjects(ctx context.Context, organizationId string, projects []model.InvitationProject) error {
+	for _, project := range projects {
+		p, err := u.projectUsecase.GetProject(ctx, organizationId, project.ProjectId)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return httpErrors.NewBadRequestError(fmt.Errorf("invalid projectId %s", project.ProjectId), "C_INVALID_PROJECT_ID", "")
+		}
+		pr, err := u.projectUsecase.GetProjectRole(ctx, project.ProjectRoleId)
+		if err != nil {
+			return err
+		}
+		if pr == nil {
+			return httpErrors.NewBadRequestError(fmt.Errorf("invalid projectRoleId %s", project.ProjectRoleId), "C_INVALID_PROJECT_ROLE_ID", "")
+		}
+	}
+	return nil
+}
+
+func (u *InvitationUsecase) sendMail(ctx context.Context, invitation model.Invitation, token string) error {
+	organization, err := u.organizationRepository.Get(ctx, invitation.OrganizationId)
+	if err != nil {
+		return err
+	}
+
+	invitationUrl := viper.GetString("console-address") + "/invitation?token=" + url.QueryEscape(token)
+	message, err := mail.MakeInvitationMessage(ctx, invitation.Email, invitation.OrganizationId, organization.Name, invitationUrl, invitation.ExpiredAt)
+	if err != nil {
+		return err
+	}
+	return enqueueMail(ctx, u.mailOutboxRepository, invitation.OrganizationId, domain.MAIL_CATEGORY_INVITATION, message)
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/mail"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	mailOutboxPollInterval = 10 * time.Second
+	mailOutboxLease        = 5 * time.Minute
+	mailOutboxBatchSize    = 20
+	mailOutboxMaxAttempts  = 8
+	mailOutboxBaseBackoff  = time.Minute
+	mailOutboxMaxBackoff   = time.Hour
+)
+
+type IMailOutboxUsecase interface {
+	Enqueue(ctx context.Context, organizationId string, category string, message *mail.MessageInfo) error
+	Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.MailOutbox, error)
+	Resend(ctx context.Context, mailOutboxId uuid.UUID) error
+	Run(ctx context.Context)
+}
+
+type MailOutboxUsecase struct {
+	repo repository.IMailOutboxRepository
+}
+
+func NewMailOutboxUsecase(r repository.Repository) IMailOutboxUsecase {
+	return &MailOutboxUsecase{
+		repo: r.MailOutbox,
+	}
+}
+
+func (u *MailOutboxUsecase) Enqueue(ctx context.Context, organizationId string, category string, message *mail.MessageInfo) error {
+	return enqueueMail(ctx, u.repo, organizationId, category, message)
+}
+
+func (u *MailOutboxUsecase) Fetch(ctx context.Context, pg *pagination.Pagination) ([]model.MailOutbox, error) {
+	return u.repo.Fetch(ctx, pg)
+}
+
+func (u *MailOutboxUsecase) Resend(ctx context.Context, mailOutboxId uuid.UUID) error {
+	mailOutbox, err := u.repo.Get(ctx, mailOutboxId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return httpErrors.NewNotFoundError(err, "MO_NOT_EXISTED_MAIL", "")
+		}
+		return err
+	}
+	if mailOutbox.Status == domain.MailOutboxStatus_PENDING {
+		return httpErrors.NewBadRequestError(fmt.Errorf("mail is already pending"), "MO_ALREADY_PENDING", "")
+	}
+	// 계정 정보를 담은 메일은 본문을 남기지 않으므로, 다시 발급해서 보내야 한다.
+	if mailOutbox.HasCredential() {
+		return httpErrors.NewBadRequestError(fmt.Errorf("credential mail can not be resent"), "MO_NOT_RESENDABLE", "")
+	}
+
+	mailOutbox.Status = domain.MailOutboxStatus_PENDING
+	mailOutbox.Attempts = 0
+	mailOutbox.NextAttemptAt = time.Now()
+	mailOutbox.LastError = ""
+	mailOutbox.SentAt = nil
+	return u.repo.UpdateResult(ctx, mailOutbox)
+}
+
+// Run sends the pending mails until ctx is done.
+func (u *MailOutboxUsecase) Run(ctx context.Context) {
+	runWorker(ctx, mailOutboxPollInterval, u.sendPending)
+}
+
+func (u *MailOutboxUsecase) sendPending(ctx context.Context) {
+	mails, err := u.repo.ClaimDue(ctx, mailOutboxLease, mailOutboxBatchSize)
+	if err != nil {
+		log.Errorf(ctx, "failed to claim mail outbox. %v", err)
+		return
+	}
+
+	for _, m := range mails {
+		err := mail.New(&mail.MessageInfo{
+			From:    m.From,
+			To:      []string{m.To},
+			Subject: m.Subject,
+			Body:    m.Body,
+		}).SendMail(ctx)
+
+		m.Attempts++
+		if err == nil {
+			now := time.Now()
+			m.Status = domain.MailOutboxStatus_SENT
+			m.SentAt = &now
+			m.LastError = ""
+		} else {
+			m.LastError = err.Error()
+			if m.Attempts >= mailOutboxMaxAttempts {
+				m.Status = domain.MailOutboxStatus_DEAD
+				log.Errorf(ctx, "mail [%s] to %s is dead after %d attempts. %v", m.ID, m.To, m.Attempts, err)
+			} else {
+				m.NextAttemptAt = time.Now().Add(mailOutboxBackoff(m.Attempts))
+				log.Warnf(ctx, "failed to send mail [%s] to %s. retry at %s. %v", m.ID, m.To, m.NextAttemptAt, err)
+			}
+		}
+
+		if m.Status != domain.MailOutboxStatus_PENDING && m.HasCredential() {
+			m.Body = ""
+		}
+
+		if err := u.repo.UpdateResult(ctx, m); err != nil {
+			log.Errorf(ctx, "failed to update mail outbox [%s]. %v", m.ID, err)
+		}
+	}
+}
+
+func mailOutboxBackoff(attempts int) time.Duration {
+	backoff := mailOutboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= mailOutboxMaxBackoff {
+			return mailOutboxMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// enqueueMail stores the message to the outbox per recipient instead of sending it in the request.
+func enqueueMail(ctx context.Context, repo repository.IMailOutboxRepository, organizationId string, category string, message *mail.MessageInfo) error {
+	for _, to := range message.To {
+		_, err := repo.Create(ctx, model.MailOutbox{
+			OrganizationId: organizationId,
+			Category:       category,
+			From:           message.From,
+			To:             to,
+			Subject:        message.Subject,
+			Body:           message.Body,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
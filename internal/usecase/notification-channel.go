@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/notifier"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	notificationDeliveryPollInterval = 10 * time.Second
+	notificationDeliveryLease        = 5 * time.Minute
+	notificationDeliveryBatchSize    = 20
+	notificationDeliveryMaxAttempts  = 5
+	notificationDeliveryBaseBackoff  = 30 * time.Second
+)
+
+type INotificationChannelUsecase interface {
+	Get(ctx context.Context, organizationId string, notificationChannelId uuid.UUID) (model.NotificationChannel, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.NotificationChannel, error)
+	Create(ctx context.Context, dto model.NotificationChannel) (notificationChannelId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.NotificationChannel) error
+	Delete(ctx context.Context, organizationId string, notificationChannelId uuid.UUID) error
+	Test(ctx context.Context, organizationId string, notificationChannelId uuid.UUID) (model.NotificationDelivery, error)
+	FetchDeliveries(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.NotificationDelivery, error)
+	Run(ctx context.Context)
+}
+
+type NotificationChannelUsecase struct {
+	repo         repository.INotificationChannelRepository
+	deliveryRepo repository.INotificationDeliveryRepository
+}
+
+func NewNotificationChannelUsecase(r repository.Repository) INotificationChannelUsecase {
+	return &NotificationChannelUsecase{
+		repo:         r.NotificationChannel,
+		deliveryRepo: r.NotificationDelivery,
+	}
+}
+
+func (u *NotificationChannelUsecase) Get(ctx context.Context, organizationId string, notificationChannelId uuid.UUID) (out model.NotificationChannel, err error) {
+	out, err = u.repo.Get(ctx, notificationChannelId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "NC_NOT_EXISTED_NOTIFICATION_CHANNEL", "")
+		}
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return model.NotificationChannel{}, httpErrors.NewNotFoundError(fmt.Errorf("not found notification channel"), "NC_NOT_EXISTED_NOTIFICATION_CHANNEL", "")
+	}
+	return
+}
+
+func (u *NotificationChannelUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.NotificationChannel, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *NotificationChannelUsecase) Create(ctx context.Context, dto model.NotificationChannel) (notificationChannelId uuid.UUID, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+	dto.UpdatorId = &userId
+
+	if err = validateNotificationChannel(ctx, dto); err != nil {
+		return uuid.Nil, err
+	}
+
+	return u.repo.Create(ctx, dto)
+}
+
+func (u *NotificationChannelUsecase) Update(ctx context.Context, dto model.NotificationChannel) error {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.UpdatorId = &userId
+
+	channel, err := u.Get(ctx, dto.OrganizationId, dto.ID)
+	if err != nil {
+		return err
+	}
+	dto.Type = channel.Type
+
+	if err = validateNotificationChannel(ctx, dto); err != nil {
+		return err
+	}
+
+	return u.repo.Update(ctx, dto)
+}
+
+func (u *NotificationChannelUsecase) Delete(ctx context.Context, organizationId string, notificationChannelId uuid.UUID) error {
+	if _, err := u.Get(ctx, organizationId, notificationChannelId); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, notificationChannelId)
+}
+
+func (u *NotificationChannelUsecase) Test(ctx context.Context, organizationId string, notificationChannelId uuid.UUID) (out model.NotificationDelivery, err error) {
+	channel, err := u.Get(ctx, organizationId, notificationChannelId)
+	if err != nil {
+		return out, err
+	}
+
+	message := &notifier.MessageInfo{
+		OrganizationId: organizationId,
+		Name:           "notification-channel-test",
+		Severity:       "info",
+		Title:          "[TKS] 알림 채널 테스트",
+		Content:        fmt.Sprintf("알림 채널 [%s] 이 정상적으로 설정되었습니다.", channel.Name),
+		Status:         "firing",
+		FiredAt:        time.Now(),
+	}
+	return sendToChannel(ctx, channel, message), nil
+}
+
+func (u *NotificationChannelUsecase) FetchDeliveries(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.NotificationDelivery, error) {
+	return u.deliveryRepo.Fetch(ctx, organizationId, pg)
+}
+
+// Run sends the queued deliveries to the channels until ctx is done.
+func (u *NotificationChannelUsecase) Run(ctx context.Context) {
+	runWorker(ctx, notificationDeliveryPollInterval, u.sendPending)
+}
+
+func (u *NotificationChannelUsecase) sendPending(ctx context.Context) {
+	deliveries, err := u.deliveryRepo.ClaimDue(ctx, notificationDeliveryLease, notificationDeliveryBatchSize)
+	if err != nil {
+		log.Errorf(ctx, "failed to claim notification deliveries. %v", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		result := u.send(ctx, delivery)
+
+		delivery.Attempts++
+		delivery.StatusCode, delivery.ErrorMessage = result.StatusCode, result.ErrorMessage
+		if result.Status == domain.NotificationDeliveryStatus_SUCCESS || delivery.Attempts >= notificationDeliveryMaxAttempts {
+			delivery.Status = result.Status
+			delivery.Message = ""
+			delivery.NextAttemptAt = nil
+			if result.Status == domain.NotificationDeliveryStatus_FAILED {
+				log.Errorf(ctx, "Failed to deliver systemNotification [%s] to %s [%s] after %d attempts. err : %s",
+					delivery.SystemNotificationId, delivery.ChannelType, delivery.Target, delivery.Attempts, delivery.ErrorMessage)
+			}
+		} else {
+			next := time.Now().Add(notificationDeliveryBaseBackoff * time.Duration(1<<(delivery.Attempts-1)))
+			delivery.NextAttemptAt = &next
+			log.Warnf(ctx, "Failed to deliver systemNotification [%s] to %s [%s]. retry at %s. err : %s",
+				delivery.SystemNotificationId, delivery.ChannelType, delivery.Target, next, delivery.ErrorMessage)
+		}
+
+		if err := u.deliveryRepo.UpdateResult(ctx, delivery); err != nil {
+			log.Errorf(ctx, "failed to update notification delivery [%s]. %v", delivery.ID, err)
+		}
+	}
+}
+
+func (u *NotificationChannelUsecase) send(ctx context.Context, delivery model.NotificationDelivery) model.NotificationDelivery {
+	failed := func(err error) model.NotificationDelivery {
+		return model.NotificationDelivery{Status: domain.NotificationDeliveryStatus_FAILED, ErrorMessage: err.Error()}
+	}
+
+	if delivery.NotificationChannelId == nil {
+		return failed(fmt.Errorf("notification channel is not set"))
+	}
+	channel, err := u.repo.Get(ctx, *delivery.NotificationChannelId)
+	if err != nil {
+		return failed(err)
+	}
+	if !channel.Enabled {
+		return failed(fmt.Errorf("notification channel is disabled"))
+	}
+
+	var message notifier.MessageInfo
+	if err = json.Unmarshal([]byte(delivery.Message), &message); err != nil {
+		return failed(err)
+	}
+	return sendToChannel(ctx, channel, &message)
+}
+
+func validateNotificationChannel(ctx context.Context, dto model.NotificationChannel) error {
+	if err := notifier.ValidateUrl(ctx, dto.Url); err != nil {
+		return httpErrors.NewBadRequestError(err, "NC_INVALID_URL", "")
+	}
+	if dto.Type == domain.NOTIFICATION_CHANNEL_TYPE_WEBHOOK && dto.Template != "" {
+		if err := notifier.ValidateTemplate(dto.Template); err != nil {
+			return httpErrors.NewBadRequestError(err, "NC_INVALID_TEMPLATE", "")
+		}
+	}
+	return nil
+}
+
+// queueToChannel returns the pending delivery of the message to the channel, which is not saved yet.
+// The alertmanager webhook does not wait for the channels, and the background worker sends it.
+func queueToChannel(channel model.NotificationChannel, message *notifier.MessageInfo) (out model.NotificationDelivery) {
+	channelId := channel.ID
+	now := time.Now()
+	out = model.NotificationDelivery{
+		OrganizationId:        channel.OrganizationId,
+		NotificationChannelId: &channelId,
+		ChannelType:           channel.Type,
+		Target:                channel.Name,
+		Status:                domain.NotificationDeliveryStatus_PENDING,
+		NextAttemptAt:         &now,
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		out.Status = domain.NotificationDeliveryStatus_FAILED
+		out.ErrorMessage = err.Error()
+		out.NextAttemptAt = nil
+		return out
+	}
+	out.Message = string(body)
+	return out
+}
+
+// sendToChannel sends the message to the channel and returns the result which is not saved yet.
+func sendToChannel(ctx context.Context, channel model.NotificationChannel, message *notifier.MessageInfo) (out model.NotificationDelivery) {
+	channelId := channel.ID
+	out = model.NotificationDelivery{
+		OrganizationId:        channel.OrganizationId,
+		NotificationChannelId: &channelId,
+		ChannelType:           channel.Type,
+		Target:                channel.Name,
+		Status:                domain.NotificationDeliveryStatus_SUCCESS,
+	}
+
+	n, err := notifier.New(channel.Type, channel.Url, channel.Template)
+	if err == nil {
+		out.StatusCode, err = n.Notify(ctx, message)
+	}
+	if err != nil {
+		out.Status = domain.NotificationDeliveryStatus_FAILED
+		out.ErrorMessage = err.Error()
+	}
+	return out
+}
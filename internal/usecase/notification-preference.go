@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/mail"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type INotificationPreferenceUsecase interface {
+	Get(ctx context.Context, userId uuid.UUID) (model.NotificationPreference, error)
+	Update(ctx context.Context, organizationId string, dto model.NotificationPreference) error
+	CheckUnsubscribeToken(ctx context.Context, token string) (model.User, error)
+	Unsubscribe(ctx context.Context, token string) error
+}
+
+type NotificationPreferenceUsecase struct {
+	repo     repository.INotificationPreferenceRepository
+	userRepo repository.IUserRepository
+	ruleRepo repository.ISystemNotificationRuleRepository
+}
+
+func NewNotificationPreferenceUsecase(r repository.Repository) INotificationPreferenceUsecase {
+	return &NotificationPreferenceUsecase{
+		repo:     r.NotificationPreference,
+		userRepo: r.User,
+		ruleRepo: r.SystemNotificationRule,
+	}
+}
+
+func (u *NotificationPreferenceUsecase) Get(ctx context.Context, userId uuid.UUID) (out model.NotificationPreference, err error) {
+	out, err = u.repo.Get(ctx, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.NewNotificationPreference(userId), nil
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+func (u *NotificationPreferenceUsecase) Update(ctx context.Context, organizationId string, dto model.NotificationPreference) error {
+	dto.UnsubscribedRules = make([]model.SystemNotificationRule, 0)
+	for _, strId := range dto.UnsubscribedRuleIds {
+		ruleId, err := uuid.Parse(strId)
+		if err != nil {
+			return httpErrors.NewBadRequestError(err, "C_INVALID_SYSTEM_NOTIFICATION_RULE_ID", "")
+		}
+		rule, err := u.ruleRepo.Get(ctx, ruleId)
+		if err != nil || rule.OrganizationId != organizationId {
+			return httpErrors.NewBadRequestError(fmt.Errorf("invalid systemNotificationRuleId %s", strId), "C_INVALID_SYSTEM_NOTIFICATION_RULE_ID", "")
+		}
+		dto.UnsubscribedRules = append(dto.UnsubscribedRules, rule)
+	}
+	if dto.Channels == nil {
+		dto.Channels = []string{}
+	}
+
+	return u.repo.Save(ctx, dto)
+}
+
+// CheckUnsubscribeToken returns the user of the unsubscribe token without changing the preference.
+func (u *NotificationPreferenceUsecase) CheckUnsubscribeToken(ctx context.Context, token string) (model.User, error) {
+	user, _, err := u.verifyUnsubscribeToken(ctx, token)
+	return user, err
+}
+
+// Unsubscribe turns off the mail of the token. It requires no authentication but the signed token.
+func (u *NotificationPreferenceUsecase) Unsubscribe(ctx context.Context, token string) error {
+	user, strRuleId, err := u.verifyUnsubscribeToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	preference, err := u.Get(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	// rule 이 없으면 메일 수신 자체를 끈다.
+	if strRuleId == "" {
+		channels := make([]string, 0)
+		for _, channel := range preference.Channels {
+			if channel != domain.NOTIFICATION_PREFERENCE_CHANNEL_EMAIL {
+				channels = append(channels, channel)
+			}
+		}
+		preference.Channels = channels
+		return u.repo.Save(ctx, preference)
+	}
+
+	ruleId, err := uuid.Parse(strRuleId)
+	if err != nil {
+		return httpErrors.NewBadRequestError(err, "NP_INVALID_UNSUBSCRIBE_TOKEN", "")
+	}
+	for _, rule := range preference.UnsubscribedRules {
+		if rule.ID == ruleId {
+			return nil
+		}
+	}
+	rule, err := u.ruleRepo.Get(ctx, ruleId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return httpErrors.NewNotFoundError(err, "SNR_NOT_EXISTED_SYSTEM_NOTIFICATION_RULE", "")
+		}
+		return err
+	}
+	preference.UnsubscribedRules = append(preference.UnsubscribedRules, rule)
+	return u.repo.Save(ctx, preference)
+}
+
+// filterNotificationRecipients drops the users who do not want the notification of the rule by their preferences.
+func filterNotificationRecipients(ctx context.Context, repo repository.INotificationPreferenceRepository, users []model.User, ruleId uuid.UUID, severity string) []model.User {
+	preferences := getNotificationPreferences(ctx, repo, users)
+
+	now := time.Now()
+	out := make([]model.User, 0)
+	for _, user := range users {
+		if preference, ok := preferences[user.ID]; ok && !preference.Accepts(domain.NOTIFICATION_PREFERENCE_CHANNEL_EMAIL, ruleId, severity, now) {
+			continue
+		}
+		out = append(out, user)
+	}
+	return out
+}
+
+func getNotificationPreferences(ctx context.Context, repo repository.INotificationPreferenceRepository, users []model.User) map[uuid.UUID]model.NotificationPreference {
+	out := make(map[uuid.UUID]model.NotificationPreference)
+
+	userIds := make([]uuid.UUID, 0)
+	for _, user := range users {
+		userIds = append(userIds, user.ID)
+	}
+	preferences, err := repo.FetchByUserIds(ctx, userIds)
+	if err != nil {
+		// 설정을 읽지 못한 경우 알림이 누락되지 않도록 모두 발송한다.
+		log.Error(ctx, "Failed to fetch notificationPreferences ", err)
+		return out
+	}
+	for _, preference := range preferences {
+		out[preference.UserId] = preference
+	}
+	return out
+}
+
+// enqueueSystemNotificationMails queues a mail to each user, so that every mail carries the unsubscribe link of the user.
+// makeMessage may return nil to skip the user.
+func enqueueSystemNotificationMails(ctx context.Context, mailOutboxRepo repository.IMailOutboxRepository, organizationId string, category string, ruleId uuid.UUID, users []model.User,
+	makeMessage func(user model.User, unsubscribeUrl string) (*mail.MessageInfo, error)) error {
+	var lastErr error
+	for _, user := range users {
+		message, err := makeMessage(user, makeUnsubscribeUrl(ctx, user.ID, ruleId))
+		if err == nil && message == nil {
+			continue
+		}
+		if err == nil {
+			err = enqueueMail(ctx, mailOutboxRepo, organizationId, category, message)
+		}
+		if err != nil {
+			log.Errorf(ctx, "Failed to enqueue mail to %s. err : %v", user.Email, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func makeUnsubscribeUrl(ctx context.Context, userId uuid.UUID, ruleId uuid.UUID) string {
+	token, err := helper.CreateUnsubscribeToken(userId.String(), ruleId.String())
+	if err != nil {
+		log.Error(ctx, "Failed to create unsubscribe token ", err)
+		return ""
+	}
+	return viper.GetString("external-address") + internal.API_PREFIX + internal.API_VERSION + "/notification-preferences/unsubscribe?token=" + url.QueryEscape(token)
+}
+
+func (u *NotificationPreferenceUsecase) verifyUnsubscribeToken(ctx context.Context, token string) (user model.User, strRuleId string, err error) {
+	strUserId, strRuleId, err := helper.VerifyUnsubscribeToken(token)
+	if err != nil {
+		return user, "", httpErrors.NewBadRequestError(err, "NP_INVALID_UNSUBSCRIBE_TOKEN", "")
+	}
+	userId, err := uuid.Parse(strUserId)
+	if err != nil {
+		return user, "", httpErrors.NewBadRequestError(err, "NP_INVALID_UNSUBSCRIBE_TOKEN", "")
+	}
+	user, err = u.userRepo.GetByUuid(ctx, userId)
+	if err != nil {
+		return user, "", httpErrors.NewBadRequestError(err, "NP_INVALID_UNSUBSCRIBE_TOKEN", "")
+	}
+	return user, strRuleId, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const defaultOnCallRotationDays = 7
+
+type IOnCallScheduleUsecase interface {
+	Get(ctx context.Context, organizationId string, onCallScheduleId uuid.UUID) (model.OnCallSchedule, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.OnCallSchedule, error)
+	Create(ctx context.Context, dto model.OnCallSchedule) (onCallScheduleId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.OnCallSchedule) error
+	Delete(ctx context.Context, organizationId string, onCallScheduleId uuid.UUID) error
+}
+
+type OnCallScheduleUsecase struct {
+	repo     repository.IOnCallScheduleRepository
+	userRepo repository.IUserRepository
+}
+
+func NewOnCallScheduleUsecase(r repository.Repository) IOnCallScheduleUsecase {
+	return &OnCallScheduleUsecase{
+		repo:     r.OnCallSchedule,
+		userRepo: r.User,
+	}
+}
+
+func (u *OnCallScheduleUsecase) Get(ctx context.Context, organizationId string, onCallScheduleId uuid.UUID) (out model.OnCallSchedule, err error) {
+	out, err = u.repo.Get(ctx, onCallScheduleId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "OCS_NOT_EXISTED_ON_CALL_SCHEDULE", "")
+		}
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return model.OnCallSchedule{}, httpErrors.NewNotFoundError(fmt.Errorf("not found on-call schedule"), "OCS_NOT_EXISTED_ON_CALL_SCHEDULE", "")
+	}
+	return
+}
+
+func (u *OnCallScheduleUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.OnCallSchedule, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *OnCallScheduleUsecase) Create(ctx context.Context, dto model.OnCallSchedule) (onCallScheduleId uuid.UUID, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+	dto.UpdatorId = &userId
+
+	if err = u.makeMembers(ctx, &dto); err != nil {
+		return uuid.Nil, err
+	}
+
+	return u.repo.Create(ctx, dto)
+}
+
+func (u *OnCallScheduleUsecase) Update(ctx context.Context, dto model.OnCallSchedule) error {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.UpdatorId = &userId
+
+	if _, err := u.Get(ctx, dto.OrganizationId, dto.ID); err != nil {
+		return err
+	}
+
+	if err := u.makeMembers(ctx, &dto); err != nil {
+		return err
+	}
+
+	return u.repo.Update(ctx, dto)
+}
+
+func (u *OnCallScheduleUsecase) Delete(ctx context.Context, organizationId string, onCallScheduleId uuid.UUID) error {
+	if _, err := u.Get(ctx, organizationId, onCallScheduleId); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, onCallScheduleId)
+}
+
+// makeMembers builds the rotation in the order of MemberIds.
+func (u *OnCallScheduleUsecase) makeMembers(ctx context.Context, dto *model.OnCallSchedule) error {
+	if dto.RotationDays == 0 {
+		dto.RotationDays = defaultOnCallRotationDays
+	}
+
+	users, err := getOrganizationUsers(ctx, u.userRepo, dto.OrganizationId, dto.MemberIds)
+	if err != nil {
+		return err
+	}
+
+	dto.Members = make([]model.OnCallScheduleMember, len(users))
+	for i, user := range users {
+		dto.Members[i] = model.OnCallScheduleMember{
+			Sequence: i,
+			UserId:   user.ID,
+		}
+	}
+	return nil
+}
+
+// getOrganizationUsers returns the users of strIds, which must belong to the organization.
+func getOrganizationUsers(ctx context.Context, userRepo repository.IUserRepository, organizationId string, strIds []string) (out []model.User, err error) {
+	out = make([]model.User, 0)
+	for _, strId := range strIds {
+		userId, err := uuid.Parse(strId)
+		if err != nil {
+			return nil, httpErrors.NewBadRequestError(err, "C_INVALID_USER_ID", "")
+		}
+		user, err := userRepo.GetByUuid(ctx, userId)
+		if err != nil || user.OrganizationId != organizationId {
+			return nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid userId %s", strId), "C_INVALID_USER_ID", "")
+		}
+		out = append(out, user)
+	}
+	return out, nil
+}
@@ -0,0 +1,431 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/archive"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	retentionJobName      = "retention"
+	retentionPollInterval = time.Minute
+	retentionLease        = time.Hour
+	retentionBatchSize    = 1000
+	retentionRunHistory   = 90 * 24 * time.Hour
+	// 감사 로그는 최소 1년 보존하고, 보관한 뒤에만 정리한다.
+	retentionMinAuditDays = 365
+	// 보관 파일에 남기지 않는 값
+	retentionRedactedValue = "******"
+)
+
+type IRetentionUsecase interface {
+	GetPolicies(ctx context.Context, organizationId string) ([]model.RetentionPolicy, error)
+	UpdatePolicies(ctx context.Context, organizationId string, dtos []model.RetentionPolicy) error
+	GetStatus(ctx context.Context, organizationId string) (model.RetentionStatus, error)
+	Run(ctx context.Context)
+}
+
+type RetentionUsecase struct {
+	repo             repository.IRetentionRepository
+	auditRepo        repository.IAuditRepository
+	organizationRepo repository.IOrganizationRepository
+}
+
+func NewRetentionUsecase(r repository.Repository) IRetentionUsecase {
+	return &RetentionUsecase{
+		repo:             r.Retention,
+		auditRepo:        r.Audit,
+		organizationRepo: r.Organization,
+	}
+}
+
+// retentionBatch is a batch of the expired rows. The records are archived before purge is called.
+type retentionBatch struct {
+	records []interface{}
+	purge   func(ctx context.Context) (int64, error)
+}
+
+// GetPolicies returns the policies of all data types. The data type without the stored policy follows the default.
+func (u *RetentionUsecase) GetPolicies(ctx context.Context, organizationId string) ([]model.RetentionPolicy, error) {
+	if _, err := u.organizationRepo.Get(ctx, organizationId); err != nil {
+		return nil, httpErrors.NewNotFoundError(err, "O_NOT_EXISTED_NAME", "")
+	}
+	out, err := u.getPolicies(ctx, organizationId)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(err, "", "")
+	}
+	return out, nil
+}
+
+func (u *RetentionUsecase) UpdatePolicies(ctx context.Context, organizationId string, dtos []model.RetentionPolicy) error {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	if _, err := u.organizationRepo.Get(ctx, organizationId); err != nil {
+		return httpErrors.NewNotFoundError(err, "O_NOT_EXISTED_NAME", "")
+	}
+
+	userId := user.GetUserId()
+	archiveEnabled := archive.New() != nil
+	dataTypes := make(map[string]bool, len(dtos))
+	for i := range dtos {
+		if err := validateRetentionPolicy(dtos[i], archiveEnabled); err != nil {
+			return err
+		}
+		if dataTypes[dtos[i].DataType] {
+			return httpErrors.NewBadRequestError(fmt.Errorf("duplicated data type %s", dtos[i].DataType), "RT_DUPLICATED_DATA_TYPE", "")
+		}
+		dataTypes[dtos[i].DataType] = true
+		dtos[i].OrganizationId = organizationId
+		dtos[i].UpdatorId = &userId
+	}
+
+	if err := u.repo.SavePolicies(ctx, dtos); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+// validateRetentionPolicy checks the policy against the data type. Audits are purged only after archived, and kept at least retentionMinAuditDays.
+func validateRetentionPolicy(policy model.RetentionPolicy, archiveEnabled bool) error {
+	switch policy.DataType {
+	case model.RetentionDataTypeAudit:
+		if policy.RetentionDays > 0 && !policy.Archive {
+			return httpErrors.NewBadRequestError(fmt.Errorf("audits must be archived before the purge"), "RT_ARCHIVE_REQUIRED", "")
+		}
+		if policy.RetentionDays > 0 && policy.RetentionDays < retentionMinAuditDays {
+			return httpErrors.NewBadRequestError(fmt.Errorf("audits must be kept at least %d days", retentionMinAuditDays), "RT_RETENTION_TOO_SHORT", "")
+		}
+	case model.RetentionDataTypeCacheEmailCode:
+		if policy.Archive {
+			return httpErrors.NewBadRequestError(fmt.Errorf("email codes can not be archived"), "RT_ARCHIVE_NOT_ALLOWED", "")
+		}
+	case model.RetentionDataTypeSystemNotification, model.RetentionDataTypeAppServeAppTask:
+	default:
+		return httpErrors.NewBadRequestError(fmt.Errorf("invalid data type %s", policy.DataType), "RT_INVALID_DATA_TYPE", "")
+	}
+
+	if policy.Archive && !archiveEnabled {
+		return httpErrors.NewBadRequestError(fmt.Errorf("retention-archive-dir is not configured"), "RT_ARCHIVE_NOT_CONFIGURED", "")
+	}
+	return nil
+}
+
+// GetStatus returns the schedule of the job and the results of the last run, or of the run in progress.
+func (u *RetentionUsecase) GetStatus(ctx context.Context, organizationId string) (out model.RetentionStatus, err error) {
+	out.Enabled = viper.GetDuration("retention-interval") > 0
+	out.ArchiveEnabled = archive.New() != nil
+
+	job, err := u.repo.GetJob(ctx, retentionJobName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, nil
+		}
+		return out, httpErrors.NewInternalServerError(err, "", "")
+	}
+	out.NextRunAt = &job.NextRunAt
+	out.LastStartedAt = job.LastStartedAt
+	out.LastFinishedAt = job.LastFinishedAt
+	out.Running = job.LastStartedAt != nil && (job.LastFinishedAt == nil || job.LastFinishedAt.Before(*job.LastStartedAt))
+
+	if job.LastStartedAt != nil {
+		out.Runs, err = u.repo.FetchRuns(ctx, *job.LastStartedAt, organizationId)
+		if err != nil {
+			return out, httpErrors.NewInternalServerError(err, "", "")
+		}
+	}
+	return out, nil
+}
+
+// Run archives and purges the expired rows of every organization by retention-interval until ctx is done.
+// Only one replica runs the job at a time by the lease of the job.
+func (u *RetentionUsecase) Run(ctx context.Context) {
+	interval := viper.GetDuration("retention-interval")
+	if interval <= 0 {
+		return
+	}
+	store := archive.New()
+
+	runWorker(ctx, retentionPollInterval, func(ctx context.Context) {
+		u.run(ctx, store, interval)
+	})
+}
+
+func (u *RetentionUsecase) run(ctx context.Context, store archive.Store, interval time.Duration) {
+	job, claimed, err := u.repo.ClaimJob(ctx, retentionJobName, retentionLease)
+	if err != nil {
+		log.Errorf(ctx, "failed to claim the retention job. %v", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	startedAt := time.Now().Truncate(time.Microsecond)
+	job.LastStartedAt = &startedAt
+	u.updateJob(ctx, job)
+
+	// 리스가 끝나기 전에 멈추고, 남은 데이터는 바로 다음 실행에서 이어서 정리한다.
+	deadline := startedAt.Add(retentionLease / 2)
+	completed := true
+
+	organizationIds, err := u.repo.FetchOrganizationIds(ctx)
+	if err != nil {
+		log.Errorf(ctx, "failed to fetch organizations for the retention. %v", err)
+	}
+organizations:
+	for _, organizationId := range organizationIds {
+		policies, err := u.getPolicies(ctx, organizationId)
+		if err != nil {
+			log.Errorf(ctx, "failed to get the retention policies of organization %s. %v", organizationId, err)
+			continue
+		}
+		for _, policy := range policies {
+			if policy.RetentionDays <= 0 {
+				continue
+			}
+			if time.Now().After(deadline) {
+				completed = false
+				break organizations
+			}
+
+			result := u.purge(ctx, store, policy, startedAt, deadline)
+			if result.Status == model.RetentionRunPartial {
+				completed = false
+			}
+			if result.Status == model.RetentionRunFailure {
+				log.Errorf(ctx, "failed to purge %s of organization %s. %s", policy.DataType, organizationId, result.Error)
+			}
+			if err = u.repo.CreateRun(ctx, result); err != nil {
+				log.Errorf(ctx, "failed to create the retention run. %v", err)
+			}
+		}
+	}
+
+	if err = u.repo.DeleteRuns(ctx, startedAt.Add(-retentionRunHistory)); err != nil {
+		log.Errorf(ctx, "failed to delete the old retention runs. %v", err)
+	}
+
+	finishedAt := time.Now()
+	job.LastFinishedAt = &finishedAt
+	job.NextRunAt = startedAt.Add(interval)
+	if !completed {
+		job.NextRunAt = finishedAt
+	}
+	u.updateJob(ctx, job)
+}
+
+// purge archives and deletes the expired rows batch by batch. A batch is deleted only after it is archived.
+func (u *RetentionUsecase) purge(ctx context.Context, store archive.Store, policy model.RetentionPolicy, jobStartedAt time.Time, deadline time.Time) model.RetentionRun {
+	now := time.Now()
+	out := model.RetentionRun{
+		ID:             uuid.New(),
+		JobStartedAt:   jobStartedAt,
+		OrganizationId: policy.OrganizationId,
+		DataType:       policy.DataType,
+		RetentionDays:  policy.RetentionDays,
+		Cutoff:         policy.Cutoff(now),
+		Status:         model.RetentionRunSuccess,
+		StartedAt:      now,
+	}
+	failed := func(err error) model.RetentionRun {
+		out.Status = model.RetentionRunFailure
+		out.Error = err.Error()
+		out.FinishedAt = time.Now()
+		return out
+	}
+
+	if err := validateRetentionPolicy(policy, store != nil); err != nil {
+		return failed(err)
+	}
+	// 감사 로그는 서명한 체크포인트에서만 잘라내므로, 서명 키 없이는 정리하지 않는다.
+	if policy.DataType == model.RetentionDataTypeAudit && viper.GetString("audit-signing-secret") == "" {
+		return failed(fmt.Errorf("audit-signing-secret is not configured"))
+	}
+	archiving := policy.Archive
+
+	for seq := 1; ; seq++ {
+		if time.Now().After(deadline) {
+			out.Status = model.RetentionRunPartial
+			break
+		}
+
+		batch, err := u.fetchExpired(ctx, policy.OrganizationId, policy.DataType, out.Cutoff)
+		if err != nil {
+			return failed(err)
+		}
+		if archiving && len(batch.records) > 0 {
+			body, err := archive.Encode(batch.records)
+			if err != nil {
+				return failed(err)
+			}
+			key := fmt.Sprintf("%s/%s/%s-%04d.jsonl.gz", policy.OrganizationId, strings.ToLower(policy.DataType),
+				jobStartedAt.UTC().Format("20060102T150405"), seq)
+			if err = store.Put(ctx, key, body); err != nil {
+				return failed(errors.Wrap(err, "failed to archive"))
+			}
+			out.ArchivedCount += int64(len(batch.records))
+			out.ArchiveKeys = append(out.ArchiveKeys, key)
+		}
+
+		purged, err := batch.purge(ctx)
+		if err != nil {
+			return failed(err)
+		}
+		out.PurgedCount += purged
+		if purged < retentionBatchSize {
+			break
+		}
+	}
+
+	out.FinishedAt = time.Now()
+	return out
+}
+
+func (u *RetentionUsecase) fetchExpired(ctx context.Context, organizationId string, dataType string, cutoff time.Time) (out retentionBatch, err error) {
+	switch dataType {
+	case model.RetentionDataTypeAudit:
+		maxSequence, err := u.getSignedSequence(ctx, organizationId)
+		if err != nil {
+			return out, err
+		}
+		audits, err := u.auditRepo.FetchExpired(ctx, organizationId, cutoff, maxSequence, retentionBatchSize)
+		if err != nil {
+			return out, err
+		}
+		ids := make([]uuid.UUID, len(audits))
+		for i, audit := range audits {
+			out.records = append(out.records, audit)
+			ids[i] = audit.ID
+		}
+		out.purge = func(ctx context.Context) (int64, error) {
+			if err := u.createRetentionCheckpoint(ctx, organizationId, audits); err != nil {
+				return 0, err
+			}
+			return u.auditRepo.Purge(ctx, ids)
+		}
+	case model.RetentionDataTypeSystemNotification:
+		systemNotifications, err := u.repo.FetchExpiredSystemNotifications(ctx, organizationId, cutoff, retentionBatchSize)
+		if err != nil {
+			return out, err
+		}
+		ids := make([]uuid.UUID, len(systemNotifications))
+		for i, systemNotification := range systemNotifications {
+			out.records = append(out.records, systemNotification)
+			ids[i] = systemNotification.ID
+		}
+		out.purge = func(ctx context.Context) (int64, error) {
+			return u.repo.PurgeSystemNotifications(ctx, ids)
+		}
+	case model.RetentionDataTypeAppServeAppTask:
+		tasks, err := u.repo.FetchExpiredAppServeAppTasks(ctx, organizationId, cutoff, retentionBatchSize)
+		if err != nil {
+			return out, err
+		}
+		ids := make([]string, len(tasks))
+		for i, task := range tasks {
+			// 앱 설정과 시크릿은 보관하지 않는다.
+			task.AppConfig = retentionRedactedValue
+			task.AppSecret = retentionRedactedValue
+			task.ExtraEnv = retentionRedactedValue
+			out.records = append(out.records, task)
+			ids[i] = task.ID
+		}
+		out.purge = func(ctx context.Context) (int64, error) {
+			return u.repo.PurgeAppServeAppTasks(ctx, ids)
+		}
+	case model.RetentionDataTypeCacheEmailCode:
+		out.purge = func(ctx context.Context) (int64, error) {
+			return u.repo.PurgeExpiredEmailCodes(ctx, organizationId, cutoff, retentionBatchSize)
+		}
+	default:
+		return out, fmt.Errorf("invalid data type %s", dataType)
+	}
+	return out, nil
+}
+
+// getSignedSequence returns the sequence of the last checkpoint, after verifying its signature.
+// The chained audits are purged only up to it, so that every purged audit has been covered by a signature.
+func (u *RetentionUsecase) getSignedSequence(ctx context.Context, organizationId string) (int64, error) {
+	checkpoint, err := u.auditRepo.GetLastCheckpoint(ctx, organizationId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if !helper.VerifySignatureWithSecret(checkpoint.SigningContent(), checkpoint.Signature) {
+		return 0, fmt.Errorf("the signature of the checkpoint %d does not match", checkpoint.Sequence)
+	}
+	return checkpoint.Sequence, nil
+}
+
+// createRetentionCheckpoint signs the last chained audit of the batch before the purge,
+// so that the chain of the remaining audits is still verifiable from it.
+func (u *RetentionUsecase) createRetentionCheckpoint(ctx context.Context, organizationId string, audits []model.Audit) error {
+	var last *model.Audit
+	for i := range audits {
+		if audits[i].Sequence > 0 {
+			last = &audits[i]
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	checkpoint := model.AuditCheckpoint{
+		ID:             uuid.New(),
+		OrganizationId: organizationId,
+		Sequence:       last.Sequence,
+		Hash:           last.Hash,
+		Retention:      true,
+		CreatedAt:      time.Now().Truncate(time.Microsecond),
+	}
+	signature, err := helper.SignWithSecret(checkpoint.SigningContent())
+	if err != nil {
+		return err
+	}
+	checkpoint.Signature = signature
+	return u.auditRepo.CreateCheckpoint(ctx, checkpoint)
+}
+
+func (u *RetentionUsecase) getPolicies(ctx context.Context, organizationId string) ([]model.RetentionPolicy, error) {
+	stored, err := u.repo.FetchPolicies(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]model.RetentionPolicy, len(stored))
+	for _, policy := range stored {
+		policies[policy.DataType] = policy
+	}
+
+	out := make([]model.RetentionPolicy, len(model.RetentionDataTypes))
+	for i, dataType := range model.RetentionDataTypes {
+		policy, ok := policies[dataType]
+		if !ok {
+			policy = model.NewRetentionPolicy(organizationId, dataType)
+		}
+		out[i] = policy
+	}
+	return out, nil
+}
+
+func (u *RetentionUsecase) updateJob(ctx context.Context, job model.RetentionJob) {
+	if err := u.repo.UpdateJob(ctx, job); err != nil {
+		log.Errorf(ctx, "failed to update the retention job. %v", err)
+	}
+}
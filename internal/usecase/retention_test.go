@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+)
+
+func TestValidateRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         model.RetentionPolicy
+		archiveEnabled bool
+		wantCode       string
+	}{
+		{"audit kept forever", model.RetentionPolicy{DataType: model.RetentionDataTypeAudit}, false, ""},
+		{"audit archived", model.RetentionPolicy{DataType: model.RetentionDataTypeAudit, RetentionDays: retentionMinAuditDays, Archive: true}, true, ""},
+		{"audit without archive", model.RetentionPolicy{DataType: model.RetentionDataTypeAudit, RetentionDays: retentionMinAuditDays}, true, "RT_ARCHIVE_REQUIRED"},
+		{"audit shorter than minimum", model.RetentionPolicy{DataType: model.RetentionDataTypeAudit, RetentionDays: retentionMinAuditDays - 1, Archive: true}, true, "RT_RETENTION_TOO_SHORT"},
+		{"audit without archive store", model.RetentionPolicy{DataType: model.RetentionDataTypeAudit, RetentionDays: retentionMinAuditDays, Archive: true}, false, "RT_ARCHIVE_NOT_CONFIGURED"},
+		{"system notification without archive", model.RetentionPolicy{DataType: model.RetentionDataTypeSystemNotification, RetentionDays: 30}, false, ""},
+		{"system notification without archive store", model.RetentionPolicy{DataType: model.RetentionDataTypeSystemNotification, RetentionDays: 30, Archive: true}, false, "RT_ARCHIVE_NOT_CONFIGURED"},
+		{"app serve app task archived", model.RetentionPolicy{DataType: model.RetentionDataTypeAppServeAppTask, RetentionDays: 30, Archive: true}, true, ""},
+		{"email code purged", model.RetentionPolicy{DataType: model.RetentionDataTypeCacheEmailCode, RetentionDays: 1}, false, ""},
+		{"email code archived", model.RetentionPolicy{DataType: model.RetentionDataTypeCacheEmailCode, RetentionDays: 1, Archive: true}, true, "RT_ARCHIVE_NOT_ALLOWED"},
+		{"invalid data type", model.RetentionPolicy{DataType: "USER", RetentionDays: 1}, true, "RT_INVALID_DATA_TYPE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRetentionPolicy(tt.policy, tt.archiveEnabled)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			restErr, ok := err.(httpErrors.IRestError)
+			if !ok || restErr.Code() != tt.wantCode {
+				t.Errorf("error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
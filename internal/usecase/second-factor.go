@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	secondFactorIssuer = "TKS"
+	recoveryCodeCount  = 10
+)
+
+type ISecondFactorUsecase interface {
+	Get(ctx context.Context, userId uuid.UUID) (model.SecondFactor, error)
+	IsRequired(ctx context.Context, organizationId string) (bool, error)
+	Enroll(ctx context.Context, userId uuid.UUID) (secret string, provisioningUri string, err error)
+	Verify(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error)
+	RegenerateRecoveryCodes(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error)
+	Delete(ctx context.Context, userId uuid.UUID, code string) error
+	ResetByAccountId(ctx context.Context, accountId string, organizationId string) error
+}
+
+type SecondFactorUsecase struct {
+	repo               repository.ISecondFactorRepository
+	userRepo           repository.IUserRepository
+	securityPolicyRepo repository.ISecurityPolicyRepository
+}
+
+func NewSecondFactorUsecase(r repository.Repository) ISecondFactorUsecase {
+	return &SecondFactorUsecase{
+		repo:               r.SecondFactor,
+		userRepo:           r.User,
+		securityPolicyRepo: r.SecurityPolicy,
+	}
+}
+
+func (u *SecondFactorUsecase) Get(ctx context.Context, userId uuid.UUID) (out model.SecondFactor, err error) {
+	out, err = u.repo.Get(ctx, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.SecondFactor{UserId: userId}, nil
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+func (u *SecondFactorUsecase) IsRequired(ctx context.Context, organizationId string) (bool, error) {
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepo, organizationId)
+	if err != nil {
+		return false, err
+	}
+	return securityPolicy.RequireSecondFactor, nil
+}
+
+// Enroll issues a new secret. The second factor is not used on login until the code of the secret is verified.
+func (u *SecondFactorUsecase) Enroll(ctx context.Context, userId uuid.UUID) (secret string, provisioningUri string, err error) {
+	current, err := u.Get(ctx, userId)
+	if err != nil {
+		return "", "", err
+	}
+	if current.Enabled {
+		return "", "", httpErrors.NewConflictError(fmt.Errorf("second factor is already enabled"), "SF_ALREADY_ENABLED", "")
+	}
+
+	user, err := u.userRepo.GetByUuid(ctx, userId)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret, err = helper.GenerateTotpSecret()
+	if err != nil {
+		return "", "", err
+	}
+	encryptedSecret, err := helper.EncryptWithSecret([]byte(secret))
+	if err != nil {
+		return "", "", httpErrors.NewInternalServerError(err, "", "")
+	}
+	err = u.repo.Save(ctx, model.SecondFactor{
+		UserId:          userId,
+		EncryptedSecret: encryptedSecret,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	provisioningUri = helper.TotpProvisioningUri(secondFactorIssuer, user.AccountId+"@"+user.Organization.ID, secret)
+	return secret, provisioningUri, nil
+}
+
+// Verify enables the enrolled second factor with the first code, and returns the recovery codes. The recovery codes are returned only here.
+func (u *SecondFactorUsecase) Verify(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error) {
+	secondFactor, err := u.getEnrolled(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if secondFactor.Enabled {
+		return nil, httpErrors.NewConflictError(fmt.Errorf("second factor is already enabled"), "SF_ALREADY_ENABLED", "")
+	}
+	if err = verifySecondFactor(ctx, u.repo, secondFactor, code, false); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = u.repo.Enable(ctx, userId, time.Now(), hashes); err != nil {
+		return nil, err
+	}
+	// 등록한 세션은 코드를 확인하였으므로 2단계 인증을 마친 세션으로 기록한다.
+	requestUser, ok := request.UserFrom(ctx)
+	sessionId, hasSession := request.SessionFrom(ctx)
+	if ok && hasSession {
+		if err = createSecondFactorSession(ctx, u.repo, u.securityPolicyRepo, userId, requestUser.GetOrganizationId(), sessionId); err != nil {
+			return nil, err
+		}
+	}
+	return recoveryCodes, nil
+}
+
+func (u *SecondFactorUsecase) RegenerateRecoveryCodes(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error) {
+	secondFactor, err := u.getEnabled(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifySecondFactor(ctx, u.repo, secondFactor, code, false); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = u.repo.UpdateRecoveryCodeHashes(ctx, userId, hashes); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// Delete disables the second factor of the user with the current code. It is not allowed when the security policy requires the second factor.
+func (u *SecondFactorUsecase) Delete(ctx context.Context, userId uuid.UUID, code string) error {
+	secondFactor, err := u.getEnabled(ctx, userId)
+	if err != nil {
+		return err
+	}
+	user, err := u.userRepo.GetByUuid(ctx, userId)
+	if err != nil {
+		return err
+	}
+	required, err := u.IsRequired(ctx, user.Organization.ID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return httpErrors.NewForbiddenError(fmt.Errorf("second factor is required by security policy"), "SF_REQUIRED_BY_POLICY", "")
+	}
+	if err = verifySecondFactor(ctx, u.repo, secondFactor, code, true); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, userId)
+}
+
+// ResetByAccountId removes the second factor of the user who lost the device. The user enrolls again on the next login.
+func (u *SecondFactorUsecase) ResetByAccountId(ctx context.Context, accountId string, organizationId string) error {
+	user, err := u.userRepo.Get(ctx, accountId, organizationId)
+	if err != nil {
+		return httpErrors.NewBadRequestError(fmt.Errorf("user not found"), "U_NO_USER", "")
+	}
+	if _, err = u.getEnrolled(ctx, user.ID); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, user.ID)
+}
+
+func (u *SecondFactorUsecase) getEnrolled(ctx context.Context, userId uuid.UUID) (out model.SecondFactor, err error) {
+	out, err = u.repo.Get(ctx, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "SF_NOT_EXISTED_SECOND_FACTOR", "")
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+func (u *SecondFactorUsecase) getEnabled(ctx context.Context, userId uuid.UUID) (out model.SecondFactor, err error) {
+	out, err = u.getEnrolled(ctx, userId)
+	if err != nil {
+		return out, err
+	}
+	if !out.Enabled {
+		return out, httpErrors.NewNotFoundError(fmt.Errorf("second factor is not enabled"), "SF_NOT_EXISTED_SECOND_FACTOR", "")
+	}
+	return out, nil
+}
+
+// verifySecondFactor checks the TOTP code, or the recovery code when allowRecoveryCode. The used recovery code is discarded.
+func verifySecondFactor(ctx context.Context, repo repository.ISecondFactorRepository, secondFactor model.SecondFactor, code string, allowRecoveryCode bool) error {
+	secret, legacy, err := helper.DecryptWithSecret(secondFactor.EncryptedSecret)
+	if err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "Failed to decrypt second factor secret"), "", "")
+	}
+	// jwt-secret 으로 암호화된 secret 은 second-factor-secret 으로 다시 암호화한다.
+	if legacy {
+		if encryptedSecret, err := helper.EncryptWithSecret(secret); err != nil {
+			log.Error(ctx, err)
+		} else if err = repo.UpdateEncryptedSecret(ctx, secondFactor.UserId, encryptedSecret); err != nil {
+			log.Error(ctx, err)
+		}
+	}
+
+	if step, ok := helper.ValidateTotp(string(secret), code, time.Now()); ok {
+		if err = repo.UseStep(ctx, secondFactor.UserId, step); err != nil {
+			log.Warn(ctx, err)
+			return httpErrors.NewBadRequestError(err, "A_INVALID_SECOND_FACTOR_CODE", "")
+		}
+		return nil
+	}
+
+	if allowRecoveryCode {
+		hash := helper.HashRecoveryCode(code)
+		for i, h := range secondFactor.RecoveryCodeHashes {
+			if h != hash {
+				continue
+			}
+			remains := append(append([]string{}, secondFactor.RecoveryCodeHashes[:i]...), secondFactor.RecoveryCodeHashes[i+1:]...)
+			if err = repo.UseRecoveryCode(ctx, secondFactor.UserId, secondFactor.RecoveryCodeHashes, remains); err != nil {
+				log.Warn(ctx, err)
+				return httpErrors.NewBadRequestError(err, "A_INVALID_SECOND_FACTOR_CODE", "")
+			}
+			log.Infof(ctx, "recovery code of user [%s] is used. %d codes remain", secondFactor.UserId, len(remains))
+			return nil
+		}
+	}
+
+	return httpErrors.NewBadRequestError(fmt.Errorf("invalid second factor code"), "A_INVALID_SECOND_FACTOR_CODE", "")
+}
+
+// createSecondFactorSession records the session which passed the second factor until the session expires.
+func createSecondFactorSession(ctx context.Context, repo repository.ISecondFactorRepository, securityPolicyRepo repository.ISecurityPolicyRepository,
+	userId uuid.UUID, organizationId string, sessionId string) error {
+	securityPolicy, err := getSecurityPolicy(ctx, securityPolicyRepo, organizationId)
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	err = repo.CreateSession(ctx, model.SecondFactorSession{
+		SessionId: sessionId,
+		UserId:    userId,
+		ExpiredAt: time.Now().Add(time.Duration(securityPolicy.SessionMaxLifespanMinutes) * time.Minute),
+	})
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+func generateRecoveryCodes() (codes []string, hashes []string, err error) {
+	codes, err = helper.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes = make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = helper.HashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openinfradev/tks-api/internal/keycloak"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ISecurityPolicyUsecase interface {
+	Get(ctx context.Context, organizationId string) (model.SecurityPolicy, error)
+	Update(ctx context.Context, dto model.SecurityPolicy) error
+}
+
+type SecurityPolicyUsecase struct {
+	repo repository.ISecurityPolicyRepository
+	kc   keycloak.IKeycloak
+}
+
+func NewSecurityPolicyUsecase(r repository.Repository, kc keycloak.IKeycloak) ISecurityPolicyUsecase {
+	return &SecurityPolicyUsecase{
+		repo: r.SecurityPolicy,
+		kc:   kc,
+	}
+}
+
+func (u *SecurityPolicyUsecase) Get(ctx context.Context, organizationId string) (model.SecurityPolicy, error) {
+	return getSecurityPolicy(ctx, u.repo, organizationId)
+}
+
+// Update applies the policy to the keycloak realm first, and stores it when keycloak accepts.
+func (u *SecurityPolicyUsecase) Update(ctx context.Context, dto model.SecurityPolicy) error {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.UpdatorId = &userId
+
+	if err := u.kc.UpdateRealm(ctx, dto.OrganizationId, dto); err != nil {
+		return httpErrors.NewInternalServerError(errors.Wrap(err, "Failed to update keycloak realm"), "SP_FAILED_TO_UPDATE_REALM", "")
+	}
+	return u.repo.Save(ctx, dto)
+}
+
+// getSecurityPolicy returns the default policy for the organization without the stored policy.
+func getSecurityPolicy(ctx context.Context, repo repository.ISecurityPolicyRepository, organizationId string) (model.SecurityPolicy, error) {
+	out, err := repo.Get(ctx, organizationId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.NewSecurityPolicy(organizationId), nil
+		}
+		return out, err
+	}
+	return out, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type IServiceAccountUsecase interface {
+	Get(ctx context.Context, organizationId string, serviceAccountId uuid.UUID) (model.ServiceAccount, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.ServiceAccount, error)
+	Create(ctx context.Context, dto model.ServiceAccount) (serviceAccountId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.ServiceAccount) error
+	Delete(ctx context.Context, organizationId string, serviceAccountId uuid.UUID) (model.ServiceAccount, error)
+}
+
+type ServiceAccountUsecase struct {
+	repo repository.IServiceAccountRepository
+}
+
+func NewServiceAccountUsecase(r repository.Repository) IServiceAccountUsecase {
+	return &ServiceAccountUsecase{
+		repo: r.ServiceAccount,
+	}
+}
+
+func (u *ServiceAccountUsecase) Get(ctx context.Context, organizationId string, serviceAccountId uuid.UUID) (out model.ServiceAccount, err error) {
+	out, err = u.repo.Get(ctx, serviceAccountId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "SA_NOT_EXISTED_SERVICE_ACCOUNT", "")
+		}
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return out, httpErrors.NewNotFoundError(fmt.Errorf("not found service account"), "SA_NOT_EXISTED_SERVICE_ACCOUNT", "")
+	}
+	return out, nil
+}
+
+func (u *ServiceAccountUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.ServiceAccount, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *ServiceAccountUsecase) Create(ctx context.Context, dto model.ServiceAccount) (serviceAccountId uuid.UUID, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+
+	if _, err = u.repo.GetByName(ctx, dto.OrganizationId, dto.Name); err == nil {
+		return uuid.Nil, httpErrors.NewConflictError(fmt.Errorf("duplicate service account name %s", dto.Name), "SA_CREATE_ALREADY_EXISTED_NAME", "")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return uuid.Nil, err
+	}
+
+	return u.repo.Create(ctx, dto)
+}
+
+func (u *ServiceAccountUsecase) Update(ctx context.Context, dto model.ServiceAccount) error {
+	if _, err := u.Get(ctx, dto.OrganizationId, dto.ID); err != nil {
+		return err
+	}
+	return u.repo.Update(ctx, dto)
+}
+
+// Delete removes the service account with its api tokens.
+func (u *ServiceAccountUsecase) Delete(ctx context.Context, organizationId string, serviceAccountId uuid.UUID) (out model.ServiceAccount, err error) {
+	out, err = u.Get(ctx, organizationId, serviceAccountId)
+	if err != nil {
+		return out, err
+	}
+	if err = u.repo.Delete(ctx, out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/keycloak"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+)
+
+type ISessionUsecase interface {
+	Fetch(ctx context.Context, organizationId string, userId uuid.UUID) ([]model.Session, error)
+	Revoke(ctx context.Context, organizationId string, userId uuid.UUID, sessionId string) error
+	FetchByAccountId(ctx context.Context, accountId string, organizationId string) ([]model.Session, error)
+	RevokeByAccountId(ctx context.Context, accountId string, organizationId string, sessionId string) error
+	RevokeAllByAccountId(ctx context.Context, accountId string, organizationId string) error
+}
+
+type SessionUsecase struct {
+	kc             keycloak.IKeycloak
+	userRepository repository.IUserRepository
+	authRepository repository.IAuthRepository
+}
+
+func NewSessionUsecase(r repository.Repository, kc keycloak.IKeycloak) ISessionUsecase {
+	return &SessionUsecase{
+		kc:             kc,
+		userRepository: r.User,
+		authRepository: r.Auth,
+	}
+}
+
+func (u *SessionUsecase) Fetch(ctx context.Context, organizationId string, userId uuid.UUID) ([]model.Session, error) {
+	sessions, err := u.kc.GetSessions(ctx, userId.String(), organizationId)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	// 최근에 사용한 세션이 먼저 보이도록 정렬한다.
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastAccessedAt.After(sessions[j].LastAccessedAt)
+	})
+	return sessions, nil
+}
+
+// Revoke signs out one of my sessions, and rejects the access tokens of the session. Tokens of the other sessions are not affected.
+func (u *SessionUsecase) Revoke(ctx context.Context, organizationId string, userId uuid.UUID, sessionId string) error {
+	if err := u.checkOwner(ctx, organizationId, userId, sessionId); err != nil {
+		return err
+	}
+
+	if err := u.kc.Logout(ctx, sessionId, organizationId); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	// access token 은 세션이 종료되어도 만료될 때까지 유효하므로 만료될 때까지 세션을 폐기 목록에 둔다.
+	expiredAt := time.Now().Add(keycloak.AccessTokenLifespan * time.Second)
+	if err := u.authRepository.RevokeSession(ctx, organizationId, sessionId, expiredAt); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+func (u *SessionUsecase) FetchByAccountId(ctx context.Context, accountId string, organizationId string) ([]model.Session, error) {
+	user, err := u.getUser(ctx, accountId, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	return u.Fetch(ctx, organizationId, user.ID)
+}
+
+// RevokeByAccountId signs out the session of the user. The other sessions of the user are not affected.
+func (u *SessionUsecase) RevokeByAccountId(ctx context.Context, accountId string, organizationId string, sessionId string) error {
+	user, err := u.getUser(ctx, accountId, organizationId)
+	if err != nil {
+		return err
+	}
+	return u.Revoke(ctx, organizationId, user.ID, sessionId)
+}
+
+func (u *SessionUsecase) RevokeAllByAccountId(ctx context.Context, accountId string, organizationId string) error {
+	user, err := u.getUser(ctx, accountId, organizationId)
+	if err != nil {
+		return err
+	}
+
+	if err = u.kc.LogoutAllSessions(ctx, user.ID.String(), organizationId); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = u.authRepository.UpdateExpiredTimeOnToken(ctx, organizationId, user.ID.String()); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+func (u *SessionUsecase) getUser(ctx context.Context, accountId string, organizationId string) (model.User, error) {
+	user, err := u.userRepository.Get(ctx, accountId, organizationId)
+	if err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return model.User{}, httpErrors.NewBadRequestError(fmt.Errorf("user not found"), "U_NO_USER", "")
+		}
+		return model.User{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	return user, nil
+}
+
+// checkOwner prevents signing out the session of another user with a guessed session id.
+func (u *SessionUsecase) checkOwner(ctx context.Context, organizationId string, userId uuid.UUID, sessionId string) error {
+	sessions, err := u.kc.GetSessions(ctx, userId.String(), organizationId)
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	for _, session := range sessions {
+		if session.ID == sessionId {
+			return nil
+		}
+	}
+	return httpErrors.NewNotFoundError(fmt.Errorf("session not found. sessionId : %s", sessionId), "A_NOT_EXISTED_SESSION", "")
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/internal/stream"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const (
+	streamStatusPollInterval = 15 * time.Second
+	streamTicketExpiration   = 30 * time.Second
+)
+
+type IStreamUsecase interface {
+	IssueTicket(ctx context.Context, organizationId string) (ticket string, expiredAt time.Time, err error)
+	ConsumeTicket(ctx context.Context, organizationId string, ticket string) error
+	Subscribe(ctx context.Context, organizationId string) (<-chan stream.Event, func())
+	Run(ctx context.Context)
+}
+
+type StreamUsecase struct {
+	clusterRepo  repository.IClusterRepository
+	appGroupRepo repository.IAppGroupRepository
+	ticketRepo   repository.IStreamTicketRepository
+}
+
+func NewStreamUsecase(r repository.Repository) IStreamUsecase {
+	return &StreamUsecase{
+		clusterRepo:  r.Cluster,
+		appGroupRepo: r.AppGroup,
+		ticketRepo:   r.StreamTicket,
+	}
+}
+
+// IssueTicket issues the one-time ticket to connect the stream of the organization.
+// The browser EventSource can not set the Authorization header, and the access token in the url is left in the logs.
+func (u *StreamUsecase) IssueTicket(ctx context.Context, organizationId string) (ticket string, expiredAt time.Time, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return "", time.Time{}, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+
+	ticket, err = helper.GenerateInvitationToken()
+	if err != nil {
+		return "", time.Time{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	expiredAt = time.Now().Add(streamTicketExpiration)
+	err = u.ticketRepo.Create(ctx, model.StreamTicket{
+		TicketHash:     helper.HashInvitationToken(ticket),
+		OrganizationId: organizationId,
+		UserId:         user.GetUserId(),
+		ExpiredAt:      expiredAt,
+	})
+	if err != nil {
+		return "", time.Time{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	return ticket, expiredAt, nil
+}
+
+// ConsumeTicket accepts the ticket of the organization only once.
+func (u *StreamUsecase) ConsumeTicket(ctx context.Context, organizationId string, ticket string) error {
+	if ticket == "" {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("empty stream ticket"), "ST_INVALID_TICKET", "")
+	}
+	out, err := u.ticketRepo.Consume(ctx, helper.HashInvitationToken(ticket))
+	if err != nil {
+		return httpErrors.NewUnauthorizedError(err, "ST_INVALID_TICKET", "")
+	}
+	if out.OrganizationId != organizationId {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("stream ticket is not for the organization %s", organizationId), "ST_INVALID_TICKET", "")
+	}
+	return nil
+}
+
+func (u *StreamUsecase) Subscribe(ctx context.Context, organizationId string) (<-chan stream.Event, func()) {
+	return stream.Subscribe(organizationId)
+}
+
+type streamStatus struct {
+	cluster string
+	stack   string
+}
+
+// streamSnapshot is the status of the clusters of the organizations watched at the last poll.
+type streamSnapshot struct {
+	organizations map[string]struct{}
+	statuses      map[string]streamStatus
+}
+
+// Run watches the status of clusters and stacks of the organizations which have the subscribers until ctx is done.
+// The status is changed by the workflows outside of tks-api, so every replica detects the transitions by itself.
+func (u *StreamUsecase) Run(ctx context.Context) {
+	var last streamSnapshot
+	runWorker(ctx, streamStatusPollInterval, func(ctx context.Context) {
+		last = u.publishStatusChanges(ctx, last)
+	})
+}
+
+func (u *StreamUsecase) publishStatusChanges(ctx context.Context, last streamSnapshot) streamSnapshot {
+	organizationIds := stream.SubscribedOrganizations()
+	// 구독자가 없으면 조회하지 않는다.
+	if len(organizationIds) == 0 {
+		return streamSnapshot{}
+	}
+
+	clusters, err := u.clusterRepo.FetchByOrganizationIds(ctx, organizationIds)
+	if err != nil {
+		log.Error(ctx, "Failed to fetch clusters ", err)
+		return last
+	}
+	clusterIds := make([]domain.ClusterId, len(clusters))
+	for i, cluster := range clusters {
+		clusterIds[i] = cluster.ID
+	}
+	appGroups, err := u.appGroupRepo.FetchByClusterIds(ctx, clusterIds)
+	if err != nil {
+		log.Error(ctx, "Failed to fetch appGroups ", err)
+		return last
+	}
+	appGroupsByCluster := make(map[domain.ClusterId][]model.AppGroup)
+	for _, appGroup := range appGroups {
+		appGroupsByCluster[appGroup.ClusterId] = append(appGroupsByCluster[appGroup.ClusterId], appGroup)
+	}
+
+	current := streamSnapshot{
+		organizations: make(map[string]struct{}, len(organizationIds)),
+		statuses:      make(map[string]streamStatus, len(clusters)),
+	}
+	for _, organizationId := range organizationIds {
+		current.organizations[organizationId] = struct{}{}
+	}
+
+	for _, cluster := range clusters {
+		stackStatus, stackStatusDesc := getStackStatus(cluster, appGroupsByCluster[cluster.ID])
+
+		status := streamStatus{
+			cluster: cluster.Status.String(),
+			stack:   stackStatus.String(),
+		}
+		current.statuses[cluster.ID.String()] = status
+
+		// 처음 조회한 조직의 상태는 비교 대상이 없으므로 발송하지 않는다.
+		if _, ok := last.organizations[cluster.OrganizationId]; !ok {
+			continue
+		}
+		previous := last.statuses[cluster.ID.String()]
+		if previous.cluster != status.cluster {
+			stream.PublishLocal(stream.Event{
+				Type:           domain.STREAM_EVENT_CLUSTER_STATUS_CHANGED,
+				OrganizationId: cluster.OrganizationId,
+				Data: domain.StreamStatusChange{
+					ID:             cluster.ID.String(),
+					Name:           cluster.Name,
+					PreviousStatus: previous.cluster,
+					Status:         status.cluster,
+					StatusDesc:     cluster.StatusDesc,
+				},
+			})
+		}
+		if previous.stack != status.stack {
+			stream.PublishLocal(stream.Event{
+				Type:           domain.STREAM_EVENT_STACK_STATUS_CHANGED,
+				OrganizationId: cluster.OrganizationId,
+				Data: domain.StreamStatusChange{
+					ID:             cluster.ID.String(),
+					Name:           cluster.Name,
+					PreviousStatus: previous.stack,
+					Status:         status.stack,
+					StatusDesc:     stackStatusDesc,
+				},
+			})
+		}
+	}
+	return current
+}
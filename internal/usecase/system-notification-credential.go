@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/helper"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	systemNotificationCredentialSecretLength       = 40
+	systemNotificationCredentialRotationGrace      = 24 * time.Hour
+	systemNotificationCredentialTimestampTolerance = 5 * time.Minute
+)
+
+type ISystemNotificationCredentialUsecase interface {
+	Get(ctx context.Context, systemNotificationCredentialId uuid.UUID) (model.SystemNotificationCredential, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotificationCredential, error)
+	Create(ctx context.Context, dto model.SystemNotificationCredential) (systemNotificationCredentialId uuid.UUID, secret string, err error)
+	Rotate(ctx context.Context, organizationId string, systemNotificationCredentialId uuid.UUID) (secret string, err error)
+	Delete(ctx context.Context, organizationId string, systemNotificationCredentialId uuid.UUID) error
+	Authenticate(ctx context.Context, header http.Header, body []byte) (model.SystemNotificationCredential, error)
+}
+
+type SystemNotificationCredentialUsecase struct {
+	repo        repository.ISystemNotificationCredentialRepository
+	clusterRepo repository.IClusterRepository
+}
+
+func NewSystemNotificationCredentialUsecase(r repository.Repository) ISystemNotificationCredentialUsecase {
+	return &SystemNotificationCredentialUsecase{
+		repo:        r.SystemNotificationCredential,
+		clusterRepo: r.Cluster,
+	}
+}
+
+func (u *SystemNotificationCredentialUsecase) Get(ctx context.Context, systemNotificationCredentialId uuid.UUID) (out model.SystemNotificationCredential, err error) {
+	out, err = u.repo.Get(ctx, systemNotificationCredentialId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "SNC_NOT_EXISTED_CREDENTIAL", "")
+		}
+		return out, err
+	}
+	return
+}
+
+func (u *SystemNotificationCredentialUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotificationCredential, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *SystemNotificationCredentialUsecase) Create(ctx context.Context, dto model.SystemNotificationCredential) (systemNotificationCredentialId uuid.UUID, secret string, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, "", httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+
+	if dto.ClusterId != "" {
+		cluster, err := u.clusterRepo.Get(ctx, domain.ClusterId(dto.ClusterId))
+		if err != nil || cluster.OrganizationId != dto.OrganizationId {
+			return uuid.Nil, "", httpErrors.NewBadRequestError(fmt.Errorf("Invalid clusterId %s", dto.ClusterId), "C_INVALID_CLUSTER_ID", "")
+		}
+	}
+
+	dto.Secret = helper.GenerateRandomString(systemNotificationCredentialSecretLength)
+	systemNotificationCredentialId, err = u.repo.Create(ctx, dto)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return systemNotificationCredentialId, dto.Secret, nil
+}
+
+func (u *SystemNotificationCredentialUsecase) Rotate(ctx context.Context, organizationId string, systemNotificationCredentialId uuid.UUID) (secret string, err error) {
+	credential, err := u.getInOrganization(ctx, organizationId, systemNotificationCredentialId)
+	if err != nil {
+		return "", err
+	}
+
+	// 기존 secret 은 유예 기간 동안 유효하므로, alertmanager 설정을 교체할 시간을 확보할 수 있다.
+	now := time.Now()
+	expiredAt := now.Add(systemNotificationCredentialRotationGrace)
+	credential.PreviousSecret = credential.Secret
+	credential.PreviousSecretExpiredAt = &expiredAt
+	credential.Secret = helper.GenerateRandomString(systemNotificationCredentialSecretLength)
+	credential.RotatedAt = &now
+
+	if err = u.repo.UpdateSecret(ctx, credential); err != nil {
+		return "", err
+	}
+
+	return credential.Secret, nil
+}
+
+func (u *SystemNotificationCredentialUsecase) Delete(ctx context.Context, organizationId string, systemNotificationCredentialId uuid.UUID) error {
+	if _, err := u.getInOrganization(ctx, organizationId, systemNotificationCredentialId); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, systemNotificationCredentialId)
+}
+
+func (u *SystemNotificationCredentialUsecase) getInOrganization(ctx context.Context, organizationId string, systemNotificationCredentialId uuid.UUID) (out model.SystemNotificationCredential, err error) {
+	out, err = u.Get(ctx, systemNotificationCredentialId)
+	if err != nil {
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return out, httpErrors.NewNotFoundError(fmt.Errorf("not found credential"), "SNC_NOT_EXISTED_CREDENTIAL", "")
+	}
+	return out, nil
+}
+
+// Authenticate verifies the credential of webhook request.
+// It accepts a bearer token ("<credentialId>.<secret>") or HMAC-SHA256 signature of "<timestamp>.<nonce>.<body>".
+// The signature requires the timestamp and nonce, and is protected from the replay.
+// The bearer token is for alertmanager, which can not send them, so it has no replay protection and relies on TLS;
+// the timestamp and nonce are still checked when the sender gives them.
+func (u *SystemNotificationCredentialUsecase) Authenticate(ctx context.Context, header http.Header, body []byte) (out model.SystemNotificationCredential, err error) {
+	timestamp := header.Get(domain.SystemNotificationCredentialHeaderTimestamp)
+	nonce := header.Get(domain.SystemNotificationCredentialHeaderNonce)
+
+	authHeader := strings.TrimSpace(header.Get("Authorization"))
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+		strId, secret, found := strings.Cut(strings.TrimSpace(parts[1]), ".")
+		if !found {
+			return out, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid bearer token"), "SNC_INVALID_CREDENTIAL", "")
+		}
+		out, err = u.getCredential(ctx, strId)
+		if err != nil {
+			return out, err
+		}
+		if !matchSecret(out, func(s string) bool {
+			return subtle.ConstantTimeCompare([]byte(s), []byte(secret)) == 1
+		}) {
+			return out, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid bearer token"), "SNC_INVALID_CREDENTIAL", "")
+		}
+		if timestamp != "" || nonce != "" {
+			if err = u.checkReplay(ctx, out.ID, timestamp, nonce); err != nil {
+				return out, err
+			}
+		}
+	} else {
+		out, err = u.getCredential(ctx, header.Get(domain.SystemNotificationCredentialHeaderId))
+		if err != nil {
+			return out, err
+		}
+		signature, err := hex.DecodeString(header.Get(domain.SystemNotificationCredentialHeaderSignature))
+		if err != nil || len(signature) == 0 {
+			return out, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid signature"), "SNC_INVALID_SIGNATURE", "")
+		}
+		if !matchSecret(out, func(s string) bool {
+			return hmac.Equal(signature, signPayload(s, timestamp, nonce, body))
+		}) {
+			return out, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid signature"), "SNC_INVALID_SIGNATURE", "")
+		}
+		if err = u.checkReplay(ctx, out.ID, timestamp, nonce); err != nil {
+			return out, err
+		}
+	}
+
+	if err := u.repo.UpdateLastUsedAt(ctx, out.ID, time.Now()); err != nil {
+		log.Error(ctx, err)
+	}
+
+	return out, nil
+}
+
+func (u *SystemNotificationCredentialUsecase) getCredential(ctx context.Context, strId string) (out model.SystemNotificationCredential, err error) {
+	id, err := uuid.Parse(strId)
+	if err != nil {
+		return out, httpErrors.NewUnauthorizedError(fmt.Errorf("invalid credential id"), "SNC_INVALID_CREDENTIAL", "")
+	}
+	out, err = u.repo.Get(ctx, id)
+	if err != nil {
+		return out, httpErrors.NewUnauthorizedError(err, "SNC_INVALID_CREDENTIAL", "")
+	}
+	return out, nil
+}
+
+func (u *SystemNotificationCredentialUsecase) checkReplay(ctx context.Context, credentialId uuid.UUID, timestamp string, nonce string) error {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || nonce == "" {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("timestamp and nonce are required"), "SNC_INVALID_TIMESTAMP", "")
+	}
+
+	diff := time.Since(time.Unix(sec, 0))
+	if diff > systemNotificationCredentialTimestampTolerance || diff < -systemNotificationCredentialTimestampTolerance {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("timestamp is out of range"), "SNC_INVALID_TIMESTAMP", "")
+	}
+
+	// nonce 는 timestamp 허용 범위 동안 DB 에 보관하여, 다른 replica 로의 재전송도 막는다.
+	used, err := u.repo.UseNonce(ctx, credentialId, nonce, time.Now().Add(2*systemNotificationCredentialTimestampTolerance))
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "SNC_FAILED_TO_CHECK_NONCE", "")
+	}
+	if used {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("nonce is already used"), "SNC_REPLAYED_REQUEST", "")
+	}
+
+	return nil
+}
+
+func matchSecret(credential model.SystemNotificationCredential, match func(secret string) bool) bool {
+	if match(credential.Secret) {
+		return true
+	}
+	if credential.PreviousSecret != "" && credential.PreviousSecretExpiredAt != nil && time.Now().Before(*credential.PreviousSecretExpiredAt) {
+		return match(credential.PreviousSecret)
+	}
+	return false
+}
+
+func signPayload(secret string, timestamp string, nonce string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/mail"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/log"
+)
+
+const (
+	systemNotificationDigestPollInterval = time.Minute
+	systemNotificationDigestBatchSize    = 1000
+)
+
+type ISystemNotificationDigestUsecase interface {
+	Run(ctx context.Context)
+}
+
+type SystemNotificationDigestUsecase struct {
+	repo                       repository.ISystemNotificationDigestRepository
+	ruleRepo                   repository.ISystemNotificationRuleRepository
+	userRepo                   repository.IUserRepository
+	mailOutboxRepo             repository.IMailOutboxRepository
+	notificationPreferenceRepo repository.INotificationPreferenceRepository
+}
+
+func NewSystemNotificationDigestUsecase(r repository.Repository) ISystemNotificationDigestUsecase {
+	return &SystemNotificationDigestUsecase{
+		repo:                       r.SystemNotificationDigest,
+		ruleRepo:                   r.SystemNotificationRule,
+		userRepo:                   r.User,
+		mailOutboxRepo:             r.MailOutbox,
+		notificationPreferenceRepo: r.NotificationPreference,
+	}
+}
+
+// Run sends the digest mails at every hour (HOURLY) and midnight (DAILY) until ctx is done.
+func (u *SystemNotificationDigestUsecase) Run(ctx context.Context) {
+	runWorker(ctx, systemNotificationDigestPollInterval, func(ctx context.Context) {
+		now := time.Now()
+		u.sendDigests(ctx, domain.SYSTEM_NOTIFICATION_DIGEST_MODE_HOURLY, now.Truncate(time.Hour))
+		u.sendDigests(ctx, domain.SYSTEM_NOTIFICATION_DIGEST_MODE_DAILY, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()))
+	})
+}
+
+func (u *SystemNotificationDigestUsecase) sendDigests(ctx context.Context, digestMode string, before time.Time) {
+	items, err := u.repo.ClaimDue(ctx, digestMode, before, systemNotificationDigestBatchSize)
+	if err != nil {
+		log.Error(ctx, "Failed to claim systemNotificationDigestItems ", err)
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	itemsByRule := make(map[uuid.UUID][]model.SystemNotificationDigestItem)
+	for _, item := range items {
+		itemsByRule[item.SystemNotificationRuleId] = append(itemsByRule[item.SystemNotificationRuleId], item)
+	}
+
+	period := fmt.Sprintf("%s ~ %s", before.Add(-time.Hour).Format("2006-01-02 15:04"), before.Format("2006-01-02 15:04"))
+	if digestMode == domain.SYSTEM_NOTIFICATION_DIGEST_MODE_DAILY {
+		period = before.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
+	for ruleId, ruleItems := range itemsByRule {
+		if err := u.sendDigest(ctx, ruleId, period, ruleItems); err != nil {
+			log.Errorf(ctx, "Failed to send digest of systemNotificationRule %s. err : %v", ruleId, err)
+		}
+	}
+}
+
+func (u *SystemNotificationDigestUsecase) sendDigest(ctx context.Context, ruleId uuid.UUID, period string, items []model.SystemNotificationDigestItem) error {
+	rule, err := u.ruleRepo.Get(ctx, ruleId)
+	if err != nil {
+		return err
+	}
+
+	users, err := getSystemNotificationRecipients(ctx, u.userRepo, rule.OrganizationId, rule)
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	// stack, alert 이름 별로 묶어 발생 횟수를 요약한다.
+	groups := make(map[string]*mail.DigestGroup)
+	for _, item := range items {
+		systemNotification := item.SystemNotification
+		key := systemNotification.Cluster.Name + "/" + systemNotification.Name
+		firedAt := systemNotification.CreatedAt
+		if systemNotification.LastFiredAt != nil {
+			firedAt = *systemNotification.LastFiredAt
+		}
+
+		group, ok := groups[key]
+		if !ok {
+			group = &mail.DigestGroup{
+				StackName: systemNotification.Cluster.Name,
+				AlertName: systemNotification.Name,
+				Severity:  systemNotification.Severity,
+			}
+			groups[key] = group
+		}
+		// 같은 알림이 다시 발생하면 행을 새로 만들지 않고 FireCount 를 올리므로 횟수를 더한다.
+		group.Count += max(systemNotification.FireCount, 1)
+		if firedAt.After(group.LastFiredAt) {
+			group.LastFiredAt = firedAt
+		}
+	}
+
+	out := make([]mail.DigestGroup, 0, len(groups))
+	for _, group := range groups {
+		out = append(out, *group)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].StackName != out[j].StackName {
+			return out[i].StackName < out[j].StackName
+		}
+		return out[i].AlertName < out[j].AlertName
+	})
+
+	// 사용자의 알림 설정에 맞는 항목만 요약해서 보낸다.
+	preferences := getNotificationPreferences(ctx, u.notificationPreferenceRepo, users)
+	now := time.Now()
+
+	title := fmt.Sprintf("[TKS] 시스템 알림 요약 - %s", rule.Name)
+	return enqueueSystemNotificationMails(ctx, u.mailOutboxRepo, rule.OrganizationId, domain.MAIL_CATEGORY_SYSTEM_NOTIFICATION_DIGEST, rule.ID, users,
+		func(user model.User, unsubscribeUrl string) (*mail.MessageInfo, error) {
+			userGroups := out
+			if preference, ok := preferences[user.ID]; ok {
+				userGroups = make([]mail.DigestGroup, 0)
+				for _, group := range out {
+					if preference.Accepts(domain.NOTIFICATION_PREFERENCE_CHANNEL_EMAIL, rule.ID, group.Severity, now) {
+						userGroups = append(userGroups, group)
+					}
+				}
+			}
+			if len(userGroups) == 0 {
+				return nil, nil
+			}
+			return mail.MakeSystemNotificationDigestMessage(ctx, rule.OrganizationId, title, period, userGroups, unsubscribeUrl, []string{user.Email})
+		})
+}
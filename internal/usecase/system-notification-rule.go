@@ -31,6 +31,8 @@ type SystemNotificationRuleUsecase struct {
 	organizationRepo               repository.IOrganizationRepository
 	userRepo                       repository.IUserRepository
 	systemNotificationTemplateRepo repository.ISystemNotificationTemplateRepository
+	notificationChannelRepo        repository.INotificationChannelRepository
+	escalationPolicyRepo           repository.IEscalationPolicyRepository
 }
 
 func NewSystemNotificationRuleUsecase(r repository.Repository) ISystemNotificationRuleUsecase {
@@ -39,6 +41,8 @@ func NewSystemNotificationRuleUsecase(r repository.Repository) ISystemNotificati
 		organizationRepo:               r.Organization,
 		userRepo:                       r.User,
 		systemNotificationTemplateRepo: r.SystemNotificationTemplate,
+		notificationChannelRepo:        r.NotificationChannel,
+		escalationPolicyRepo:           r.EscalationPolicy,
 	}
 }
 
@@ -67,6 +71,17 @@ func (u *SystemNotificationRuleUsecase) Create(ctx context.Context, dto model.Sy
 		}
 	}
 
+	// NotificationChannels
+	dto.NotificationChannels, err = u.getNotificationChannels(ctx, dto.OrganizationId, dto.NotificationChannelIds)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// EscalationPolicy
+	if err = u.checkEscalationPolicy(ctx, dto.OrganizationId, dto.EscalationPolicyId); err != nil {
+		return uuid.Nil, err
+	}
+
 	// Make parameters
 	dto.SystemNotificationCondition.Parameter = []byte(helper.ModelToJson(dto.SystemNotificationCondition.Parameters))
 
@@ -96,6 +111,17 @@ func (u *SystemNotificationRuleUsecase) Update(ctx context.Context, dto model.Sy
 		}
 	}
 
+	// NotificationChannels
+	dto.NotificationChannels, err = u.getNotificationChannels(ctx, dto.OrganizationId, dto.NotificationChannelIds)
+	if err != nil {
+		return err
+	}
+
+	// EscalationPolicy
+	if err = u.checkEscalationPolicy(ctx, dto.OrganizationId, dto.EscalationPolicyId); err != nil {
+		return err
+	}
+
 	// Make parameters
 	dto.SystemNotificationCondition.Parameter = []byte(helper.ModelToJson(dto.SystemNotificationCondition.Parameters))
 	dto.SystemNotificationCondition.ID = rule.SystemNotificationCondition.ID
@@ -113,6 +139,33 @@ func (u *SystemNotificationRuleUsecase) Update(ctx context.Context, dto model.Sy
 	return nil
 }
 
+func (u *SystemNotificationRuleUsecase) getNotificationChannels(ctx context.Context, organizationId string, strIds []string) (out []model.NotificationChannel, err error) {
+	out = make([]model.NotificationChannel, 0)
+	for _, strId := range strIds {
+		notificationChannelId, err := uuid.Parse(strId)
+		if err != nil {
+			return nil, httpErrors.NewBadRequestError(err, "C_INVALID_NOTIFICATION_CHANNEL_ID", "")
+		}
+		channel, err := u.notificationChannelRepo.Get(ctx, notificationChannelId)
+		if err != nil || channel.OrganizationId != organizationId {
+			return nil, httpErrors.NewBadRequestError(fmt.Errorf("invalid notificationChannelId %s", strId), "C_INVALID_NOTIFICATION_CHANNEL_ID", "")
+		}
+		out = append(out, channel)
+	}
+	return out, nil
+}
+
+func (u *SystemNotificationRuleUsecase) checkEscalationPolicy(ctx context.Context, organizationId string, escalationPolicyId *uuid.UUID) error {
+	if escalationPolicyId == nil {
+		return nil
+	}
+	policy, err := u.escalationPolicyRepo.Get(ctx, *escalationPolicyId)
+	if err != nil || policy.OrganizationId != organizationId {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invalid escalationPolicyId %s", escalationPolicyId), "C_INVALID_ESCALATION_POLICY_ID", "")
+	}
+	return nil
+}
+
 func (u *SystemNotificationRuleUsecase) Get(ctx context.Context, systemNotificationRuleId uuid.UUID) (res model.SystemNotificationRule, err error) {
 	res, err = u.repo.Get(ctx, systemNotificationRuleId)
 	if err != nil {
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ISystemNotificationSilenceUsecase interface {
+	Get(ctx context.Context, organizationId string, systemNotificationSilenceId uuid.UUID) (model.SystemNotificationSilence, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotificationSilence, error)
+	Create(ctx context.Context, dto model.SystemNotificationSilence) (systemNotificationSilenceId uuid.UUID, err error)
+	Update(ctx context.Context, dto model.SystemNotificationSilence) error
+	Delete(ctx context.Context, organizationId string, systemNotificationSilenceId uuid.UUID) (model.SystemNotificationSilence, error)
+}
+
+type SystemNotificationSilenceUsecase struct {
+	repo                       repository.ISystemNotificationSilenceRepository
+	clusterRepo                repository.IClusterRepository
+	systemNotificationRuleRepo repository.ISystemNotificationRuleRepository
+}
+
+func NewSystemNotificationSilenceUsecase(r repository.Repository) ISystemNotificationSilenceUsecase {
+	return &SystemNotificationSilenceUsecase{
+		repo:                       r.SystemNotificationSilence,
+		clusterRepo:                r.Cluster,
+		systemNotificationRuleRepo: r.SystemNotificationRule,
+	}
+}
+
+func (u *SystemNotificationSilenceUsecase) Get(ctx context.Context, organizationId string, systemNotificationSilenceId uuid.UUID) (out model.SystemNotificationSilence, err error) {
+	out, err = u.repo.Get(ctx, systemNotificationSilenceId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return out, httpErrors.NewNotFoundError(err, "SNS_NOT_EXISTED_SILENCE", "")
+		}
+		return out, err
+	}
+	if out.OrganizationId != organizationId {
+		return model.SystemNotificationSilence{}, httpErrors.NewNotFoundError(fmt.Errorf("not found silence"), "SNS_NOT_EXISTED_SILENCE", "")
+	}
+	return
+}
+
+func (u *SystemNotificationSilenceUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotificationSilence, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *SystemNotificationSilenceUsecase) Create(ctx context.Context, dto model.SystemNotificationSilence) (systemNotificationSilenceId uuid.UUID, err error) {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.CreatorId = &userId
+	dto.UpdatorId = &userId
+
+	if err = u.validate(ctx, dto); err != nil {
+		return uuid.Nil, err
+	}
+
+	return u.repo.Create(ctx, dto)
+}
+
+func (u *SystemNotificationSilenceUsecase) Update(ctx context.Context, dto model.SystemNotificationSilence) error {
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
+	}
+	userId := user.GetUserId()
+	dto.UpdatorId = &userId
+
+	if _, err := u.Get(ctx, dto.OrganizationId, dto.ID); err != nil {
+		return err
+	}
+
+	if err := u.validate(ctx, dto); err != nil {
+		return err
+	}
+
+	return u.repo.Update(ctx, dto)
+}
+
+func (u *SystemNotificationSilenceUsecase) Delete(ctx context.Context, organizationId string, systemNotificationSilenceId uuid.UUID) (out model.SystemNotificationSilence, err error) {
+	out, err = u.Get(ctx, organizationId, systemNotificationSilenceId)
+	if err != nil {
+		return out, err
+	}
+	if err = u.repo.Delete(ctx, systemNotificationSilenceId); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+func (u *SystemNotificationSilenceUsecase) validate(ctx context.Context, dto model.SystemNotificationSilence) error {
+	if !dto.EndsAt.After(dto.StartsAt) || !dto.EndsAt.After(time.Now()) {
+		return httpErrors.NewBadRequestError(fmt.Errorf("invalid silence period"), "SNS_INVALID_PERIOD", "")
+	}
+
+	if dto.ClusterId != "" {
+		cluster, err := u.clusterRepo.Get(ctx, domain.ClusterId(dto.ClusterId))
+		if err != nil || cluster.OrganizationId != dto.OrganizationId {
+			return httpErrors.NewBadRequestError(fmt.Errorf("invalid clusterId %s", dto.ClusterId), "C_INVALID_CLUSTER_ID", "")
+		}
+	}
+
+	if dto.SystemNotificationRuleId != nil {
+		rule, err := u.systemNotificationRuleRepo.Get(ctx, *dto.SystemNotificationRuleId)
+		if err != nil || rule.OrganizationId != dto.OrganizationId {
+			return httpErrors.NewBadRequestError(fmt.Errorf("invalid systemNotificationRuleId %s", dto.SystemNotificationRuleId), "C_INVALID_SYSTEM_NOTIFICATION_RULE_ID", "")
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -12,8 +14,10 @@ import (
 	"github.com/openinfradev/tks-api/internal/mail"
 	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
 	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/notifier"
 	"github.com/openinfradev/tks-api/internal/pagination"
 	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/internal/stream"
 	"github.com/openinfradev/tks-api/pkg/domain"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
 	"github.com/openinfradev/tks-api/pkg/log"
@@ -26,7 +30,7 @@ type ISystemNotificationUsecase interface {
 	GetByName(ctx context.Context, organizationId string, name string) (model.SystemNotification, error)
 	FetchSystemNotifications(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotification, error)
 	FetchPolicyNotifications(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.SystemNotification, error)
-	Create(ctx context.Context, dto domain.CreateSystemNotificationRequest) (err error)
+	Create(ctx context.Context, credential model.SystemNotificationCredential, dto domain.CreateSystemNotificationRequest) (err error)
 	Update(ctx context.Context, dto model.SystemNotification) error
 	Delete(ctx context.Context, dto model.SystemNotification) error
 
@@ -40,6 +44,12 @@ type SystemNotificationUsecase struct {
 	appGroupRepo               repository.IAppGroupRepository
 	systemNotificationRuleRepo repository.ISystemNotificationRuleRepository
 	userRepo                   repository.IUserRepository
+	notificationDeliveryRepo   repository.INotificationDeliveryRepository
+	mailOutboxRepo             repository.IMailOutboxRepository
+	escalationPolicyRepo       repository.IEscalationPolicyRepository
+	silenceRepo                repository.ISystemNotificationSilenceRepository
+	digestRepo                 repository.ISystemNotificationDigestRepository
+	notificationPreferenceRepo repository.INotificationPreferenceRepository
 }
 
 func NewSystemNotificationUsecase(r repository.Repository) ISystemNotificationUsecase {
@@ -50,10 +60,16 @@ func NewSystemNotificationUsecase(r repository.Repository) ISystemNotificationUs
 		organizationRepo:           r.Organization,
 		systemNotificationRuleRepo: r.SystemNotificationRule,
 		userRepo:                   r.User,
+		notificationDeliveryRepo:   r.NotificationDelivery,
+		mailOutboxRepo:             r.MailOutbox,
+		escalationPolicyRepo:       r.EscalationPolicy,
+		silenceRepo:                r.SystemNotificationSilence,
+		digestRepo:                 r.SystemNotificationDigest,
+		notificationPreferenceRepo: r.NotificationPreference,
 	}
 }
 
-func (u *SystemNotificationUsecase) Create(ctx context.Context, input domain.CreateSystemNotificationRequest) (err error) {
+func (u *SystemNotificationUsecase) Create(ctx context.Context, credential model.SystemNotificationCredential, input domain.CreateSystemNotificationRequest) (err error) {
 	if input.SystemNotifications == nil || len(input.SystemNotifications) == 0 {
 		return fmt.Errorf("No data found")
 	}
@@ -73,6 +89,12 @@ func (u *SystemNotificationUsecase) Create(ctx context.Context, input domain.Cre
 			continue
 		}
 
+		// credential 의 범위를 벗어나는 알림은 저장하지 않는다.
+		if organizationId != credential.OrganizationId || (credential.ClusterId != "" && clusterId != credential.ClusterId) {
+			log.Warnf(ctx, "Rejected systemNotification out of credential scope. credentialId [%s], clusterId [%s]", credential.ID, clusterId)
+			continue
+		}
+
 		organization, err := u.organizationRepo.Get(ctx, organizationId)
 		if err != nil {
 			log.Error(ctx, err)
@@ -111,11 +133,9 @@ func (u *SystemNotificationUsecase) Create(ctx context.Context, input domain.Cre
 		}
 
 		var systemNotificationRuleId *uuid.UUID
-		if systemNotification.Annotations.SystemNotificationRuleId != "" {
-			id, err := uuid.Parse(systemNotification.Annotations.SystemNotificationRuleId)
-			if err == nil {
-				systemNotificationRuleId = &id
-			}
+		rule, ok := u.getCredentialRule(ctx, credential, systemNotification.Annotations.SystemNotificationRuleId)
+		if ok {
+			systemNotificationRuleId = &rule.ID
 		}
 
 		dto := model.SystemNotification{
@@ -145,49 +165,85 @@ func (u *SystemNotificationUsecase) Create(ctx context.Context, input domain.Cre
 			}
 		}
 
-		_, err = u.repo.Create(ctx, dto)
-		if err != nil {
-			log.Error(ctx, "Failed to create systemNotification ", err)
+		// 동일한 알림이 반복 발생하면 열려있는 알림에 발생 횟수만 누적하고, resolved 이면 자동으로 종료한다.
+		dto.Fingerprint = makeSystemNotificationFingerprint(dto)
+		firedAt := time.Now()
+		if !systemNotification.StartsAt.IsZero() {
+			firedAt = systemNotification.StartsAt
+		}
+		dto.LastFiredAt = &firedAt
+
+		opened, err := u.repo.GetOpenedByFingerprint(ctx, organizationId, dto.Fingerprint)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(ctx, "Failed to get systemNotification by fingerprint ", err)
 			continue
 		}
+		existed := err == nil
 
-		if systemNotificationRuleId != nil {
-			rule, err := u.systemNotificationRuleRepo.Get(ctx, *systemNotificationRuleId)
+		if strings.EqualFold(systemNotification.Status, "resolved") {
+			if !existed {
+				continue
+			}
+			_, err = u.repo.CreateSystemNotificationAction(ctx, model.SystemNotificationAction{
+				SystemNotificationId: opened.ID,
+				Content:              "Resolved by alertmanager",
+				Status:               domain.SystemNotificationActionStatus_CLOSED,
+			})
 			if err != nil {
-				log.Error(ctx, "Failed to get systemNotificationRule ", err)
+				log.Error(ctx, "Failed to close systemNotification ", err)
 				continue
 			}
+			opened.Status = domain.SystemNotificationActionStatus_CLOSED
+			publishSystemNotification(ctx, domain.STREAM_EVENT_SYSTEM_NOTIFICATION_UPDATED, opened)
+			continue
+		}
 
-			if rule.SystemNotificationCondition.EnableEmail {
-				to := []string{}
-
-				// 아무것도 지정되어 있지 않다면, organization 전체 대상으로 발송한다.
-				if rule.TargetUsers == nil || len(rule.TargetUsers) == 0 {
-					users, err := u.userRepo.List(ctx, u.userRepo.OrganizationFilter(organizationId))
-					if err != nil || users == nil {
-						log.Error(ctx, "Failed to get users ", err)
-						continue
-					}
-					for _, user := range *users {
-						to = append(to, user.Email)
-					}
-				} else {
-					for _, user := range rule.TargetUsers {
-						to = append(to, user.Email)
-					}
-				}
+		if existed {
+			dto.ID = opened.ID
+			dto.SilenceId = opened.SilenceId
+			// 무음 기간이 끝났거나 무음이 삭제되었으면 무음을 해제하고, 처음 발생한 알림처럼 발송한다.
+			if opened.SilenceId != nil {
+				dto.SilenceId = u.findSilence(ctx, dto)
+			}
+			if err = u.repo.UpdateFired(ctx, dto); err != nil {
+				log.Error(ctx, "Failed to update systemNotification ", err)
+				continue
+			}
+			opened.FireCount++
+			if opened.SilenceId == nil {
+				publishSystemNotification(ctx, domain.STREAM_EVENT_SYSTEM_NOTIFICATION_UPDATED, opened)
+				continue
+			}
+			if dto.SilenceId != nil {
+				continue
+			}
+			log.Infof(ctx, "systemNotification [%s] is unsilenced from [%s]", dto.ID, opened.SilenceId)
+			dto.Status = opened.Status
+			dto.FireCount = opened.FireCount
+		} else {
+			// 무음 기간에 해당하는 알림은 기록만 하고 발송하지 않는다.
+			dto.SilenceId = u.findSilence(ctx, dto)
+
+			dto.ID, err = u.repo.Create(ctx, dto)
+			if err != nil {
+				log.Error(ctx, "Failed to create systemNotification ", err)
+				continue
+			}
 
-				message, err := mail.MakeSystemNotificationMessage(ctx, organizationId, systemNotification.Annotations.Message, systemNotification.Annotations.Description, to)
-				if err != nil {
-					log.Error(ctx, fmt.Sprintf("Failed to make email content. err : %s", err.Error()))
-					continue
-				}
-				mailer := mail.New(message)
-				if err := mailer.SendMail(ctx); err != nil {
-					log.Error(ctx, fmt.Sprintf("Failed to send email to %s. err : %s", to, err.Error()))
-					continue
-				}
+			if dto.SilenceId != nil {
+				log.Infof(ctx, "systemNotification [%s] is silenced by [%s]", dto.ID, dto.SilenceId)
+				continue
 			}
+
+			dto.Status = domain.SystemNotificationActionStatus_CREATED
+			dto.FireCount = 1
+		}
+
+		publishSystemNotification(ctx, domain.STREAM_EVENT_SYSTEM_NOTIFICATION_CREATED, dto)
+
+		if systemNotificationRuleId != nil {
+			u.deliver(ctx, rule, dto)
+			u.startEscalation(ctx, rule, dto)
 		}
 
 	}
@@ -195,6 +251,28 @@ func (u *SystemNotificationUsecase) Create(ctx context.Context, input domain.Cre
 	return nil
 }
 
+// getCredentialRule returns the rule of the annotation only if it is in the organization of the credential.
+// 알림의 내용은 외부에서 전달되므로 다른 조직의 규칙으로 발송하지 않는다.
+func (u *SystemNotificationUsecase) getCredentialRule(ctx context.Context, credential model.SystemNotificationCredential, ruleId string) (model.SystemNotificationRule, bool) {
+	if ruleId == "" {
+		return model.SystemNotificationRule{}, false
+	}
+	id, err := uuid.Parse(ruleId)
+	if err != nil {
+		return model.SystemNotificationRule{}, false
+	}
+	rule, err := u.systemNotificationRuleRepo.Get(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to get systemNotificationRule ", err)
+		return model.SystemNotificationRule{}, false
+	}
+	if rule.OrganizationId != credential.OrganizationId {
+		log.Warnf(ctx, "Rejected systemNotificationRule [%s] out of credential scope. credentialId [%s]", ruleId, credential.ID)
+		return model.SystemNotificationRule{}, false
+	}
+	return rule, true
+}
+
 func (u *SystemNotificationUsecase) Update(ctx context.Context, dto model.SystemNotification) error {
 	return nil
 }
@@ -294,7 +372,7 @@ func (u *SystemNotificationUsecase) CreateSystemNotificationAction(ctx context.C
 		return uuid.Nil, httpErrors.NewUnauthorizedError(fmt.Errorf("Invalid token"), "A_INVALID_TOKEN", "")
 	}
 
-	_, err = u.repo.Get(ctx, dto.SystemNotificationId)
+	systemNotification, err := u.repo.Get(ctx, dto.SystemNotificationId)
 	if err != nil {
 		return uuid.Nil, httpErrors.NewBadRequestError(fmt.Errorf("Not found systemNotification"), "EV_NOT_FOUND_EVENT", "")
 	}
@@ -309,6 +387,9 @@ func (u *SystemNotificationUsecase) CreateSystemNotificationAction(ctx context.C
 	}
 	log.Info(ctx, "newly created systemNotificationActionId:", systemNotificationActionId)
 
+	systemNotification.Status = dto.Status
+	publishSystemNotification(ctx, domain.STREAM_EVENT_SYSTEM_NOTIFICATION_UPDATED, systemNotification)
+
 	return
 }
 
@@ -333,19 +414,28 @@ func (u *SystemNotificationUsecase) makeAdditionalInfo(systemNotification *model
 	//systemNotification.Status = model.SystemNotificationActionStatus_CREATED
 
 	if len(systemNotification.SystemNotificationActions) > 0 {
-		systemNotification.TakedAt = &systemNotification.SystemNotificationActions[0].CreatedAt
-		for _, action := range systemNotification.SystemNotificationActions {
+		for i := range systemNotification.SystemNotificationActions {
+			action := &systemNotification.SystemNotificationActions[i]
 			if action.Status == domain.SystemNotificationActionStatus_CLOSED {
 				systemNotification.ClosedAt = &action.CreatedAt
 				systemNotification.ProcessingSec = int((action.CreatedAt).Sub(systemNotification.CreatedAt).Seconds())
 			}
-		}
 
-		systemNotification.LastTaker = systemNotification.SystemNotificationActions[len(systemNotification.SystemNotificationActions)-1].Taker
-		systemNotification.TakedSec = int((systemNotification.SystemNotificationActions[0].CreatedAt).Sub(systemNotification.CreatedAt).Seconds())
+			// 자동 종료처럼 처리자가 없는 action 은 담당자가 처리한 것으로 보지 않는다.
+			if action.TakerId == nil {
+				continue
+			}
+			if systemNotification.TakedAt == nil {
+				systemNotification.TakedAt = &action.CreatedAt
+				systemNotification.TakedSec = int((action.CreatedAt).Sub(systemNotification.CreatedAt).Seconds())
+			}
+			systemNotification.LastTaker = action.Taker
+		}
 		//systemNotification.Status = systemNotification.SystemNotificationActions[len(systemNotification.SystemNotificationActions)-1].Status
 	}
 
+	systemNotification.Silenced = systemNotification.SilenceId != nil
+
 	systemNotification.Read = false
 	for _, v := range systemNotification.Readers {
 		if v.ID == userId {
@@ -355,6 +445,172 @@ func (u *SystemNotificationUsecase) makeAdditionalInfo(systemNotification *model
 	}
 }
 
+func (u *SystemNotificationUsecase) findSilence(ctx context.Context, dto model.SystemNotification) *uuid.UUID {
+	silences, err := u.silenceRepo.FetchActive(ctx, dto.OrganizationId, time.Now())
+	if err != nil {
+		log.Error(ctx, "Failed to fetch systemNotificationSilences ", err)
+		return nil
+	}
+	now := time.Now()
+	for _, silence := range silences {
+		if silence.Mutes(dto, now) {
+			return &silence.ID
+		}
+	}
+	return nil
+}
+
+// deliver sends the systemNotification to email and notification channels of the rule, and records the results.
+func (u *SystemNotificationUsecase) deliver(ctx context.Context, rule model.SystemNotificationRule, dto model.SystemNotification) {
+	deliveries := make([]model.NotificationDelivery, 0)
+
+	if rule.SystemNotificationCondition.EnableEmail {
+		// critical 이 아닌 알림은 digest 설정에 따라 모아서 요약 메일로 발송한다.
+		if rule.SystemNotificationCondition.EmailDigestMode != "" && !strings.EqualFold(dto.Severity, "critical") {
+			_, err := u.digestRepo.Create(ctx, model.SystemNotificationDigestItem{
+				OrganizationId:           dto.OrganizationId,
+				SystemNotificationRuleId: rule.ID,
+				SystemNotificationId:     dto.ID,
+				DigestMode:               rule.SystemNotificationCondition.EmailDigestMode,
+			})
+			if err != nil {
+				log.Error(ctx, "Failed to create systemNotificationDigestItem ", err)
+			}
+		} else {
+			deliveries = append(deliveries, u.deliverToEmail(ctx, rule, dto))
+		}
+	}
+
+	message := &notifier.MessageInfo{
+		OrganizationId: dto.OrganizationId,
+		ClusterId:      dto.ClusterId.String(),
+		Name:           dto.Name,
+		Severity:       dto.Severity,
+		Node:           dto.Node,
+		Title:          dto.MessageTitle,
+		Content:        dto.MessageContent,
+		ActionProposal: dto.MessageActionProposal,
+		GrafanaUrl:     dto.GrafanaUrl,
+		Status:         "firing",
+	}
+	if dto.LastFiredAt != nil {
+		message.FiredAt = *dto.LastFiredAt
+	}
+	for _, channel := range rule.NotificationChannels {
+		if !channel.Enabled {
+			continue
+		}
+		deliveries = append(deliveries, queueToChannel(channel, message))
+	}
+
+	for _, delivery := range deliveries {
+		delivery.OrganizationId = dto.OrganizationId
+		delivery.SystemNotificationId = dto.ID
+		delivery.SystemNotificationRuleId = &rule.ID
+		if delivery.Status == domain.NotificationDeliveryStatus_FAILED {
+			log.Errorf(ctx, "Failed to deliver systemNotification [%s] to %s [%s]. err : %s", dto.ID, delivery.ChannelType, delivery.Target, delivery.ErrorMessage)
+		}
+		if _, err := u.notificationDeliveryRepo.Create(ctx, delivery); err != nil {
+			log.Error(ctx, "Failed to create notificationDelivery ", err)
+		}
+	}
+}
+
+// startEscalation schedules the first tier of the escalation policy for a critical systemNotification.
+// The escalation stops when the systemNotification is taken or closed.
+func (u *SystemNotificationUsecase) startEscalation(ctx context.Context, rule model.SystemNotificationRule, dto model.SystemNotification) {
+	if rule.EscalationPolicyId == nil || !strings.EqualFold(dto.Severity, "critical") {
+		return
+	}
+
+	policy, err := u.escalationPolicyRepo.Get(ctx, *rule.EscalationPolicyId)
+	if err != nil || len(policy.Tiers) == 0 {
+		log.Error(ctx, "Failed to get escalationPolicy ", err)
+		return
+	}
+
+	next := time.Now().Add(time.Duration(policy.Tiers[0].DelayMinutes) * time.Minute)
+	if err = u.repo.UpdateEscalation(ctx, dto.ID, 0, &next); err != nil {
+		log.Error(ctx, "Failed to start escalation ", err)
+	}
+}
+
+func (u *SystemNotificationUsecase) deliverToEmail(ctx context.Context, rule model.SystemNotificationRule, dto model.SystemNotification) (out model.NotificationDelivery) {
+	out = model.NotificationDelivery{
+		ChannelType: domain.NOTIFICATION_CHANNEL_TYPE_EMAIL,
+		Status:      domain.NotificationDeliveryStatus_FAILED,
+	}
+
+	users, err := getSystemNotificationRecipients(ctx, u.userRepo, dto.OrganizationId, rule)
+	if err != nil {
+		out.ErrorMessage = fmt.Sprintf("Failed to get users. err : %v", err)
+		return out
+	}
+	users = filterNotificationRecipients(ctx, u.notificationPreferenceRepo, users, rule.ID, dto.Severity)
+	out.Target = joinUserEmails(users)
+
+	// 실제 발송은 mail outbox 에서 재시도와 함께 처리된다.
+	err = enqueueSystemNotificationMails(ctx, u.mailOutboxRepo, dto.OrganizationId, domain.MAIL_CATEGORY_SYSTEM_NOTIFICATION, rule.ID, users,
+		func(user model.User, unsubscribeUrl string) (*mail.MessageInfo, error) {
+			return mail.MakeSystemNotificationMessage(ctx, dto.OrganizationId, dto.MessageTitle, dto.MessageContent, unsubscribeUrl, []string{user.Email})
+		})
+	if err != nil {
+		out.ErrorMessage = fmt.Sprintf("Failed to enqueue email. err : %s", err.Error())
+		return out
+	}
+
+	out.Status = domain.NotificationDeliveryStatus_SUCCESS
+	return out
+}
+
+func getSystemNotificationRecipients(ctx context.Context, userRepo repository.IUserRepository, organizationId string, rule model.SystemNotificationRule) ([]model.User, error) {
+	// 아무것도 지정되어 있지 않다면, organization 전체 대상으로 발송한다.
+	if rule.TargetUsers == nil || len(rule.TargetUsers) == 0 {
+		users, err := userRepo.List(ctx, userRepo.OrganizationFilter(organizationId))
+		if err != nil || users == nil {
+			return nil, fmt.Errorf("no users. %v", err)
+		}
+		return *users, nil
+	}
+	return rule.TargetUsers, nil
+}
+
+func joinUserEmails(users []model.User) string {
+	to := make([]string, 0)
+	for _, user := range users {
+		to = append(to, user.Email)
+	}
+	return strings.Join(to, ",")
+}
+
+func publishSystemNotification(ctx context.Context, eventType string, systemNotification model.SystemNotification) {
+	stream.Publish(ctx, stream.Event{
+		Type:           eventType,
+		OrganizationId: systemNotification.OrganizationId,
+		Data: domain.StreamSystemNotification{
+			ID:               systemNotification.ID.String(),
+			NotificationType: systemNotification.NotificationType,
+			Name:             systemNotification.Name,
+			ClusterId:        systemNotification.ClusterId.String(),
+			Severity:         systemNotification.Severity,
+			MessageTitle:     systemNotification.MessageTitle,
+			Status:           systemNotification.Status.String(),
+			FireCount:        systemNotification.FireCount,
+		},
+	})
+}
+
+// makeSystemNotificationFingerprint identifies the same alert by alertname, cluster, node and rule (and policy name for policy notifications).
+func makeSystemNotificationFingerprint(dto model.SystemNotification) string {
+	ruleId := ""
+	if dto.SystemNotificationRuleId != nil {
+		ruleId = dto.SystemNotificationRuleId.String()
+	}
+	key := strings.Join([]string{dto.Name, dto.ClusterId.String(), dto.Node, ruleId, dto.PolicyName}, "|")
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
 func (u *SystemNotificationUsecase) makeGrafanaUrl(ctx context.Context, primaryCluster model.Cluster, systemNotification domain.SystemNotificationRequest, clusterId domain.ClusterId) (url string) {
 	primaryGrafanaEndpoint := ""
 	appGroups, err := u.appGroupRepo.Fetch(ctx, primaryCluster.ID, nil)
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"gorm.io/gorm"
+)
+
+type fakeSystemNotificationRuleRepository struct {
+	repository.ISystemNotificationRuleRepository
+	rules []model.SystemNotificationRule
+}
+
+func (r *fakeSystemNotificationRuleRepository) Get(ctx context.Context, systemNotificationRuleId uuid.UUID) (model.SystemNotificationRule, error) {
+	for _, rule := range r.rules {
+		if rule.ID == systemNotificationRuleId {
+			return rule, nil
+		}
+	}
+	return model.SystemNotificationRule{}, gorm.ErrRecordNotFound
+}
+
+func TestGetCredentialRule(t *testing.T) {
+	org1Rule := model.SystemNotificationRule{ID: uuid.New(), OrganizationId: "org1"}
+	org2Rule := model.SystemNotificationRule{ID: uuid.New(), OrganizationId: "org2"}
+	u := &SystemNotificationUsecase{
+		systemNotificationRuleRepo: &fakeSystemNotificationRuleRepository{rules: []model.SystemNotificationRule{org1Rule, org2Rule}},
+	}
+	credential := model.SystemNotificationCredential{ID: uuid.New(), OrganizationId: "org1"}
+
+	tests := []struct {
+		name   string
+		ruleId string
+		want   bool
+	}{
+		{"rule of credential organization", org1Rule.ID.String(), true},
+		{"rule of other organization", org2Rule.ID.String(), false},
+		{"unknown rule", uuid.New().String(), false},
+		{"invalid id", "rule", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := u.getCredentialRule(context.Background(), credential, tt.ruleId)
+			if ok != tt.want {
+				t.Errorf("getCredentialRule() = %v, want %v", ok, tt.want)
+			}
+			if ok && rule.OrganizationId != credential.OrganizationId {
+				t.Errorf("getCredentialRule() returned the rule of organization %s", rule.OrganizationId)
+			}
+		})
+	}
+}
@@ -1,23 +1,44 @@
 package usecase
 
 type Usecase struct {
-	Auth                       IAuthUsecase
-	User                       IUserUsecase
-	Cluster                    IClusterUsecase
-	Organization               IOrganizationUsecase
-	AppGroup                   IAppGroupUsecase
-	AppServeApp                IAppServeAppUsecase
-	CloudAccount               ICloudAccountUsecase
-	StackTemplate              IStackTemplateUsecase
-	Dashboard                  IDashboardUsecase
-	SystemNotification         ISystemNotificationUsecase
-	SystemNotificationTemplate ISystemNotificationTemplateUsecase
-	SystemNotificationRule     ISystemNotificationRuleUsecase
-	Stack                      IStackUsecase
-	Project                    IProjectUsecase
-	Role                       IRoleUsecase
-	Permission                 IPermissionUsecase
-	Audit                      IAuditUsecase
-	PolicyTemplate             IPolicyTemplateUsecase
-	Policy                     IPolicyUsecase
+	Auth                         IAuthUsecase
+	User                         IUserUsecase
+	Cluster                      IClusterUsecase
+	Organization                 IOrganizationUsecase
+	AppGroup                     IAppGroupUsecase
+	AppServeApp                  IAppServeAppUsecase
+	CloudAccount                 ICloudAccountUsecase
+	StackTemplate                IStackTemplateUsecase
+	Dashboard                    IDashboardUsecase
+	SystemNotification           ISystemNotificationUsecase
+	SystemNotificationTemplate   ISystemNotificationTemplateUsecase
+	SystemNotificationRule       ISystemNotificationRuleUsecase
+	SystemNotificationCredential ISystemNotificationCredentialUsecase
+	NotificationChannel          INotificationChannelUsecase
+	MailOutbox                   IMailOutboxUsecase
+	EscalationPolicy             IEscalationPolicyUsecase
+	OnCallSchedule               IOnCallScheduleUsecase
+	SystemNotificationSilence    ISystemNotificationSilenceUsecase
+	SystemNotificationDigest     ISystemNotificationDigestUsecase
+	NotificationPreference       INotificationPreferenceUsecase
+	ServiceAccount               IServiceAccountUsecase
+	ApiToken                     IApiTokenUsecase
+	SecurityPolicy               ISecurityPolicyUsecase
+	Retention                    IRetentionUsecase
+	SecondFactor                 ISecondFactorUsecase
+	IdentityProvider             IIdentityProviderUsecase
+	Scim                         IScimUsecase
+	Session                      ISessionUsecase
+	Invitation                   IInvitationUsecase
+	UserImport                   IUserImportUsecase
+	Impersonation                IImpersonationUsecase
+	Stream                       IStreamUsecase
+	Stack                        IStackUsecase
+	Project                      IProjectUsecase
+	Role                         IRoleUsecase
+	Permission                   IPermissionUsecase
+	Audit                        IAuditUsecase
+	AuditForward                 IAuditForwardUsecase
+	PolicyTemplate               IPolicyTemplateUsecase
+	Policy                       IPolicyUsecase
 }
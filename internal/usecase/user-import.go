@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/openinfradev/tks-api/internal/middleware/auth/request"
+	"github.com/openinfradev/tks-api/internal/model"
+	"github.com/openinfradev/tks-api/internal/pagination"
+	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/httpErrors"
+	"github.com/openinfradev/tks-api/pkg/log"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	userImportPollInterval = 10 * time.Second
+	userImportLease        = 5 * time.Minute
+	userImportMaxRows      = 1000
+)
+
+type IUserImportUsecase interface {
+	Import(ctx context.Context, organizationId string, rows []model.UserImportRow) (model.UserImportJob, error)
+	Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.UserImportJob, error)
+	Get(ctx context.Context, organizationId string, jobId uuid.UUID) (model.UserImportJob, error)
+	Export(ctx context.Context, organizationId string) ([]model.UserExport, error)
+	Run(ctx context.Context)
+}
+
+type UserImportUsecase struct {
+	repo              repository.IUserImportRepository
+	roleRepository    repository.IRoleRepository
+	projectRepository repository.IProjectRepository
+	clusterRepository repository.IClusterRepository
+	userUsecase       IUserUsecase
+	permissionUsecase IPermissionUsecase
+	invitationUsecase IInvitationUsecase
+}
+
+func NewUserImportUsecase(r repository.Repository, userUsecase IUserUsecase, permissionUsecase IPermissionUsecase, invitationUsecase IInvitationUsecase) IUserImportUsecase {
+	return &UserImportUsecase{
+		repo:              r.UserImport,
+		roleRepository:    r.Role,
+		projectRepository: r.Project,
+		clusterRepository: r.Cluster,
+		userUsecase:       userUsecase,
+		permissionUsecase: permissionUsecase,
+		invitationUsecase: invitationUsecase,
+	}
+}
+
+// Import validates every row and stores the job to create the users in the background.
+// The job is rejected without creating any user if a row is invalid.
+func (u *UserImportUsecase) Import(ctx context.Context, organizationId string, rows []model.UserImportRow) (model.UserImportJob, error) {
+	if len(rows) == 0 {
+		return model.UserImportJob{}, httpErrors.NewBadRequestError(fmt.Errorf("no rows to import"), "UI_EMPTY_ROWS", "")
+	}
+	if len(rows) > userImportMaxRows {
+		return model.UserImportJob{}, httpErrors.NewBadRequestError(fmt.Errorf("too many rows. %d > %d", len(rows), userImportMaxRows), "UI_TOO_MANY_ROWS", "")
+	}
+
+	if err := u.validate(ctx, organizationId, rows); err != nil {
+		return model.UserImportJob{}, err
+	}
+
+	job := model.UserImportJob{
+		OrganizationId: organizationId,
+		Status:         model.UserImportStatusPending,
+		Rows:           rows,
+		TotalCount:     len(rows),
+	}
+	if user, ok := request.UserFrom(ctx); ok {
+		creatorId := user.GetUserId()
+		job.CreatorId = &creatorId
+	}
+	for _, row := range rows {
+		if row.Status == model.UserImportRowStatusInvalid {
+			job.FailedCount++
+		}
+	}
+	if job.FailedCount > 0 {
+		now := time.Now()
+		job.Status = model.UserImportStatusRejected
+		job.CompletedAt = &now
+	}
+
+	jobId, err := u.repo.Create(ctx, job)
+	if err != nil {
+		return model.UserImportJob{}, httpErrors.NewInternalServerError(err, "", "")
+	}
+	return u.Get(ctx, organizationId, jobId)
+}
+
+func (u *UserImportUsecase) Fetch(ctx context.Context, organizationId string, pg *pagination.Pagination) ([]model.UserImportJob, error) {
+	return u.repo.Fetch(ctx, organizationId, pg)
+}
+
+func (u *UserImportUsecase) Get(ctx context.Context, organizationId string, jobId uuid.UUID) (model.UserImportJob, error) {
+	job, err := u.repo.Get(ctx, organizationId, jobId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.UserImportJob{}, httpErrors.NewNotFoundError(err, "UI_NOT_EXISTED_USER_IMPORT", "")
+		}
+		return model.UserImportJob{}, err
+	}
+	return job, nil
+}
+
+// Export returns every user of the organization with the project memberships, ordered by the account id.
+func (u *UserImportUsecase) Export(ctx context.Context, organizationId string) ([]model.UserExport, error) {
+	users, err := u.userUsecase.List(ctx, organizationId)
+	if err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return []model.UserExport{}, nil
+		}
+		return nil, err
+	}
+
+	projects, err := u.projectRepository.GetProjectsWithMembers(ctx, organizationId)
+	if err != nil {
+		return nil, httpErrors.NewInternalServerError(err, "", "")
+	}
+	memberships := make(map[uuid.UUID][]model.UserExportProject)
+	for _, project := range projects {
+		for _, member := range project.ProjectMembers {
+			membership := model.UserExportProject{
+				ProjectId:   project.ID,
+				ProjectName: project.Name,
+			}
+			if member.ProjectRole != nil {
+				membership.ProjectRoleName = member.ProjectRole.Name
+			}
+			memberships[member.ProjectUserId] = append(memberships[member.ProjectUserId], membership)
+		}
+	}
+
+	out := make([]model.UserExport, len(*users))
+	for i, user := range *users {
+		out[i] = model.UserExport{
+			User:     user,
+			Projects: memberships[user.ID],
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].User.AccountId < out[j].User.AccountId
+	})
+	return out, nil
+}
+
+// Run creates the users of the pending jobs until ctx is done.
+func (u *UserImportUsecase) Run(ctx context.Context) {
+	runWorker(ctx, userImportPollInterval, u.runDue)
+}
+
+func (u *UserImportUsecase) runDue(ctx context.Context) {
+	jobs, err := u.repo.ClaimDue(ctx, userImportLease)
+	if err != nil {
+		log.Errorf(ctx, "failed to claim user import. %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		u.run(ctx, job)
+	}
+}
+
+func (u *UserImportUsecase) run(ctx context.Context, job model.UserImportJob) {
+	clusters, err := u.clusterRepository.FetchByOrganizationId(ctx, job.OrganizationId, uuid.Nil, nil)
+	if err != nil {
+		// lease 가 지나면 다시 실행된다.
+		log.Errorf(ctx, "failed to fetch clusters of user import [%s]. %v", job.ID, err)
+		return
+	}
+	stackIds := make([]string, len(clusters))
+	for i, cluster := range clusters {
+		stackIds[i] = cluster.ID.String()
+	}
+
+	for i := range job.Rows {
+		row := &job.Rows[i]
+		if row.Status != model.UserImportRowStatusPending {
+			continue
+		}
+
+		u.createUser(ctx, job.OrganizationId, stackIds, row)
+		if err := u.repo.UpdateRow(ctx, *row); err != nil {
+			log.Errorf(ctx, "failed to update row %d of user import [%s]. %v", row.Line, job.ID, err)
+		}
+
+		// 처리 중에는 다른 worker 가 가져가지 않도록 lease 를 연장한다.
+		job.NextAttemptAt = time.Now().Add(userImportLease)
+		countUserImportRows(&job)
+		if err := u.repo.UpdateResult(ctx, job); err != nil {
+			log.Errorf(ctx, "failed to update user import [%s]. %v", job.ID, err)
+		}
+	}
+
+	now := time.Now()
+	job.Status = model.UserImportStatusCompleted
+	job.CompletedAt = &now
+	countUserImportRows(&job)
+	if err := u.repo.UpdateResult(ctx, job); err != nil {
+		log.Errorf(ctx, "failed to complete user import [%s]. %v", job.ID, err)
+		return
+	}
+	log.Infof(ctx, "user import [%s] is completed. created : %d, failed : %d", job.ID, job.CreatedCount, job.FailedCount)
+}
+
+// createUser creates the user of the row without a password and invites the user to set the password,
+// and records the result to the row.
+func (u *UserImportUsecase) createUser(ctx context.Context, organizationId string, stackIds []string, row *model.UserImportRow) {
+	fail := func(code string, detail string) {
+		row.Status = model.UserImportRowStatusFailed
+		row.Message = userImportMessage(code, detail)
+	}
+
+	// 검증 이후에 생성되거나 삭제된 사용자, 역할이 있을 수 있으므로 다시 확인한다.
+	if _, err := u.userUsecase.GetByAccountId(ctx, row.AccountId, organizationId); err == nil {
+		fail("UI_EXISTED_ACCOUNT_ID", row.AccountId)
+		return
+	}
+	roles, missing, err := u.getRoles(ctx, organizationId, row.Roles, nil)
+	if err != nil {
+		log.Errorf(ctx, "failed to get roles of %s. %v", row.AccountId, err)
+		fail("UI_FAILED_TO_CREATE_USER", "")
+		return
+	}
+	if missing != "" {
+		fail("UI_NOT_EXISTED_ROLE", missing)
+		return
+	}
+
+	created, err := u.userUsecase.Create(ctx, &model.User{
+		AccountId:    row.AccountId,
+		Name:         row.Name,
+		Email:        row.Email,
+		Department:   row.Department,
+		Description:  row.Description,
+		Roles:        roles,
+		Organization: model.Organization{ID: organizationId},
+	})
+	if err != nil {
+		log.Errorf(ctx, "failed to create imported user %s. %v", row.AccountId, err)
+		fail("UI_FAILED_TO_CREATE_USER", "")
+		return
+	}
+	row.Status = model.UserImportRowStatusCreated
+	row.Message = ""
+	row.UserId = &created.ID
+
+	// 사용자는 생성되었으므로 초대에 실패하면 기록만 하고 관리자가 비밀번호를 초기화한다.
+	if err = u.invitationUsecase.InviteUser(ctx, *created, true); err != nil {
+		log.Errorf(ctx, "failed to invite imported user %s. %v", row.AccountId, err)
+		row.Message = userImportMessage("UI_FAILED_TO_INVITE_USER", "")
+	}
+	if err = u.syncClusterAdminPermission(ctx, organizationId, stackIds, *created); err != nil {
+		log.Errorf(ctx, "failed to sync cluster admin permission of imported user %s. %v", row.AccountId, err)
+	}
+}
+
+// validate marks the invalid rows. The rows already marked invalid by the format are not checked again.
+func (u *UserImportUsecase) validate(ctx context.Context, organizationId string, rows []model.UserImportRow) error {
+	accountIds := make(map[string]int)
+	emails := make(map[string]int)
+	roleCache := make(map[string]*model.Role)
+
+	for i := range rows {
+		row := &rows[i]
+		row.Email = strings.ToLower(strings.TrimSpace(row.Email))
+		if row.Status == model.UserImportRowStatusInvalid {
+			continue
+		}
+		row.Status = model.UserImportRowStatusPending
+
+		invalid := func(code string, detail string) {
+			row.Status = model.UserImportRowStatusInvalid
+			row.Message = userImportMessage(code, detail)
+		}
+
+		if line, ok := accountIds[row.AccountId]; ok {
+			invalid("UI_DUPLICATED_ACCOUNT_ID", fmt.Sprintf("line %d", line))
+			continue
+		}
+		accountIds[row.AccountId] = row.Line
+		if line, ok := emails[row.Email]; ok {
+			invalid("UI_DUPLICATED_EMAIL", fmt.Sprintf("line %d", line))
+			continue
+		}
+		emails[row.Email] = row.Line
+
+		if _, err := u.userUsecase.GetByAccountId(ctx, row.AccountId, organizationId); err == nil {
+			invalid("UI_EXISTED_ACCOUNT_ID", row.AccountId)
+			continue
+		} else if _, status := httpErrors.ErrorResponse(err); status != http.StatusNotFound {
+			return err
+		}
+		if _, err := u.userUsecase.GetByEmail(ctx, row.Email, organizationId); err == nil {
+			invalid("UI_EXISTED_EMAIL", row.Email)
+			continue
+		} else if _, status := httpErrors.ErrorResponse(err); status != http.StatusNotFound {
+			return err
+		}
+
+		_, missing, err := u.getRoles(ctx, organizationId, row.Roles, roleCache)
+		if err != nil {
+			return httpErrors.NewInternalServerError(err, "", "")
+		}
+		if missing != "" {
+			invalid("UI_NOT_EXISTED_ROLE", missing)
+			continue
+		}
+	}
+	return nil
+}
+
+// getRoles returns the roles of the names. The first name not existing in the organization is returned as missing.
+func (u *UserImportUsecase) getRoles(ctx context.Context, organizationId string, names []string, cache map[string]*model.Role) (roles []model.Role, missing string, err error) {
+	for _, name := range names {
+		role, ok := cache[name]
+		if !ok {
+			if role, err = u.roleRepository.GetTksRoleByRoleName(ctx, organizationId, name); err != nil {
+				return nil, "", err
+			}
+			if cache != nil {
+				cache[name] = role
+			}
+		}
+		if role == nil {
+			return nil, name, nil
+		}
+		roles = append(roles, *role)
+	}
+	return roles, "", nil
+}
+
+// syncClusterAdminPermission gives the cluster-admin client roles of the stacks to the user by the permissions of the user's roles.
+func (u *UserImportUsecase) syncClusterAdminPermission(ctx context.Context, organizationId string, stackIds []string, user model.User) error {
+	var permissionSets []*model.PermissionSet
+	for _, role := range user.Roles {
+		permissionSet, err := u.permissionUsecase.GetPermissionSetByRoleId(ctx, role.ID)
+		if err != nil {
+			return err
+		}
+		permissionSets = append(permissionSets, permissionSet)
+	}
+	mergedPermissionSet := u.permissionUsecase.MergePermissionWithOrOperator(ctx, permissionSets...)
+
+	var targetPermission *model.Permission
+	for _, permission := range mergedPermissionSet.Stack.Children {
+		if permission.Key == model.MiddleClusterAccessControlKey {
+			targetPermission = permission
+		}
+	}
+	edgePermissions := model.GetEdgePermission(targetPermission, nil, nil)
+
+	roleNames := map[string]string{
+		model.OperationCreate: "cluster-admin-create",
+		model.OperationRead:   "cluster-admin-read",
+		model.OperationUpdate: "cluster-admin-update",
+		model.OperationDelete: "cluster-admin-delete",
+	}
+	for _, stackId := range stackIds {
+		for _, edgePermission := range edgePermissions {
+			roleName, ok := roleNames[edgePermission.Key]
+			if !ok {
+				continue
+			}
+			if err := u.permissionUsecase.SyncKeycloakWithClusterAdminPermission(ctx, organizationId,
+				stackId+"-k8s-api", user.ID.String(), roleName, *edgePermission.IsAllowed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func countUserImportRows(job *model.UserImportJob) {
+	job.CreatedCount = 0
+	job.FailedCount = 0
+	for _, row := range job.Rows {
+		switch row.Status {
+		case model.UserImportRowStatusCreated:
+			job.CreatedCount++
+		case model.UserImportRowStatusFailed, model.UserImportRowStatusInvalid:
+			job.FailedCount++
+		}
+	}
+}
+
+func userImportMessage(code string, detail string) string {
+	text := httpErrors.ErrorCode(code).GetText()
+	if detail == "" {
+		return text
+	}
+	return fmt.Sprintf("%s (%s)", text, detail)
+}
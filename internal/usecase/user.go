@@ -4,15 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Nerzal/gocloak/v13"
 	"github.com/google/uuid"
-	"github.com/openinfradev/tks-api/internal/helper"
 	"github.com/openinfradev/tks-api/internal/keycloak"
 	"github.com/openinfradev/tks-api/internal/mail"
 	"github.com/openinfradev/tks-api/internal/model"
 	"github.com/openinfradev/tks-api/internal/pagination"
 	"github.com/openinfradev/tks-api/internal/repository"
+	"github.com/openinfradev/tks-api/pkg/domain"
 	"github.com/openinfradev/tks-api/pkg/httpErrors"
 	"github.com/openinfradev/tks-api/pkg/log"
 	"github.com/pkg/errors"
@@ -29,7 +30,10 @@ type IUserUsecase interface {
 	Update(ctx context.Context, user *model.User) (*model.User, error)
 	ResetPassword(ctx context.Context, userId uuid.UUID) error
 	ResetPasswordByAccountId(ctx context.Context, accountId string, organizationId string) error
-	GenerateRandomPassword(ctx context.Context) string
+	UnlockByAccountId(ctx context.Context, accountId string, organizationId string) error
+	DisableByAccountId(ctx context.Context, accountId string, organizationId string) error
+	EnableByAccountId(ctx context.Context, accountId string, organizationId string) error
+	GenerateRandomPassword(ctx context.Context, organizationId string) string
 	Delete(ctx context.Context, userId uuid.UUID, organizationId string) error
 	GetByAccountId(ctx context.Context, accountId string, organizationId string) (*model.User, error)
 	GetByEmail(ctx context.Context, email string, organizationId string) (*model.User, error)
@@ -38,6 +42,7 @@ type IUserUsecase interface {
 
 	UpdateByAccountId(ctx context.Context, user *model.User) (*model.User, error)
 	UpdatePasswordByAccountId(ctx context.Context, accountId string, originPassword string, newPassword string, organizationId string) error
+	SetPasswordByAccountId(ctx context.Context, accountId string, organizationId string, password string) error
 	RenewalPasswordExpiredTime(ctx context.Context, userId uuid.UUID) error
 	RenewalPasswordExpiredTimeByAccountId(ctx context.Context, accountId string, organizationId string) error
 	DeleteByAccountId(ctx context.Context, accountId string, organizationId string) error
@@ -50,11 +55,14 @@ type IUserUsecase interface {
 }
 
 type UserUsecase struct {
-	authRepository         repository.IAuthRepository
-	userRepository         repository.IUserRepository
-	roleRepository         repository.IRoleRepository
-	organizationRepository repository.IOrganizationRepository
-	kc                     keycloak.IKeycloak
+	authRepository           repository.IAuthRepository
+	userRepository           repository.IUserRepository
+	roleRepository           repository.IRoleRepository
+	organizationRepository   repository.IOrganizationRepository
+	mailOutboxRepository     repository.IMailOutboxRepository
+	securityPolicyRepository repository.ISecurityPolicyRepository
+	apiTokenRepository       repository.IApiTokenRepository
+	kc                       keycloak.IKeycloak
 }
 
 func (u *UserUsecase) RenewalPasswordExpiredTime(ctx context.Context, userId uuid.UUID) error {
@@ -101,7 +109,7 @@ func (u *UserUsecase) ResetPassword(ctx context.Context, userId uuid.UUID) error
 		return httpErrors.NewInternalServerError(err, "", "")
 	}
 
-	randomPassword := helper.GenerateRandomString(passwordLength)
+	randomPassword := u.GenerateRandomPassword(ctx, user.Organization.ID)
 	userInKeycloak.Credentials = &[]gocloak.CredentialRepresentation{
 		{
 			Type:      gocloak.StringP("password"),
@@ -123,9 +131,7 @@ func (u *UserUsecase) ResetPassword(ctx context.Context, userId uuid.UUID) error
 		return httpErrors.NewInternalServerError(err, "", "")
 	}
 
-	mailer := mail.New(message)
-
-	if err := mailer.SendMail(ctx); err != nil {
+	if err := enqueueMail(ctx, u.mailOutboxRepository, user.Organization.ID, domain.MAIL_CATEGORY_TEMPORARY_PASSWORD, message); err != nil {
 		return httpErrors.NewInternalServerError(err, "", "")
 	}
 
@@ -143,8 +149,75 @@ func (u *UserUsecase) ResetPasswordByAccountId(ctx context.Context, accountId st
 	return u.ResetPassword(ctx, user.ID)
 }
 
-func (u *UserUsecase) GenerateRandomPassword(ctx context.Context) string {
-	return helper.GenerateRandomString(passwordLength)
+// UnlockByAccountId clears the login failures of the user, so the user can login before the lock expires.
+func (u *UserUsecase) UnlockByAccountId(ctx context.Context, accountId string, organizationId string) error {
+	if _, err := u.userRepository.Get(ctx, accountId, organizationId); err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return httpErrors.NewBadRequestError(fmt.Errorf("user not found"), "U_NO_USER", "")
+		}
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err := u.authRepository.DeleteLoginFailure(ctx, model.LoginFailureKindAccount, organizationId, accountId); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+// DisableByAccountId blocks the login of the user, and revokes the sessions and the api tokens of the user.
+func (u *UserUsecase) DisableByAccountId(ctx context.Context, accountId string, organizationId string) error {
+	user, err := u.userRepository.Get(ctx, accountId, organizationId)
+	if err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return httpErrors.NewBadRequestError(fmt.Errorf("user not found"), "U_NO_USER", "")
+		}
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	if err = u.kc.UpdateUserEnabled(ctx, organizationId, user.ID.String(), false); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = u.userRepository.UpdateDisabled(ctx, user.ID, true); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	if err = u.kc.LogoutAllSessions(ctx, user.ID.String(), organizationId); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = u.authRepository.UpdateExpiredTimeOnToken(ctx, organizationId, user.ID.String()); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = u.apiTokenRepository.RevokeByUserId(ctx, user.ID, time.Now()); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+func (u *UserUsecase) EnableByAccountId(ctx context.Context, accountId string, organizationId string) error {
+	user, err := u.userRepository.Get(ctx, accountId, organizationId)
+	if err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return httpErrors.NewBadRequestError(fmt.Errorf("user not found"), "U_NO_USER", "")
+		}
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	if err = u.kc.UpdateUserEnabled(ctx, organizationId, user.ID.String(), true); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = u.userRepository.UpdateDisabled(ctx, user.ID, false); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
+// GenerateRandomPassword makes a temporary password which satisfies the security policy of the organization.
+func (u *UserUsecase) GenerateRandomPassword(ctx context.Context, organizationId string) string {
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepository, organizationId)
+	if err != nil {
+		log.Error(ctx, err)
+		securityPolicy = model.NewSecurityPolicy(organizationId)
+	}
+	return securityPolicy.GenerateTemporaryPassword()
 }
 
 func (u *UserUsecase) ValidateAccount(ctx context.Context, userId uuid.UUID, password string, organizationId string) error {
@@ -202,7 +275,7 @@ func (u *UserUsecase) DeleteAdmin(ctx context.Context, organizationId string) er
 
 func (u *UserUsecase) CreateAdmin(ctx context.Context, user *model.User) (*model.User, error) {
 	// Generate Admin user object
-	randomPassword := helper.GenerateRandomString(passwordLength)
+	randomPassword := u.GenerateRandomPassword(ctx, user.Organization.ID)
 	user.Password = randomPassword
 
 	// Create Admin user in keycloak & DB
@@ -220,8 +293,7 @@ func (u *UserUsecase) CreateAdmin(ctx context.Context, user *model.User) (*model
 	if err != nil {
 		return nil, httpErrors.NewInternalServerError(err, "", "")
 	}
-	mailer := mail.New(message)
-	if err := mailer.SendMail(ctx); err != nil {
+	if err := enqueueMail(ctx, u.mailOutboxRepository, resUser.Organization.ID, domain.MAIL_CATEGORY_GENERATING_ORGANIZATION, message); err != nil {
 		return nil, httpErrors.NewInternalServerError(err, "", "")
 	}
 
@@ -239,9 +311,7 @@ func (u *UserUsecase) SendEmailForTemporaryPassword(ctx context.Context, account
 		return err
 	}
 
-	mailer := mail.New(message)
-
-	if err := mailer.SendMail(ctx); err != nil {
+	if err := enqueueMail(ctx, u.mailOutboxRepository, organizationId, domain.MAIL_CATEGORY_TEMPORARY_PASSWORD, message); err != nil {
 		return err
 	}
 
@@ -257,6 +327,13 @@ func (u *UserUsecase) UpdatePasswordByAccountId(ctx context.Context, accountId s
 	if _, err := u.kc.Login(ctx, accountId, originPassword, organizationId); err != nil {
 		return httpErrors.NewBadRequestError(fmt.Errorf("invalid origin password"), "A_INVALID_PASSWORD", "")
 	}
+	securityPolicy, err := getSecurityPolicy(ctx, u.securityPolicyRepository, organizationId)
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = securityPolicy.ValidatePassword(newPassword); err != nil {
+		return httpErrors.NewBadRequestError(err, "A_PASSWORD_POLICY", "")
+	}
 	originUser, err := u.kc.GetUser(ctx, organizationId, accountId)
 	if err != nil {
 		return err
@@ -271,6 +348,10 @@ func (u *UserUsecase) UpdatePasswordByAccountId(ctx context.Context, accountId s
 
 	err = u.kc.UpdateUser(ctx, organizationId, originUser)
 	if err != nil {
+		// 비밀번호 이력은 keycloak 의 password policy 로 검사한다.
+		if apiErr, ok := err.(*gocloak.APIError); ok && apiErr.Code == http.StatusBadRequest {
+			return httpErrors.NewBadRequestError(err, "A_PASSWORD_POLICY", "")
+		}
 		return errors.Wrap(err, "updating user in keycloak failed")
 	}
 
@@ -288,6 +369,36 @@ func (u *UserUsecase) UpdatePasswordByAccountId(ctx context.Context, accountId s
 	return nil
 }
 
+// SetPasswordByAccountId sets the password of the user without the current password, e.g. on the acceptance of the invitation.
+func (u *UserUsecase) SetPasswordByAccountId(ctx context.Context, accountId string, organizationId string, password string) error {
+	user, err := u.userRepository.Get(ctx, accountId, organizationId)
+	if err != nil {
+		if _, status := httpErrors.ErrorResponse(err); status == http.StatusNotFound {
+			return httpErrors.NewBadRequestError(fmt.Errorf("user not found"), "U_NO_USER", "")
+		}
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	userInKeycloak, err := u.kc.GetUser(ctx, organizationId, accountId)
+	if err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+
+	userInKeycloak.Credentials = &[]gocloak.CredentialRepresentation{
+		{
+			Type:      gocloak.StringP("password"),
+			Value:     gocloak.StringP(password),
+			Temporary: gocloak.BoolP(false),
+		},
+	}
+	if err = u.kc.UpdateUser(ctx, organizationId, userInKeycloak); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	if err = u.userRepository.UpdatePasswordAt(ctx, user.ID, organizationId, false); err != nil {
+		return httpErrors.NewInternalServerError(err, "", "")
+	}
+	return nil
+}
+
 func (u *UserUsecase) List(ctx context.Context, organizationId string) (users *[]model.User, err error) {
 	users, err = u.userRepository.List(ctx, u.userRepository.OrganizationFilter(organizationId))
 	if err != nil {
@@ -368,6 +479,7 @@ func (u *UserUsecase) UpdateByAccountId(ctx context.Context, user *model.User) (
 			ID:        gocloak.StringP(user.ID.String()),
 			Email:     gocloak.StringP(user.Email),
 			FirstName: gocloak.StringP(user.Name),
+			Enabled:   gocloak.BoolP(!(*users)[0].Disabled),
 		})
 		if err != nil {
 			return nil, err
@@ -422,6 +534,8 @@ func (u *UserUsecase) DeleteByAccountId(ctx context.Context, accountId string, o
 	return nil
 }
 
+// Create creates the user in keycloak and DB. The user without the password can not login until the password is set,
+// and the disabled user is created disabled.
 func (u *UserUsecase) Create(ctx context.Context, user *model.User) (*model.User, error) {
 	// Create user in keycloak
 	var groups []string
@@ -429,19 +543,23 @@ func (u *UserUsecase) Create(ctx context.Context, user *model.User) (*model.User
 		groups = append(groups, fmt.Sprintf("%s@%s", role.Name, user.Organization.ID))
 	}
 
-	userUuidStr, err := u.kc.CreateUser(ctx, user.Organization.ID, &gocloak.User{
-		Username: gocloak.StringP(user.AccountId),
-		Credentials: &[]gocloak.CredentialRepresentation{
+	kcUser := &gocloak.User{
+		Username:  gocloak.StringP(user.AccountId),
+		Enabled:   gocloak.BoolP(!user.Disabled),
+		Email:     gocloak.StringP(user.Email),
+		Groups:    &groups,
+		FirstName: gocloak.StringP(user.Name),
+	}
+	if user.Password != "" {
+		kcUser.Credentials = &[]gocloak.CredentialRepresentation{
 			{
 				Type:      gocloak.StringP("password"),
 				Value:     gocloak.StringP(user.Password),
 				Temporary: gocloak.BoolP(false),
 			},
-		},
-		Email:     gocloak.StringP(user.Email),
-		Groups:    &groups,
-		FirstName: gocloak.StringP(user.Name),
-	})
+		}
+	}
+	userUuidStr, err := u.kc.CreateUser(ctx, user.Organization.ID, kcUser)
 	if err != nil {
 		return nil, err
 	}
@@ -510,6 +628,10 @@ func (u *UserUsecase) UpdateByAccountIdByAdmin(ctx context.Context, newUser *mod
 	originUser.Department = newUser.Department
 	originUser.Description = newUser.Description
 	originUser.Roles = newUser.Roles
+	// 외부 식별자는 SCIM 프로비저닝에서만 지정하므로 비어 있으면 유지한다.
+	if newUser.ExternalId != "" {
+		originUser.ExternalId = newUser.ExternalId
+	}
 
 	resp, err := u.userRepository.Update(ctx, &originUser)
 	if err != nil {
@@ -550,10 +672,13 @@ func (u *UserUsecase) ExpirePassword(ctx context.Context, userId uuid.UUID) erro
 
 func NewUserUsecase(r repository.Repository, kc keycloak.IKeycloak) IUserUsecase {
 	return &UserUsecase{
-		authRepository:         r.Auth,
-		userRepository:         r.User,
-		roleRepository:         r.Role,
-		kc:                     kc,
-		organizationRepository: r.Organization,
+		authRepository:           r.Auth,
+		userRepository:           r.User,
+		roleRepository:           r.Role,
+		kc:                       kc,
+		organizationRepository:   r.Organization,
+		mailOutboxRepository:     r.MailOutbox,
+		securityPolicyRepository: r.SecurityPolicy,
+		apiTokenRepository:       r.ApiToken,
 	}
 }
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// Worker is the background job of a usecase. Run returns when ctx is done.
+type Worker interface {
+	Run(ctx context.Context)
+}
+
+// StartWorkers runs the workers in the background until ctx is done.
+func StartWorkers(ctx context.Context, workers ...Worker) {
+	for _, worker := range workers {
+		go worker.Run(ctx)
+	}
+}
+
+// runWorker calls work at once and then every interval until ctx is done.
+// 작업 중에 멈추더라도 각 작업은 lease 가 지나면 다른 replica 가 다시 가져간다.
+func runWorker(ctx context.Context, interval time.Duration, work func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		work(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
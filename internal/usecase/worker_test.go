@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunWorker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		runWorker(ctx, time.Millisecond, func(ctx context.Context) {
+			select {
+			case calls <- struct{}{}:
+			default:
+			}
+		})
+		close(done)
+	}()
+
+	// 처음에는 바로 실행하고 이후에는 주기마다 실행한다.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatalf("work is not called %d times", i+1)
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker does not stop after the context is done")
+	}
+}
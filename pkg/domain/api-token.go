@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+)
+
+type ApiTokenResponse struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	TokenPrefix    string             `json:"tokenPrefix"`
+	EndpointGroups []string           `json:"endpointGroups"`
+	ExpiredAt      *time.Time         `json:"expiredAt"`
+	LastUsedAt     *time.Time         `json:"lastUsedAt"`
+	RevokedAt      *time.Time         `json:"revokedAt"`
+	Creator        SimpleUserResponse `json:"creator"`
+	CreatedAt      time.Time          `json:"createdAt"`
+}
+
+type GetApiTokensResponse struct {
+	ApiTokens  []ApiTokenResponse `json:"apiTokens"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type CreateApiTokenRequest struct {
+	Name           string     `json:"name" validate:"required,min=1,max=50"`
+	EndpointGroups []string   `json:"endpointGroups"`
+	ExpiredAt      *time.Time `json:"expiredAt"`
+}
+
+// CreateApiTokenResponse has the token only at the creation. It can not be retrieved again.
+type CreateApiTokenResponse struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+type RevokeApiTokenResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -5,19 +5,42 @@ import (
 )
 
 type AuditResponse struct {
-	ID               string    `json:"id"`
-	OrganizationId   string    `json:"organizationId"`
-	OrganizationName string    `json:"organizationName"`
-	Description      string    `json:"description"`
-	Group            string    `json:"group"`
-	Message          string    `json:"message"`
-	ClientIP         string    `json:"clientIP"`
-	UserId           string    `json:"userId"`
-	UserAccountId    string    `json:"userAccountId"`
-	UserName         string    `json:"userName"`
-	UserRoles        string    `json:"userRoles"`
-	CreatedAt        time.Time `json:"createdAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
+	ID                         string `json:"id"`
+	OrganizationId             string `json:"organizationId"`
+	OrganizationName           string `json:"organizationName"`
+	Sequence                   int64  `json:"sequence"`
+	PrevHash                   string `json:"prevHash"`
+	Hash                       string `json:"hash"`
+	Description                string `json:"description"`
+	Group                      string `json:"group"`
+	Message                    string `json:"message"`
+	MessageEn                  string `json:"messageEn"`
+	ClientIP                   string `json:"clientIP"`
+	UserId                     string `json:"userId"`
+	UserAccountId              string `json:"userAccountId"`
+	UserName                   string `json:"userName"`
+	UserRoles                  string `json:"userRoles"`
+	ImpersonatorId             string `json:"impersonatorId,omitempty"`
+	ImpersonatorAccountId      string `json:"impersonatorAccountId,omitempty"`
+	ImpersonatorOrganizationId string `json:"impersonatorOrganizationId,omitempty"`
+	Endpoint                   string `json:"endpoint"`
+	Method                     string `json:"method"`
+	Path                       string `json:"path"`
+	ResourceType               string `json:"resourceType"`
+	ResourceId                 string `json:"resourceId"`
+	StatusCode                 int    `json:"statusCode"`
+	// SUCCESS, FAILURE
+	Result    string                `json:"result"`
+	RequestId string                `json:"requestId"`
+	Changes   []AuditChangeResponse `json:"changes"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+type AuditChangeResponse struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
 }
 
 type CreateAuditRequest struct {
@@ -32,3 +55,19 @@ type GetAuditsResponse struct {
 	Audits     []AuditResponse    `json:"audits"`
 	Pagination PaginationResponse `json:"pagination"`
 }
+
+type VerifyAuditsResponse struct {
+	OrganizationId  string    `json:"organizationId"`
+	Verified        bool      `json:"verified"`
+	CheckedCount    int64     `json:"checkedCount"`
+	FirstSequence   int64     `json:"firstSequence"`
+	LastSequence    int64     `json:"lastSequence"`
+	LastHash        string    `json:"lastHash"`
+	CheckpointCount int64     `json:"checkpointCount"`
+	BrokenAuditId   string    `json:"brokenAuditId,omitempty"`
+	BrokenSequence  int64     `json:"brokenSequence,omitempty"`
+	BrokenReason    string    `json:"brokenReason,omitempty"`
+	VerifiedAt      time.Time `json:"verifiedAt"`
+	// 보존 기간이 지나 정리된 마지막 감사 로그의 sequence
+	ArchivedSequence int64 `json:"archivedSequence,omitempty"`
+}
@@ -15,7 +15,18 @@ type LoginResponse struct {
 		Department      string               `json:"department"`
 		Organization    OrganizationResponse `json:"organization"`
 		PasswordExpired bool                 `json:"passwordExpired"`
+		// 조직의 보안 정책상 2단계 인증 등록이 필요하면 등록 전까지 my-profile 의 2단계 인증 API 만 사용할 수 있다.
+		SecondFactorEnrollmentRequired bool `json:"secondFactorEnrollmentRequired"`
 	} `json:"user"`
+	// SecondFactorRequired 이면 token 대신 발급된 secondFactorToken 과 인증 코드로 /auth/login/second-factor 를 호출한다.
+	SecondFactorRequired bool   `json:"secondFactorRequired"`
+	SecondFactorToken    string `json:"secondFactorToken,omitempty"`
+}
+
+type LoginSecondFactorRequest struct {
+	SecondFactorToken string `json:"secondFactorToken" validate:"required"`
+	// TOTP 코드 또는 복구 코드
+	Code string `json:"code" validate:"required"`
 }
 
 type VerifyIdentityForLostIdRequest struct {
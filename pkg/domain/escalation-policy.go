@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+)
+
+type EscalationTierResponse struct {
+	Level                int                                 `json:"level"`
+	DelayMinutes         int                                 `json:"delayMinutes"`
+	TargetUsers          []SimpleUserResponse                `json:"targetUsers"`
+	OnCallSchedule       SimpleOnCallScheduleResponse        `json:"onCallSchedule"`
+	NotificationChannels []SimpleNotificationChannelResponse `json:"notificationChannels"`
+}
+
+type EscalationPolicyResponse struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	RepeatCount int                      `json:"repeatCount"`
+	Tiers       []EscalationTierResponse `json:"tiers"`
+	Creator     SimpleUserResponse       `json:"creator"`
+	Updator     SimpleUserResponse       `json:"updator"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	UpdatedAt   time.Time                `json:"updatedAt"`
+}
+
+type SimpleEscalationPolicyResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type GetEscalationPoliciesResponse struct {
+	EscalationPolicies []EscalationPolicyResponse `json:"escalationPolicies"`
+	Pagination         PaginationResponse         `json:"pagination"`
+}
+
+type GetEscalationPolicyResponse struct {
+	EscalationPolicy EscalationPolicyResponse `json:"escalationPolicy"`
+}
+
+type EscalationTierRequest struct {
+	DelayMinutes           int      `json:"delayMinutes" validate:"required,min=1,max=1440"`
+	TargetUserIds          []string `json:"targetUserIds"`
+	OnCallScheduleId       string   `json:"onCallScheduleId"`
+	NotificationChannelIds []string `json:"notificationChannelIds"`
+}
+
+type CreateEscalationPolicyRequest struct {
+	Name        string                  `json:"name" validate:"required,name"`
+	Description string                  `json:"description"`
+	RepeatCount int                     `json:"repeatCount" validate:"min=0,max=10"`
+	Tiers       []EscalationTierRequest `json:"tiers" validate:"required,min=1,max=10,dive"`
+}
+
+type CreateEscalationPolicyResponse struct {
+	ID string `json:"id"`
+}
+
+type UpdateEscalationPolicyRequest struct {
+	Name        string                  `json:"name" validate:"required,name"`
+	Description string                  `json:"description"`
+	RepeatCount int                     `json:"repeatCount" validate:"min=0,max=10"`
+	Tiers       []EscalationTierRequest `json:"tiers" validate:"required,min=1,max=10,dive"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"time"
+)
+
+type IdentityProviderRoleMappingResponse struct {
+	Type          string             `json:"type"`
+	AttributeName string             `json:"attributeName,omitempty"`
+	Value         string             `json:"value"`
+	Role          SimpleRoleResponse `json:"role"`
+}
+
+type IdentityProviderResponse struct {
+	ID           string                                `json:"id"`
+	Alias        string                                `json:"alias"`
+	Type         string                                `json:"type"`
+	DisplayName  string                                `json:"displayName"`
+	Enabled      bool                                  `json:"enabled"`
+	Config       map[string]string                     `json:"config"`
+	DefaultRole  *SimpleRoleResponse                   `json:"defaultRole,omitempty"`
+	RoleMappings []IdentityProviderRoleMappingResponse `json:"roleMappings"`
+	Creator      SimpleUserResponse                    `json:"creator"`
+	CreatedAt    time.Time                             `json:"createdAt"`
+	UpdatedAt    time.Time                             `json:"updatedAt"`
+}
+
+type GetIdentityProvidersResponse struct {
+	IdentityProviders []IdentityProviderResponse `json:"identityProviders"`
+	Pagination        PaginationResponse         `json:"pagination"`
+}
+
+type GetIdentityProviderResponse struct {
+	IdentityProvider IdentityProviderResponse `json:"identityProvider"`
+}
+
+type IdentityProviderRoleMappingRequest struct {
+	Type string `json:"type" validate:"required,oneof=group attribute"`
+	// type 이 attribute 인 경우 OIDC claim, SAML attribute 또는 LDAP attribute 의 이름
+	AttributeName string `json:"attributeName" validate:"required_if=Type attribute"`
+	Value         string `json:"value" validate:"required"`
+	RoleId        string `json:"roleId" validate:"required"`
+}
+
+// Config 의 키
+//   - oidc : authorizationUrl, tokenUrl, clientId (필수), userInfoUrl, jwksUrl, issuer, logoutUrl, defaultScope
+//   - saml : singleSignOnServiceUrl, idpEntityId (필수), singleLogoutServiceUrl, nameIDPolicyFormat, signingCertificate
+//   - ldap : connectionUrl, usersDn, bindDn (필수), vendor (ad, other), usernameLDAPAttribute, rdnLDAPAttribute, uuidLDAPAttribute, userObjectClasses, customUserSearchFilter
+//   - 공통 : groupsAttribute (그룹을 가진 claim 또는 attribute, 기본값 oidc/saml 은 groups, ldap 은 memberOf)
+//
+// Secret 은 oidc 의 client secret, ldap 의 bind credential 이며 저장되거나 조회되지 않는다.
+type CreateIdentityProviderRequest struct {
+	Alias         string                               `json:"alias" validate:"required,rfc1123"`
+	Type          string                               `json:"type" validate:"required,oneof=ldap oidc saml"`
+	DisplayName   string                               `json:"displayName" validate:"required,name"`
+	Enabled       bool                                 `json:"enabled"`
+	Config        map[string]string                    `json:"config" validate:"required"`
+	Secret        string                               `json:"secret"`
+	DefaultRoleId *string                              `json:"defaultRoleId"`
+	RoleMappings  []IdentityProviderRoleMappingRequest `json:"roleMappings" validate:"dive"`
+}
+
+type CreateIdentityProviderResponse struct {
+	ID string `json:"id"`
+}
+
+// Secret 이 비어 있으면 기존 secret 을 유지한다.
+type UpdateIdentityProviderRequest struct {
+	DisplayName   string                               `json:"displayName" validate:"required,name"`
+	Enabled       bool                                 `json:"enabled"`
+	Config        map[string]string                    `json:"config" validate:"required"`
+	Secret        string                               `json:"secret"`
+	DefaultRoleId *string                              `json:"defaultRoleId"`
+	RoleMappings  []IdentityProviderRoleMappingRequest `json:"roleMappings" validate:"dive"`
+}
+
+type DeleteIdentityProviderResponse struct {
+	ID    string `json:"id"`
+	Alias string `json:"alias"`
+}
+
+type LoginIdentityProviderResponse struct {
+	Alias       string `json:"alias"`
+	Type        string `json:"type"`
+	DisplayName string `json:"displayName"`
+}
+
+type GetLoginIdentityProvidersResponse struct {
+	IdentityProviders []LoginIdentityProviderResponse `json:"identityProviders"`
+}
+
+// LoginWithIdentityProviderRequest 는 keycloak 의 로그인 화면(kc_idp_hint=alias)에서 돌아온 authorization code 로 로그인한다.
+type LoginWithIdentityProviderRequest struct {
+	OrganizationId string `json:"organizationId" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+	RedirectUri    string `json:"redirectUri" validate:"required,url"`
+}
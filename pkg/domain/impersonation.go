@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"time"
+)
+
+type ImpersonationResponse struct {
+	ID             string             `json:"id"`
+	OrganizationId string             `json:"organizationId"`
+	User           SimpleUserResponse `json:"user"`
+	Impersonator   SimpleUserResponse `json:"impersonator"`
+	Reason         string             `json:"reason"`
+	// ACTIVE, STOPPED, EXPIRED
+	Status    string     `json:"status"`
+	ExpiredAt time.Time  `json:"expiredAt"`
+	StoppedAt *time.Time `json:"stoppedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type CreateImpersonationRequest struct {
+	Reason string `json:"reason" validate:"required,max=200"`
+}
+
+type CreateImpersonationResponse struct {
+	Impersonation ImpersonationResponse `json:"impersonation"`
+	Token         string                `json:"token"`
+	ExpiredAt     time.Time             `json:"expiredAt"`
+}
+
+type GetImpersonationsResponse struct {
+	Impersonations []ImpersonationResponse `json:"impersonations"`
+	Pagination     PaginationResponse      `json:"pagination"`
+}
+
+type StopImpersonationResponse struct {
+	ID             string `json:"id"`
+	OrganizationId string `json:"organizationId"`
+	AccountId      string `json:"accountId"`
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"time"
+)
+
+type InvitationProjectResponse struct {
+	ProjectId       string `json:"projectId"`
+	ProjectName     string `json:"projectName"`
+	ProjectRoleId   string `json:"projectRoleId"`
+	ProjectRoleName string `json:"projectRoleName"`
+}
+
+type InvitationResponse struct {
+	ID         string                      `json:"id"`
+	Email      string                      `json:"email"`
+	Name       string                      `json:"name"`
+	Department string                      `json:"department"`
+	Roles      []SimpleRoleResponse        `json:"roles"`
+	Projects   []InvitationProjectResponse `json:"projects"`
+	// PENDING, ACCEPTED, REVOKED, EXPIRED
+	Status     string             `json:"status"`
+	ExpiredAt  time.Time          `json:"expiredAt"`
+	SentCount  int                `json:"sentCount"`
+	LastSentAt time.Time          `json:"lastSentAt"`
+	AcceptedAt *time.Time         `json:"acceptedAt"`
+	Creator    SimpleUserResponse `json:"creator"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+type GetInvitationsResponse struct {
+	Invitations []InvitationResponse `json:"invitations"`
+	Pagination  PaginationResponse   `json:"pagination"`
+}
+
+type GetInvitationResponse struct {
+	Invitation InvitationResponse `json:"invitation"`
+}
+
+type InvitationProjectRequest struct {
+	ProjectId     string `json:"projectId" validate:"required"`
+	ProjectRoleId string `json:"projectRoleId" validate:"required"`
+}
+
+type CreateInvitationRequest struct {
+	Email      string                     `json:"email" validate:"required,email"`
+	Name       string                     `json:"name" validate:"omitempty,name"`
+	Department string                     `json:"department" validate:"min=0,max=50"`
+	RoleIds    []string                   `json:"roleIds" validate:"required,min=1"`
+	Projects   []InvitationProjectRequest `json:"projects" validate:"dive"`
+}
+
+type CreateInvitationResponse struct {
+	ID string `json:"id"`
+}
+
+type ResendInvitationResponse struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+type RevokeInvitationResponse struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+// GetInvitationByTokenResponse is shown to the invitee before the acceptance.
+type GetInvitationByTokenResponse struct {
+	OrganizationId   string               `json:"organizationId"`
+	OrganizationName string               `json:"organizationName"`
+	Email            string               `json:"email"`
+	Name             string               `json:"name"`
+	Roles            []SimpleRoleResponse `json:"roles"`
+	ExpiredAt        time.Time            `json:"expiredAt"`
+	// 이미 생성된 사용자의 초대이면 계정 ID 는 바꿀 수 없고 비밀번호만 설정한다.
+	AccountId string `json:"accountId,omitempty"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+	// 이미 생성된 사용자의 초대이면 무시한다.
+	AccountId  string `json:"accountId" validate:"omitempty,min=0,max=20,alphanum"`
+	Password   string `json:"password" validate:"required"`
+	Name       string `json:"name" validate:"omitempty,name"`
+	Department string `json:"department" validate:"min=0,max=50"`
+	// 가입과 함께 2단계 인증을 등록한다. 로그인 후 인증 코드를 확인하면 사용된다.
+	EnrollSecondFactor bool `json:"enrollSecondFactor"`
+}
+
+type AcceptInvitationResponse struct {
+	OrganizationId string                        `json:"organizationId"`
+	AccountId      string                        `json:"accountId"`
+	SecondFactor   *CreateMySecondFactorResponse `json:"secondFactor,omitempty"`
+}
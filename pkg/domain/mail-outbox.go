@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	MAIL_CATEGORY_VERIFY_IDENTITY            = "VERIFY_IDENTITY"
+	MAIL_CATEGORY_TEMPORARY_PASSWORD         = "TEMPORARY_PASSWORD"
+	MAIL_CATEGORY_GENERATING_ORGANIZATION    = "GENERATING_ORGANIZATION"
+	MAIL_CATEGORY_SYSTEM_NOTIFICATION        = "SYSTEM_NOTIFICATION"
+	MAIL_CATEGORY_SYSTEM_NOTIFICATION_DIGEST = "SYSTEM_NOTIFICATION_DIGEST"
+	MAIL_CATEGORY_INVITATION                 = "INVITATION"
+	MAIL_CATEGORY_IMPERSONATION              = "IMPERSONATION"
+)
+
+// enum
+type MailOutboxStatus int32
+
+const (
+	MailOutboxStatus_PENDING MailOutboxStatus = iota
+	MailOutboxStatus_SENT
+	MailOutboxStatus_DEAD
+)
+
+var mailOutboxStatus = [...]string{
+	"PENDING",
+	"SENT",
+	"DEAD",
+}
+
+func (m MailOutboxStatus) String() string { return mailOutboxStatus[(m)] }
+func (m MailOutboxStatus) FromString(s string) MailOutboxStatus {
+	for i, v := range mailOutboxStatus {
+		if v == s {
+			return MailOutboxStatus(i)
+		}
+	}
+	return MailOutboxStatus_PENDING
+}
+
+type MailOutboxResponse struct {
+	ID             string     `json:"id"`
+	OrganizationId string     `json:"organizationId"`
+	Category       string     `json:"category"`
+	To             string     `json:"to"`
+	Subject        string     `json:"subject"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt"`
+	LastError      string     `json:"lastError"`
+	SentAt         *time.Time `json:"sentAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type GetMailOutboxesResponse struct {
+	MailOutboxes []MailOutboxResponse `json:"mailOutboxes"`
+	Pagination   PaginationResponse   `json:"pagination"`
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	NOTIFICATION_CHANNEL_TYPE_SLACK      = "SLACK"
+	NOTIFICATION_CHANNEL_TYPE_MATTERMOST = "MATTERMOST"
+	NOTIFICATION_CHANNEL_TYPE_TEAMS      = "TEAMS"
+	NOTIFICATION_CHANNEL_TYPE_WEBHOOK    = "WEBHOOK"
+	NOTIFICATION_CHANNEL_TYPE_EMAIL      = "EMAIL"
+)
+
+// enum
+type NotificationDeliveryStatus int32
+
+const (
+	NotificationDeliveryStatus_SUCCESS NotificationDeliveryStatus = iota
+	NotificationDeliveryStatus_FAILED
+	NotificationDeliveryStatus_PENDING
+)
+
+var notificationDeliveryStatus = [...]string{
+	"SUCCESS",
+	"FAILED",
+	"PENDING",
+}
+
+func (m NotificationDeliveryStatus) String() string { return notificationDeliveryStatus[(m)] }
+func (m NotificationDeliveryStatus) FromString(s string) NotificationDeliveryStatus {
+	for i, v := range notificationDeliveryStatus {
+		if v == s {
+			return NotificationDeliveryStatus(i)
+		}
+	}
+	return NotificationDeliveryStatus_FAILED
+}
+
+type NotificationChannelResponse struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Type        string             `json:"type"`
+	Url         string             `json:"url"`
+	Template    string             `json:"template"`
+	Enabled     bool               `json:"enabled"`
+	Creator     SimpleUserResponse `json:"creator"`
+	Updator     SimpleUserResponse `json:"updator"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+type SimpleNotificationChannelResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+type GetNotificationChannelsResponse struct {
+	NotificationChannels []NotificationChannelResponse `json:"notificationChannels"`
+	Pagination           PaginationResponse            `json:"pagination"`
+}
+
+type GetNotificationChannelResponse struct {
+	NotificationChannel NotificationChannelResponse `json:"notificationChannel"`
+}
+
+type CreateNotificationChannelRequest struct {
+	Name        string `json:"name" validate:"required,name"`
+	Description string `json:"description"`
+	Type        string `json:"type" validate:"required,oneof=SLACK MATTERMOST TEAMS WEBHOOK"`
+	Url         string `json:"url" validate:"required,url"`
+	Template    string `json:"template"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type CreateNotificationChannelResponse struct {
+	ID string `json:"id"`
+}
+
+type UpdateNotificationChannelRequest struct {
+	Name        string `json:"name" validate:"required,name"`
+	Description string `json:"description"`
+	Url         string `json:"url" validate:"required,url"`
+	Template    string `json:"template"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type TestNotificationChannelResponse struct {
+	Status       string `json:"status"`
+	StatusCode   int    `json:"statusCode"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+type NotificationDeliveryResponse struct {
+	ID                       string    `json:"id"`
+	SystemNotificationId     string    `json:"systemNotificationId"`
+	SystemNotificationRuleId string    `json:"systemNotificationRuleId"`
+	NotificationChannelId    string    `json:"notificationChannelId"`
+	EscalationLevel          int       `json:"escalationLevel"`
+	ChannelType              string    `json:"channelType"`
+	Target                   string    `json:"target"`
+	Status                   string    `json:"status"`
+	StatusCode               int       `json:"statusCode"`
+	ErrorMessage             string    `json:"errorMessage"`
+	Attempts                 int       `json:"attempts"`
+	CreatedAt                time.Time `json:"createdAt"`
+}
+
+type GetNotificationDeliveriesResponse struct {
+	NotificationDeliveries []NotificationDeliveryResponse `json:"notificationDeliveries"`
+	Pagination             PaginationResponse             `json:"pagination"`
+}
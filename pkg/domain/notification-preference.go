@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	NOTIFICATION_PREFERENCE_CHANNEL_EMAIL = "EMAIL"
+)
+
+type NotificationPreferenceResponse struct {
+	Channels          []string                               `json:"channels"`
+	MinimumSeverity   string                                 `json:"minimumSeverity"`
+	QuietHoursStart   string                                 `json:"quietHoursStart"`
+	QuietHoursEnd     string                                 `json:"quietHoursEnd"`
+	Timezone          string                                 `json:"timezone"`
+	UnsubscribedRules []SimpleSystemNotificationRuleResponse `json:"unsubscribedRules"`
+	UpdatedAt         time.Time                              `json:"updatedAt"`
+}
+
+type GetMyNotificationPreferenceResponse struct {
+	NotificationPreference NotificationPreferenceResponse `json:"notificationPreference"`
+}
+
+type UpdateMyNotificationPreferenceRequest struct {
+	Channels            []string `json:"channels" validate:"dive,oneof=EMAIL"`
+	MinimumSeverity     string   `json:"minimumSeverity" validate:"omitempty,oneof=critical warning info"`
+	QuietHoursStart     string   `json:"quietHoursStart" validate:"required_with=QuietHoursEnd,omitempty,datetime=15:04"`
+	QuietHoursEnd       string   `json:"quietHoursEnd" validate:"required_with=QuietHoursStart,omitempty,datetime=15:04"`
+	Timezone            string   `json:"timezone" validate:"omitempty,timezone"`
+	UnsubscribedRuleIds []string `json:"unsubscribedRuleIds"`
+}
+
+type UpdateMyNotificationPreferenceResponse struct {
+	NotificationPreference NotificationPreferenceResponse `json:"notificationPreference"`
+}
+
+type UnsubscribeNotificationResponse struct {
+	Message string `json:"message"`
+}
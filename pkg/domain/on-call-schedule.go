@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+)
+
+type OnCallScheduleResponse struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	Description   string               `json:"description"`
+	StartAt       time.Time            `json:"startAt"`
+	RotationDays  int                  `json:"rotationDays"`
+	Members       []SimpleUserResponse `json:"members"`
+	CurrentOnCall SimpleUserResponse   `json:"currentOnCall"`
+	Creator       SimpleUserResponse   `json:"creator"`
+	Updator       SimpleUserResponse   `json:"updator"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+}
+
+type SimpleOnCallScheduleResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type GetOnCallSchedulesResponse struct {
+	OnCallSchedules []OnCallScheduleResponse `json:"onCallSchedules"`
+	Pagination      PaginationResponse       `json:"pagination"`
+}
+
+type GetOnCallScheduleResponse struct {
+	OnCallSchedule OnCallScheduleResponse `json:"onCallSchedule"`
+}
+
+type CreateOnCallScheduleRequest struct {
+	Name         string    `json:"name" validate:"required,name"`
+	Description  string    `json:"description"`
+	StartAt      time.Time `json:"startAt" validate:"required"`
+	RotationDays int       `json:"rotationDays" validate:"omitempty,min=1,max=365"`
+	MemberIds    []string  `json:"memberIds" validate:"required,min=1"`
+}
+
+type CreateOnCallScheduleResponse struct {
+	ID string `json:"id"`
+}
+
+type UpdateOnCallScheduleRequest struct {
+	Name         string    `json:"name" validate:"required,name"`
+	Description  string    `json:"description"`
+	StartAt      time.Time `json:"startAt" validate:"required"`
+	RotationDays int       `json:"rotationDays" validate:"omitempty,min=1,max=365"`
+	MemberIds    []string  `json:"memberIds" validate:"required,min=1"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+type RetentionPolicyResponse struct {
+	// AUDIT, SYSTEM_NOTIFICATION, APP_SERVE_APP_TASK, CACHE_EMAIL_CODE
+	DataType      string             `json:"dataType"`
+	RetentionDays int                `json:"retentionDays"`
+	Archive       bool               `json:"archive"`
+	Updator       SimpleUserResponse `json:"updator"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+}
+
+type GetRetentionPoliciesResponse struct {
+	RetentionPolicies []RetentionPolicyResponse `json:"retentionPolicies"`
+}
+
+type UpdateRetentionPolicyRequest struct {
+	DataType string `json:"dataType" validate:"required,oneof=AUDIT SYSTEM_NOTIFICATION APP_SERVE_APP_TASK CACHE_EMAIL_CODE"`
+	// 0 이면 정리하지 않는다.
+	RetentionDays int  `json:"retentionDays" validate:"min=0,max=3650"`
+	Archive       bool `json:"archive"`
+}
+
+type UpdateRetentionPoliciesRequest struct {
+	RetentionPolicies []UpdateRetentionPolicyRequest `json:"retentionPolicies" validate:"required,min=1,dive"`
+}
+
+type UpdateRetentionPoliciesResponse struct {
+	RetentionPolicies []RetentionPolicyResponse `json:"retentionPolicies"`
+}
+
+type RetentionRunResponse struct {
+	OrganizationId string    `json:"organizationId"`
+	DataType       string    `json:"dataType"`
+	RetentionDays  int       `json:"retentionDays"`
+	Cutoff         time.Time `json:"cutoff"`
+	ArchivedCount  int64     `json:"archivedCount"`
+	PurgedCount    int64     `json:"purgedCount"`
+	ArchiveKeys    []string  `json:"archiveKeys"`
+	// SUCCESS, PARTIAL, FAILURE
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+type GetRetentionStatusResponse struct {
+	Enabled        bool                   `json:"enabled"`
+	ArchiveEnabled bool                   `json:"archiveEnabled"`
+	Running        bool                   `json:"running"`
+	NextRunAt      *time.Time             `json:"nextRunAt"`
+	LastStartedAt  *time.Time             `json:"lastStartedAt"`
+	LastFinishedAt *time.Time             `json:"lastFinishedAt"`
+	Runs           []RetentionRunResponse `json:"runs"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+type SecondFactorResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabledAt"`
+	RemainingRecoveryCodes int        `json:"remainingRecoveryCodes"`
+	Required               bool       `json:"required"`
+}
+
+type GetMySecondFactorResponse struct {
+	SecondFactor SecondFactorResponse `json:"secondFactor"`
+}
+
+type CreateMySecondFactorResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningUri string `json:"provisioningUri"`
+}
+
+type VerifyMySecondFactorRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type VerifyMySecondFactorResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type RegenerateMyRecoveryCodesRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type RegenerateMyRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type DeleteMySecondFactorRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type ResetUserSecondFactorResponse struct {
+	AccountId string `json:"accountId"`
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+type SecurityPolicyResponse struct {
+	PasswordExpiryDays        int                `json:"passwordExpiryDays"`
+	PasswordMinLength         int                `json:"passwordMinLength"`
+	PasswordRequireUppercase  bool               `json:"passwordRequireUppercase"`
+	PasswordRequireLowercase  bool               `json:"passwordRequireLowercase"`
+	PasswordRequireDigit      bool               `json:"passwordRequireDigit"`
+	PasswordRequireSpecial    bool               `json:"passwordRequireSpecial"`
+	PasswordHistoryDepth      int                `json:"passwordHistoryDepth"`
+	SessionIdleTimeoutMinutes int                `json:"sessionIdleTimeoutMinutes"`
+	SessionMaxLifespanMinutes int                `json:"sessionMaxLifespanMinutes"`
+	RequireSecondFactor       bool               `json:"requireSecondFactor"`
+	Updator                   SimpleUserResponse `json:"updator"`
+	UpdatedAt                 time.Time          `json:"updatedAt"`
+}
+
+type GetSecurityPolicyResponse struct {
+	SecurityPolicy SecurityPolicyResponse `json:"securityPolicy"`
+}
+
+type UpdateSecurityPolicyRequest struct {
+	PasswordExpiryDays        int  `json:"passwordExpiryDays" validate:"min=0,max=3650"`
+	PasswordMinLength         int  `json:"passwordMinLength" validate:"min=8,max=128"`
+	PasswordRequireUppercase  bool `json:"passwordRequireUppercase"`
+	PasswordRequireLowercase  bool `json:"passwordRequireLowercase"`
+	PasswordRequireDigit      bool `json:"passwordRequireDigit"`
+	PasswordRequireSpecial    bool `json:"passwordRequireSpecial"`
+	PasswordHistoryDepth      int  `json:"passwordHistoryDepth" validate:"min=0,max=24"`
+	SessionIdleTimeoutMinutes int  `json:"sessionIdleTimeoutMinutes" validate:"min=5,max=43200"`
+	SessionMaxLifespanMinutes int  `json:"sessionMaxLifespanMinutes" validate:"min=5,max=43200,gtefield=SessionIdleTimeoutMinutes"`
+	RequireSecondFactor       bool `json:"requireSecondFactor"`
+}
+
+type UpdateSecurityPolicyResponse struct {
+	SecurityPolicy SecurityPolicyResponse `json:"securityPolicy"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+)
+
+type ServiceAccountResponse struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Roles       []SimpleRoleResponse `json:"roles"`
+	Creator     SimpleUserResponse   `json:"creator"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+}
+
+type GetServiceAccountsResponse struct {
+	ServiceAccounts []ServiceAccountResponse `json:"serviceAccounts"`
+	Pagination      PaginationResponse       `json:"pagination"`
+}
+
+type GetServiceAccountResponse struct {
+	ServiceAccount ServiceAccountResponse `json:"serviceAccount"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name        string             `json:"name" validate:"required,min=1,max=30,alphanum"`
+	Description string             `json:"description" validate:"min=0,max=100"`
+	Roles       []UserCreationRole `json:"roles" validate:"required,min=1,dive"`
+}
+
+type CreateServiceAccountResponse struct {
+	ID string `json:"id"`
+}
+
+type UpdateServiceAccountRequest struct {
+	Description string             `json:"description" validate:"min=0,max=100"`
+	Roles       []UserCreationRole `json:"roles" validate:"required,min=1,dive"`
+}
+
+type DeleteServiceAccountResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
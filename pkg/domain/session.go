@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type SessionResponse struct {
+	ID             string    `json:"id"`
+	IpAddress      string    `json:"ipAddress"`
+	Clients        []string  `json:"clients"`
+	StartedAt      time.Time `json:"startedAt"`
+	LastAccessedAt time.Time `json:"lastAccessedAt"`
+	Current        bool      `json:"current"`
+}
+
+type GetMySessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+type GetUserSessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+type RevokeUserSessionResponse struct {
+	AccountId string `json:"accountId"`
+	SessionId string `json:"sessionId,omitempty"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+const (
+	STREAM_EVENT_SYSTEM_NOTIFICATION_CREATED = "SYSTEM_NOTIFICATION_CREATED"
+	STREAM_EVENT_SYSTEM_NOTIFICATION_UPDATED = "SYSTEM_NOTIFICATION_UPDATED"
+	STREAM_EVENT_CLUSTER_STATUS_CHANGED      = "CLUSTER_STATUS_CHANGED"
+	STREAM_EVENT_STACK_STATUS_CHANGED        = "STACK_STATUS_CHANGED"
+)
+
+// StreamSystemNotification is the data of the systemNotification events. The clients get the detail by the id.
+type StreamSystemNotification struct {
+	ID               string `json:"id"`
+	NotificationType string `json:"notificationType"`
+	Name             string `json:"name"`
+	ClusterId        string `json:"clusterId"`
+	Severity         string `json:"severity"`
+	MessageTitle     string `json:"messageTitle"`
+	Status           string `json:"status"`
+	FireCount        int    `json:"fireCount"`
+}
+
+type CreateNotificationStreamTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiredAt time.Time `json:"expiredAt"`
+}
+
+// StreamStatusChange is the data of the cluster and stack status events.
+type StreamStatusChange struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+	StatusDesc     string `json:"statusDesc"`
+}
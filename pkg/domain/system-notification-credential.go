@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	SystemNotificationCredentialHeaderId        = "X-TKS-Credential-Id"
+	SystemNotificationCredentialHeaderTimestamp = "X-TKS-Timestamp"
+	SystemNotificationCredentialHeaderNonce     = "X-TKS-Nonce"
+	SystemNotificationCredentialHeaderSignature = "X-TKS-Signature"
+)
+
+type SystemNotificationCredentialResponse struct {
+	ID             string             `json:"id"`
+	OrganizationId string             `json:"organizationId"`
+	ClusterId      string             `json:"clusterId"`
+	Name           string             `json:"name"`
+	Description    string             `json:"description"`
+	LastUsedAt     *time.Time         `json:"lastUsedAt"`
+	RotatedAt      *time.Time         `json:"rotatedAt"`
+	Creator        SimpleUserResponse `json:"creator"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	UpdatedAt      time.Time          `json:"updatedAt"`
+}
+
+type GetSystemNotificationCredentialsResponse struct {
+	SystemNotificationCredentials []SystemNotificationCredentialResponse `json:"systemNotificationCredentials"`
+	Pagination                    PaginationResponse                     `json:"pagination"`
+}
+
+type CreateSystemNotificationCredentialRequest struct {
+	Name        string `json:"name" validate:"required,name"`
+	Description string `json:"description"`
+	ClusterId   string `json:"clusterId"`
+}
+
+type CreateSystemNotificationCredentialResponse struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+	Token  string `json:"token"`
+}
+
+type RotateSystemNotificationCredentialResponse struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+	Token  string `json:"token"`
+}
@@ -4,6 +4,11 @@ import (
 	"time"
 )
 
+const (
+	SYSTEM_NOTIFICATION_DIGEST_MODE_HOURLY = "HOURLY"
+	SYSTEM_NOTIFICATION_DIGEST_MODE_DAILY  = "DAILY"
+)
+
 // enum
 type SystemNotificationRuleStatus int32
 
@@ -38,6 +43,8 @@ type SystemNotificationRuleResponse struct {
 	MessageContent              string                                   `json:"messageContent"`
 	MessageActionProposal       string                                   `json:"messageActionProposal"`
 	TargetUsers                 []SimpleUserResponse                     `json:"targetUsers"`
+	NotificationChannels        []SimpleNotificationChannelResponse      `json:"notificationChannels"`
+	EscalationPolicy            SimpleEscalationPolicyResponse           `json:"escalationPolicy"`
 	SystemNotificationTemplate  SimpleSystemNotificationTemplateResponse `json:"systemNotificationTemplate"`
 	SystemNotificationCondition SystemNotificationConditionResponse      `json:"systemNotificationCondition"`
 	IsSystem                    bool                                     `json:"isSystem"`
@@ -60,6 +67,7 @@ type SystemNotificationConditionResponse struct {
 	Duration                 string                        `json:"duration"`
 	Parameters               []SystemNotificationParameter `json:"parameters"`
 	EnableEmail              bool                          `json:"enableEmail"`
+	EmailDigestMode          string                        `json:"emailDigestMode"`
 	EnablePortal             bool                          `json:"enablePortal"`
 }
 
@@ -86,13 +94,16 @@ type CreateSystemNotificationRuleRequest struct {
 	MessageContent               string   `json:"messageContent" validate:"required"`
 	MessageActionProposal        string   `json:"messageActionProposal"`
 	TargetUserIds                []string `json:"targetUserIds"`
+	NotificationChannelIds       []string `json:"notificationChannelIds"`
+	EscalationPolicyId           string   `json:"escalationPolicyId"`
 	SystemNotificationTemplateId string   `json:"systemNotificationTemplateId" validate:"required"`
 	SystemNotificationCondition  struct {
-		Severity     string                        `json:"severity"`
-		Duration     string                        `json:"duration"`
-		Parameters   []SystemNotificationParameter `json:"parameters"`
-		EnableEmail  bool                          `json:"enableEmail"`
-		EnablePortal bool                          `json:"enablePortal"`
+		Severity        string                        `json:"severity"`
+		Duration        string                        `json:"duration"`
+		Parameters      []SystemNotificationParameter `json:"parameters"`
+		EnableEmail     bool                          `json:"enableEmail"`
+		EmailDigestMode string                        `json:"emailDigestMode" validate:"omitempty,oneof=HOURLY DAILY"`
+		EnablePortal    bool                          `json:"enablePortal"`
 	} `json:"systemNotificationCondition"`
 }
 
@@ -107,6 +118,8 @@ type UpdateSystemNotificationRuleRequest struct {
 	MessageContent               string   `json:"messageContent" validate:"required"`
 	MessageActionProposal        string   `json:"messageActionProposal"`
 	TargetUserIds                []string `json:"targetUserIds"`
+	NotificationChannelIds       []string `json:"notificationChannelIds"`
+	EscalationPolicyId           string   `json:"escalationPolicyId"`
 	SystemNotificationTemplateId string   `json:"systemNotificationTemplateId" validate:"required"`
 	SystemNotificationCondition  struct {
 		SystemNotificationRuleId string                        `json:"systemNotificationRuleId"`
@@ -114,6 +127,7 @@ type UpdateSystemNotificationRuleRequest struct {
 		Duration                 string                        `json:"duration"`
 		Parameters               []SystemNotificationParameter `json:"parameters"`
 		EnableEmail              bool                          `json:"enableEmail"`
+		EmailDigestMode          string                        `json:"emailDigestMode" validate:"omitempty,oneof=HOURLY DAILY"`
 		EnablePortal             bool                          `json:"enablePortal"`
 	} `json:"systemNotificationCondition"`
 }
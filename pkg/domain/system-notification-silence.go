@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	SYSTEM_NOTIFICATION_SILENCE_STATUS_PENDING = "PENDING"
+	SYSTEM_NOTIFICATION_SILENCE_STATUS_ACTIVE  = "ACTIVE"
+	SYSTEM_NOTIFICATION_SILENCE_STATUS_EXPIRED = "EXPIRED"
+)
+
+type SystemNotificationSilenceResponse struct {
+	ID                     string                               `json:"id"`
+	ClusterId              string                               `json:"clusterId"`
+	Node                   string                               `json:"node"`
+	SystemNotificationRule SimpleSystemNotificationRuleResponse `json:"systemNotificationRule"`
+	Severity               string                               `json:"severity"`
+	StartsAt               time.Time                            `json:"startsAt"`
+	EndsAt                 time.Time                            `json:"endsAt"`
+	Comment                string                               `json:"comment"`
+	Status                 string                               `json:"status"`
+	Creator                SimpleUserResponse                   `json:"creator"`
+	Updator                SimpleUserResponse                   `json:"updator"`
+	CreatedAt              time.Time                            `json:"createdAt"`
+	UpdatedAt              time.Time                            `json:"updatedAt"`
+}
+
+type GetSystemNotificationSilencesResponse struct {
+	SystemNotificationSilences []SystemNotificationSilenceResponse `json:"systemNotificationSilences"`
+	Pagination                 PaginationResponse                  `json:"pagination"`
+}
+
+type GetSystemNotificationSilenceResponse struct {
+	SystemNotificationSilence SystemNotificationSilenceResponse `json:"systemNotificationSilence"`
+}
+
+type CreateSystemNotificationSilenceRequest struct {
+	ClusterId                string    `json:"clusterId"`
+	Node                     string    `json:"node"`
+	SystemNotificationRuleId string    `json:"systemNotificationRuleId"`
+	Severity                 string    `json:"severity"`
+	StartsAt                 time.Time `json:"startsAt" validate:"required"`
+	EndsAt                   time.Time `json:"endsAt" validate:"required"`
+	Comment                  string    `json:"comment" validate:"required,max=2000"`
+}
+
+type CreateSystemNotificationSilenceResponse struct {
+	ID string `json:"id"`
+}
+
+type UpdateSystemNotificationSilenceRequest struct {
+	ClusterId                string    `json:"clusterId"`
+	Node                     string    `json:"node"`
+	SystemNotificationRuleId string    `json:"systemNotificationRuleId"`
+	Severity                 string    `json:"severity"`
+	StartsAt                 time.Time `json:"startsAt" validate:"required"`
+	EndsAt                   time.Time `json:"endsAt" validate:"required"`
+	Comment                  string    `json:"comment" validate:"required,max=2000"`
+}
+
+type DeleteSystemNotificationSilenceResponse struct {
+	ID      string `json:"id"`
+	Comment string `json:"comment"`
+}
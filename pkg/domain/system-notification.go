@@ -105,6 +105,10 @@ type SystemNotificationResponse struct {
 	CreatedAt                 time.Time                          `json:"createdAt"`
 	UpdatedAt                 time.Time                          `json:"updatedAt"`
 	PolicyName                string                             `json:"policyName"`
+	FireCount                 int                                `json:"fireCount"`
+	LastFiredAt               *time.Time                         `json:"lastFiredAt"`
+	EscalationLevel           int                                `json:"escalationLevel"`
+	Silenced                  bool                               `json:"silenced"`
 }
 
 type SystemNotificationActionResponse struct {
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+)
+
+type ImportUserRequest struct {
+	AccountId   string   `json:"accountId" validate:"required,min=0,max=20,alphanum"`
+	Name        string   `json:"name" validate:"name"`
+	Email       string   `json:"email" validate:"required,email"`
+	Department  string   `json:"department" validate:"min=0,max=50"`
+	Description string   `json:"description" validate:"min=0,max=100"`
+	Roles       []string `json:"roles" validate:"required,min=1"`
+}
+
+// ImportUsersRequest is the json body of the import. The csv body has the header of accountId,name,email,department,description,roles
+// and the roles are separated by ';'.
+type ImportUsersRequest struct {
+	Users []ImportUserRequest `json:"users"`
+}
+
+type UserImportRowResponse struct {
+	Line        int      `json:"line"`
+	AccountId   string   `json:"accountId"`
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	Department  string   `json:"department"`
+	Description string   `json:"description"`
+	Roles       []string `json:"roles"`
+	// PENDING, INVALID, CREATED, FAILED
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type UserImportResponse struct {
+	ID string `json:"id"`
+	// PENDING, RUNNING, COMPLETED, REJECTED
+	Status       string             `json:"status"`
+	TotalCount   int                `json:"totalCount"`
+	CreatedCount int                `json:"createdCount"`
+	FailedCount  int                `json:"failedCount"`
+	StartedAt    *time.Time         `json:"startedAt"`
+	CompletedAt  *time.Time         `json:"completedAt"`
+	Creator      SimpleUserResponse `json:"creator"`
+	CreatedAt    time.Time          `json:"createdAt"`
+}
+
+type ImportUsersResponse struct {
+	UserImport UserImportResponse      `json:"userImport"`
+	Rows       []UserImportRowResponse `json:"rows"`
+}
+
+type GetUserImportsResponse struct {
+	UserImports []UserImportResponse `json:"userImports"`
+	Pagination  PaginationResponse   `json:"pagination"`
+}
+
+type GetUserImportResponse struct {
+	UserImport UserImportResponse      `json:"userImport"`
+	Rows       []UserImportRowResponse `json:"rows"`
+}
+
+type ExportUserProjectResponse struct {
+	ProjectId       string `json:"projectId"`
+	ProjectName     string `json:"projectName"`
+	ProjectRoleName string `json:"projectRoleName"`
+}
+
+type ExportUserResponse struct {
+	AccountId   string                      `json:"accountId"`
+	Name        string                      `json:"name"`
+	Email       string                      `json:"email"`
+	Department  string                      `json:"department"`
+	Description string                      `json:"description"`
+	Roles       []string                    `json:"roles"`
+	Projects    []ExportUserProjectResponse `json:"projects"`
+	CreatedAt   time.Time                   `json:"createdAt"`
+}
+
+type ExportUsersResponse struct {
+	Users []ExportUserResponse `json:"users"`
+}
@@ -4,37 +4,65 @@ type ErrorCode string
 
 var errorMap = map[ErrorCode]string{
 	// Common
-	"C_INTERNAL_ERROR":                          "예상하지 못한 오류가 발생했습니다. 문제가 계속되면 관리자에게 문의해주세요.",
-	"C_INVALID_ACCOUNT_ID":                      "유효하지 않은 어카운트 아이디입니다. 어카운트 아이디를 확인하세요.",
-	"C_INVALID_STACK_ID":                        "유효하지 않은 스택 아이디입니다. 스택 아이디를 확인하세요.",
-	"C_INVALID_CLUSTER_ID":                      "유효하지 않은 클러스터 아이디입니다. 클러스터 아이디를 확인하세요.",
-	"C_INVALID_APPGROUP_ID":                     "유효하지 않은 앱그룹 아이디입니다. 앱그룹 아이디를 확인하세요.",
-	"C_INVALID_ORGANIZATION_ID":                 "유효하지 않은 조직 아이디입니다. 조직 아이디를 확인하세요.",
-	"C_INVALID_PROJECT_ID":                      "유효하지 않은 프로젝트 아이디입니다. 아이디를 확인하세요.",
-	"C_INVALID_CLOUD_ACCOUNT_ID":                "유효하지 않은 클라우드어카운트 아이디입니다. 클라우드어카운트 아이디를 확인하세요.",
-	"C_INVALID_STACK_TEMPLATE_ID":               "유효하지 않은 스택템플릿 아이디입니다. 스택템플릿 아이디를 확인하세요.",
-	"C_INVALID_SYSTEM_NOTIFICATION_TEMPLATE_ID": "유효하지 않은 알림템플릿 아이디입니다. 알림템플릿 아이디를 확인하세요.",
-	"C_INVALID_SYSTEM_NOTIFICATION_RULE_ID":     "유효하지 않은 알림설정 아이디입니다. 알림설정 아이디를 확인하세요.",
-	"C_INVALID_ASA_ID":                          "유효하지 않은 앱서빙앱 아이디입니다. 앱서빙앱 아이디를 확인하세요.",
-	"C_INVALID_ASA_TASK_ID":                     "유효하지 않은 테스크 아이디입니다. 테스크 아이디를 확인하세요.",
-	"C_INVALID_CLOUD_SERVICE":                   "유효하지 않은 클라우드서비스입니다.",
-	"C_INVALID_AUDIT_ID":                        "유효하지 않은 로그 아이디입니다. 로그 아이디를 확인하세요.",
-	"C_INVALID_POLICY_TEMPLATE_ID":              "유효하지 않은 정책 템플릿 아이디입니다. 정책 템플릿 아이디를 확인하세요.",
-	"C_INVALID_POLICY_ID":                       "유효하지 않은 정책 아이디입니다. 정책 아이디를 확인하세요.",
-	"C_FAILED_TO_CALL_WORKFLOW":                 "워크플로우 호출에 실패했습니다.",
+	"C_INTERNAL_ERROR":                            "예상하지 못한 오류가 발생했습니다. 문제가 계속되면 관리자에게 문의해주세요.",
+	"C_INVALID_ACCOUNT_ID":                        "유효하지 않은 어카운트 아이디입니다. 어카운트 아이디를 확인하세요.",
+	"C_INVALID_STACK_ID":                          "유효하지 않은 스택 아이디입니다. 스택 아이디를 확인하세요.",
+	"C_INVALID_CLUSTER_ID":                        "유효하지 않은 클러스터 아이디입니다. 클러스터 아이디를 확인하세요.",
+	"C_INVALID_APPGROUP_ID":                       "유효하지 않은 앱그룹 아이디입니다. 앱그룹 아이디를 확인하세요.",
+	"C_INVALID_ORGANIZATION_ID":                   "유효하지 않은 조직 아이디입니다. 조직 아이디를 확인하세요.",
+	"C_INVALID_PROJECT_ID":                        "유효하지 않은 프로젝트 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_CLOUD_ACCOUNT_ID":                  "유효하지 않은 클라우드어카운트 아이디입니다. 클라우드어카운트 아이디를 확인하세요.",
+	"C_INVALID_STACK_TEMPLATE_ID":                 "유효하지 않은 스택템플릿 아이디입니다. 스택템플릿 아이디를 확인하세요.",
+	"C_INVALID_SYSTEM_NOTIFICATION_TEMPLATE_ID":   "유효하지 않은 알림템플릿 아이디입니다. 알림템플릿 아이디를 확인하세요.",
+	"C_INVALID_SYSTEM_NOTIFICATION_RULE_ID":       "유효하지 않은 알림설정 아이디입니다. 알림설정 아이디를 확인하세요.",
+	"C_INVALID_MAIL_OUTBOX_ID":                    "유효하지 않은 메일 아이디입니다. 메일 아이디를 확인하세요.",
+	"C_INVALID_NOTIFICATION_CHANNEL_ID":           "유효하지 않은 알림 채널 아이디입니다. 알림 채널 아이디를 확인하세요.",
+	"C_INVALID_SYSTEM_NOTIFICATION_CREDENTIAL_ID": "유효하지 않은 알림 수신 인증정보 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_ESCALATION_POLICY_ID":              "유효하지 않은 에스컬레이션 정책 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_ON_CALL_SCHEDULE_ID":               "유효하지 않은 당직 일정 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_SYSTEM_NOTIFICATION_SILENCE_ID":    "유효하지 않은 알림 무음 설정 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_USER_ID":                           "유효하지 않은 사용자 아이디입니다. 사용자 아이디를 확인하세요.",
+	"C_INVALID_SERVICE_ACCOUNT_ID":                "유효하지 않은 서비스 계정 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_API_TOKEN_ID":                      "유효하지 않은 API 토큰 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_IDENTITY_PROVIDER_ID":              "유효하지 않은 외부 인증 서버 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_SESSION_ID":                        "유효하지 않은 세션 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_INVITATION_ID":                     "유효하지 않은 초대 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_PROJECT_ROLE_ID":                   "유효하지 않은 프로젝트 역할 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_IMPERSONATION_ID":                  "유효하지 않은 사용자 대리 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_USER_IMPORT_ID":                    "유효하지 않은 사용자 일괄 등록 아이디입니다. 아이디를 확인하세요.",
+	"C_INVALID_ASA_ID":                            "유효하지 않은 앱서빙앱 아이디입니다. 앱서빙앱 아이디를 확인하세요.",
+	"C_INVALID_ASA_TASK_ID":                       "유효하지 않은 테스크 아이디입니다. 테스크 아이디를 확인하세요.",
+	"C_INVALID_CLOUD_SERVICE":                     "유효하지 않은 클라우드서비스입니다.",
+	"C_INVALID_AUDIT_ID":                          "유효하지 않은 로그 아이디입니다. 로그 아이디를 확인하세요.",
+	"C_INVALID_POLICY_TEMPLATE_ID":                "유효하지 않은 정책 템플릿 아이디입니다. 정책 템플릿 아이디를 확인하세요.",
+	"C_INVALID_POLICY_ID":                         "유효하지 않은 정책 아이디입니다. 정책 아이디를 확인하세요.",
+	"C_FAILED_TO_CALL_WORKFLOW":                   "워크플로우 호출에 실패했습니다.",
 
 	// Auth
-	"A_INVALID_ID":              "아이디가 존재하지 않습니다.",
-	"A_INVALID_PASSWORD":        "비밀번호가 일치하지 않습니다.",
-	"A_SAME_OLD_PASSWORD":       "기존 비밀번호와 동일합니다.",
-	"A_INVALID_TOKEN":           "사용자 토큰 오류",
-	"A_EXPIRED_TOKEN":           "사용자 토큰 만료",
-	"A_INVALID_USER_CREDENTIAL": "비밀번호가 일치하지 않습니다.",
-	"A_INVALID_ORIGIN_PASSWORD": "기존 비밀번호가 일치하지 않습니다.",
-	"A_INVALID_CODE":            "인증번호가 일치하지 않습니다.",
-	"A_NO_SESSION":              "세션 정보를 찾을 수 없습니다.",
-	"A_EXPIRED_CODE":            "인증번호가 만료되었습니다.",
-	"A_UNUSABLE_TOKEN":          "사용할 수 없는 토큰입니다.",
+	"A_INVALID_ID":                     "아이디가 존재하지 않습니다.",
+	"A_INVALID_PASSWORD":               "비밀번호가 일치하지 않습니다.",
+	"A_SAME_OLD_PASSWORD":              "기존 비밀번호와 동일합니다.",
+	"A_INVALID_TOKEN":                  "사용자 토큰 오류",
+	"A_EXPIRED_TOKEN":                  "사용자 토큰 만료",
+	"A_INVALID_USER_CREDENTIAL":        "비밀번호가 일치하지 않습니다.",
+	"A_INVALID_ORIGIN_PASSWORD":        "기존 비밀번호가 일치하지 않습니다.",
+	"A_INVALID_CODE":                   "인증번호가 일치하지 않습니다.",
+	"A_NO_SESSION":                     "세션 정보를 찾을 수 없습니다.",
+	"A_EXPIRED_CODE":                   "인증번호가 만료되었습니다.",
+	"A_UNUSABLE_TOKEN":                 "사용할 수 없는 토큰입니다.",
+	"A_TOO_MANY_ATTEMPTS":              "인증 시도가 너무 많습니다. 잠시 후 다시 시도하세요.",
+	"A_LOCKED_ACCOUNT":                 "로그인 실패가 반복되어 계정이 잠겼습니다. 관리자에게 문의하세요.",
+	"A_LOCKED_CLIENT_IP":               "인증 실패가 반복되어 접속이 일시적으로 차단되었습니다.",
+	"A_EXCEEDED_CODE_ATTEMPTS":         "인증번호 입력 횟수를 초과하였습니다. 인증번호를 다시 요청하세요.",
+	"A_PASSWORD_POLICY":                "비밀번호가 조직의 비밀번호 정책에 맞지 않습니다.",
+	"A_EXPIRED_PASSWORD":               "비밀번호가 만료되었습니다. 비밀번호를 변경하세요.",
+	"A_SECOND_FACTOR_REQUIRED":         "조직의 보안 정책에 따라 2단계 인증을 등록해야 합니다.",
+	"A_SECOND_FACTOR_NOT_VERIFIED":     "2단계 인증을 마치지 않은 세션입니다. 다시 로그인하세요.",
+	"A_INVALID_SECOND_FACTOR_CODE":     "2단계 인증 코드가 일치하지 않습니다.",
+	"A_FAILED_IDENTITY_PROVIDER_LOGIN": "외부 인증 서버를 통한 로그인에 실패하였습니다.",
+	"A_EXPIRED_SECOND_FACTOR_TOKEN":    "2단계 인증 시간이 만료되었습니다. 다시 로그인하세요.",
+	"A_DISABLED_ACCOUNT":               "비활성화된 계정입니다. 관리자에게 문의하세요.",
+	"A_NOT_EXISTED_SESSION":            "존재하지 않는 세션입니다.",
 
 	// Organization
 	"O_INVALID_ORGANIZATION_NAME":                   "조직에 이미 존재하는 이름입니다.",
@@ -47,6 +75,91 @@ var errorMap = map[ErrorCode]string{
 	// User
 	"U_NO_USER": "해당 사용자 정보를 찾을 수 없습니다.",
 
+	// ServiceAccount
+	"SA_NOT_EXISTED_SERVICE_ACCOUNT": "서비스 계정이 존재하지 않습니다.",
+	"SA_CREATE_ALREADY_EXISTED_NAME": "이미 존재하는 서비스 계정 이름입니다.",
+
+	// SecurityPolicy
+	"SP_FAILED_TO_UPDATE_REALM": "보안 정책을 인증 서버에 반영하는데 실패하였습니다.",
+
+	// SecondFactor
+	"SF_NOT_EXISTED_SECOND_FACTOR": "등록된 2단계 인증이 없습니다.",
+	"SF_ALREADY_ENABLED":           "이미 2단계 인증이 등록되어 있습니다.",
+	"SF_REQUIRED_BY_POLICY":        "조직의 보안 정책에 따라 2단계 인증을 해제할 수 없습니다.",
+
+	// IdentityProvider
+	"IDP_NOT_EXISTED_IDENTITY_PROVIDER": "외부 인증 서버가 존재하지 않습니다.",
+	"IDP_CREATE_ALREADY_EXISTED_ALIAS":  "이미 존재하는 외부 인증 서버 별칭입니다.",
+	"IDP_INVALID_CONFIG":                "외부 인증 서버의 설정이 올바르지 않습니다.",
+	"IDP_INVALID_ROLE":                  "역할 매핑에 조직의 역할이 아닌 역할이 있습니다.",
+	"IDP_FAILED_TO_APPLY_REALM":         "외부 인증 서버를 인증 서버에 반영하는데 실패하였습니다.",
+	"IDP_DISABLED_IDENTITY_PROVIDER":    "사용하지 않는 외부 인증 서버입니다.",
+	"IDP_NO_MAPPED_ROLE":                "외부 디렉터리의 사용자에게 매핑된 역할이 없습니다. 관리자에게 문의하세요.",
+
+	// Invitation
+	"INV_NOT_EXISTED_INVITATION":     "초대가 존재하지 않습니다.",
+	"INV_INVALID_INVITATION":         "유효하지 않은 초대입니다.",
+	"INV_EXPIRED_INVITATION":         "초대가 만료되었습니다. 관리자에게 초대 메일의 재발송을 요청하세요.",
+	"INV_NOT_PENDING_INVITATION":     "대기 중인 초대가 아닙니다.",
+	"INV_ALREADY_INVITED":            "이미 초대한 이메일입니다.",
+	"INV_ALREADY_EXISTED_USER":       "조직에 이미 존재하는 사용자의 이메일입니다.",
+	"INV_ALREADY_EXISTED_ACCOUNT_ID": "이미 존재하는 아이디입니다.",
+	"INV_INVALID_ROLE":               "조직의 역할이 아닌 역할이 있습니다.",
+
+	// UserImport
+	"UI_NOT_EXISTED_USER_IMPORT": "사용자 일괄 등록이 존재하지 않습니다.",
+	"UI_INVALID_FILE":            "일괄 등록 파일의 형식이 올바르지 않습니다.",
+	"UI_EMPTY_ROWS":              "등록할 사용자가 없습니다.",
+	"UI_TOO_MANY_ROWS":           "한번에 등록할 수 있는 사용자 수를 초과하였습니다.",
+	"UI_DUPLICATED_ACCOUNT_ID":   "파일 내에 중복된 아이디입니다.",
+	"UI_DUPLICATED_EMAIL":        "파일 내에 중복된 이메일입니다.",
+	"UI_EXISTED_ACCOUNT_ID":      "이미 존재하는 아이디입니다.",
+	"UI_EXISTED_EMAIL":           "이미 존재하는 이메일입니다.",
+	"UI_NOT_EXISTED_ROLE":        "존재하지 않는 역할입니다.",
+	"UI_FAILED_TO_CREATE_USER":   "사용자를 생성하는데 실패하였습니다.",
+	"UI_FAILED_TO_INVITE_USER":   "사용자는 생성되었지만 초대 메일을 보내는데 실패하였습니다. 비밀번호를 초기화하세요.",
+
+	// Impersonation
+	"IMP_NOT_ALLOWED":               "마스터 조직의 관리자만 사용자를 대리할 수 있습니다.",
+	"IMP_MASTER_USER":               "마스터 조직의 사용자는 대리할 수 없습니다.",
+	"IMP_DISABLED_USER":             "비활성화된 사용자는 대리할 수 없습니다.",
+	"IMP_NOT_EXISTED_IMPERSONATION": "사용자 대리 세션이 존재하지 않습니다.",
+	"IMP_NOT_ACTIVE":                "진행 중인 사용자 대리 세션이 아닙니다.",
+	"IMP_NOT_ALLOWED_ENDPOINT":      "사용자 대리 중에는 호출할 수 없는 API 입니다.",
+	"IMP_NOT_CONFIGURED":            "토큰 서명 키가 설정되지 않아 사용자를 대리할 수 없습니다.",
+
+	// Audit
+	"AU_APPEND_ONLY":              "감사 로그는 삭제할 수 없습니다. 보존 기간이 지나면 보관 후 정리됩니다.",
+	"AU_INVALID_DATE_RANGE":       "내보낼 기간이 올바르지 않습니다. 기간은 최대 1년입니다.",
+	"AU_INVALID_FORMAT":           "지원하지 않는 형식입니다. csv 또는 jsonl 을 사용하세요.",
+	"AU_NOT_ALLOWED_ORGANIZATION": "다른 조직의 감사 로그는 검증할 수 없습니다.",
+
+	// Retention
+	"RT_INVALID_DATA_TYPE":      "지원하지 않는 데이터 종류입니다.",
+	"RT_DUPLICATED_DATA_TYPE":   "같은 데이터 종류의 보존 정책이 중복되었습니다.",
+	"RT_ARCHIVE_NOT_ALLOWED":    "이메일 인증 코드는 보관할 수 없습니다.",
+	"RT_ARCHIVE_REQUIRED":       "감사 로그는 보관하도록 설정해야 정리할 수 있습니다.",
+	"RT_RETENTION_TOO_SHORT":    "감사 로그의 보존 기간이 최소 보존 기간보다 짧습니다.",
+	"RT_ARCHIVE_NOT_CONFIGURED": "보관 저장소가 설정되지 않아 보관할 수 없습니다.",
+
+	// Scim
+	"SCIM_INVALID_FILTER":    "SCIM 필터 형식이 올바르지 않습니다.",
+	"SCIM_INVALID_PATH":      "SCIM PATCH 경로가 올바르지 않습니다.",
+	"SCIM_INVALID_VALUE":     "SCIM 속성 값이 올바르지 않습니다.",
+	"SCIM_MUTABILITY":        "변경할 수 없는 SCIM 속성입니다.",
+	"SCIM_UNIQUENESS":        "이미 존재하는 사용자입니다.",
+	"SCIM_NOT_EXISTED_USER":  "SCIM 사용자가 존재하지 않습니다.",
+	"SCIM_NOT_EXISTED_GROUP": "SCIM 그룹(역할)이 존재하지 않습니다.",
+	"SCIM_NO_DEFAULT_ROLE":   "프로비저닝된 사용자에게 부여할 기본 역할이 조직에 없습니다.",
+
+	// ApiToken
+	"AT_NOT_EXISTED_API_TOKEN":        "API 토큰이 존재하지 않습니다.",
+	"AT_INVALID_ENDPOINT_GROUP":       "유효하지 않은 API 그룹입니다.",
+	"AT_INVALID_EXPIRED_AT":           "API 토큰의 만료 시간은 현재 시간 이후여야 합니다.",
+	"AT_NOT_ALLOWED_ENDPOINT":         "API 토큰으로 호출할 수 없는 API 입니다.",
+	"AT_CANNOT_CREATE_WITH_API_TOKEN": "API 토큰으로는 새로운 API 토큰을 발급할 수 없습니다.",
+	"AT_PROVISIONING_TOKEN_REQUIRED":  "SCIM 그룹만 허용된 서비스 계정의 API 토큰으로만 호출할 수 있습니다.",
+
 	// CloudAccount
 	"CA_INVALID_CLIENT_TOKEN_ID":    "유효하지 않은 토큰입니다. AccessKeyId, SecretAccessKey, SessionToken 을 확인후 다시 입력하세요.",
 	"CA_INVALID_CLOUD_ACCOUNT_NAME": "유효하지 않은 클라우드계정 이름입니다. 클라우드계정 이름을 확인하세요.",
@@ -101,6 +214,39 @@ var errorMap = map[ErrorCode]string{
 	"SNR_INVALID_ENABLE_PORTAL":                 "알림 방법의 포탈은 설정을 변경할 수 없습니다.",
 	"SNR_CANNOT_DELETE_SYSTEM_RULE":             "시스템 알림 설정은 삭제 할 수 없습니다.",
 
+	// SystemNotificationCredential
+	"SNC_NOT_EXISTED_CREDENTIAL": "알림 수신 인증정보가 존재하지 않습니다.",
+	"SNC_INVALID_CREDENTIAL":     "유효하지 않은 알림 수신 인증정보입니다.",
+	"SNC_INVALID_SIGNATURE":      "유효하지 않은 알림 서명입니다.",
+	"SNC_INVALID_TIMESTAMP":      "알림 요청의 timestamp 또는 nonce 가 유효하지 않습니다.",
+	"SNC_REPLAYED_REQUEST":       "이미 처리된 알림 요청입니다.",
+	"SNC_FAILED_TO_CHECK_NONCE":  "알림 요청의 nonce 를 확인하는데 실패했습니다.",
+
+	// MailOutbox
+	"MO_NOT_EXISTED_MAIL": "메일이 존재하지 않습니다.",
+	"MO_ALREADY_PENDING":  "이미 발송 대기 중인 메일입니다.",
+	"MO_NOT_RESENDABLE":   "계정 정보를 담은 메일은 다시 보낼 수 없습니다. 새로 발급하세요.",
+
+	// NotificationChannel
+	"NC_NOT_EXISTED_NOTIFICATION_CHANNEL": "알림 채널이 존재하지 않습니다.",
+	"NC_INVALID_TEMPLATE":                 "유효하지 않은 웹훅 템플릿입니다. 템플릿의 결과는 JSON 이어야 합니다.",
+	"NC_INVALID_URL":                      "유효하지 않은 알림 채널 주소입니다. 외부에서 접근 가능한 https 주소만 사용할 수 있습니다.",
+	"ST_INVALID_TICKET":                   "유효하지 않거나 만료된 스트림 티켓입니다.",
+
+	// EscalationPolicy
+	"EP_NOT_EXISTED_ESCALATION_POLICY": "에스컬레이션 정책이 존재하지 않습니다.",
+	"EP_EMPTY_TIER_TARGET":             "에스컬레이션 단계마다 사용자, 당직 일정 또는 알림 채널 중 하나 이상을 지정해야 합니다.",
+
+	// SystemNotificationSilence
+	"SNS_NOT_EXISTED_SILENCE": "알림 무음 설정이 존재하지 않습니다.",
+	"SNS_INVALID_PERIOD":      "알림 무음 기간이 올바르지 않습니다. 종료 시각은 시작 시각과 현재 시각 이후여야 합니다.",
+
+	// OnCallSchedule
+	"OCS_NOT_EXISTED_ON_CALL_SCHEDULE": "당직 일정이 존재하지 않습니다.",
+
+	// NotificationPreference
+	"NP_INVALID_UNSUBSCRIBE_TOKEN": "유효하지 않은 수신 거부 링크입니다.",
+
 	// AppGroup
 	"AG_NOT_FOUND_CLUSTER":         "지장한 클러스터가 존재하지 않습니다.",
 	"AG_NOT_FOUND_APPGROUP":        "지장한 앱그룹이 존재하지 않습니다.",
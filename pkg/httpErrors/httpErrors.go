@@ -100,6 +100,9 @@ func NewConflictError(err error, code string, text string) IRestError {
 func NewForbiddenError(err error, code string, text string) IRestError {
 	return NewRestError(http.StatusForbidden, err, ErrorCode(code), text)
 }
+func NewTooManyRequestsError(err error, code string, text string) IRestError {
+	return NewRestError(http.StatusTooManyRequests, err, ErrorCode(code), text)
+}
 
 /*
 func NewTestError(err error, code string, v ...interface{}) IRestError {